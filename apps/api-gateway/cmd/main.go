@@ -1,13 +1,22 @@
 package main
 
 import (
+	"api-gateway/internal/audit"
+	"api-gateway/internal/cache"
+	gwconfig "api-gateway/internal/config"
+	"api-gateway/internal/events"
+	"api-gateway/internal/handler"
 	"api-gateway/internal/middleware"
 	"context"
 	"fmt"
 	"github.com/Ruletk/OnlineClinic/pkg/config"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/v9"
+	"time"
 )
 
 func main() {
@@ -21,7 +30,44 @@ func main() {
 	}
 	logging.InitLogger(*cfg)
 
-	_ = mainContext
+	shutdownTracer, err := tracing.InitTracer(*cfg)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to initialize tracing")
+	}
+	defer func() {
+		if err := shutdownTracer(mainContext); err != nil {
+			logging.Logger.WithError(err).Error("Failed to shutdown tracer")
+		}
+	}()
+
+	cacheCfg, err := gwconfig.LoadCacheConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to load cache configuration")
+	}
+	responseCache := newCacheStore(mainContext, cfg, cacheCfg)
+
+	auditCfg, err := gwconfig.LoadAuditConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to load audit configuration")
+	}
+	auditSink := newAuditSink(cfg, auditCfg)
+	if auditSink != nil {
+		defer func() {
+			if err := auditSink.Close(); err != nil {
+				logging.Logger.WithError(err).Error("Failed to close audit sink")
+			}
+		}()
+	}
+
+	securityCfg, err := gwconfig.LoadSecurityConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to load security configuration")
+	}
+
+	validationCfg, err := gwconfig.LoadValidationConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to load validation configuration")
+	}
 
 	r := gin.Default()
 
@@ -33,16 +79,25 @@ func main() {
 			Url:  fmt.Sprintf("http://%s:%s", "auth", "8080"),
 		},
 		"doctor": {
-			Name: "doctor",
-			Host: "doctor",
-			Port: "8080",
-			Url:  fmt.Sprintf("http://%s:%s", "doctor", "8080"),
+			Name:         "doctor",
+			Host:         "doctor",
+			Port:         "8080",
+			Url:          fmt.Sprintf("http://%s:%s", "doctor", "8080"),
+			GrpcPort:     "50051",
+			GrpcServices: []string{"doctor.v1.DoctorService"},
+			// Slot booking methods are called by the appointment service only
+			GrpcMethods: []string{
+				"doctor.v1.DoctorService/CheckTimeAvailability",
+				"doctor.v1.DoctorService/GetAvailableSlots",
+			},
 		},
 		"patient": {
-			Name: "patient",
-			Host: "patient",
-			Port: "8080",
-			Url:  fmt.Sprintf("http://%s:%s", "patient", "8080"),
+			Name:         "patient",
+			Host:         "patient",
+			Port:         "8080",
+			Url:          fmt.Sprintf("http://%s:%s", "patient", "8080"),
+			GrpcPort:     "50051",
+			GrpcServices: []string{"patient.PatientService"},
 		},
 		"appointment": {
 			Name: "appointment",
@@ -52,20 +107,134 @@ func main() {
 		},
 	}
 
-	r.Use(middleware.PrometheusMiddleware())
+	docsHandler := handler.NewDocsHandler(services, 3*time.Second)
+
+	var specValidator *middleware.SpecValidator
+	if validationCfg.Enabled() {
+		specValidator = middleware.NewSpecValidator(validationCfg.ValidateResponses())
+		go refreshSpec(mainContext, docsHandler, specValidator, validationCfg.RefreshInterval)
+	}
+
+	r.Use(logging.RequestIDMiddleware())
+	r.Use(tracing.GinMiddleware(cfg.Tracing.ServiceName))
+	r.Use(metrics.GinMiddleware())
+	r.Use(middleware.CORS(*securityCfg))
+	r.Use(middleware.SecurityHeaders(*securityCfg))
+	r.Use(middleware.CSRF(*securityCfg))
 	r.Use(middleware.TokenMiddleware())
+	r.Use(middleware.Audit(auditSink, *auditCfg))
 
 	for serviceName, serviceConfig := range services {
 		serviceGroup := r.Group(fmt.Sprintf("/%s", serviceName))
 		{
-			serviceGroup.Any("/*proxyPath", middleware.ReverseProxy(&serviceConfig))
+			serviceGroup.Any("/*proxyPath",
+				middleware.RequestValidation(specValidator),
+				middleware.ResponseCache(responseCache, *cacheCfg),
+				middleware.ReverseProxy(&serviceConfig),
+			)
+		}
+
+		// gRPC and gRPC-Web calls use the standard /<package>.<Service>/<Method> path.
+		for _, grpcService := range serviceConfig.GrpcServices {
+			r.POST(fmt.Sprintf("/%s/:method", grpcService), middleware.GRPCProxy(&serviceConfig))
 		}
 	}
 
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	dashboardHandler := handler.NewDashboardHandler(services, 3*time.Second)
+	dashboardHandler.RegisterRoutes(r.Group("/me"))
 
+	docsHandler.RegisterRoutes(r.Group("/docs"))
+
+	// Real-time events are optional, the gateway serves everything else without NATS.
+	natsConn, err := nats.Connect(cfg.Nats.Url)
+	if err != nil {
+		logging.Logger.WithError(err).Error("Failed to connect to NATS, real-time events are disabled")
+	} else {
+		eventsSource := events.NewNatsSource(natsConn)
+		defer eventsSource.Close()
+		eventsHandler := handler.NewEventsHandler(eventsSource, 15*time.Second)
+		eventsHandler.RegisterRoutes(r.Group("/events"))
+	}
+
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Native gRPC clients connect with plaintext HTTP/2.
+	r.UseH2C = true
 	if err := r.Run(":8080"); err != nil {
 		logging.Logger.Fatalf("Failed to start server: %v", err)
 		panic(err)
 	}
 }
+
+// newCacheStore creates the response cache storage selected by GATEWAY_CACHE_BACKEND.
+// Returns nil when caching is disabled. If Redis is unreachable, the in-memory store is used.
+func newCacheStore(ctx context.Context, cfg *config.Config, cacheCfg *gwconfig.CacheConfig) cache.Store {
+	switch cacheCfg.Backend {
+	case "redis":
+		rdb := redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		if err := rdb.Ping(ctx).Err(); err != nil {
+			logging.Logger.WithError(err).Error("Failed to connect to Redis, falling back to in-memory response cache")
+			return cache.NewMemoryStore(cacheCfg.MaxEntries)
+		}
+		if err := tracing.InstrumentRedis(rdb); err != nil {
+			logging.Logger.WithError(err).Error("Failed to instrument Redis client")
+		}
+		return cache.NewRedisStore(rdb)
+	case "memory":
+		return cache.NewMemoryStore(cacheCfg.MaxEntries)
+	default:
+		return nil
+	}
+}
+
+// newAuditSink creates the audit sink selected by GATEWAY_AUDIT_SINK, or returns nil when auditing is disabled.
+// Access to health data must not go unrecorded, so a configured sink that can't be opened stops the gateway.
+func newAuditSink(cfg *config.Config, auditCfg *gwconfig.AuditConfig) audit.Sink {
+	switch auditCfg.Sink {
+	case "file":
+		sink, err := audit.NewFileSink(auditCfg.FilePath)
+		if err != nil {
+			logging.Logger.WithError(err).Fatal("Failed to open audit log")
+		}
+		return sink
+	case "nats":
+		nc, err := nats.Connect(cfg.Nats.Url)
+		if err != nil {
+			logging.Logger.WithError(err).Fatal("Failed to connect to NATS for audit records")
+		}
+		return audit.NewNatsSink(nc, auditCfg.Subject)
+	default:
+		return nil
+	}
+}
+
+// refreshSpec keeps the validator in sync with the documents served by the services.
+// Documents are reloaded every interval, so services started later or redeployed with new routes are picked up.
+func refreshSpec(ctx context.Context, docs *handler.DocsHandler, validator *middleware.SpecValidator, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		doc, unavailable, err := docs.Load(ctx)
+		switch {
+		case err != nil:
+			logging.Logger.WithError(err).Warn("Failed to load OpenAPI documents, requests are not validated yet")
+		default:
+			if err := validator.Update(doc); err != nil {
+				logging.Logger.WithError(err).Error("Failed to update request validation")
+			} else if len(unavailable) > 0 {
+				logging.Logger.Warnf("Request validation is missing the specs of: %v", unavailable)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// maxRecordSize bounds one line of the audit file.
+const maxRecordSize = 1 << 20
+
+// FileSink appends records as JSON lines and chains them by hash, so edits,
+// removals and reordering of past records can be detected with Verify.
+type FileSink struct {
+	mu       sync.Mutex
+	file     *os.File
+	lastHash string
+}
+
+// NewFileSink opens path for appending, creating it when missing.
+// An existing file is verified first and the chain continues from its last record.
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	lastHash, err := Verify(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("existing audit log is corrupted: %w", err)
+	}
+	return &FileSink{file: file, lastHash: lastHash}, nil
+}
+
+func (s *FileSink) Write(_ context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.PrevHash = s.lastHash
+	hash, err := ComputeHash(record)
+	if err != nil {
+		return fmt.Errorf("failed to hash audit record: %w", err)
+	}
+	record.Hash = hash
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+	s.lastHash = hash
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// Verify checks the hash chain of an audit log and returns the hash of its last record.
+// The error names the first line that doesn't match.
+func Verify(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	lastHash := ""
+	line := 0
+	for scanner.Scan() {
+		line++
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return "", fmt.Errorf("line %d: invalid record: %w", line, err)
+		}
+		if record.PrevHash != lastHash {
+			return "", fmt.Errorf("line %d: chain broken, previous hash does not match", line)
+		}
+		hash, err := ComputeHash(record)
+		if err != nil {
+			return "", fmt.Errorf("line %d: %w", line, err)
+		}
+		if hash != record.Hash {
+			return "", fmt.Errorf("line %d: record was modified", line)
+		}
+		lastHash = hash
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return lastHash, nil
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileSinkTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func TestFileSinkSuite(t *testing.T) {
+	suite.Run(t, new(FileSinkTestSuite))
+}
+
+func (s *FileSinkTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "audit", "audit.log")
+}
+
+func (s *FileSinkTestSuite) record(userID string) Record {
+	return Record{
+		Time:        time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC),
+		UserID:      userID,
+		Roles:       []string{"doctor"},
+		Method:      "GET",
+		Path:        "/patient/patients/42",
+		ResourceIDs: map[string]string{"patients": "42"},
+		Status:      200,
+	}
+}
+
+func (s *FileSinkTestSuite) writeRecords(users ...string) {
+	sink, err := NewFileSink(s.path)
+	s.Require().NoError(err)
+	for _, user := range users {
+		s.Require().NoError(sink.Write(context.Background(), s.record(user)))
+	}
+	s.Require().NoError(sink.Close())
+}
+
+func (s *FileSinkTestSuite) verify() error {
+	file, err := os.Open(s.path)
+	s.Require().NoError(err)
+	defer file.Close()
+	_, err = Verify(file)
+	return err
+}
+
+func (s *FileSinkTestSuite) TestWrite_ChainsRecords() {
+	s.writeRecords("1", "2")
+	s.NoError(s.verify())
+
+	data, err := os.ReadFile(s.path)
+	s.Require().NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	s.Len(lines, 2)
+	s.NotContains(lines[0], `"prevHash"`)
+	s.Contains(lines[1], `"prevHash"`)
+}
+
+func (s *FileSinkTestSuite) TestReopen_ContinuesChain() {
+	s.writeRecords("1")
+	s.writeRecords("2", "3")
+	s.NoError(s.verify())
+}
+
+func (s *FileSinkTestSuite) TestVerify_DetectsModification() {
+	s.writeRecords("1", "2", "3")
+
+	data, err := os.ReadFile(s.path)
+	s.Require().NoError(err)
+	tampered := strings.Replace(string(data), `"userId":"2"`, `"userId":"7"`, 1)
+	s.Require().NoError(os.WriteFile(s.path, []byte(tampered), 0o600))
+
+	s.ErrorContains(s.verify(), "line 2: record was modified")
+
+	_, err = NewFileSink(s.path)
+	s.ErrorContains(err, "corrupted")
+}
+
+func (s *FileSinkTestSuite) TestVerify_DetectsRemoval() {
+	s.writeRecords("1", "2", "3")
+
+	data, err := os.ReadFile(s.path)
+	s.Require().NoError(err)
+	lines := strings.SplitAfter(string(data), "\n")
+	s.Require().NoError(os.WriteFile(s.path, []byte(lines[0]+lines[2]), 0o600))
+
+	s.ErrorContains(s.verify(), "line 2: chain broken")
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
+	"github.com/nats-io/nats.go"
+)
+
+// NatsSink publishes every record as JSON to a NATS subject.
+// Tamper evidence is left to the consumer that persists the records.
+type NatsSink struct {
+	nc      *nats.Conn
+	subject string
+}
+
+func NewNatsSink(nc *nats.Conn, subject string) *NatsSink {
+	return &NatsSink{nc: nc, subject: subject}
+}
+
+func (s *NatsSink) Write(ctx context.Context, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	msg := nats.NewMsg(s.subject)
+	msg.Data = data
+	tracing.InjectNats(ctx, msg)
+	logging.InjectRequestIDNats(ctx, msg)
+
+	err = s.nc.PublishMsg(msg)
+	metrics.NatsPublished(s.subject, err)
+	if err != nil {
+		return fmt.Errorf("failed to publish audit record: %w", err)
+	}
+	return nil
+}
+
+// Close flushes pending records and closes the connection.
+func (s *NatsSink) Close() error {
+	if err := s.nc.Flush(); err != nil {
+		s.nc.Close()
+		return err
+	}
+	s.nc.Close()
+	return nil
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AnonymousUserID is the UserID of records of requests without a verified caller.
+const AnonymousUserID = "anonymous"
+
+// Record is one access to a sensitive route.
+type Record struct {
+	Time        time.Time         `json:"time"`
+	RequestID   string            `json:"requestId"`
+	UserID      string            `json:"userId"` // AnonymousUserID for requests without a verified caller
+	Roles       []string          `json:"roles"`
+	Method      string            `json:"method"`
+	Path        string            `json:"path"`
+	ResourceIDs map[string]string `json:"resourceIds"` // Resource name to ID, e.g. patients -> 42
+	Status      int               `json:"status"`
+	ClientIP    string            `json:"clientIp"`
+	PrevHash    string            `json:"prevHash,omitempty"` // Hash of the previous record, set by chaining sinks
+	Hash        string            `json:"hash,omitempty"`
+}
+
+// Sink stores audit records. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+	Close() error
+}
+
+// ComputeHash returns the chain hash of record: SHA-256 over its JSON form without the Hash field.
+// PrevHash is part of the hashed data, so changing any record breaks every hash after it.
+func ComputeHash(record Record) (string, error) {
+	record.Hash = ""
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryItem struct {
+	entry    *Entry
+	tag      string
+	removeAt time.Time
+}
+
+type memoryStore struct {
+	mu         sync.Mutex
+	items      map[string]memoryItem
+	tags       map[string]map[string]struct{}
+	maxEntries int
+	now        func() time.Time
+}
+
+// NewMemoryStore creates a Store held in the gateway process.
+// When maxEntries is reached, expired entries are swept, and new entries are dropped if there is still no room.
+func NewMemoryStore(maxEntries int) Store {
+	return &memoryStore{
+		items:      make(map[string]memoryItem),
+		tags:       make(map[string]map[string]struct{}),
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+func (m *memoryStore) Get(_ context.Context, key string) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.items[key]
+	if !ok {
+		return nil, nil
+	}
+	if !m.now().Before(item.removeAt) {
+		m.remove(key)
+		return nil, nil
+	}
+	return item.entry, nil
+}
+
+func (m *memoryStore) Set(_ context.Context, key, tag string, entry *Entry, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.items[key]; !exists && len(m.items) >= m.maxEntries {
+		m.sweep()
+		if len(m.items) >= m.maxEntries {
+			return nil
+		}
+	}
+
+	m.remove(key)
+	m.items[key] = memoryItem{entry: entry, tag: tag, removeAt: m.now().Add(ttl)}
+	if m.tags[tag] == nil {
+		m.tags[tag] = make(map[string]struct{})
+	}
+	m.tags[tag][key] = struct{}{}
+	return nil
+}
+
+func (m *memoryStore) InvalidateTag(_ context.Context, tag string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.tags[tag] {
+		delete(m.items, key)
+	}
+	delete(m.tags, tag)
+	return nil
+}
+
+// remove deletes key and its tag reference. Caller must hold the lock.
+func (m *memoryStore) remove(key string) {
+	item, ok := m.items[key]
+	if !ok {
+		return
+	}
+	delete(m.items, key)
+	if keys := m.tags[item.tag]; keys != nil {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(m.tags, item.tag)
+		}
+	}
+}
+
+// sweep deletes every entry past its removal time. Caller must hold the lock.
+func (m *memoryStore) sweep() {
+	now := m.now()
+	for key, item := range m.items {
+		if !now.Before(item.removeAt) {
+			m.remove(key)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "gateway:cache:"
+
+type redisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a Store shared by every gateway replica.
+// Each tag is a Redis set holding the keys stored with it.
+func NewRedisStore(client redis.UniversalClient) Store {
+	return &redisStore{client: client}
+}
+
+func (r *redisStore) Get(ctx context.Context, key string) (*Entry, error) {
+	data, err := r.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache entry: %w", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *redisStore) Set(ctx context.Context, key, tag string, entry *Entry, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	tagKey := redisKeyPrefix + "tag:" + tag
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, redisKeyPrefix+key, data, ttl)
+	pipe.SAdd(ctx, tagKey, key)
+	// The tag set lives at least as long as its newest entry.
+	pipe.ExpireGT(ctx, tagKey, ttl)
+	pipe.ExpireNX(ctx, tagKey, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	return nil
+}
+
+func (r *redisStore) InvalidateTag(ctx context.Context, tag string) error {
+	tagKey := redisKeyPrefix + "tag:" + tag
+	keys, err := r.client.SMembers(ctx, tagKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read cache tag: %w", err)
+	}
+
+	toDelete := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		toDelete = append(toDelete, redisKeyPrefix+key)
+	}
+	toDelete = append(toDelete, tagKey)
+
+	if err := r.client.Del(ctx, toDelete...).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate cache tag: %w", err)
+	}
+	return nil
+}
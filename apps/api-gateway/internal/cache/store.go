@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Entry is a cached upstream response.
+type Entry struct {
+	Status    int         `json:"status"`
+	Header    http.Header `json:"header"`
+	Body      []byte      `json:"body"`
+	ETag      string      `json:"etag"`
+	ExpiresAt time.Time   `json:"expiresAt"` // The entry is fresh until this moment, later it must be revalidated
+	// Vary lists the request headers the upstream response depends on. Such an entry holds
+	// no response, the responses are stored per value of these headers under their own keys.
+	Vary []string `json:"vary,omitempty"`
+}
+
+// Fresh reports whether the entry can be served without asking the upstream.
+func (e *Entry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// Store keeps cached responses. Every entry belongs to a tag,
+// so all responses of one resource can be dropped at once.
+type Store interface {
+	// Get returns the entry for key, or nil when nothing is stored.
+	Get(ctx context.Context, key string) (*Entry, error)
+	// Set stores the entry under key for ttl. The ttl covers the stale period as well.
+	Set(ctx context.Context, key, tag string, entry *Entry, ttl time.Duration) error
+	// InvalidateTag removes every entry stored with tag.
+	InvalidateTag(ctx context.Context, tag string) error
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+// DefaultAuditPrefixes cover patient data read over HTTP, including the dashboard,
+// and over gRPC and gRPC-Web, which use the /<package>.<Service>/<Method> path.
+const DefaultAuditPrefixes = "/patient/,/patient.PatientService/,/me/"
+
+type AuditConfig struct {
+	Sink     string   // Where records go: "none", "file" or "nats"
+	Prefixes []string // Gateway path prefixes whose accesses are recorded
+	FilePath string   // Audit log file of the file sink
+	Subject  string   // NATS subject of the nats sink
+}
+
+// LoadAuditConfig reads the gateway audit configuration from the environment.
+func LoadAuditConfig() (*AuditConfig, error) {
+	sink := pkgconfig.GetEnvWithDefault("GATEWAY_AUDIT_SINK", "none")
+	prefixes := pkgconfig.GetEnvWithDefault("GATEWAY_AUDIT_PREFIXES", DefaultAuditPrefixes)
+	filePath := pkgconfig.GetEnvWithDefault("GATEWAY_AUDIT_FILE", "/var/log/gateway/audit.log")
+	subject := pkgconfig.GetEnvWithDefault("GATEWAY_AUDIT_SUBJECT", "audit.access")
+
+	auditConfig := AuditConfig{
+		Sink:     sink,
+		Prefixes: ParsePrefixes(prefixes),
+		FilePath: filePath,
+		Subject:  subject,
+	}
+
+	if err := auditConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid audit configuration: %w", err)
+	}
+
+	return &auditConfig, nil
+}
+
+// ParsePrefixes splits a comma separated list of path prefixes, skipping empty items.
+func ParsePrefixes(value string) []string {
+	return parseList(value)
+}
+
+// Sensitive reports whether accesses to path must be recorded.
+func (c AuditConfig) Sensitive(path string) bool {
+	for _, prefix := range c.Prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c AuditConfig) Validate() error {
+	var errs []error
+
+	switch c.Sink {
+	case "none":
+	case "file":
+		if c.FilePath == "" {
+			errs = append(errs, fmt.Errorf("audit file path cannot be empty"))
+		}
+	case "nats":
+		if c.Subject == "" {
+			errs = append(errs, fmt.Errorf("audit subject cannot be empty"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid audit sink: %s", c.Sink))
+	}
+	for _, prefix := range c.Prefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			errs = append(errs, fmt.Errorf("audit prefix must start with '/': %s", prefix))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestAuditConfig(t *testing.T) {
+	suite.Run(t, new(AuditConfigTestSuite))
+}
+
+func (suite *AuditConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *AuditConfigTestSuite) TestLoadAuditConfig_NoEnv() {
+	cfg, err := LoadAuditConfig()
+	suite.NoError(err)
+	suite.Equal("none", cfg.Sink)
+	suite.Equal([]string{"/patient/", "/patient.PatientService/", "/me/"}, cfg.Prefixes)
+	suite.Equal("audit.access", cfg.Subject)
+}
+
+func (suite *AuditConfigTestSuite) TestLoadAuditConfig_InvalidSink() {
+	_ = os.Setenv("GATEWAY_AUDIT_SINK", "syslog")
+	_, err := LoadAuditConfig()
+	suite.ErrorContains(err, "invalid audit sink")
+}
+
+func (suite *AuditConfigTestSuite) TestLoadAuditConfig_InvalidPrefix() {
+	_ = os.Setenv("GATEWAY_AUDIT_PREFIXES", "/patient/, doctor")
+	_, err := LoadAuditConfig()
+	suite.ErrorContains(err, "audit prefix must start with '/': doctor")
+}
+
+func (suite *AuditConfigTestSuite) TestSensitive() {
+	cfg := AuditConfig{Prefixes: ParsePrefixes(" /patient/ ,,/me/")}
+	suite.True(cfg.Sensitive("/patient/patients/1"))
+	suite.True(cfg.Sensitive("/me/dashboard"))
+	suite.False(cfg.Sensitive("/doctor/doctors/1"))
+	suite.False(cfg.Sensitive("/patient"))
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+// RouteTTL overrides the cache lifetime for every gateway path starting with Prefix.
+type RouteTTL struct {
+	Prefix string
+	TTL    time.Duration
+}
+
+type CacheConfig struct {
+	Backend    string        // Cache storage: "none", "memory" or "redis"
+	DefaultTTL time.Duration // Lifetime for responses without Cache-Control max-age, 0 disables caching them
+	StaleTTL   time.Duration // How long expired entries with an ETag are kept for revalidation
+	MaxEntries int           // Upper bound of entries held by the memory backend
+	RouteTTLs  []RouteTTL    // Per-route overrides, sorted by prefix length, longest first
+}
+
+// LoadCacheConfig reads the gateway cache configuration from the environment.
+func LoadCacheConfig() (*CacheConfig, error) {
+	backend := pkgconfig.GetEnvWithDefault("GATEWAY_CACHE_BACKEND", "memory")
+	defaultTTL := pkgconfig.GetEnvWithDefault("GATEWAY_CACHE_DEFAULT_TTL", "0s")
+	staleTTL := pkgconfig.GetEnvWithDefault("GATEWAY_CACHE_STALE_TTL", "5m")
+	maxEntries := pkgconfig.GetEnvWithDefault("GATEWAY_CACHE_MAX_ENTRIES", "10000")
+	routeTTLs := pkgconfig.GetEnvWithDefault("GATEWAY_CACHE_ROUTE_TTLS", "")
+
+	defaultTTLDuration, err := time.ParseDuration(defaultTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_CACHE_DEFAULT_TTL value: %w", err)
+	}
+
+	staleTTLDuration, err := time.ParseDuration(staleTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_CACHE_STALE_TTL value: %w", err)
+	}
+
+	maxEntriesInt, err := strconv.Atoi(maxEntries)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_CACHE_MAX_ENTRIES value: %w", err)
+	}
+
+	routes, err := ParseRouteTTLs(routeTTLs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_CACHE_ROUTE_TTLS value: %w", err)
+	}
+
+	cacheConfig := CacheConfig{
+		Backend:    backend,
+		DefaultTTL: defaultTTLDuration,
+		StaleTTL:   staleTTLDuration,
+		MaxEntries: maxEntriesInt,
+		RouteTTLs:  routes,
+	}
+
+	if err := cacheConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cache configuration: %w", err)
+	}
+
+	return &cacheConfig, nil
+}
+
+// ParseRouteTTLs parses a comma separated list of prefix=duration pairs,
+// for example "/doctor/doctors=5m,/doctor/slots=30s".
+// The result is sorted so that the most specific prefix comes first.
+func ParseRouteTTLs(value string) ([]RouteTTL, error) {
+	var routes []RouteTTL
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		prefix, ttl, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("route ttl %q must be in prefix=duration format", pair)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(ttl))
+		if err != nil {
+			return nil, fmt.Errorf("route ttl %q: %w", pair, err)
+		}
+		routes = append(routes, RouteTTL{Prefix: strings.TrimSpace(prefix), TTL: duration})
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].Prefix) > len(routes[j].Prefix)
+	})
+	return routes, nil
+}
+
+// RouteTTL returns the configured override for path and whether one exists.
+func (c CacheConfig) RouteTTL(path string) (time.Duration, bool) {
+	for _, route := range c.RouteTTLs {
+		if strings.HasPrefix(path, route.Prefix) {
+			return route.TTL, true
+		}
+	}
+	return 0, false
+}
+
+func (c CacheConfig) Validate() error {
+	var errs []error
+
+	switch c.Backend {
+	case "none", "memory", "redis":
+	default:
+		errs = append(errs, fmt.Errorf("invalid cache backend: %s", c.Backend))
+	}
+	if c.DefaultTTL < 0 {
+		errs = append(errs, fmt.Errorf("cache default ttl cannot be negative"))
+	}
+	if c.StaleTTL < 0 {
+		errs = append(errs, fmt.Errorf("cache stale ttl cannot be negative"))
+	}
+	if c.MaxEntries <= 0 {
+		errs = append(errs, fmt.Errorf("cache max entries must be greater than 0"))
+	}
+	for _, route := range c.RouteTTLs {
+		if !strings.HasPrefix(route.Prefix, "/") {
+			errs = append(errs, fmt.Errorf("cache route prefix must start with '/': %s", route.Prefix))
+		}
+		if route.TTL < 0 {
+			errs = append(errs, fmt.Errorf("cache route ttl cannot be negative: %s", route.Prefix))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
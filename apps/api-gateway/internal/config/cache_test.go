@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestCacheConfig(t *testing.T) {
+	suite.Run(t, new(CacheConfigTestSuite))
+}
+
+func (suite *CacheConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *CacheConfigTestSuite) TestLoadCacheConfig_NoEnv() {
+	cfg, err := LoadCacheConfig()
+	suite.NoError(err)
+	suite.Equal("memory", cfg.Backend)
+	suite.Equal(time.Duration(0), cfg.DefaultTTL)
+	suite.Equal(5*time.Minute, cfg.StaleTTL)
+	suite.Equal(10000, cfg.MaxEntries)
+	suite.Empty(cfg.RouteTTLs)
+}
+
+func (suite *CacheConfigTestSuite) TestLoadCacheConfig_InvalidBackend() {
+	_ = os.Setenv("GATEWAY_CACHE_BACKEND", "memcached")
+	_, err := LoadCacheConfig()
+	suite.ErrorContains(err, "invalid cache backend")
+}
+
+func (suite *CacheConfigTestSuite) TestLoadCacheConfig_InvalidDuration() {
+	_ = os.Setenv("GATEWAY_CACHE_DEFAULT_TTL", "often")
+	_, err := LoadCacheConfig()
+	suite.ErrorContains(err, "GATEWAY_CACHE_DEFAULT_TTL")
+}
+
+func (suite *CacheConfigTestSuite) TestParseRouteTTLs_LongestPrefixFirst() {
+	routes, err := ParseRouteTTLs("/doctor=1m, /doctor/slots=10s ,")
+	suite.NoError(err)
+	suite.Equal([]RouteTTL{
+		{Prefix: "/doctor/slots", TTL: 10 * time.Second},
+		{Prefix: "/doctor", TTL: time.Minute},
+	}, routes)
+
+	ttl, ok := CacheConfig{RouteTTLs: routes}.RouteTTL("/doctor/slots/5")
+	suite.True(ok)
+	suite.Equal(10*time.Second, ttl)
+
+	_, ok = CacheConfig{RouteTTLs: routes}.RouteTTL("/patient/1")
+	suite.False(ok)
+}
+
+func (suite *CacheConfigTestSuite) TestParseRouteTTLs_Invalid() {
+	_, err := ParseRouteTTLs("/doctor")
+	suite.ErrorContains(err, "prefix=duration")
+
+	_, err = ParseRouteTTLs("/doctor=soon")
+	suite.Error(err)
+}
+
+func (suite *CacheConfigTestSuite) TestValidate_RelativePrefix() {
+	cfg := CacheConfig{Backend: "memory", MaxEntries: 1, RouteTTLs: []RouteTTL{{Prefix: "doctor", TTL: time.Second}}}
+	suite.ErrorContains(cfg.Validate(), "must start with '/'")
+}
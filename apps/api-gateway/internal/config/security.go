@@ -0,0 +1,91 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+type SecurityConfig struct {
+	AllowedOrigins        []string      // Browser origins allowed to call the gateway with credentials
+	HSTSMaxAge            time.Duration // Max age of Strict-Transport-Security, 0 disables the header
+	ContentSecurityPolicy string        // Value of the Content-Security-Policy header
+	CSRFEnabled           bool          // Whether cookie authenticated state-changing requests need the CSRF token
+	CookieSecure          bool          // Whether cookies set through the gateway are marked Secure
+}
+
+// LoadSecurityConfig reads the gateway CORS, security headers and CSRF configuration from the environment.
+func LoadSecurityConfig() (*SecurityConfig, error) {
+	origins := pkgconfig.GetEnvWithDefault("GATEWAY_CORS_ORIGINS", "http://localhost:3000")
+	hstsMaxAge := pkgconfig.GetEnvWithDefault("GATEWAY_HSTS_MAX_AGE", "8760h")
+	csp := pkgconfig.GetEnvWithDefault("GATEWAY_CSP", "default-src 'self'; frame-ancestors 'none'")
+	csrf := pkgconfig.GetEnvWithDefault("GATEWAY_CSRF_ENABLED", "true")
+	cookieSecure := pkgconfig.GetEnvWithDefault("GATEWAY_COOKIE_SECURE", "true")
+
+	hstsDuration, err := time.ParseDuration(hstsMaxAge)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_HSTS_MAX_AGE value: %w", err)
+	}
+	csrfEnabled, err := strconv.ParseBool(csrf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_CSRF_ENABLED value: %w", err)
+	}
+	cookieSecureBool, err := strconv.ParseBool(cookieSecure)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_COOKIE_SECURE value: %w", err)
+	}
+
+	securityConfig := SecurityConfig{
+		AllowedOrigins:        parseList(origins),
+		HSTSMaxAge:            hstsDuration,
+		ContentSecurityPolicy: csp,
+		CSRFEnabled:           csrfEnabled,
+		CookieSecure:          cookieSecureBool,
+	}
+
+	if err := securityConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid security configuration: %w", err)
+	}
+
+	return &securityConfig, nil
+}
+
+// parseList splits a comma separated list, skipping empty items.
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (c SecurityConfig) Validate() error {
+	var errs []error
+
+	for _, origin := range c.AllowedOrigins {
+		// Credentials are allowed, so every origin must be listed explicitly.
+		if origin == "*" {
+			errs = append(errs, fmt.Errorf("wildcard CORS origin is not allowed with credentials"))
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			errs = append(errs, fmt.Errorf("invalid CORS origin: %s", origin))
+		}
+	}
+	if c.HSTSMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("HSTS max age cannot be negative"))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SecurityConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestSecurityConfig(t *testing.T) {
+	suite.Run(t, new(SecurityConfigTestSuite))
+}
+
+func (suite *SecurityConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *SecurityConfigTestSuite) TestLoadSecurityConfig_NoEnv() {
+	cfg, err := LoadSecurityConfig()
+	suite.NoError(err)
+	suite.Equal([]string{"http://localhost:3000"}, cfg.AllowedOrigins)
+	suite.Equal(8760*time.Hour, cfg.HSTSMaxAge)
+	suite.Contains(cfg.ContentSecurityPolicy, "frame-ancestors 'none'")
+	suite.True(cfg.CSRFEnabled)
+	suite.True(cfg.CookieSecure)
+}
+
+func (suite *SecurityConfigTestSuite) TestLoadSecurityConfig_Origins() {
+	_ = os.Setenv("GATEWAY_CORS_ORIGINS", " https://clinic.example.com, ,http://localhost:5173")
+	cfg, err := LoadSecurityConfig()
+	suite.NoError(err)
+	suite.Equal([]string{"https://clinic.example.com", "http://localhost:5173"}, cfg.AllowedOrigins)
+}
+
+func (suite *SecurityConfigTestSuite) TestLoadSecurityConfig_WildcardOrigin() {
+	_ = os.Setenv("GATEWAY_CORS_ORIGINS", "*")
+	_, err := LoadSecurityConfig()
+	suite.ErrorContains(err, "wildcard CORS origin is not allowed")
+}
+
+func (suite *SecurityConfigTestSuite) TestLoadSecurityConfig_InvalidOrigin() {
+	_ = os.Setenv("GATEWAY_CORS_ORIGINS", "clinic.example.com")
+	_, err := LoadSecurityConfig()
+	suite.ErrorContains(err, "invalid CORS origin: clinic.example.com")
+}
+
+func (suite *SecurityConfigTestSuite) TestLoadSecurityConfig_InvalidValues() {
+	_ = os.Setenv("GATEWAY_HSTS_MAX_AGE", "year")
+	_, err := LoadSecurityConfig()
+	suite.ErrorContains(err, "invalid GATEWAY_HSTS_MAX_AGE value")
+
+	os.Clearenv()
+	_ = os.Setenv("GATEWAY_CSRF_ENABLED", "maybe")
+	_, err = LoadSecurityConfig()
+	suite.ErrorContains(err, "invalid GATEWAY_CSRF_ENABLED value")
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+// Validation modes of the gateway.
+const (
+	ValidationOff      = "off"      // Requests are proxied without checks
+	ValidationRequests = "requests" // Requests violating the OpenAPI spec are rejected
+	ValidationDev      = "dev"      // As requests, upstream responses are also checked and drift is logged
+)
+
+type ValidationConfig struct {
+	Mode            string        // One of ValidationOff, ValidationRequests, ValidationDev
+	RefreshInterval time.Duration // How often the service specs are reloaded
+}
+
+// LoadValidationConfig reads the gateway OpenAPI validation configuration from the environment.
+func LoadValidationConfig() (*ValidationConfig, error) {
+	mode := pkgconfig.GetEnvWithDefault("GATEWAY_VALIDATION_MODE", ValidationOff)
+	refresh := pkgconfig.GetEnvWithDefault("GATEWAY_VALIDATION_REFRESH", "1m")
+
+	refreshDuration, err := time.ParseDuration(refresh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GATEWAY_VALIDATION_REFRESH value: %w", err)
+	}
+
+	validationConfig := ValidationConfig{
+		Mode:            mode,
+		RefreshInterval: refreshDuration,
+	}
+
+	if err := validationConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid validation configuration: %w", err)
+	}
+
+	return &validationConfig, nil
+}
+
+// Enabled reports whether requests are validated.
+func (c ValidationConfig) Enabled() bool {
+	return c.Mode == ValidationRequests || c.Mode == ValidationDev
+}
+
+// ValidateResponses reports whether upstream responses are checked for contract drift.
+func (c ValidationConfig) ValidateResponses() bool {
+	return c.Mode == ValidationDev
+}
+
+func (c ValidationConfig) Validate() error {
+	var errs []error
+
+	switch c.Mode {
+	case ValidationOff, ValidationRequests, ValidationDev:
+	default:
+		errs = append(errs, fmt.Errorf("invalid validation mode: %s", c.Mode))
+	}
+	if c.RefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("validation refresh interval must be greater than 0"))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
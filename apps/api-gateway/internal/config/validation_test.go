@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidationConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestValidationConfig(t *testing.T) {
+	suite.Run(t, new(ValidationConfigTestSuite))
+}
+
+func (suite *ValidationConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *ValidationConfigTestSuite) TestLoadValidationConfig_NoEnv() {
+	cfg, err := LoadValidationConfig()
+	suite.NoError(err)
+	suite.Equal(ValidationOff, cfg.Mode)
+	suite.Equal(time.Minute, cfg.RefreshInterval)
+	suite.False(cfg.Enabled())
+	suite.False(cfg.ValidateResponses())
+}
+
+func (suite *ValidationConfigTestSuite) TestLoadValidationConfig_Modes() {
+	_ = os.Setenv("GATEWAY_VALIDATION_MODE", ValidationRequests)
+	cfg, err := LoadValidationConfig()
+	suite.NoError(err)
+	suite.True(cfg.Enabled())
+	suite.False(cfg.ValidateResponses())
+
+	_ = os.Setenv("GATEWAY_VALIDATION_MODE", ValidationDev)
+	cfg, err = LoadValidationConfig()
+	suite.NoError(err)
+	suite.True(cfg.Enabled())
+	suite.True(cfg.ValidateResponses())
+}
+
+func (suite *ValidationConfigTestSuite) TestLoadValidationConfig_InvalidMode() {
+	_ = os.Setenv("GATEWAY_VALIDATION_MODE", "strict")
+	_, err := LoadValidationConfig()
+	suite.ErrorContains(err, "invalid validation mode")
+}
+
+func (suite *ValidationConfigTestSuite) TestLoadValidationConfig_InvalidRefresh() {
+	_ = os.Setenv("GATEWAY_VALIDATION_REFRESH", "0s")
+	_, err := LoadValidationConfig()
+	suite.ErrorContains(err, "refresh interval")
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"errors"
+	"strings"
+)
+
+// SubjectPrefix starts the NATS subjects of events meant for one user.
+// Services publish to events.user.<userID>.<event>, e.g. events.user.42.appointment.confirmed.
+const SubjectPrefix = "events.user."
+
+// Event is one real-time update for a user.
+type Event struct {
+	Name string // Event name, the subject after the user ID, e.g. appointment.confirmed
+	Data []byte // Payload as published, usually JSON
+}
+
+// Source delivers the events of a user. Implementations must be safe for concurrent use.
+type Source interface {
+	// Subscribe sends the events of userID to events until unsubscribe is called.
+	// Events are dropped when the channel is full, a slow client must not hold up the others.
+	Subscribe(userID string, events chan<- Event) (unsubscribe func(), err error)
+}
+
+// UserSubject returns the wildcard subject covering every event of userID.
+func UserSubject(userID string) (string, error) {
+	if userID == "" || strings.ContainsAny(userID, ".*> \t\r\n") {
+		return "", errors.New("invalid user ID for event subject")
+	}
+	return SubjectPrefix + userID + ".>", nil
+}
+
+// EventName returns the event name of a subject of userID.
+func EventName(subject, userID string) string {
+	return strings.TrimPrefix(subject, SubjectPrefix+userID+".")
+}
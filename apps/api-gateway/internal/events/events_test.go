@@ -0,0 +1,30 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EventsTestSuite struct {
+	suite.Suite
+}
+
+func TestEventsSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
+
+func (s *EventsTestSuite) TestUserSubject() {
+	subject, err := UserSubject("42")
+	s.NoError(err)
+	s.Equal("events.user.42.>", subject)
+
+	for _, userID := range []string{"", "*", "42.>", "4 2", ">"} {
+		_, err := UserSubject(userID)
+		s.Error(err, userID)
+	}
+}
+
+func (s *EventsTestSuite) TestEventName() {
+	s.Equal("appointment.confirmed", EventName("events.user.42.appointment.confirmed", "42"))
+}
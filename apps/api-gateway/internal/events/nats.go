@@ -0,0 +1,47 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/nats-io/nats.go"
+)
+
+// NatsSource reads user events from NATS, one subscription per connected client.
+type NatsSource struct {
+	nc *nats.Conn
+}
+
+func NewNatsSource(nc *nats.Conn) *NatsSource {
+	return &NatsSource{nc: nc}
+}
+
+func (s *NatsSource) Subscribe(userID string, events chan<- Event) (func(), error) {
+	subject, err := UserSubject(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.nc.Subscribe(subject, func(msg *nats.Msg) {
+		event := Event{Name: EventName(msg.Subject, userID), Data: msg.Data}
+		select {
+		case events <- event:
+		default:
+			logging.Logger.WithField("subject", msg.Subject).Warn("Dropped event for slow client")
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+	}
+
+	return func() {
+		if err := sub.Unsubscribe(); err != nil {
+			logging.Logger.WithError(err).WithField("subject", subject).Warn("Failed to unsubscribe from user events")
+		}
+	}, nil
+}
+
+// Close closes the NATS connection.
+func (s *NatsSource) Close() {
+	s.nc.Close()
+}
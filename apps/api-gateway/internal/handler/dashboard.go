@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"api-gateway/internal/middleware"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// maxSectionSize limits how much of an upstream response is read into the dashboard.
+const maxSectionSize = 1 << 20
+
+// patientSection is the section holding the caller's patient record, its ID addresses the other patient sections.
+const patientSection = "patient"
+
+// dashboardSection is one upstream call of the dashboard.
+type dashboardSection struct {
+	name    string
+	service string
+	// needsPatient sections are fetched after the patient record, with its ID.
+	needsPatient bool
+	// path builds the upstream path from the caller and the patient ID, an error skips the call.
+	path func(userID, patientID string) (string, error)
+}
+
+// SectionResult holds the data of one dashboard section, or the reason it is missing.
+type SectionResult struct {
+	Status int             `json:"status,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type DashboardUser struct {
+	ID    string   `json:"id"`
+	Roles []string `json:"roles"`
+}
+
+type DashboardResponse struct {
+	User     DashboardUser            `json:"user"`
+	Partial  bool                     `json:"partial"` // True when at least one section failed
+	Sections map[string]SectionResult `json:"sections"`
+}
+
+type DashboardHandler struct {
+	client   *http.Client
+	services map[string]middleware.ServiceConfig
+	timeout  time.Duration
+	sections []dashboardSection
+}
+
+// NewDashboardHandler creates the handler of the /me endpoints.
+// Upstreams are called through services, each call is limited by timeout.
+func NewDashboardHandler(services map[string]middleware.ServiceConfig, timeout time.Duration) *DashboardHandler {
+	return &DashboardHandler{
+		client:   &http.Client{},
+		services: services,
+		timeout:  timeout,
+		sections: []dashboardSection{
+			{name: patientSection, service: "patient", path: func(userID, _ string) (string, error) {
+				return "/patients/user/" + url.PathEscape(userID), nil
+			}},
+			{name: "allergies", service: "patient", needsPatient: true, path: patientPath("/patients/%s/allergies")},
+			{name: "insurances", service: "patient", needsPatient: true, path: patientPath("/patients/%s/insurances")},
+			{name: "prescriptions", service: "patient", needsPatient: true, path: patientPath("/patients/%s/prescriptions")},
+			{name: "appointments", service: "appointment", path: func(userID, _ string) (string, error) {
+				return "/user/" + url.PathEscape(userID), nil
+			}},
+		},
+	}
+}
+
+// patientPath builds paths addressed by the ID of the caller's patient record.
+// The record is looked up by the verified user, a patient ID from the client is never used.
+func patientPath(format string) func(string, string) (string, error) {
+	return func(_ string, patientID string) (string, error) {
+		if patientID == "" {
+			return "", fmt.Errorf("patient record is not available")
+		}
+		return fmt.Sprintf(format, url.PathEscape(patientID)), nil
+	}
+}
+
+func (h *DashboardHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/dashboard", h.Dashboard)
+}
+
+// Dashboard combines the caller's patient record, medical data and appointments into one document.
+// The caller comes from the access token checked by TokenMiddleware, the patient record is the one
+// linked to that user. Sections are fetched in parallel, the medical data once the patient record is known.
+// A failing upstream only fails its own section, the response is marked partial.
+// When every section fails, the status is 502.
+func (h *DashboardHandler) Dashboard(c *gin.Context) {
+	claims, ok := middleware.Identity(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	token := middleware.AccessToken(c)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	userID := claims.UserID.String()
+	results := make([]SectionResult, len(h.sections))
+	h.fetchSections(ctx, results, false, userID, "", token)
+	h.fetchSections(ctx, results, true, userID, h.patientID(results), token)
+
+	response := DashboardResponse{
+		User:     DashboardUser{ID: userID, Roles: claims.Roles},
+		Sections: make(map[string]SectionResult, len(h.sections)),
+	}
+	failed := 0
+	for i, section := range h.sections {
+		if results[i].Error != "" {
+			failed++
+			logging.GinEntry(c).WithField("section", section.name).Warnf("Dashboard section failed: %s", results[i].Error)
+		}
+		response.Sections[section.name] = results[i]
+	}
+	response.Partial = failed > 0
+
+	status := http.StatusOK
+	if failed == len(h.sections) {
+		status = http.StatusBadGateway
+	}
+	c.JSON(status, response)
+}
+
+// fetchSections fetches the sections with the given needsPatient in parallel into results.
+// Support function for Dashboard.
+func (h *DashboardHandler) fetchSections(ctx context.Context, results []SectionResult, needsPatient bool, userID, patientID, token string) {
+	var wg sync.WaitGroup
+	for i, section := range h.sections {
+		if section.needsPatient != needsPatient {
+			continue
+		}
+		path, err := section.path(userID, patientID)
+		if err != nil {
+			results[i] = SectionResult{Error: err.Error()}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, section dashboardSection, path string) {
+			defer wg.Done()
+			results[i] = h.fetch(ctx, section.service, path, token)
+		}(i, section, path)
+	}
+	wg.Wait()
+}
+
+// patientID returns the ID of the fetched patient record, or an empty string when it is missing.
+// Support function for Dashboard.
+func (h *DashboardHandler) patientID(results []SectionResult) string {
+	for i, section := range h.sections {
+		if section.name != patientSection || results[i].Data == nil {
+			continue
+		}
+		var patient struct {
+			ID json.Number `json:"id"`
+		}
+		if err := json.Unmarshal(results[i].Data, &patient); err != nil {
+			return ""
+		}
+		return patient.ID.String()
+	}
+	return ""
+}
+
+// fetch calls one upstream with the caller identity, the correlation ID and the trace context.
+// Support function for Dashboard.
+func (h *DashboardHandler) fetch(ctx context.Context, serviceName, path, token string) SectionResult {
+	service, ok := h.services[serviceName]
+	if !ok {
+		return SectionResult{Error: fmt.Sprintf("service %s is not configured", serviceName)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, service.Url+path, nil)
+	if err != nil {
+		return SectionResult{Error: fmt.Sprintf("failed to create request: %v", err)}
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(middleware.AccessTokenKey, token)
+	req.Header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(ctx))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return SectionResult{Error: fmt.Sprintf("%s service unavailable", serviceName)}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSectionSize))
+	if err != nil {
+		return SectionResult{Status: resp.StatusCode, Error: fmt.Sprintf("failed to read %s response", serviceName)}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return SectionResult{Status: resp.StatusCode, Error: fmt.Sprintf("%s service responded with %d", serviceName, resp.StatusCode)}
+	}
+	if !json.Valid(body) {
+		return SectionResult{Status: resp.StatusCode, Error: fmt.Sprintf("%s service returned invalid JSON", serviceName)}
+	}
+	return SectionResult{Status: resp.StatusCode, Data: body}
+}
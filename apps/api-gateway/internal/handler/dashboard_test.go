@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"api-gateway/internal/middleware"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type DashboardHandlerTestSuite struct {
+	suite.Suite
+	patient     *httptest.Server
+	appointment *httptest.Server
+	router      *gin.Engine
+	seenTokens  chan string
+}
+
+func TestDashboardHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DashboardHandlerTestSuite))
+}
+
+func (s *DashboardHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	s.seenTokens = make(chan string, 10)
+
+	patient := gin.New()
+	patient.GET("/patients/user/:userId", func(c *gin.Context) {
+		s.seenTokens <- c.GetHeader(middleware.AccessTokenKey)
+		if c.Param("userId") != "42" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "patient not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": 7, "user_id": 42})
+	})
+	// Like the patient service, records are addressed by the numeric patient ID
+	record := patient.Group("/patients/:id", func(c *gin.Context) {
+		if _, err := strconv.ParseInt(c.Param("id"), 10, 64); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
+		}
+	})
+	record.GET("", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"id": c.Param("id")}) })
+	record.GET("/allergies", func(c *gin.Context) { c.JSON(http.StatusOK, []gin.H{{"patientId": c.Param("id")}}) })
+	record.GET("/insurances", func(c *gin.Context) { c.JSON(http.StatusInternalServerError, gin.H{"error": "db down"}) })
+	record.GET("/prescriptions", func(c *gin.Context) { c.JSON(http.StatusOK, []gin.H{}) })
+	s.patient = httptest.NewServer(patient)
+
+	appointment := gin.New()
+	appointment.GET("/user/:user_id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"appointments": []gin.H{{"userId": c.Param("user_id")}}})
+	})
+	s.appointment = httptest.NewServer(appointment)
+
+	s.router = s.newRouter(map[string]middleware.ServiceConfig{
+		"patient":     {Name: "patient", Url: s.patient.URL},
+		"appointment": {Name: "appointment", Url: s.appointment.URL},
+	})
+}
+
+func (s *DashboardHandlerTestSuite) TearDownTest() {
+	s.patient.Close()
+	s.appointment.Close()
+}
+
+// testTokenHeader carries the token the test router puts into the context, standing in for TokenMiddleware.
+const testTokenHeader = "Test-Verified-Token"
+
+func (s *DashboardHandlerTestSuite) newRouter(services map[string]middleware.ServiceConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if token := c.GetHeader(testTokenHeader); token != "" {
+			c.Set(middleware.AccessTokenKey, token)
+		}
+	})
+	NewDashboardHandler(services, time.Second).RegisterRoutes(r.Group("/me"))
+	return r
+}
+
+func testToken() string {
+	return tokenFor(`{"userId":42,"roles":["patient"]}`)
+}
+
+func tokenFor(payload string) string {
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func (s *DashboardHandlerTestSuite) get(router *gin.Engine, path string, token string) (*httptest.ResponseRecorder, DashboardResponse) {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set(testTokenHeader, token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp DashboardResponse
+	if w.Code != http.StatusUnauthorized {
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w, resp
+}
+
+func (s *DashboardHandlerTestSuite) TestDashboard_Unauthenticated() {
+	w, _ := s.get(s.router, "/me/dashboard", "")
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (s *DashboardHandlerTestSuite) TestDashboard_ClientTokenHeaderIgnored() {
+	req := httptest.NewRequest(http.MethodGet, "/me/dashboard", nil)
+	req.Header.Set(middleware.AccessTokenKey, testToken())
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusUnauthorized, w.Code, "Only the token verified by TokenMiddleware identifies the caller")
+}
+
+func (s *DashboardHandlerTestSuite) TestDashboard_PartialResults() {
+	w, resp := s.get(s.router, "/me/dashboard", testToken())
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("42", resp.User.ID)
+	s.Equal([]string{"patient"}, resp.User.Roles)
+	s.True(resp.Partial, "Failed insurances section should mark the response partial")
+
+	s.JSONEq(`{"id":7,"user_id":42}`, string(resp.Sections["patient"].Data))
+	s.JSONEq(`[{"patientId":"7"}]`, string(resp.Sections["allergies"].Data), "Medical data should be read for the caller's own record")
+	s.JSONEq(`{"appointments":[{"userId":"42"}]}`, string(resp.Sections["appointments"].Data))
+
+	insurances := resp.Sections["insurances"]
+	s.Equal(http.StatusInternalServerError, insurances.Status)
+	s.Contains(insurances.Error, "responded with 500")
+	s.Empty(insurances.Data)
+
+	s.Equal(testToken(), <-s.seenTokens, "Caller identity should be forwarded upstream")
+}
+
+func (s *DashboardHandlerTestSuite) TestDashboard_PatientIDQueryIgnored() {
+	_, resp := s.get(s.router, "/me/dashboard?patientId=99", testToken())
+
+	s.JSONEq(`[{"patientId":"7"}]`, string(resp.Sections["allergies"].Data), "Patient ID from the client should not be used")
+}
+
+func (s *DashboardHandlerTestSuite) TestDashboard_NoPatientRecord() {
+	w, resp := s.get(s.router, "/me/dashboard", tokenFor(`{"userId":5,"roles":["patient"]}`))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.True(resp.Partial)
+	s.Equal(http.StatusNotFound, resp.Sections["patient"].Status)
+	s.Contains(resp.Sections["allergies"].Error, "patient record")
+	s.Empty(resp.Sections["appointments"].Error, "Sections not needing the patient should still load")
+}
+
+func (s *DashboardHandlerTestSuite) TestDashboard_AllUpstreamsDown() {
+	s.patient.Close()
+	s.appointment.Close()
+
+	w, resp := s.get(s.router, "/me/dashboard", testToken())
+
+	s.Equal(http.StatusBadGateway, w.Code)
+	s.True(resp.Partial)
+	s.Contains(resp.Sections["appointments"].Error, "unavailable")
+}
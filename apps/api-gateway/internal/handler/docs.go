@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"api-gateway/internal/middleware"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSpecSize limits how much of a service document is read.
+const maxSpecSize = 4 << 20
+
+// swaggerUICSP relaxes the gateway CSP for the Swagger UI page, its assets come from the CDN
+// and it is started by an inline script.
+const swaggerUICSP = "default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com; " +
+	"style-src 'self' https://unpkg.com; img-src 'self' data:; frame-ancestors 'none'"
+
+// swaggerUIPage loads Swagger UI from a CDN and points it at the merged document.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>OnlineClinic API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "%s", dom_id: "#swagger-ui", withCredentials: true });
+  </script>
+</body>
+</html>`
+
+type DocsHandler struct {
+	client   *http.Client
+	services map[string]middleware.ServiceConfig
+	timeout  time.Duration
+}
+
+// NewDocsHandler creates the handler of the /docs endpoints.
+// Every service in services must serve its document at /openapi.json, each fetch is limited by timeout.
+func NewDocsHandler(services map[string]middleware.ServiceConfig, timeout time.Duration) *DocsHandler {
+	return &DocsHandler{
+		client:   &http.Client{},
+		services: services,
+		timeout:  timeout,
+	}
+}
+
+func (h *DocsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("", h.SwaggerUI)
+	router.GET("/openapi.json", h.Spec)
+}
+
+// SwaggerUI serves the Swagger UI page for the merged document.
+func (h *DocsHandler) SwaggerUI(c *gin.Context) {
+	c.Header("Content-Security-Policy", swaggerUICSP)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(fmt.Sprintf(swaggerUIPage, c.FullPath()+"/openapi.json")))
+}
+
+// Spec serves the merged document of all services.
+// Services that don't answer are left out and listed in x-unavailable-services.
+// When no service answers, the status is 502.
+func (h *DocsHandler) Spec(c *gin.Context) {
+	merged, unavailable, err := h.Load(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "No service documentation available"})
+		return
+	}
+
+	if len(unavailable) > 0 {
+		merged.Extensions = map[string]any{"x-unavailable-services": unavailable}
+	}
+	c.JSON(http.StatusOK, merged)
+}
+
+// Load fetches the documents of all services in parallel and merges them under their public prefixes.
+// It also returns the sorted names of services that didn't answer, and fails only when none did.
+func (h *DocsHandler) Load(ctx context.Context) (*openapi3.T, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		docs        = make(map[string]*openapi3.T, len(h.services))
+		unavailable []string
+	)
+	for name, service := range h.services {
+		wg.Add(1)
+		go func(name string, service middleware.ServiceConfig) {
+			defer wg.Done()
+			doc, err := h.fetch(ctx, service)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logging.FromContext(ctx).WithError(err).WithField("service", name).Warn("Failed to load service OpenAPI document")
+				unavailable = append(unavailable, name)
+				return
+			}
+			docs[name] = doc
+		}(name, service)
+	}
+	wg.Wait()
+	sort.Strings(unavailable)
+
+	if len(docs) == 0 {
+		return nil, unavailable, errors.New("no service documentation available")
+	}
+	return openapi.Merge("OnlineClinic API", "1.0.0", docs), unavailable, nil
+}
+
+// fetch loads the document served by one service.
+// Support function for Load.
+func (h *DocsHandler) fetch(ctx context.Context, service middleware.ServiceConfig) (*openapi3.T, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, service.Url+"/openapi.json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(ctx))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s service responded with %d", service.Name, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSpecSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return openapi3.NewLoader().LoadFromData(body)
+}
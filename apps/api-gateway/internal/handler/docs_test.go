@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"api-gateway/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type DocsHandlerTestSuite struct {
+	suite.Suite
+	doctor *httptest.Server
+	broken *httptest.Server
+}
+
+func TestDocsHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DocsHandlerTestSuite))
+}
+
+func (s *DocsHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+
+	doc := openapi.NewDocument("Doctor service", "1.0.0")
+	s.Require().NoError(doc.Add(openapi.Operation{
+		Method:    http.MethodGet,
+		Path:      "/doctors/:id",
+		Responses: map[int]any{http.StatusOK: nil},
+		Secured:   true,
+	}))
+	doctor := gin.New()
+	doctor.GET("/openapi.json", doc.Handler())
+	s.doctor = httptest.NewServer(doctor)
+
+	broken := gin.New()
+	broken.GET("/openapi.json", func(c *gin.Context) { c.String(http.StatusInternalServerError, "boom") })
+	s.broken = httptest.NewServer(broken)
+}
+
+func (s *DocsHandlerTestSuite) TearDownTest() {
+	s.doctor.Close()
+	s.broken.Close()
+}
+
+func (s *DocsHandlerTestSuite) newRouter(services map[string]middleware.ServiceConfig) *gin.Engine {
+	router := gin.New()
+	NewDocsHandler(services, time.Second).RegisterRoutes(router.Group("/docs"))
+	return router
+}
+
+func (s *DocsHandlerTestSuite) TestSpecMergesServices() {
+	router := s.newRouter(map[string]middleware.ServiceConfig{
+		"doctor":  {Name: "doctor", Url: s.doctor.URL},
+		"patient": {Name: "patient", Url: s.broken.URL},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	merged, err := openapi3.NewLoader().LoadFromData(w.Body.Bytes())
+	s.Require().NoError(err)
+	item := merged.Paths.Value("/doctor/doctors/{id}")
+	s.Require().NotNil(item)
+	s.Equal([]string{"doctor"}, item.Get.Tags)
+	s.Equal("cookie", merged.Components.SecuritySchemes[openapi.SecuritySchemeName].Value.In)
+	s.Equal([]any{"patient"}, merged.Extensions["x-unavailable-services"])
+}
+
+func (s *DocsHandlerTestSuite) TestSpecAllServicesDown() {
+	router := s.newRouter(map[string]middleware.ServiceConfig{
+		"patient": {Name: "patient", Url: s.broken.URL},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	s.Equal(http.StatusBadGateway, w.Code)
+}
+
+func (s *DocsHandlerTestSuite) TestSwaggerUI() {
+	router := s.newRouter(map[string]middleware.ServiceConfig{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Header().Get("Content-Type"), "text/html")
+	s.Contains(w.Body.String(), `url: "/docs/openapi.json"`)
+	s.Contains(w.Header().Get("Content-Security-Policy"), "https://unpkg.com")
+}
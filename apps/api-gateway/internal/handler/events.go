@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"api-gateway/internal/events"
+	"api-gateway/internal/middleware"
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// eventBuffer is how many events are kept for a client that is still writing the previous ones.
+const eventBuffer = 64
+
+type EventsHandler struct {
+	source    events.Source
+	heartbeat time.Duration
+}
+
+// NewEventsHandler creates the handler of the /events endpoint.
+// A comment is sent every heartbeat, so proxies don't close idle streams.
+func NewEventsHandler(source events.Source, heartbeat time.Duration) *EventsHandler {
+	return &EventsHandler{
+		source:    source,
+		heartbeat: heartbeat,
+	}
+}
+
+func (h *EventsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("", h.Stream)
+}
+
+// Stream sends the events of the caller as Server-Sent Events until the client disconnects.
+// The caller must be authenticated by TokenMiddleware, an access token sent by the client
+// in a header is not trusted here, it would let anyone listen to the events of any user.
+func (h *EventsHandler) Stream(c *gin.Context) {
+	claims, ok := middleware.Identity(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	userID := claims.UserID.String()
+
+	userEvents := make(chan events.Event, eventBuffer)
+	unsubscribe, err := h.source.Subscribe(userID, userEvents)
+	if err != nil {
+		logging.GinEntry(c).WithError(err).Error("Failed to subscribe to user events")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Events unavailable"})
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	// The comment sends the headers right away, the client knows the stream is open.
+	if !h.write(c, []byte(": connected\n\n")) {
+		return
+	}
+	logging.GinEntry(c).WithField("userId", userID).Debug("Event stream opened")
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			logging.GinEntry(c).WithField("userId", userID).Debug("Event stream closed")
+			return
+		case event := <-userEvents:
+			if !h.write(c, encodeEvent(event)) {
+				return
+			}
+		case <-ticker.C:
+			if !h.write(c, []byte(": ping\n\n")) {
+				return
+			}
+		}
+	}
+}
+
+// write sends data to the client immediately, it reports false once the client is gone.
+// Support function for Stream.
+func (h *EventsHandler) write(c *gin.Context, data []byte) bool {
+	if _, err := c.Writer.Write(data); err != nil {
+		return false
+	}
+	c.Writer.Flush()
+	return true
+}
+
+// encodeEvent formats event as a Server-Sent Event, each line of the payload in its own data field.
+func encodeEvent(event events.Event) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "event: %s\n", event.Name)
+	for _, line := range bytes.Split(event.Data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimSuffix(line, []byte("\r")))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"api-gateway/internal/events"
+	"api-gateway/internal/middleware"
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// memorySource delivers events published in the test to the subscribed users.
+type memorySource struct {
+	mu          sync.Mutex
+	subscribers map[string]chan<- events.Event
+	subscribed  chan string
+	err         error
+}
+
+func (s *memorySource) Subscribe(userID string, userEvents chan<- events.Event) (func(), error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.mu.Lock()
+	s.subscribers[userID] = userEvents
+	s.mu.Unlock()
+	s.subscribed <- userID
+
+	return func() {
+		s.mu.Lock()
+		delete(s.subscribers, userID)
+		s.mu.Unlock()
+	}, nil
+}
+
+func (s *memorySource) publish(userID string, event events.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch, ok := s.subscribers[userID]; ok {
+		ch <- event
+	}
+}
+
+func (s *memorySource) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+type EventsHandlerTestSuite struct {
+	suite.Suite
+	source *memorySource
+	server *httptest.Server
+}
+
+func TestEventsHandlerSuite(t *testing.T) {
+	suite.Run(t, new(EventsHandlerTestSuite))
+}
+
+func (s *EventsHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	s.source = &memorySource{subscribers: map[string]chan<- events.Event{}, subscribed: make(chan string, 1)}
+
+	r := gin.New()
+	// Stands in for TokenMiddleware, which sets the token validated by the auth service.
+	r.Use(func(c *gin.Context) {
+		if token := c.GetHeader("X-Test-Token"); token != "" {
+			c.Set(middleware.AccessTokenKey, token)
+		}
+	})
+	NewEventsHandler(s.source, 50*time.Millisecond).RegisterRoutes(r.Group("/events"))
+	s.server = httptest.NewServer(r)
+}
+
+func (s *EventsHandlerTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *EventsHandlerTestSuite) open(ctx context.Context, header, token string) *http.Response {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.server.URL+"/events", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Accept", "text/event-stream")
+	if token != "" {
+		req.Header.Set(header, token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	return resp
+}
+
+// readEvent reads the stream up to the next event, skipping comments.
+func (s *EventsHandlerTestSuite) readEvent(reader *bufio.Reader) []string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		s.Require().NoError(err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && len(lines) > 0:
+			return lines
+		case line == "" || strings.HasPrefix(line, ":"):
+		default:
+			lines = append(lines, line)
+		}
+	}
+}
+
+func (s *EventsHandlerTestSuite) TestStream_DeliversUserEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	resp := s.open(ctx, "X-Test-Token", testToken())
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+	s.Equal("42", <-s.source.subscribed)
+
+	s.source.publish("42", events.Event{Name: "appointment.confirmed", Data: []byte(`{"id":7}`)})
+	s.source.publish("42", events.Event{Name: "slot.opened", Data: []byte("first\nsecond")})
+
+	reader := bufio.NewReader(resp.Body)
+	s.Equal([]string{"event: appointment.confirmed", `data: {"id":7}`}, s.readEvent(reader))
+	s.Equal([]string{"event: slot.opened", "data: first", "data: second"}, s.readEvent(reader))
+
+	cancel()
+	s.Eventually(func() bool { return s.source.count() == 0 }, time.Second, 10*time.Millisecond,
+		"the subscription ends with the client")
+}
+
+func (s *EventsHandlerTestSuite) TestStream_Heartbeat() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp := s.open(ctx, "X-Test-Token", testToken())
+	defer resp.Body.Close()
+	<-s.source.subscribed
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Equal(": connected\n", line)
+	_, _ = reader.ReadString('\n')
+	line, err = reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Equal(": ping\n", line)
+}
+
+func (s *EventsHandlerTestSuite) TestStream_RequiresGatewayAuthentication() {
+	resp := s.open(context.Background(), "", "")
+	_ = resp.Body.Close()
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+
+	// A token the client puts in the header itself was never checked by the auth service.
+	resp = s.open(context.Background(), middleware.AccessTokenKey, testToken())
+	_ = resp.Body.Close()
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Equal(0, s.source.count())
+}
+
+func (s *EventsHandlerTestSuite) TestStream_SourceUnavailable() {
+	s.source.err = errors.New("nats down")
+
+	resp := s.open(context.Background(), "X-Test-Token", testToken())
+	_ = resp.Body.Close()
+	s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"api-gateway/internal/audit"
+	gwconfig "api-gateway/internal/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Audit records every access to the sensitive routes of cfg in sink, after the response is known.
+// The caller comes from the access token verified by TokenMiddleware, requests without one are recorded
+// as anonymous, whatever identity the client claims. The resource IDs from the path and the *Id query parameters.
+// A sink failure is logged and doesn't fail the request, it was already answered.
+// Must be registered after TokenMiddleware.
+func Audit(sink audit.Sink, cfg gwconfig.AuditConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if sink == nil || !cfg.Sensitive(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		started := time.Now().UTC()
+		c.Next()
+
+		record := audit.Record{
+			Time:        started,
+			RequestID:   logging.RequestIDFromContext(c.Request.Context()),
+			UserID:      audit.AnonymousUserID,
+			Roles:       []string{},
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			ResourceIDs: resourceIDs(c),
+			Status:      c.Writer.Status(),
+			ClientIP:    c.ClientIP(),
+		}
+		if claims, ok := Identity(c); ok {
+			record.UserID = claims.UserID.String()
+			if claims.Roles != nil {
+				record.Roles = claims.Roles
+			}
+		}
+
+		if err := sink.Write(c.Request.Context(), record); err != nil {
+			logging.GinEntry(c).WithError(err).Error("Failed to write audit record")
+		}
+	}
+}
+
+// resourceIDs resolves the resources a request touches.
+// In the path, an ID segment is named by the segment before it: /patient/patients/42/allergies
+// gives patients=42. Query parameters ending in "id", like patientId, are kept under their own name.
+func resourceIDs(c *gin.Context) map[string]string {
+	ids := make(map[string]string)
+
+	segments := strings.Split(strings.Trim(c.Request.URL.Path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if isResourceID(segments[i]) {
+			ids[segments[i-1]] = segments[i]
+		}
+	}
+
+	for name, values := range c.Request.URL.Query() {
+		if len(values) > 0 && values[0] != "" && strings.HasSuffix(strings.ToLower(name), "id") {
+			ids[name] = values[0]
+		}
+	}
+	return ids
+}
+
+// isResourceID reports whether a path segment is an ID: a UUID or a number.
+func isResourceID(segment string) bool {
+	if _, err := uuid.Parse(segment); err == nil {
+		return true
+	}
+	_, err := strconv.ParseInt(segment, 10, 64)
+	return err == nil
+}
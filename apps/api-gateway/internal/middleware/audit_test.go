@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"api-gateway/internal/audit"
+	gwconfig "api-gateway/internal/config"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	records []audit.Record
+	err     error
+}
+
+func (s *memorySink) Write(_ context.Context, record audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+type AuditTestSuite struct {
+	suite.Suite
+	sink   *memorySink
+	router *gin.Engine
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
+
+func (s *AuditTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	s.sink = &memorySink{}
+	cfg := gwconfig.AuditConfig{Sink: "file", Prefixes: gwconfig.ParsePrefixes(gwconfig.DefaultAuditPrefixes)}
+
+	s.router = gin.New()
+	s.router.Use(logging.RequestIDMiddleware(), verifiedToken, Audit(s.sink, cfg))
+	s.router.Any("/patient/*proxyPath", func(c *gin.Context) { c.Status(http.StatusOK) })
+	s.router.POST("/patient.PatientService/:method", func(c *gin.Context) { c.Status(http.StatusOK) })
+	s.router.GET("/me/dashboard", func(c *gin.Context) { c.Status(http.StatusForbidden) })
+	s.router.GET("/doctor/*proxyPath", func(c *gin.Context) { c.Status(http.StatusOK) })
+}
+
+func (s *AuditTestSuite) do(method, path, token string) {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set(testTokenHeader, token)
+	}
+	s.router.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func tokenWithRoles(payload string) string {
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func (s *AuditTestSuite) TestSensitiveRoute_Recorded() {
+	id := "0b8e9f9a-2f6e-4b55-9d59-3a8f1f3cbd11"
+	s.do(http.MethodGet, "/patient/patients/"+id+"/allergies", tokenWithRoles(`{"userId":7,"roles":["doctor"]}`))
+
+	s.Require().Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal("7", record.UserID)
+	s.Equal([]string{"doctor"}, record.Roles)
+	s.Equal(http.MethodGet, record.Method)
+	s.Equal(http.StatusOK, record.Status)
+	s.Equal(map[string]string{"patients": id}, record.ResourceIDs)
+	s.NotEmpty(record.RequestID)
+	s.False(record.Time.IsZero())
+}
+
+func (s *AuditTestSuite) TestQueryIDs_RecordedWithStatus() {
+	s.do(http.MethodGet, "/me/dashboard?patientId=42&page=2", "")
+
+	s.Require().Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal(audit.AnonymousUserID, record.UserID)
+	s.Equal([]string{}, record.Roles)
+	s.Equal(http.StatusForbidden, record.Status)
+	s.Equal(map[string]string{"patientId": "42"}, record.ResourceIDs)
+}
+
+func (s *AuditTestSuite) TestClientToken_RecordedAsAnonymous() {
+	req := httptest.NewRequest(http.MethodGet, "/patient/patients/42", nil)
+	req.Header.Set(AccessTokenKey, tokenWithRoles(`{"userId":7,"roles":["admin"]}`))
+	s.router.ServeHTTP(httptest.NewRecorder(), req)
+
+	s.Require().Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal(audit.AnonymousUserID, record.UserID, "Unverified token should not name the caller")
+	s.Equal([]string{}, record.Roles)
+}
+
+func (s *AuditTestSuite) TestGRPCPatientCall_Recorded() {
+	s.do(http.MethodPost, "/patient.PatientService/GetPatient", tokenWithRoles(`{"userId":7,"roles":["doctor"]}`))
+
+	s.Require().Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal("7", record.UserID)
+	s.Equal("/patient.PatientService/GetPatient", record.Path)
+	s.Equal(http.StatusOK, record.Status)
+}
+
+func (s *AuditTestSuite) TestOtherRoutes_NotRecorded() {
+	s.do(http.MethodGet, "/doctor/doctors/1", "")
+	s.Empty(s.sink.records)
+}
+
+func (s *AuditTestSuite) TestSinkFailure_DoesNotFailRequest() {
+	s.sink.err = errors.New("disk full")
+
+	req := httptest.NewRequest(http.MethodDelete, "/patient/allergies/1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
@@ -1,12 +1,21 @@
 package middleware
 
 import (
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"net/http"
 )
 
+// TokenMiddleware exchanges the session cookie for an access token at the auth service
+// and stores it in the context under AccessTokenKey, see AccessToken.
+// A client sent X-Access-Token header is removed first, so a forged token never reaches
+// the handlers or the upstreams.
 func TokenMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		c.Request.Header.Del(AccessTokenKey)
+
 		token, err := c.Cookie("token")
 		if err != nil || token == "" {
 			c.Next()
@@ -15,7 +24,7 @@ func TokenMiddleware() gin.HandlerFunc {
 
 		client := &http.Client{}
 
-		req, err := http.NewRequest("GET", "http://auth:8080/refresh", nil)
+		req, err := http.NewRequestWithContext(c.Request.Context(), "GET", "http://auth:8080/refresh", nil)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 				"error": "Failed to create auth request",
@@ -24,6 +33,8 @@ func TokenMiddleware() gin.HandlerFunc {
 		}
 
 		req.AddCookie(&http.Cookie{Name: "token", Value: token})
+		req.Header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(c.Request.Context()))
+		otel.GetTextMapPropagator().Inject(c.Request.Context(), propagation.HeaderCarrier(req.Header))
 
 		resp, err := client.Do(req)
 		if err != nil {
@@ -42,7 +53,7 @@ func TokenMiddleware() gin.HandlerFunc {
 		}
 
 		if newToken := resp.Header.Get("X-Access-Token"); newToken != "" {
-			c.Set("X-Access-Token", newToken)
+			c.Set(AccessTokenKey, newToken)
 		}
 
 		c.Next()
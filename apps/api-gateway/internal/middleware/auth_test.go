@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// testTokenHeader carries the token verifiedToken puts into the context.
+const testTokenHeader = "Test-Verified-Token"
+
+// verifiedToken stands in for TokenMiddleware in tests.
+func verifiedToken(c *gin.Context) {
+	if token := c.GetHeader(testTokenHeader); token != "" {
+		c.Set(AccessTokenKey, token)
+	}
+}
+
+type TokenMiddlewareTestSuite struct {
+	suite.Suite
+}
+
+func TestTokenMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TokenMiddlewareTestSuite))
+}
+
+func (s *TokenMiddlewareTestSuite) TestClientTokenHeaderRemoved() {
+	gin.SetMode(gin.TestMode)
+	var seenHeader, seenToken string
+	r := gin.New()
+	r.Use(TokenMiddleware())
+	r.GET("/", func(c *gin.Context) {
+		seenHeader = c.GetHeader(AccessTokenKey)
+		seenToken = AccessToken(c)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(AccessTokenKey, tokenWithRoles(`{"userId":1,"roles":["admin"]}`))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Empty(seenHeader, "Client sent token should not reach the handlers")
+	s.Empty(seenToken, "Anonymous request should have no access token")
+}
@@ -0,0 +1,387 @@
+package middleware
+
+import (
+	"api-gateway/internal/cache"
+	gwconfig "api-gateway/internal/config"
+	"bytes"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// CacheStatusHeader tells the client how the response was produced: HIT, MISS or REVALIDATED.
+const CacheStatusHeader = "X-Cache"
+
+// uncachedHeaders are never stored, they are either per connection or per request.
+var uncachedHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization", "Te", "Trailer",
+	"Transfer-Encoding", "Upgrade", "Set-Cookie", "Date", "Content-Length",
+	logging.RequestIDHeader, CacheStatusHeader,
+}
+
+// ResponseCache caches GET responses of a proxied service.
+//
+// Freshness comes from the per-route override in cfg, otherwise from the upstream
+// Cache-Control (s-maxage, max-age), otherwise from cfg.DefaultTTL. Responses marked no-store,
+// or carrying cookies, are never stored. Expired entries with an ETag are revalidated with
+// If-None-Match, and a 304 from the upstream refreshes the stored copy.
+//
+// Responses naming request headers in Vary are stored per value of these headers, so e.g.
+// responses localized by Accept-Language are served only to clients asking for the same language.
+// Vary: * responses are never stored.
+//
+// Requests of a verified caller get their own entries, so private responses never leak between users.
+// Requests with credentials TokenMiddleware did not verify, an Authorization header or a session
+// without an access token, bypass the cache: their identity can't be trusted to pick an entry.
+// A successful non-GET request drops every cached response of the same resource,
+// the resource being the first path segment after the service, e.g. /doctor/slots.
+// Must be registered after TokenMiddleware.
+func ResponseCache(store cache.Store, cfg gwconfig.CacheConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if store == nil {
+			c.Next()
+			return
+		}
+
+		path := c.Request.URL.Path
+		tag := resourceTag(path)
+
+		switch c.Request.Method {
+		case http.MethodGet:
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			c.Next()
+			if status := c.Writer.Status(); status < http.StatusBadRequest {
+				if err := store.InvalidateTag(c.Request.Context(), tag); err != nil {
+					logging.GinEntry(c).WithError(err).Warn("Failed to invalidate cached responses")
+				}
+			}
+			return
+		default:
+			c.Next()
+			return
+		}
+
+		requestDirectives := parseCacheControl(c.GetHeader("Cache-Control"))
+		if _, noStore := requestDirectives["no-store"]; noStore || isStreamingRequest(c.Request) {
+			c.Next()
+			return
+		}
+
+		identity, ok := requestIdentity(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		key := cacheKey(c.Request, identity)
+		now := time.Now()
+
+		entry, vary, err := lookupEntry(c, store, key)
+		if err != nil {
+			logging.GinEntry(c).WithError(err).Warn("Failed to read cached response")
+			entry = nil
+		}
+
+		_, noCache := requestDirectives["no-cache"]
+		if entry != nil && entry.Fresh(now) && !noCache {
+			writeEntry(c, entry, "HIT")
+			return
+		}
+
+		// An expired entry can still be reused when the upstream confirms the ETag.
+		// A client sending its own If-None-Match gets the upstream answer as is.
+		revalidate := entry != nil && entry.ETag != "" && c.GetHeader("If-None-Match") == ""
+		if revalidate {
+			c.Request.Header.Set("If-None-Match", entry.ETag)
+		}
+
+		writer := newBufferedWriter(c.Writer)
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+		if revalidate {
+			c.Request.Header.Del("If-None-Match")
+		}
+
+		if revalidate && writer.status == http.StatusNotModified {
+			ttl, storable := responseTTL(cfg, path, writer.header, identity != "")
+			if !storable {
+				ttl = 0
+			}
+			entry.ExpiresAt = now.Add(ttl)
+			saveEntry(c, store, key, tag, vary, entry, ttl, cfg.StaleTTL)
+			writeEntry(c, entry, "REVALIDATED")
+			return
+		}
+
+		writer.header.Set(CacheStatusHeader, "MISS")
+		writer.flush()
+
+		if writer.status != http.StatusOK || len(writer.header.Values("Set-Cookie")) > 0 {
+			return
+		}
+		ttl, storable := responseTTL(cfg, path, writer.header, identity != "")
+		if !storable {
+			return
+		}
+		vary, storable = responseVary(writer.header)
+		if !storable {
+			return
+		}
+
+		saveEntry(c, store, key, tag, vary, &cache.Entry{
+			Status:    writer.status,
+			Header:    storedHeader(writer.header),
+			Body:      writer.body.Bytes(),
+			ETag:      writer.header.Get("ETag"),
+			ExpiresAt: now.Add(ttl),
+		}, ttl, cfg.StaleTTL)
+	}
+}
+
+// lookupEntry returns the entry stored for the request and the Vary headers it was selected by.
+// Support function for ResponseCache.
+func lookupEntry(c *gin.Context, store cache.Store, key string) (*cache.Entry, []string, error) {
+	entry, err := store.Get(c.Request.Context(), key)
+	if err != nil || entry == nil || len(entry.Vary) == 0 {
+		return entry, nil, err
+	}
+	vary := entry.Vary
+	entry, err = store.Get(c.Request.Context(), variantKey(key, vary, c.Request.Header))
+	return entry, vary, err
+}
+
+// saveEntry stores entry, keeping it past its freshness when it can be revalidated.
+// With vary the entry goes under the key of its variant, and key remembers the Vary headers.
+// Support function for ResponseCache.
+func saveEntry(c *gin.Context, store cache.Store, key, tag string, vary []string, entry *cache.Entry, ttl, staleTTL time.Duration) {
+	keep := ttl
+	if entry.ETag != "" {
+		keep += staleTTL
+	}
+	if keep <= 0 {
+		return
+	}
+	if len(vary) > 0 {
+		if err := store.Set(c.Request.Context(), key, tag, &cache.Entry{Vary: vary}, keep); err != nil {
+			logging.GinEntry(c).WithError(err).Warn("Failed to store cached response")
+			return
+		}
+		key = variantKey(key, vary, c.Request.Header)
+	}
+	if err := store.Set(c.Request.Context(), key, tag, entry, keep); err != nil {
+		logging.GinEntry(c).WithError(err).Warn("Failed to store cached response")
+	}
+}
+
+// writeEntry sends a cached response, answering 304 when the client already has it.
+// Support function for ResponseCache.
+func writeEntry(c *gin.Context, entry *cache.Entry, status string) {
+	header := c.Writer.Header()
+	for name, values := range entry.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	header.Set(CacheStatusHeader, status)
+
+	if entry.ETag != "" && c.GetHeader("If-None-Match") == entry.ETag {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		c.Abort()
+		return
+	}
+
+	header.Set("Content-Length", strconv.Itoa(len(entry.Body)))
+	c.Status(entry.Status)
+	_, _ = c.Writer.Write(entry.Body)
+	c.Abort()
+}
+
+// responseTTL decides how long a response stays fresh.
+// The second value is false when the response must not be stored at all.
+func responseTTL(cfg gwconfig.CacheConfig, path string, header http.Header, perUser bool) (time.Duration, bool) {
+	directives := parseCacheControl(header.Get("Cache-Control"))
+	if _, ok := directives["no-store"]; ok {
+		return 0, false
+	}
+	if _, ok := directives["private"]; ok && !perUser {
+		return 0, false
+	}
+
+	if ttl, ok := cfg.RouteTTL(path); ok {
+		return ttl, true
+	}
+	if _, ok := directives["no-cache"]; ok {
+		return 0, true
+	}
+	for _, name := range []string{"s-maxage", "max-age"} {
+		if value, ok := directives[name]; ok {
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return 0, true
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return cfg.DefaultTTL, true
+}
+
+// parseCacheControl splits a Cache-Control header into lowercase directives and their values.
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), `"`)
+	}
+	return directives
+}
+
+// resourceTag groups the cached responses of one resource: /doctor/slots/5 belongs to /doctor/slots.
+func resourceTag(path string) string {
+	segments := strings.SplitN(strings.Trim(path, "/"), "/", 3)
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// cacheKey builds the entry key from the path, the query and the caller identity.
+func cacheKey(req *http.Request, identity string) string {
+	key := req.URL.Path
+	if query := req.URL.Query().Encode(); query != "" {
+		key += "?" + query
+	}
+	if accept := req.Header.Get("Accept"); accept != "" {
+		key += "|accept=" + accept
+	}
+	if identity != "" {
+		key += "|user=" + identity
+	}
+	return key
+}
+
+// responseVary returns the request headers named in the Vary of the response, canonical and sorted.
+// The second value is false for Vary: *, the response can't be matched to later requests.
+func responseVary(header http.Header) ([]string, bool) {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			switch {
+			case name == "*":
+				return nil, false
+			case name != "":
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names), true
+}
+
+// variantKey extends key with the values of the vary request headers.
+func variantKey(key string, vary []string, header http.Header) string {
+	for _, name := range vary {
+		key += "|" + strings.ToLower(name) + "=" + strings.Join(header.Values(name), ",")
+	}
+	return key
+}
+
+// requestIdentity returns the caller identity used to separate cache entries, or an empty string for anonymous requests.
+// The user ID comes from the access token TokenMiddleware got from the auth service.
+// The second value is false when the request carries credentials that were not verified.
+func requestIdentity(c *gin.Context) (string, bool) {
+	if token := AccessToken(c); token != "" {
+		if claims, ok := ParseAccessClaims(token); ok {
+			return claims.UserID.String(), true
+		}
+		return "", false
+	}
+	if c.GetHeader("Authorization") != "" {
+		return "", false
+	}
+	if cookie, err := c.Cookie("token"); err == nil && cookie != "" {
+		return "", false
+	}
+	return "", true
+}
+
+// isStreamingRequest reports whether the response is a stream that can't be buffered.
+func isStreamingRequest(req *http.Request) bool {
+	return req.Header.Get("Upgrade") != "" ||
+		strings.Contains(req.Header.Get("Accept"), "text/event-stream")
+}
+
+// storedHeader copies the response headers that are safe to replay.
+func storedHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	for _, name := range uncachedHeaders {
+		stored.Del(name)
+	}
+	return stored
+}
+
+// bufferedWriter holds the upstream response, so it can be inspected and stored before it is sent.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedWriter(w gin.ResponseWriter) *bufferedWriter {
+	return &bufferedWriter{
+		ResponseWriter: w,
+		header:         w.Header().Clone(),
+		status:         http.StatusOK,
+	}
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0 || w.status != http.StatusOK
+}
+
+func (w *bufferedWriter) Flush() {}
+
+// flush sends the buffered response to the underlying writer.
+func (w *bufferedWriter) flush() {
+	header := w.ResponseWriter.Header()
+	for name, values := range w.header {
+		header[name] = values
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,276 @@
+package middleware
+
+import (
+	"api-gateway/internal/cache"
+	gwconfig "api-gateway/internal/config"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResponseCacheTestSuite struct {
+	suite.Suite
+	store    cache.Store
+	cfg      gwconfig.CacheConfig
+	router   *gin.Engine
+	calls    int
+	upstream func(c *gin.Context)
+}
+
+func TestResponseCacheSuite(t *testing.T) {
+	suite.Run(t, new(ResponseCacheTestSuite))
+}
+
+func (s *ResponseCacheTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.store = cache.NewMemoryStore(100)
+	s.cfg = gwconfig.CacheConfig{Backend: "memory", StaleTTL: time.Minute, MaxEntries: 100}
+	s.calls = 0
+	s.upstream = func(c *gin.Context) {
+		c.Header("Cache-Control", "max-age=60")
+		c.String(http.StatusOK, "response %d", s.calls)
+	}
+}
+
+func (s *ResponseCacheTestSuite) newRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(verifiedToken)
+	r.Any("/doctor/*proxyPath", ResponseCache(s.store, s.cfg), func(c *gin.Context) {
+		s.calls++
+		s.upstream(c)
+	})
+	return r
+}
+
+func (s *ResponseCacheTestSuite) do(router *gin.Engine, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func accessToken(userID int) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"userId":%d}`, userID)))
+	return "header." + payload + ".signature"
+}
+
+func (s *ResponseCacheTestSuite) TestGet_CachedByMaxAge() {
+	router := s.newRouter()
+
+	first := s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	second := s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+
+	s.Equal(1, s.calls, "Second request should be served from cache")
+	s.Equal("MISS", first.Header().Get(CacheStatusHeader))
+	s.Equal("HIT", second.Header().Get(CacheStatusHeader))
+	s.Equal(first.Body.String(), second.Body.String())
+}
+
+func (s *ResponseCacheTestSuite) TestGet_NoStoreNotCached() {
+	s.upstream = func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.String(http.StatusOK, "secret")
+	}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+
+	s.Equal(2, s.calls)
+}
+
+func (s *ResponseCacheTestSuite) TestGet_NoHeadersUsesDefaultTTL() {
+	s.upstream = func(c *gin.Context) { c.String(http.StatusOK, "plain") }
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors", nil)
+	s.Equal(2, s.calls, "Default TTL of zero should disable caching")
+
+	s.cfg.DefaultTTL = time.Minute
+	router = s.newRouter()
+	s.do(router, http.MethodGet, "/doctor/doctors", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors", nil)
+	s.Equal(3, s.calls)
+}
+
+func (s *ResponseCacheTestSuite) TestGet_RouteOverride() {
+	s.cfg.RouteTTLs = []gwconfig.RouteTTL{{Prefix: "/doctor/slots", TTL: 0}}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/slots/1", nil)
+	s.do(router, http.MethodGet, "/doctor/slots/1", nil)
+
+	s.Equal(2, s.calls, "Route override of zero should win over max-age")
+}
+
+func (s *ResponseCacheTestSuite) TestGet_SeparateEntriesPerVaryHeader() {
+	s.upstream = func(c *gin.Context) {
+		c.Header("Cache-Control", "max-age=60")
+		c.Header("Vary", "Accept-Language")
+		c.String(http.StatusOK, "response in %s", c.GetHeader("Accept-Language"))
+	}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/specializations", map[string]string{"Accept-Language": "en"})
+	kk := s.do(router, http.MethodGet, "/doctor/specializations", map[string]string{"Accept-Language": "kk"})
+	en := s.do(router, http.MethodGet, "/doctor/specializations", map[string]string{"Accept-Language": "en"})
+
+	s.Equal(2, s.calls, "Each language should have an own entry")
+	s.Equal("MISS", kk.Header().Get(CacheStatusHeader))
+	s.Equal("response in kk", kk.Body.String())
+	s.Equal("HIT", en.Header().Get(CacheStatusHeader))
+	s.Equal("response in en", en.Body.String())
+}
+
+func (s *ResponseCacheTestSuite) TestGet_VaryStarNotCached() {
+	s.upstream = func(c *gin.Context) {
+		c.Header("Cache-Control", "max-age=60")
+		c.Header("Vary", "*")
+		c.String(http.StatusOK, "varies")
+	}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+
+	s.Equal(2, s.calls)
+}
+
+func (s *ResponseCacheTestSuite) TestGet_SeparateEntriesPerUser() {
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{testTokenHeader: accessToken(1)})
+	s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{testTokenHeader: accessToken(2)})
+	hit := s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{testTokenHeader: accessToken(1)})
+
+	s.Equal(2, s.calls, "Each user should have an own entry")
+	s.Equal("HIT", hit.Header().Get(CacheStatusHeader))
+}
+
+func (s *ResponseCacheTestSuite) TestGet_UnverifiedCredentialsBypassCache() {
+	router := s.newRouter()
+
+	// A forged token in the header is not an identity, and must not reach the entry of user 1
+	s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{testTokenHeader: accessToken(1)})
+	forged := s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{AccessTokenKey: accessToken(1)})
+	s.Equal(2, s.calls, "Client sent token should not select a cached entry")
+	s.Equal("MISS", forged.Header().Get(CacheStatusHeader))
+
+	for _, headers := range []map[string]string{
+		{"Authorization": "Bearer abc"},
+		{"Cookie": "token=abc"},
+		{testTokenHeader: "not-a-token"},
+	} {
+		s.do(router, http.MethodGet, "/doctor/doctors/2", headers)
+		s.do(router, http.MethodGet, "/doctor/doctors/2", headers)
+	}
+	s.Equal(8, s.calls, "Requests with unverified credentials should never use the cache")
+
+	s.do(router, http.MethodGet, "/doctor/doctors/2", nil)
+	s.Equal(9, s.calls, "Responses to unverified requests should not be stored for anonymous callers")
+}
+
+func (s *ResponseCacheTestSuite) TestGet_PrivateOnlyCachedPerUser() {
+	s.upstream = func(c *gin.Context) {
+		c.Header("Cache-Control", "private, max-age=60")
+		c.String(http.StatusOK, "mine")
+	}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	s.Equal(2, s.calls, "Anonymous private responses should not be stored")
+
+	s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{testTokenHeader: accessToken(1)})
+	s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{testTokenHeader: accessToken(1)})
+	s.Equal(3, s.calls)
+}
+
+func (s *ResponseCacheTestSuite) TestGet_RevalidatesWithETag() {
+	s.upstream = func(c *gin.Context) {
+		if c.GetHeader("If-None-Match") == `"v1"` {
+			c.Header("Cache-Control", "no-cache")
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.Header("Cache-Control", "no-cache")
+		c.Header("ETag", `"v1"`)
+		c.String(http.StatusOK, "versioned")
+	}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	second := s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+
+	s.Equal(2, s.calls, "no-cache responses should be revalidated")
+	s.Equal("REVALIDATED", second.Header().Get(CacheStatusHeader))
+	s.Equal(http.StatusOK, second.Code)
+	s.Equal("versioned", second.Body.String())
+}
+
+func (s *ResponseCacheTestSuite) TestGet_ClientETagMatch() {
+	s.upstream = func(c *gin.Context) {
+		c.Header("Cache-Control", "max-age=60")
+		c.Header("ETag", `"v1"`)
+		c.String(http.StatusOK, "versioned")
+	}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	w := s.do(router, http.MethodGet, "/doctor/doctors/1", map[string]string{"If-None-Match": `"v1"`})
+
+	s.Equal(1, s.calls)
+	s.Equal(http.StatusNotModified, w.Code)
+	s.Empty(w.Body.String())
+}
+
+func (s *ResponseCacheTestSuite) TestMutation_InvalidatesResource() {
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	s.do(router, http.MethodGet, "/doctor/slots/1", nil)
+	s.Equal(3, s.calls)
+
+	s.do(router, http.MethodPut, "/doctor/doctors/1", nil)
+	s.Equal(4, s.calls)
+
+	s.do(router, http.MethodGet, "/doctor/doctors", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	s.do(router, http.MethodGet, "/doctor/slots/1", nil)
+	s.Equal(6, s.calls, "Only the mutated resource should be invalidated")
+}
+
+func (s *ResponseCacheTestSuite) TestMutation_FailedKeepsCache() {
+	router := s.newRouter()
+	s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+
+	s.upstream = func(c *gin.Context) { c.Status(http.StatusBadRequest) }
+	s.do(router, http.MethodDelete, "/doctor/doctors/1", nil)
+
+	w := s.do(router, http.MethodGet, "/doctor/doctors/1", nil)
+	s.Equal("HIT", w.Header().Get(CacheStatusHeader))
+}
+
+func (s *ResponseCacheTestSuite) TestGet_NonOKNotCached() {
+	s.upstream = func(c *gin.Context) {
+		c.Header("Cache-Control", "max-age=60")
+		c.Status(http.StatusNotFound)
+	}
+	router := s.newRouter()
+
+	s.do(router, http.MethodGet, "/doctor/doctors/404", nil)
+	s.do(router, http.MethodGet, "/doctor/doctors/404", nil)
+
+	s.Equal(2, s.calls)
+}
@@ -0,0 +1,246 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"golang.org/x/net/http2"
+)
+
+const (
+	grpcContentType        = "application/grpc"
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// grpcWebTrailerFlag marks the frame carrying trailers in a gRPC-Web response body.
+	grpcWebTrailerFlag = 0x80
+)
+
+// grpcTransport talks plaintext HTTP/2 (h2c) to upstream gRPC servers.
+var grpcTransport = &http2.Transport{
+	AllowHTTP: true,
+	DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, addr)
+	},
+}
+
+// grpcHopHeaders are dropped when a gRPC request is forwarded.
+var grpcHopHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Connection", "Transfer-Encoding", "Upgrade", "Content-Length", "Host",
+}
+
+// GRPCProxy forwards gRPC calls to the gRPC port of service.
+// Native gRPC (HTTP/2, application/grpc) is proxied as is. gRPC-Web calls from browsers
+// (application/grpc-web and application/grpc-web-text) are translated to native gRPC,
+// and the upstream trailers are sent back as the final gRPC-Web frame.
+// The route must be /<package>.<Service>/:method, the path is passed upstream unchanged.
+// Methods missing from service.GrpcMethods, when it is set, are answered as unimplemented.
+func GRPCProxy(service *ServiceConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if service.GrpcPort == "" {
+			writeGRPCError(c, grpcCodeUnimplemented, fmt.Sprintf("%s service has no gRPC port", service.Name))
+			return
+		}
+		method := strings.TrimPrefix(c.Request.URL.Path, "/")
+		if len(service.GrpcMethods) > 0 && !slices.Contains(service.GrpcMethods, method) {
+			writeGRPCError(c, grpcCodeUnimplemented, fmt.Sprintf("method %s is not exposed", method))
+			return
+		}
+		remote := &url.URL{Scheme: "http", Host: net.JoinHostPort(service.Host, service.GrpcPort)}
+
+		contentType := c.GetHeader("Content-Type")
+		switch {
+		case strings.HasPrefix(contentType, grpcWebContentType):
+			proxyGRPCWeb(c, remote, strings.HasPrefix(contentType, grpcWebTextContentType))
+		case strings.HasPrefix(contentType, grpcContentType):
+			if c.Request.ProtoMajor != 2 {
+				c.AbortWithStatusJSON(http.StatusHTTPVersionNotSupported, gin.H{"error": "gRPC requires HTTP/2"})
+				return
+			}
+			proxyGRPC(c, remote)
+		default:
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{"error": "Unsupported gRPC content type"})
+		}
+	}
+}
+
+// proxyGRPC streams a native gRPC call, trailers included, to remote.
+// Support function for GRPCProxy.
+func proxyGRPC(c *gin.Context, remote *url.URL) {
+	proxy := &httputil.ReverseProxy{
+		Transport:     grpcTransport,
+		FlushInterval: -1,
+		Director: func(req *http.Request) {
+			req.URL.Scheme = remote.Scheme
+			req.URL.Host = remote.Host
+			req.Host = remote.Host
+			setUpstreamIdentity(c, req.Header)
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			logging.GinEntry(c).WithError(err).Error("gRPC upstream call failed")
+			writeGRPCError(c, grpcCodeUnavailable, "upstream unavailable")
+		},
+	}
+	proxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// proxyGRPCWeb translates a gRPC-Web call into a native gRPC call to remote.
+// Support function for GRPCProxy.
+func proxyGRPCWeb(c *gin.Context, remote *url.URL, textMode bool) {
+	var body io.Reader = c.Request.Body
+	if textMode {
+		body = base64.NewDecoder(base64.StdEncoding, c.Request.Body)
+	}
+
+	req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodPost, remote.String()+c.Request.URL.Path, body)
+	if err != nil {
+		writeGRPCError(c, grpcCodeInternal, "failed to create upstream request")
+		return
+	}
+	req.Header = c.Request.Header.Clone()
+	for _, name := range grpcHopHeaders {
+		req.Header.Del(name)
+	}
+	req.Header.Del("X-Grpc-Web")
+	req.Header.Set("Content-Type", grpcContentType+grpcWebCodec(c.GetHeader("Content-Type")))
+	req.Header.Set("Te", "trailers")
+	setUpstreamIdentity(c, req.Header)
+
+	resp, err := grpcTransport.RoundTrip(req)
+	if err != nil {
+		logging.GinEntry(c).WithError(err).Error("gRPC-Web upstream call failed")
+		writeGRPCError(c, grpcCodeUnavailable, "upstream unavailable")
+		return
+	}
+	defer resp.Body.Close()
+
+	header := c.Writer.Header()
+	for name, values := range resp.Header {
+		switch name {
+		case "Content-Type", "Content-Length", "Trailer":
+			continue
+		}
+		header[name] = values
+	}
+	responseType := grpcWebContentType + "+proto"
+	if textMode {
+		responseType = grpcWebTextContentType + "+proto"
+	}
+	header.Set("Content-Type", responseType)
+	c.Status(http.StatusOK)
+
+	var out io.Writer = c.Writer
+	var encoder io.WriteCloser
+	if textMode {
+		encoder = base64.NewEncoder(base64.StdEncoding, c.Writer)
+		out = encoder
+	}
+
+	trailer := http.Header{}
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := out.Write(buf[:n]); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			logging.GinEntry(c).WithError(readErr).Warn("gRPC-Web upstream stream interrupted")
+			trailer.Set("Grpc-Status", grpcCodeUnavailable)
+			trailer.Set("Grpc-Message", "upstream stream interrupted")
+			break
+		}
+	}
+
+	for name, values := range resp.Trailer {
+		if trailer.Get(name) == "" {
+			trailer[name] = values
+		}
+	}
+	// Trailers-only responses carry the status in the headers.
+	if trailer.Get("Grpc-Status") == "" {
+		trailer.Set("Grpc-Status", resp.Header.Get("Grpc-Status"))
+		trailer.Set("Grpc-Message", resp.Header.Get("Grpc-Message"))
+	}
+	_, _ = out.Write(grpcWebTrailerFrame(trailer))
+	if encoder != nil {
+		_ = encoder.Close()
+	}
+	c.Writer.Flush()
+}
+
+// grpcWebCodec returns the message codec suffix of a gRPC-Web content type, e.g. "+proto".
+func grpcWebCodec(contentType string) string {
+	if strings.HasPrefix(contentType, grpcWebTextContentType) {
+		return strings.TrimPrefix(contentType, grpcWebTextContentType)
+	}
+	return strings.TrimPrefix(contentType, grpcWebContentType)
+}
+
+// grpcWebTrailerFrame encodes trailers as the last frame of a gRPC-Web body.
+func grpcWebTrailerFrame(trailer http.Header) []byte {
+	var payload bytes.Buffer
+	for name, values := range trailer {
+		for _, value := range values {
+			payload.WriteString(strings.ToLower(name) + ": " + value + "\r\n")
+		}
+	}
+	frame := make([]byte, 5, 5+payload.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(payload.Len()))
+	return append(frame, payload.Bytes()...)
+}
+
+// setUpstreamIdentity adds the access token, the correlation ID and the trace context to gRPC metadata.
+// Any token sent by the client is dropped, only the one verified by TokenMiddleware is forwarded.
+func setUpstreamIdentity(c *gin.Context, header http.Header) {
+	header.Del(AccessTokenKey)
+	if token := AccessToken(c); token != "" {
+		header.Set(AccessTokenKey, token)
+	}
+	header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(c.Request.Context()))
+	otel.GetTextMapPropagator().Inject(c.Request.Context(), propagation.HeaderCarrier(header))
+}
+
+// gRPC status codes used by the gateway itself.
+const (
+	grpcCodeInternal      = "13"
+	grpcCodeUnavailable   = "14"
+	grpcCodeUnimplemented = "12"
+)
+
+// writeGRPCError answers with a trailers-only gRPC response, understood by gRPC and gRPC-Web clients.
+func writeGRPCError(c *gin.Context, code, message string) {
+	contentType := c.GetHeader("Content-Type")
+	if contentType == "" {
+		contentType = grpcContentType
+	}
+	header := c.Writer.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Grpc-Status", code)
+	header.Set("Grpc-Message", message)
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Abort()
+}
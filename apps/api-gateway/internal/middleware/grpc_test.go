@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type GRPCProxyTestSuite struct {
+	suite.Suite
+	upstream  *grpc.Server
+	gateway   *httptest.Server
+	seenToken chan string
+}
+
+func TestGRPCProxySuite(t *testing.T) {
+	suite.Run(t, new(GRPCProxyTestSuite))
+}
+
+func (s *GRPCProxyTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{Logger: config.LoggerConfig{TestMode: true}})
+	s.seenToken = make(chan string, 1)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.upstream = grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("x-access-token"); len(values) > 0 {
+			s.seenToken <- values[0]
+		}
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(s.upstream, health.NewServer())
+	go func() { _ = s.upstream.Serve(lis) }()
+
+	host, port, _ := net.SplitHostPort(lis.Addr().String())
+	service := &ServiceConfig{
+		Name: "health", Host: host, Port: "8080", GrpcPort: port,
+		GrpcMethods: []string{"grpc.health.v1.Health/Check"},
+	}
+
+	r := gin.New()
+	r.UseH2C = true
+	r.Use(verifiedToken)
+	r.GET("/health/*proxyPath", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/grpc.health.v1.Health/:method", GRPCProxy(service))
+	r.POST("/missing.Service/:method", GRPCProxy(&ServiceConfig{Name: "missing"}))
+	s.gateway = httptest.NewServer(r.Handler())
+}
+
+func (s *GRPCProxyTestSuite) TearDownTest() {
+	s.gateway.Close()
+	s.upstream.Stop()
+}
+
+func (s *GRPCProxyTestSuite) TestNativeGRPC() {
+	conn, err := grpc.NewClient(strings.TrimPrefix(s.gateway.URL, "http://"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), testTokenHeader, "token-1")
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	s.Require().NoError(err)
+	s.Equal(healthpb.HealthCheckResponse_SERVING, resp.Status)
+	s.Equal("token-1", <-s.seenToken, "Identity should reach the upstream metadata")
+}
+
+func (s *GRPCProxyTestSuite) TestNativeGRPC_ClientTokenDropped() {
+	conn, err := grpc.NewClient(strings.TrimPrefix(s.gateway.URL, "http://"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), AccessTokenKey, "forged")
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	s.Require().NoError(err)
+	s.Empty(s.seenToken, "Client sent token should not reach the upstream")
+}
+
+func (s *GRPCProxyTestSuite) TestNativeGRPC_MethodNotExposed() {
+	conn, err := grpc.NewClient(strings.TrimPrefix(s.gateway.URL, "http://"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), testTokenHeader, "token-1")
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	s.Require().NoError(err)
+	_, err = stream.Recv()
+	s.Equal(codes.Unimplemented, status.Code(err), "Methods missing from GrpcMethods should not be proxied")
+	s.Empty(s.seenToken, "The upstream should not be called")
+}
+
+func (s *GRPCProxyTestSuite) TestGRPCWeb_ClientTokenReplaced() {
+	req, _ := http.NewRequest(http.MethodPost, s.gateway.URL+"/grpc.health.v1.Health/Check",
+		bytes.NewReader(grpcWebFrame(&healthpb.HealthCheckRequest{})))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	req.Header.Set(AccessTokenKey, "forged")
+	req.Header.Set(testTokenHeader, "token-1")
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("token-1", <-s.seenToken, "Only the verified token should reach the upstream")
+}
+
+func grpcWebFrame(msg proto.Message) []byte {
+	data, _ := proto.Marshal(msg)
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
+
+// readGRPCWebBody splits a gRPC-Web body into the message frames and the trailers.
+func (s *GRPCProxyTestSuite) readGRPCWebBody(body []byte) ([][]byte, string) {
+	var messages [][]byte
+	trailers := ""
+	for len(body) >= 5 {
+		flag := body[0]
+		length := binary.BigEndian.Uint32(body[1:5])
+		s.Require().GreaterOrEqual(len(body)-5, int(length))
+		payload := body[5 : 5+length]
+		if flag&grpcWebTrailerFlag != 0 {
+			trailers = string(payload)
+		} else {
+			messages = append(messages, payload)
+		}
+		body = body[5+length:]
+	}
+	return messages, trailers
+}
+
+func (s *GRPCProxyTestSuite) TestGRPCWeb() {
+	req, _ := http.NewRequest(http.MethodPost, s.gateway.URL+"/grpc.health.v1.Health/Check",
+		bytes.NewReader(grpcWebFrame(&healthpb.HealthCheckRequest{})))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("application/grpc-web+proto", resp.Header.Get("Content-Type"))
+
+	messages, trailers := s.readGRPCWebBody(body)
+	s.Require().Len(messages, 1)
+	var health healthpb.HealthCheckResponse
+	s.Require().NoError(proto.Unmarshal(messages[0], &health))
+	s.Equal(healthpb.HealthCheckResponse_SERVING, health.Status)
+	s.Contains(trailers, "grpc-status: 0\r\n")
+}
+
+func (s *GRPCProxyTestSuite) TestGRPCWebText() {
+	payload := base64.StdEncoding.EncodeToString(grpcWebFrame(&healthpb.HealthCheckRequest{Service: "unknown"}))
+	req, _ := http.NewRequest(http.MethodPost, s.gateway.URL+"/grpc.health.v1.Health/Check", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/grpc-web-text")
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	encoded, _ := io.ReadAll(resp.Body)
+	body, err := base64.StdEncoding.DecodeString(string(encoded))
+	s.Require().NoError(err)
+
+	messages, trailers := s.readGRPCWebBody(body)
+	s.Empty(messages)
+	s.Contains(trailers, "grpc-status: 5\r\n", "NOT_FOUND from the upstream should be passed through")
+}
+
+func (s *GRPCProxyTestSuite) TestNoGRPCPort() {
+	req, _ := http.NewRequest(http.MethodPost, s.gateway.URL+"/missing.Service/Call", bytes.NewReader(nil))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(grpcCodeUnimplemented, resp.Header.Get("Grpc-Status"))
+}
@@ -4,23 +4,33 @@ import (
 	"fmt"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 )
 
 type ServiceConfig struct {
-	Name string
-	Host string
-	Port string
-	Url  string
+	Name         string
+	Host         string
+	Port         string
+	Url          string
+	GrpcPort     string   // Port of the upstream gRPC server, empty when the service has none
+	GrpcServices []string // Fully qualified gRPC services served on GrpcPort, e.g. "patient.PatientService"
+	// GrpcMethods limits the methods of GrpcServices exposed to clients, e.g. "doctor.v1.DoctorService/GetAvailableSlots".
+	// Empty exposes every method. Methods meant for other services only must be left out.
+	GrpcMethods []string
 }
 
+// ReverseProxy forwards the request to the service, with the request ID, access token and trace context.
+// WebSocket upgrades are tunneled once the service switches protocols, and event streams are
+// flushed to the client as each event arrives instead of being buffered.
 func ReverseProxy(service *ServiceConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		remote, err := url.Parse(fmt.Sprintf("http://%s:%s", service.Host, service.Port))
 		if err != nil {
-			logging.Logger.Errorf("Failed to parse remote URL: %v", err)
+			logging.GinEntry(c).Errorf("Failed to parse remote URL: %v", err)
 			c.AbortWithStatusJSON(500, gin.H{"error": "Internal Server Error"})
 			return
 		}
@@ -34,9 +44,19 @@ func ReverseProxy(service *ServiceConfig) gin.HandlerFunc {
 			req.URL.Path = c.Param("proxyPath")
 			req.URL.RawQuery = c.Request.URL.RawQuery
 
-			if accessToken, exists := c.Get("X-Access-Token"); exists {
-				req.Header.Set("X-Access-Token", accessToken.(string))
+			req.Header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(c.Request.Context()))
+
+			if accessToken, exists := c.Get(AccessTokenKey); exists {
+				req.Header.Set(AccessTokenKey, accessToken.(string))
 			}
+
+			// The span started by the tracing middleware is the root of the trace,
+			// upstream services continue it from the traceparent header.
+			otel.GetTextMapPropagator().Inject(c.Request.Context(), propagation.HeaderCarrier(req.Header))
+		}
+
+		if isStreamingRequest(c.Request) {
+			proxy.FlushInterval = -1
 		}
 
 		proxy.ServeHTTP(c.Writer, c.Request)
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	gwconfig "api-gateway/internal/config"
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReverseProxyTestSuite struct {
+	suite.Suite
+	upstream *httptest.Server
+	gateway  *httptest.Server
+	release  chan struct{}
+}
+
+func TestReverseProxySuite(t *testing.T) {
+	suite.Run(t, new(ReverseProxyTestSuite))
+}
+
+func (s *ReverseProxyTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	s.release = make(chan struct{})
+
+	mux := http.NewServeMux()
+	// Echoes every line back after switching to the "echo" protocol, as a WebSocket server would.
+	mux.HandleFunc("/socket", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "echo" {
+			http.Error(w, "upgrade required", http.StatusUpgradeRequired)
+			return
+		}
+		conn, rw, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: echo\r\nConnection: Upgrade\r\n\r\n")
+		_ = rw.Flush()
+		for {
+			line, err := rw.ReadString('\n')
+			if err != nil {
+				return
+			}
+			_, _ = rw.WriteString("echo " + line)
+			_ = rw.Flush()
+		}
+	})
+	// Sends one event, then holds the stream open until the test releases it.
+	mux.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: first\ndata: 1\n\n")
+		http.NewResponseController(w).Flush()
+		<-s.release
+	})
+	s.upstream = httptest.NewServer(mux)
+
+	remote, err := url.Parse(s.upstream.URL)
+	s.Require().NoError(err)
+	service := &ServiceConfig{Name: "doctor", Host: remote.Hostname(), Port: remote.Port()}
+
+	r := gin.New()
+	// The writer wrapped by the security middleware must keep hijacking and flushing working.
+	r.Use(SecurityHeaders(gwconfig.SecurityConfig{CookieSecure: true}))
+	r.Any("/doctor/*proxyPath", ReverseProxy(service))
+	s.gateway = httptest.NewServer(r)
+}
+
+func (s *ReverseProxyTestSuite) TearDownTest() {
+	close(s.release)
+	s.gateway.Close()
+	s.upstream.Close()
+}
+
+func (s *ReverseProxyTestSuite) TestUpgrade_Tunneled() {
+	conn, err := net.Dial("tcp", s.gateway.Listener.Addr().String())
+	s.Require().NoError(err)
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, err = fmt.Fprintf(conn, "GET /doctor/socket HTTP/1.1\r\nHost: gateway\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+	s.Require().NoError(err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	s.Require().NoError(err)
+	s.Equal(http.StatusSwitchingProtocols, resp.StatusCode)
+	s.Equal("echo", resp.Header.Get("Upgrade"))
+
+	for _, message := range []string{"hello", "again"} {
+		_, err = io.WriteString(conn, message+"\n")
+		s.Require().NoError(err)
+		line, err := reader.ReadString('\n')
+		s.Require().NoError(err)
+		s.Equal("echo "+message+"\n", line)
+	}
+}
+
+func (s *ReverseProxyTestSuite) TestEventStream_FlushedPerEvent() {
+	req, err := http.NewRequest(http.MethodGet, s.gateway.URL+"/doctor/stream", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Accept", "text/event-stream")
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The upstream hasn't finished, the event must arrive anyway.
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := reader.ReadString('\n')
+		s.Require().NoError(err)
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	s.Equal([]string{"event: first", "data: 1"}, lines)
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	gwconfig "api-gateway/internal/config"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CSRFCookieName = "csrf_token"   // Cookie holding the CSRF token, readable by the frontend
+	CSRFHeaderName = "X-CSRF-Token" // Header the frontend echoes the token in
+)
+
+// CORS answers preflight requests and sets the CORS headers for the configured origins.
+// Credentials are allowed, so origins are never matched by wildcard.
+// The gRPC-Web request headers are allowed and the call status exposed, so browsers can call GRPCProxy.
+func CORS(cfg gwconfig.SecurityConfig) gin.HandlerFunc {
+	return cors.New(cors.Config{
+		AllowOrigins: cfg.AllowedOrigins,
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{
+			"Origin", "Content-Type", "Accept", "Authorization", CSRFHeaderName, logging.RequestIDHeader,
+			"X-Grpc-Web", "X-User-Agent", "Grpc-Timeout",
+		},
+		ExposeHeaders:    []string{logging.RequestIDHeader, "X-Cache", "Grpc-Status", "Grpc-Message"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	})
+}
+
+// SecurityHeaders sets HSTS, CSP and the framing, sniffing and referrer protections on every response.
+// Cookies set by the services are hardened on the way out, see secureCookieWriter.
+// Handlers may replace the CSP for their own pages, like the Swagger UI does.
+func SecurityHeaders(cfg gwconfig.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		if cfg.HSTSMaxAge > 0 {
+			header.Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d; includeSubDomains", int(cfg.HSTSMaxAge.Seconds())))
+		}
+		if cfg.ContentSecurityPolicy != "" {
+			header.Set("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("Referrer-Policy", "no-referrer")
+
+		writer := &secureCookieWriter{ResponseWriter: c.Writer, secure: cfg.CookieSecure}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		// Gin writes the headers of empty responses itself, after the handlers.
+		if !writer.Written() {
+			writer.harden()
+		}
+	}
+}
+
+// CSRF enforces the double-submit cookie pattern for cookie authenticated requests.
+// Safe requests get a random token in CSRFCookieName when they have none. State-changing requests
+// carrying the session cookie must echo that token in CSRFHeaderName, a cross-site page can
+// make the browser send the cookies but can't read them to fill the header.
+// Requests without the session cookie are left alone, they can't ride on a browser session.
+func CSRF(cfg gwconfig.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.CSRFEnabled {
+			c.Next()
+			return
+		}
+
+		cookieToken, _ := c.Cookie(CSRFCookieName)
+
+		if isSafeMethod(c.Request.Method) {
+			if cookieToken == "" {
+				token, err := newCSRFToken()
+				if err != nil {
+					logging.GinEntry(c).WithError(err).Error("Failed to generate CSRF token")
+				} else {
+					http.SetCookie(c.Writer, &http.Cookie{
+						Name:     CSRFCookieName,
+						Value:    token,
+						Path:     "/",
+						Secure:   cfg.CookieSecure,
+						HttpOnly: false, // The frontend reads it to fill the header
+						SameSite: http.SameSiteStrictMode,
+					})
+				}
+			}
+			c.Next()
+			return
+		}
+
+		if session, err := c.Cookie("token"); err != nil || session == "" {
+			c.Next()
+			return
+		}
+
+		headerToken := c.GetHeader(CSRFHeaderName)
+		if cookieToken == "" || subtle.ConstantTimeCompare([]byte(cookieToken), []byte(headerToken)) != 1 {
+			logging.GinEntry(c).Warn("Rejected request with missing or invalid CSRF token")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "CSRF token missing or invalid"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// isSafeMethod reports whether method doesn't change state, per RFC 9110.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// newCSRFToken returns 32 random bytes, base64 encoded.
+// Support function for CSRF.
+func newCSRFToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// secureCookieWriter hardens the Set-Cookie headers of a response before they are sent.
+// WriteHeader only records the status in gin, so headers are hardened on the first actual write.
+// Cookies without a SameSite attribute get Lax, and all are marked Secure when secure is set,
+// so the services don't have to know how the gateway is exposed.
+type secureCookieWriter struct {
+	gin.ResponseWriter
+	secure   bool
+	hardened bool
+}
+
+func (w *secureCookieWriter) WriteHeaderNow() {
+	w.harden()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *secureCookieWriter) Write(data []byte) (int, error) {
+	w.harden()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *secureCookieWriter) WriteString(s string) (int, error) {
+	w.harden()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *secureCookieWriter) Flush() {
+	w.harden()
+	w.ResponseWriter.Flush()
+}
+
+func (w *secureCookieWriter) harden() {
+	if w.hardened {
+		return
+	}
+	w.hardened = true
+
+	header := w.Header()
+	values := header.Values("Set-Cookie")
+	if len(values) == 0 {
+		return
+	}
+	header.Del("Set-Cookie")
+	for _, value := range values {
+		cookie, err := http.ParseSetCookie(value)
+		if err != nil {
+			header.Add("Set-Cookie", value)
+			continue
+		}
+		if cookie.SameSite == 0 || cookie.SameSite == http.SameSiteDefaultMode {
+			cookie.SameSite = http.SameSiteLaxMode
+		}
+		cookie.Secure = cookie.Secure || w.secure
+		header.Add("Set-Cookie", cookie.String())
+	}
+}
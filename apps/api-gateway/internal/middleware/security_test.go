@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	gwconfig "api-gateway/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SecurityTestSuite struct {
+	suite.Suite
+	cfg    gwconfig.SecurityConfig
+	router *gin.Engine
+	calls  int
+}
+
+func TestSecuritySuite(t *testing.T) {
+	suite.Run(t, new(SecurityTestSuite))
+}
+
+func (s *SecurityTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	s.cfg = gwconfig.SecurityConfig{
+		AllowedOrigins:        []string{"https://clinic.example.com"},
+		HSTSMaxAge:            time.Hour,
+		ContentSecurityPolicy: "default-src 'self'",
+		CSRFEnabled:           true,
+		CookieSecure:          true,
+	}
+	s.calls = 0
+	s.router = s.newRouter(s.cfg)
+}
+
+func (s *SecurityTestSuite) newRouter(cfg gwconfig.SecurityConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(CORS(cfg), SecurityHeaders(cfg), CSRF(cfg))
+	r.Any("/patient/*proxyPath", func(c *gin.Context) {
+		s.calls++
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	r.POST("/patient.PatientService/:method", func(c *gin.Context) {
+		s.calls++
+		c.Header("Grpc-Status", "0")
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func (s *SecurityTestSuite) do(req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *SecurityTestSuite) cookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func (s *SecurityTestSuite) TestCORS_AllowedOrigin() {
+	req := httptest.NewRequest(http.MethodOptions, "/patient/patients", nil)
+	req.Header.Set("Origin", "https://clinic.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", CSRFHeaderName)
+
+	w := s.do(req)
+	s.Equal(http.StatusNoContent, w.Code)
+	s.Equal("https://clinic.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	s.Equal("true", w.Header().Get("Access-Control-Allow-Credentials"))
+	s.Contains(w.Header().Get("Access-Control-Allow-Headers"), http.CanonicalHeaderKey(CSRFHeaderName))
+	s.Equal(0, s.calls)
+}
+
+func (s *SecurityTestSuite) TestCORS_GRPCWebPreflight() {
+	req := httptest.NewRequest(http.MethodOptions, "/patient.PatientService/GetPatient", nil)
+	req.Header.Set("Origin", "https://clinic.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,x-user-agent,grpc-timeout")
+
+	w := s.do(req)
+	s.Equal(http.StatusNoContent, w.Code)
+	s.Equal("https://clinic.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	for _, name := range []string{"X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"} {
+		s.Contains(w.Header().Get("Access-Control-Allow-Headers"), name)
+	}
+	s.Equal(0, s.calls)
+
+	req = httptest.NewRequest(http.MethodPost, "/patient.PatientService/GetPatient", nil)
+	req.Header.Set("Origin", "https://clinic.example.com")
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+
+	w = s.do(req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Header().Get("Access-Control-Expose-Headers"), "Grpc-Status")
+	s.Contains(w.Header().Get("Access-Control-Expose-Headers"), "Grpc-Message")
+	s.Equal(1, s.calls)
+}
+
+func (s *SecurityTestSuite) TestCORS_UnknownOriginRejected() {
+	req := httptest.NewRequest(http.MethodGet, "/patient/patients", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+
+	w := s.do(req)
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+	s.Equal(0, s.calls)
+}
+
+func (s *SecurityTestSuite) TestSecurityHeaders() {
+	w := s.do(httptest.NewRequest(http.MethodGet, "/patient/patients", nil))
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("max-age=3600; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	s.Equal("default-src 'self'", w.Header().Get("Content-Security-Policy"))
+	s.Equal("DENY", w.Header().Get("X-Frame-Options"))
+	s.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+	s.Equal("no-referrer", w.Header().Get("Referrer-Policy"))
+
+	s.cfg.HSTSMaxAge = 0
+	s.router = s.newRouter(s.cfg)
+	w = s.do(httptest.NewRequest(http.MethodGet, "/patient/patients", nil))
+	s.Empty(w.Header().Get("Strict-Transport-Security"))
+}
+
+func (s *SecurityTestSuite) TestCSRF_TokenIssuedOnSafeRequest() {
+	w := s.do(httptest.NewRequest(http.MethodGet, "/patient/patients", nil))
+
+	cookie := s.cookie(w.Result().Cookies(), CSRFCookieName)
+	s.Require().NotNil(cookie)
+	s.NotEmpty(cookie.Value)
+	s.False(cookie.HttpOnly)
+	s.True(cookie.Secure)
+	s.Equal(http.SameSiteStrictMode, cookie.SameSite)
+
+	req := httptest.NewRequest(http.MethodGet, "/patient/patients", nil)
+	req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: cookie.Value})
+	s.Nil(s.cookie(s.do(req).Result().Cookies(), CSRFCookieName), "an existing token is kept")
+}
+
+func (s *SecurityTestSuite) TestCSRF_CookieAuthenticatedRequest() {
+	newRequest := func(header string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/patient/patients", nil)
+		req.AddCookie(&http.Cookie{Name: "token", Value: "session"})
+		req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: "csrf"})
+		if header != "" {
+			req.Header.Set(CSRFHeaderName, header)
+		}
+		return req
+	}
+
+	s.Equal(http.StatusForbidden, s.do(newRequest("")).Code)
+	s.Equal(http.StatusForbidden, s.do(newRequest("other")).Code)
+	s.Equal(0, s.calls)
+
+	s.Equal(http.StatusOK, s.do(newRequest("csrf")).Code)
+	s.Equal(1, s.calls)
+}
+
+func (s *SecurityTestSuite) TestCSRF_WithoutSessionCookie_PassedThrough() {
+	s.Equal(http.StatusOK, s.do(httptest.NewRequest(http.MethodDelete, "/patient/patients/1", nil)).Code)
+
+	s.cfg.CSRFEnabled = false
+	s.router = s.newRouter(s.cfg)
+	req := httptest.NewRequest(http.MethodPost, "/patient/patients", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "session"})
+	s.Equal(http.StatusOK, s.do(req).Code)
+	s.Equal(2, s.calls)
+}
+
+func (s *SecurityTestSuite) TestUpstreamCookiesHardened() {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "token", Value: "session", Path: "/", HttpOnly: true})
+		http.SetCookie(w, &http.Cookie{Name: "pref", Value: "dark", SameSite: http.SameSiteStrictMode})
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer upstream.Close()
+	remote, _ := url.Parse(upstream.URL)
+
+	s.router = gin.New()
+	s.router.Use(SecurityHeaders(s.cfg))
+	s.router.POST("/auth/login", gin.WrapH(httputil.NewSingleHostReverseProxy(remote)))
+	s.router.POST("/auth/logout", func(c *gin.Context) {
+		c.SetCookie("token", "", -1, "/", "", false, true)
+		c.Status(http.StatusNoContent)
+	})
+
+	// The reverse proxy needs a CloseNotifier, which the recorder isn't.
+	gateway := httptest.NewServer(s.router)
+	defer gateway.Close()
+	resp, err := http.Post(gateway.URL+"/auth/login", "application/json", nil)
+	s.Require().NoError(err)
+	_ = resp.Body.Close()
+
+	token := s.cookie(resp.Cookies(), "token")
+	s.Require().NotNil(token)
+	s.True(token.Secure)
+	s.True(token.HttpOnly)
+	s.Equal(http.SameSiteLaxMode, token.SameSite)
+	pref := s.cookie(resp.Cookies(), "pref")
+	s.Require().NotNil(pref)
+	s.Equal(http.SameSiteStrictMode, pref.SameSite, "an explicit SameSite is kept")
+
+	w := s.do(httptest.NewRequest(http.MethodPost, "/auth/logout", nil))
+	s.Equal(http.StatusNoContent, w.Code)
+	token = s.cookie(w.Result().Cookies(), "token")
+	s.Require().NotNil(token)
+	s.True(token.Secure, "cookies of empty responses are hardened too")
+}
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// maxValidatedResponseSize limits how much of an upstream response is kept for contract checks.
+const maxValidatedResponseSize = 1 << 20
+
+// ValidationIssue is one violation of the OpenAPI spec found in a request.
+type ValidationIssue struct {
+	In      string `json:"in"`              // path, query, header, cookie or body
+	Field   string `json:"field,omitempty"` // Parameter name, or the JSON pointer inside the body
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponse is the 400 body sent for requests that violate the spec.
+type ValidationErrorResponse struct {
+	Error  string            `json:"error"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// SpecValidator holds the router built from the merged OpenAPI document of the services.
+// The document can be replaced at any time with Update.
+type SpecValidator struct {
+	router            atomic.Pointer[routers.Router]
+	validateResponses bool
+}
+
+// NewSpecValidator creates a validator without a document, requests pass until Update is called.
+// With validateResponses, upstream responses are also checked and mismatches are logged.
+func NewSpecValidator(validateResponses bool) *SpecValidator {
+	return &SpecValidator{validateResponses: validateResponses}
+}
+
+// Update replaces the document requests are validated against.
+func (v *SpecValidator) Update(doc *openapi3.T) error {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return fmt.Errorf("failed to build router from OpenAPI document: %w", err)
+	}
+	v.router.Store(&router)
+	return nil
+}
+
+// RequestValidation rejects proxied requests whose path parameters, query or JSON body
+// don't match the OpenAPI spec of the route, answering 400 with every issue found.
+// Routes missing from the spec are passed through. Authentication is left to the services.
+// When the validator checks responses, upstream answers that drift from the spec are logged
+// and sent to the client unchanged.
+func RequestValidation(validator *SpecValidator) gin.HandlerFunc {
+	options := &openapi3filter.Options{
+		MultiError:            true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+	}
+
+	return func(c *gin.Context) {
+		if validator == nil {
+			c.Next()
+			return
+		}
+		router := validator.router.Load()
+		if router == nil || isStreamingRequest(c.Request) {
+			c.Next()
+			return
+		}
+
+		route, pathParams, err := (*router).FindRoute(c.Request)
+		if err != nil {
+			// Undocumented route or method, the service decides.
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ValidationErrorResponse{
+				Error:  "Request does not match the API specification",
+				Issues: validationIssues(err),
+			})
+			return
+		}
+
+		if !validator.validateResponses {
+			c.Next()
+			return
+		}
+
+		writer := &teeWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.truncated {
+			return
+		}
+		err = openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 writer.Status(),
+			Header:                 writer.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+			Options:                options,
+		})
+		if err != nil {
+			logging.GinEntry(c).
+				WithField("route", c.Request.Method+" "+route.Path).
+				WithField("status", writer.Status()).
+				WithError(err).
+				Warn("Upstream response does not match the API specification")
+		}
+	}
+}
+
+// validationIssues flattens the errors of openapi3filter into client friendly issues.
+// Support function for RequestValidation.
+func validationIssues(err error) []ValidationIssue {
+	var issues []ValidationIssue
+
+	// RequestError unwraps to its schema errors, so it is matched directly.
+	requestErr, ok := err.(*openapi3filter.RequestError)
+	if !ok {
+		var multi openapi3.MultiError
+		if errors.As(err, &multi) {
+			for _, e := range multi {
+				issues = append(issues, validationIssues(e)...)
+			}
+			return issues
+		}
+		if !errors.As(err, &requestErr) {
+			return []ValidationIssue{{In: "request", Message: err.Error()}}
+		}
+	}
+
+	issue := ValidationIssue{In: "body", Message: requestErr.Reason}
+	if param := requestErr.Parameter; param != nil {
+		issue.In = param.In
+		issue.Field = param.Name
+	}
+
+	// Each schema violation of the body becomes its own issue.
+	var schemaErrs openapi3.MultiError
+	if issue.In == "body" && errors.As(requestErr.Err, &schemaErrs) {
+		for _, e := range schemaErrs {
+			issues = append(issues, schemaIssue(issue, e))
+		}
+		return issues
+	}
+	if requestErr.Err != nil {
+		return []ValidationIssue{schemaIssue(issue, requestErr.Err)}
+	}
+	return []ValidationIssue{issue}
+}
+
+// schemaIssue fills the field and message of issue from a schema violation.
+func schemaIssue(issue ValidationIssue, err error) ValidationIssue {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		if issue.Message == "" {
+			issue.Message = err.Error()
+		}
+		return issue
+	}
+	if pointer := schemaErr.JSONPointer(); len(pointer) > 0 && issue.In == "body" {
+		issue.Field = strings.Join(pointer, "/")
+	}
+	issue.Message = schemaErr.Reason
+	return issue
+}
+
+// teeWriter copies the response body, up to maxValidatedResponseSize, while it is sent.
+type teeWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *teeWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *teeWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *teeWriter) keep(data []byte) {
+	if w.truncated {
+		return
+	}
+	if w.body.Len()+len(data) > maxValidatedResponseSize {
+		w.truncated = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(data)
+}
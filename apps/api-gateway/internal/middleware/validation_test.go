@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type createDoctor struct {
+	FirstName string `json:"first_name" binding:"required"`
+	Age       int    `json:"age"`
+}
+
+type doctorQuery struct {
+	Limit int `form:"limit"`
+}
+
+type doctorResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type RequestValidationTestSuite struct {
+	suite.Suite
+	validator    *SpecValidator
+	upstream     func(c *gin.Context)
+	upstreamBody string
+	calls        int
+}
+
+func TestRequestValidationSuite(t *testing.T) {
+	suite.Run(t, new(RequestValidationTestSuite))
+}
+
+func (s *RequestValidationTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	s.validator = NewSpecValidator(false)
+	s.Require().NoError(s.validator.Update(s.spec()))
+	s.calls = 0
+	s.upstreamBody = ""
+	s.upstream = func(c *gin.Context) {
+		c.JSON(http.StatusOK, doctorResponse{ID: "1", Name: "House"})
+	}
+}
+
+// spec builds the gateway document the same way the docs handler does.
+func (s *RequestValidationTestSuite) spec() *openapi3.T {
+	doc := openapi.NewDocument("Doctor service", "1.0.0")
+	s.Require().NoError(doc.Add(
+		openapi.Operation{
+			Method:    http.MethodPost,
+			Path:      "/doctors",
+			Request:   createDoctor{},
+			Responses: map[int]any{http.StatusCreated: doctorResponse{}},
+		},
+		openapi.Operation{
+			Method:    http.MethodGet,
+			Path:      "/doctors/:id",
+			Query:     doctorQuery{},
+			Responses: map[int]any{http.StatusOK: doctorResponse{}},
+		},
+	))
+	return openapi.Merge("Gateway", "1.0.0", map[string]*openapi3.T{"doctor": doc.Spec()})
+}
+
+func (s *RequestValidationTestSuite) newRouter(validator *SpecValidator) *gin.Engine {
+	r := gin.New()
+	r.Any("/doctor/*proxyPath", RequestValidation(validator), func(c *gin.Context) {
+		s.calls++
+		body, _ := io.ReadAll(c.Request.Body)
+		s.upstreamBody = string(body)
+		s.upstream(c)
+	})
+	return r
+}
+
+func (s *RequestValidationTestSuite) do(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RequestValidationTestSuite) decode(w *httptest.ResponseRecorder) ValidationErrorResponse {
+	var response ValidationErrorResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func (s *RequestValidationTestSuite) TestValidBody_ReachesUpstreamIntact() {
+	router := s.newRouter(s.validator)
+
+	w := s.do(router, http.MethodPost, "/doctor/doctors", `{"first_name":"Gregory","age":50}`)
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(1, s.calls)
+	s.JSONEq(`{"first_name":"Gregory","age":50}`, s.upstreamBody)
+}
+
+func (s *RequestValidationTestSuite) TestInvalidBody_Rejected() {
+	router := s.newRouter(s.validator)
+
+	w := s.do(router, http.MethodPost, "/doctor/doctors", `{"age":"fifty"}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Equal(0, s.calls)
+
+	response := s.decode(w)
+	s.NotEmpty(response.Error)
+	s.Require().Len(response.Issues, 2)
+	fields := []string{response.Issues[0].Field, response.Issues[1].Field}
+	s.Contains(fields, "age")
+	for _, issue := range response.Issues {
+		s.Equal("body", issue.In)
+		s.NotEmpty(issue.Message)
+	}
+}
+
+func (s *RequestValidationTestSuite) TestMissingBody_Rejected() {
+	router := s.newRouter(s.validator)
+
+	w := s.do(router, http.MethodPost, "/doctor/doctors", "")
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Equal("body", s.decode(w).Issues[0].In)
+}
+
+func (s *RequestValidationTestSuite) TestInvalidQuery_Rejected() {
+	router := s.newRouter(s.validator)
+
+	w := s.do(router, http.MethodGet, "/doctor/doctors/1?limit=many", "")
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Equal(0, s.calls)
+
+	issues := s.decode(w).Issues
+	s.Require().Len(issues, 1)
+	s.Equal("query", issues[0].In)
+	s.Equal("limit", issues[0].Field)
+}
+
+func (s *RequestValidationTestSuite) TestUndocumentedRoute_PassedThrough() {
+	router := s.newRouter(s.validator)
+
+	s.Equal(http.StatusOK, s.do(router, http.MethodGet, "/doctor/slots", "").Code)
+	s.Equal(http.StatusOK, s.do(router, http.MethodDelete, "/doctor/doctors/1", "").Code)
+	s.Equal(2, s.calls)
+}
+
+func (s *RequestValidationTestSuite) TestNoSpecLoaded_PassedThrough() {
+	router := s.newRouter(NewSpecValidator(false))
+
+	w := s.do(router, http.MethodPost, "/doctor/doctors", `{"age":"fifty"}`)
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(1, s.calls)
+
+	router = s.newRouter(nil)
+	w = s.do(router, http.MethodPost, "/doctor/doctors", `{"age":"fifty"}`)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *RequestValidationTestSuite) TestDevMode_LogsResponseDrift() {
+	validator := NewSpecValidator(true)
+	s.Require().NoError(validator.Update(s.spec()))
+	router := s.newRouter(validator)
+	hook := test.NewLocal(logging.Logger)
+
+	w := s.do(router, http.MethodGet, "/doctor/doctors/1", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Empty(hook.AllEntries())
+
+	s.upstream = func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": 1, "name": "House"})
+	}
+	w = s.do(router, http.MethodGet, "/doctor/doctors/1", "")
+	s.Equal(http.StatusOK, w.Code, "drifting responses are still delivered")
+	s.JSONEq(`{"id":1,"name":"House"}`, w.Body.String())
+
+	entry := hook.LastEntry()
+	s.Require().NotNil(entry)
+	s.Equal(logrus.WarnLevel, entry.Level)
+	s.Equal("GET /doctor/doctors/{id}", entry.Data["route"])
+}
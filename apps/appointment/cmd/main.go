@@ -9,8 +9,14 @@ import (
 	"github.com/Ruletk/OnlineClinic/pkg/config"
 	"github.com/Ruletk/OnlineClinic/pkg/database"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"time"
 )
 
@@ -25,6 +31,16 @@ func main() {
 	}
 	logging.InitLogger(*cfg)
 
+	shutdownTracer, err := tracing.InitTracer(*cfg)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to initialize tracing")
+	}
+	defer func() {
+		if err := shutdownTracer(mainCtx); err != nil {
+			logging.Logger.WithError(err).Error("Failed to shutdown tracer")
+		}
+	}()
+
 	rdb := redis.NewClient(&redis.Options{
 		Addr: fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
 		//Password: cfg.Redis.Password,
@@ -41,28 +57,63 @@ func main() {
 
 	logging.Logger.Info("Connected to Redis successfully")
 
+	if err := tracing.InstrumentRedis(rdb); err != nil {
+		logging.Logger.WithError(err).Error("Failed to instrument Redis client")
+	}
+
 	db, err := database.NewPostgresDatabase(cfg)
 	if err != nil {
 		logging.Logger.WithError(err).Fatal("Failed to connect to the database")
 		panic(err) // The initialization of the app failed, without a database we can't do anything
 	}
 
+	if err := tracing.InstrumentGorm(db); err != nil {
+		logging.Logger.WithError(err).Error("Failed to instrument database")
+	}
+	if err := metrics.InstrumentGorm(db); err != nil {
+		logging.Logger.WithError(err).Error("Failed to register database metrics")
+	}
+
 	appointmentRepo := repository.NewAppointmentRepository(db)
 	redisRepo := repository.NewRedisRepository(rdb)
 	appointmentDBRedisRepo := repository.NewAppointmentDBRedisRepository(appointmentRepo, redisRepo, mainCtx)
 	logging.Logger.Info("Appointment repository initialized successfully")
 
-	appointmentService := service.NewAppointmentService(appointmentDBRedisRepo)
+	// The connection is lazy, the doctor service doesn't have to be up yet
+	doctorAddr := config.GetEnvWithDefault("DOCTOR_GRPC_ADDR", "doctor:50051")
+	dialOpts := append(tracing.GRPCDialOptions(), metrics.GRPCDialOptions()...)
+	dialOpts = append(dialOpts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(logging.UnaryClientRequestIDInterceptor()),
+	)
+	doctorConn, err := grpc.NewClient(doctorAddr, dialOpts...)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to create the doctor service client")
+	}
+	defer doctorConn.Close()
+	doctorRepo := repository.NewGRPCAppointmentRepository(doctorpb.NewDoctorServiceClient(doctorConn))
+
+	appointmentService := service.NewAppointmentService(appointmentDBRedisRepo, doctorRepo)
 
 	controller := controller2.NewAppointmentController(appointmentService)
 
 	r := gin.Default()
+	r.Use(logging.RequestIDMiddleware())
+	r.Use(tracing.GinMiddleware(cfg.Tracing.ServiceName))
+	r.Use(metrics.GinMiddleware())
 	r.Use(logging.GinLogger(logging.Logger), gin.Recovery())
 
 	group := r.Group("")
 
 	controller.RegisterRoutes(group)
 
+	doc := openapi.NewDocument("Appointment service", "1.0.0")
+	if err := controller.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to build the OpenAPI document")
+	}
+	r.GET("/openapi.json", doc.Handler())
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	if err := r.Run(fmt.Sprintf(":8080")); err != nil {
 		logging.Logger.WithError(err).Fatal("Failed to start the server")
 	}
@@ -3,11 +3,13 @@ package controller
 import (
 	"appointment/internal/dto"
 	"appointment/internal/service"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type AppointmentController struct {
@@ -18,6 +20,9 @@ func (c *AppointmentController) RegisterRoutes(router *gin.RouterGroup) {
 	appointments := router.Group("")
 	{
 		appointments.POST("", c.Create)
+		appointments.POST("/hold", c.Hold)
+		appointments.POST("/:id/confirm", c.Confirm)
+		appointments.POST("/:id/release", c.Release)
 		appointments.GET("/:id", c.GetByID)
 		appointments.DELETE("/:id", c.Delete)
 		appointments.GET("/user/:user_id", c.GetByUserID)
@@ -39,7 +44,7 @@ func (c *AppointmentController) Create(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.Create(&req)
+	resp, err := c.service.Create(ctx.Request.Context(), &req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -48,6 +53,65 @@ func (c *AppointmentController) Create(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, resp)
 }
 
+func (c *AppointmentController) Hold(ctx *gin.Context) {
+	var req dto.CreateAppointmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Hold(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *AppointmentController) Confirm(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid appointment ID"})
+		return
+	}
+
+	resp, err := c.service.Confirm(ctx.Request.Context(), &dto.AppointmentIDRequest{ID: id})
+	if err != nil {
+		checkoutError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *AppointmentController) Release(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid appointment ID"})
+		return
+	}
+
+	if err := c.service.Release(ctx.Request.Context(), &dto.AppointmentIDRequest{ID: id}); err != nil {
+		checkoutError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// checkoutError answers a failed checkout step; a missing or finished checkout is the caller's problem.
+func checkoutError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "appointment not found"})
+	case errors.Is(err, service.ErrNotPending), errors.Is(err, service.ErrHoldExpired):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 func (c *AppointmentController) Delete(ctx *gin.Context) {
 	id, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
@@ -55,7 +119,7 @@ func (c *AppointmentController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err = c.service.Delete(&dto.AppointmentIDRequest{ID: id})
+	err = c.service.Delete(ctx.Request.Context(), &dto.AppointmentIDRequest{ID: id})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -71,7 +135,7 @@ func (c *AppointmentController) GetByUserID(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.GetByUserID(&dto.GetAppointmentsByUserIDRequest{UserID: userID})
+	resp, err := c.service.GetByUserID(ctx.Request.Context(), &dto.GetAppointmentsByUserIDRequest{UserID: userID})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -87,7 +151,7 @@ func (c *AppointmentController) GetByDoctorID(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.GetByDoctorID(&dto.GetAppointmentsByDoctorIDRequest{DoctorID: doctorID})
+	resp, err := c.service.GetByDoctorID(ctx.Request.Context(), &dto.GetAppointmentsByDoctorIDRequest{DoctorID: doctorID})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -103,8 +167,12 @@ func (c *AppointmentController) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.GetByID(&dto.AppointmentIDRequest{ID: id})
+	resp, err := c.service.GetByID(ctx.Request.Context(), &dto.AppointmentIDRequest{ID: id})
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "appointment not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -126,7 +194,7 @@ func (c *AppointmentController) ChangeStatus(ctx *gin.Context) {
 	}
 	req.ID = id
 
-	resp, err := c.service.ChangeStatus(&req)
+	resp, err := c.service.ChangeStatus(ctx.Request.Context(), &req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
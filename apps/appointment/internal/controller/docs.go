@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"appointment/internal/dto"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+)
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (c *AppointmentController) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"appointments"}
+	single := map[int]any{
+		http.StatusOK:                  dto.AppointmentResponse{},
+		http.StatusBadRequest:          failure,
+		http.StatusInternalServerError: failure,
+	}
+	list := map[int]any{
+		http.StatusOK:                  dto.AppointmentListResponse{},
+		http.StatusBadRequest:          failure,
+		http.StatusInternalServerError: failure,
+	}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/", Summary: "Book an appointment", Tags: tags,
+			Request: dto.CreateAppointmentRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             dto.AppointmentResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/hold", Summary: "Hold a slot for a pending appointment until checkout", Tags: tags,
+			Request: dto.CreateAppointmentRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             dto.AppointmentResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/:id/confirm", Summary: "Confirm a pending appointment", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  dto.AppointmentResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusConflict:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/:id/release", Summary: "Abandon a pending appointment and free its slot", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusConflict:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/:id", Summary: "Get an appointment", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  dto.AppointmentResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/:id", Summary: "Delete an appointment", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/user/:user_id", Summary: "List appointments of a user", Tags: tags,
+			Responses: list,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctor/:doctor_id", Summary: "List appointments of a doctor", Tags: tags,
+			Responses: list,
+		},
+		openapi.Operation{
+			Method: http.MethodPatch, Path: "/:id/status", Summary: "Change the status of an appointment", Tags: tags,
+			Request:   dto.ChangeAppointmentStatusRequest{},
+			Responses: single,
+		},
+	)
+}
@@ -12,6 +12,7 @@ type AppointmentResponse struct {
 	Date      string                  `json:"date"`
 	Status    model.AppointmentStatus `json:"status"`
 	Notes     string                  `json:"notes,omitempty"`
+	HeldUntil *time.Time              `json:"held_until,omitempty"`
 	CreatedAt time.Time               `json:"created_at"`
 	UpdatedAt time.Time               `json:"updated_at"`
 }
@@ -29,6 +30,7 @@ func AppointmentResponseFromModel(a *model.Appointment) *AppointmentResponse {
 		Date:      a.Date.Format(time.RFC3339),
 		Status:    a.Status,
 		Notes:     a.Notes,
+		HeldUntil: a.HeldUntil,
 		CreatedAt: a.CreatedAt,
 		UpdatedAt: a.UpdatedAt,
 	}
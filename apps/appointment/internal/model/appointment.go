@@ -10,9 +10,14 @@ type Appointment struct {
 	UserID   int64             `json:"user_id" gorm:"type:uuid;not null"`
 	DoctorID uuid.UUID         `json:"doctor_id" gorm:"type:uuid;not null"`
 	Date     time.Time         `json:"date" gorm:"not null"`
-	Status   AppointmentStatus `json:"status" gorm:"type:ENUM('scheduled', 'completed', 'canceled');not null;default:'scheduled'"`
+	Status   AppointmentStatus `json:"status" gorm:"type:ENUM('pending', 'scheduled', 'completed', 'canceled');not null;default:'scheduled'"`
 	Notes    string            `json:"notes" gorm:"type:text;default:null"` // Optional notes for the appointment
 
+	// Token of the doctor slot reservation, required to release the slot. Empty for appointments booked before reservations.
+	ReservationToken *uuid.UUID `json:"reservation_token,omitempty" gorm:"type:uuid;default:null"`
+	// End of the slot hold of a pending appointment. After it the doctor service frees the slot.
+	HeldUntil *time.Time `json:"held_until,omitempty" gorm:"default:null"`
+
 	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"` // Soft delete
@@ -21,6 +26,8 @@ type Appointment struct {
 type AppointmentStatus string
 
 const (
+	// Pending appointments hold their slot while the patient checks out
+	Pending   AppointmentStatus = "pending"
 	Scheduled AppointmentStatus = "scheduled"
 	Completed AppointmentStatus = "completed"
 	Canceled  AppointmentStatus = "canceled"
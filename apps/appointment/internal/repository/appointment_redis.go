@@ -7,11 +7,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
 	"github.com/google/uuid"
 )
 
 const (
 	appointmentCacheTTL = 5 * time.Minute
+
+	// Cache names of the hit and miss metrics
+	appointmentCache     = "appointment"
+	appointmentListCache = "appointment_list"
 )
 
 type AppointmentDBRedisRepository struct {
@@ -55,9 +60,11 @@ func (a *AppointmentDBRedisRepository) GetByID(id uuid.UUID) (*model.Appointment
 	if err == nil && cached != "" {
 		var appointment model.Appointment
 		if err := json.Unmarshal([]byte(cached), &appointment); err == nil {
+			metrics.CacheHit(appointmentCache)
 			return &appointment, nil
 		}
 	}
+	metrics.CacheMiss(appointmentCache)
 
 	// Cache miss or error - get from DB
 	appointment, err := a.repo.GetByID(id)
@@ -132,9 +139,11 @@ func (a *AppointmentDBRedisRepository) ListByUserID(userID int64) ([]model.Appoi
 	if err == nil && cached != "" {
 		var appointments []model.Appointment
 		if err := json.Unmarshal([]byte(cached), &appointments); err == nil {
+			metrics.CacheHit(appointmentListCache)
 			return appointments, nil
 		}
 	}
+	metrics.CacheMiss(appointmentListCache)
 
 	// Cache miss or error - get from DB
 	appointments, err := a.repo.ListByUserID(userID)
@@ -161,9 +170,11 @@ func (a *AppointmentDBRedisRepository) ListByDoctorID(doctorID uuid.UUID) ([]mod
 	if err == nil && cached != "" {
 		var appointments []model.Appointment
 		if err := json.Unmarshal([]byte(cached), &appointments); err == nil {
+			metrics.CacheHit(appointmentListCache)
 			return appointments, nil
 		}
 	}
+	metrics.CacheMiss(appointmentListCache)
 
 	// Cache miss or error - get from DB
 	appointments, err := a.repo.ListByDoctorID(doctorID)
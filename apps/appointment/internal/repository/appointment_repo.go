@@ -31,8 +31,9 @@ func (a appointmentRepository) GetByID(id uuid.UUID) (*model.Appointment, error)
 	return &appointment, nil
 }
 
+// Update writes every field, so clearing an optional one like held_until takes effect.
 func (a appointmentRepository) Update(appointment *model.Appointment) error {
-	return a.db.Model(&model.Appointment{}).Where("id = ?", appointment.ID).Updates(appointment).Error
+	return a.db.Model(&model.Appointment{}).Where("id = ?", appointment.ID).Select("*").Omit("id", "created_at").Updates(appointment).Error
 }
 
 func (a appointmentRepository) Delete(id uuid.UUID) error {
@@ -1,25 +1,29 @@
 package repository
 
 import (
-	"appointment/internal/proto/gen"
 	"context"
 	"fmt"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
 	"time"
 )
 
 type GRPCAppointmentRepository interface {
-	CheckTimeAvailability(ctx context.Context, req *gen.CheckTimeAvailabilityRequest) (*gen.CheckTimeAvailabilityResponse, error)
-	GetAvailableSlots(ctx context.Context, req *gen.GetAvailableSlotsRequest) (*gen.GetAvailableSlotsResponse, error)
-	ChangeTimeSlot(ctx context.Context, req *gen.ChangeTimeSlotRequest) (*gen.ChangeTimeSlotResponse, error)
+	CheckTimeAvailability(ctx context.Context, req *doctorpb.CheckTimeAvailabilityRequest) (*doctorpb.CheckTimeAvailabilityResponse, error)
+	GetAvailableSlots(ctx context.Context, req *doctorpb.GetAvailableSlotsRequest) (*doctorpb.GetAvailableSlotsResponse, error)
+	ChangeTimeSlot(ctx context.Context, req *doctorpb.ChangeTimeSlotRequest) (*doctorpb.ChangeTimeSlotResponse, error)
+	ReserveSlot(ctx context.Context, req *doctorpb.ReserveSlotRequest) (*doctorpb.ReserveSlotResponse, error)
+	ReleaseSlot(ctx context.Context, req *doctorpb.ReleaseSlotRequest) (*doctorpb.ReleaseSlotResponse, error)
+	HoldSlot(ctx context.Context, req *doctorpb.HoldSlotRequest) (*doctorpb.HoldSlotResponse, error)
+	ConfirmSlot(ctx context.Context, req *doctorpb.ConfirmSlotRequest) (*doctorpb.ConfirmSlotResponse, error)
 }
 
 type grpcAppointmentRepository struct {
-	client         gen.DoctorServiceClient
+	client         doctorpb.DoctorServiceClient
 	defaultTimeout time.Duration
 }
 
-func NewGRPCAppointmentRepository(client gen.DoctorServiceClient) GRPCAppointmentRepository {
+func NewGRPCAppointmentRepository(client doctorpb.DoctorServiceClient) GRPCAppointmentRepository {
 	logging.Logger.Info("Initializing gRPC Appointment Repository")
 	return &grpcAppointmentRepository{
 		client: client,
@@ -28,7 +32,7 @@ func NewGRPCAppointmentRepository(client gen.DoctorServiceClient) GRPCAppointmen
 	}
 }
 
-func (g *grpcAppointmentRepository) CheckTimeAvailability(ctx context.Context, req *gen.CheckTimeAvailabilityRequest) (*gen.CheckTimeAvailabilityResponse, error) {
+func (g *grpcAppointmentRepository) CheckTimeAvailability(ctx context.Context, req *doctorpb.CheckTimeAvailabilityRequest) (*doctorpb.CheckTimeAvailabilityResponse, error) {
 	logging.Logger.Infof("Checking time availability for doctor ID: %s, date: %s", req.DoctorId, req.SlotTime)
 	if err := g.checkClient(); err != nil {
 		return nil, err
@@ -39,7 +43,7 @@ func (g *grpcAppointmentRepository) CheckTimeAvailability(ctx context.Context, r
 	return g.client.CheckTimeAvailability(c, req)
 }
 
-func (g *grpcAppointmentRepository) GetAvailableSlots(ctx context.Context, req *gen.GetAvailableSlotsRequest) (*gen.GetAvailableSlotsResponse, error) {
+func (g *grpcAppointmentRepository) GetAvailableSlots(ctx context.Context, req *doctorpb.GetAvailableSlotsRequest) (*doctorpb.GetAvailableSlotsResponse, error) {
 	logging.Logger.Infof("Getting available slots for doctor ID: %s, and date between: %s and %s", req.DoctorId, req.StartDate, req.EndDate)
 	if err := g.checkClient(); err != nil {
 		return nil, err
@@ -51,7 +55,7 @@ func (g *grpcAppointmentRepository) GetAvailableSlots(ctx context.Context, req *
 	return g.client.GetAvailableSlots(c, req)
 }
 
-func (g *grpcAppointmentRepository) ChangeTimeSlot(ctx context.Context, req *gen.ChangeTimeSlotRequest) (*gen.ChangeTimeSlotResponse, error) {
+func (g *grpcAppointmentRepository) ChangeTimeSlot(ctx context.Context, req *doctorpb.ChangeTimeSlotRequest) (*doctorpb.ChangeTimeSlotResponse, error) {
 	logging.Logger.Infof("Changing time slot for doctor: %s, time: %s, status: %v", req.DoctorId, req.SlotTime, req.IsAvailable)
 	if err := g.checkClient(); err != nil {
 		return nil, err
@@ -63,6 +67,54 @@ func (g *grpcAppointmentRepository) ChangeTimeSlot(ctx context.Context, req *gen
 	return g.client.ChangeTimeSlot(c, req)
 }
 
+func (g *grpcAppointmentRepository) ReserveSlot(ctx context.Context, req *doctorpb.ReserveSlotRequest) (*doctorpb.ReserveSlotResponse, error) {
+	logging.Logger.Infof("Reserving time slot for doctor: %s, time: %s, appointment: %s", req.DoctorId, req.SlotTime, req.AppointmentId)
+	if err := g.checkClient(); err != nil {
+		return nil, err
+	}
+
+	c, cancel := context.WithTimeout(ctx, g.defaultTimeout)
+	defer cancel()
+
+	return g.client.ReserveSlot(c, req)
+}
+
+func (g *grpcAppointmentRepository) ReleaseSlot(ctx context.Context, req *doctorpb.ReleaseSlotRequest) (*doctorpb.ReleaseSlotResponse, error) {
+	logging.Logger.Infof("Releasing time slot for doctor: %s, time: %s, appointment: %s", req.DoctorId, req.SlotTime, req.AppointmentId)
+	if err := g.checkClient(); err != nil {
+		return nil, err
+	}
+
+	c, cancel := context.WithTimeout(ctx, g.defaultTimeout)
+	defer cancel()
+
+	return g.client.ReleaseSlot(c, req)
+}
+
+func (g *grpcAppointmentRepository) HoldSlot(ctx context.Context, req *doctorpb.HoldSlotRequest) (*doctorpb.HoldSlotResponse, error) {
+	logging.Logger.Infof("Holding time slot for doctor: %s, time: %s, appointment: %s", req.DoctorId, req.SlotTime, req.AppointmentId)
+	if err := g.checkClient(); err != nil {
+		return nil, err
+	}
+
+	c, cancel := context.WithTimeout(ctx, g.defaultTimeout)
+	defer cancel()
+
+	return g.client.HoldSlot(c, req)
+}
+
+func (g *grpcAppointmentRepository) ConfirmSlot(ctx context.Context, req *doctorpb.ConfirmSlotRequest) (*doctorpb.ConfirmSlotResponse, error) {
+	logging.Logger.Infof("Confirming time slot for doctor: %s, time: %s, appointment: %s", req.DoctorId, req.SlotTime, req.AppointmentId)
+	if err := g.checkClient(); err != nil {
+		return nil, err
+	}
+
+	c, cancel := context.WithTimeout(ctx, g.defaultTimeout)
+	defer cancel()
+
+	return g.client.ConfirmSlot(c, req)
+}
+
 func (g *grpcAppointmentRepository) checkClient() error {
 	if g.client == nil {
 		logging.Logger.Error("g.client is nil, ensure the grpcAppointmentRepository is properly initialized")
@@ -3,26 +3,42 @@ package service
 import (
 	"appointment/internal/dto"
 	"appointment/internal/model"
-	"appointment/internal/proto/gen"
 	"appointment/internal/repository"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 	"time"
 )
 
+// compensationTimeout limits each call that undoes a failed booking step.
+const compensationTimeout = 5 * time.Second
+
+var (
+	// ErrNotPending is returned when a checkout step is applied to an appointment that is not pending.
+	ErrNotPending = errors.New("appointment is not pending")
+	// ErrHoldExpired is returned when the slot hold ended before the checkout was confirmed.
+	ErrHoldExpired = errors.New("slot hold has expired")
+)
+
 type AppointmentService interface {
-	Create(req *dto.CreateAppointmentRequest) (*dto.AppointmentResponse, error)
-	Delete(req *dto.AppointmentIDRequest) error
+	Create(ctx context.Context, req *dto.CreateAppointmentRequest) (*dto.AppointmentResponse, error)
+	// Hold, Confirm and Release book an appointment in checkout steps: the slot is held
+	// for a pending appointment until the patient confirms or abandons the checkout.
+	Hold(ctx context.Context, req *dto.CreateAppointmentRequest) (*dto.AppointmentResponse, error)
+	Confirm(ctx context.Context, req *dto.AppointmentIDRequest) (*dto.AppointmentResponse, error)
+	Release(ctx context.Context, req *dto.AppointmentIDRequest) error
+	Delete(ctx context.Context, req *dto.AppointmentIDRequest) error
 
-	GetByUserID(req *dto.GetAppointmentsByUserIDRequest) (*dto.AppointmentListResponse, error)
-	GetByID(req *dto.AppointmentIDRequest) (*dto.AppointmentResponse, error)
-	GetByDoctorID(req *dto.GetAppointmentsByDoctorIDRequest) (*dto.AppointmentListResponse, error)
+	GetByUserID(ctx context.Context, req *dto.GetAppointmentsByUserIDRequest) (*dto.AppointmentListResponse, error)
+	GetByID(ctx context.Context, req *dto.AppointmentIDRequest) (*dto.AppointmentResponse, error)
+	GetByDoctorID(ctx context.Context, req *dto.GetAppointmentsByDoctorIDRequest) (*dto.AppointmentListResponse, error)
 
-	ChangeStatus(req *dto.ChangeAppointmentStatusRequest) (*dto.AppointmentResponse, error)
+	ChangeStatus(ctx context.Context, req *dto.ChangeAppointmentStatusRequest) (*dto.AppointmentResponse, error)
 }
 
 type appointmentService struct {
@@ -30,161 +46,427 @@ type appointmentService struct {
 	grpc repository.GRPCAppointmentRepository
 }
 
-func (a appointmentService) Create(req *dto.CreateAppointmentRequest) (*dto.AppointmentResponse, error) {
-	logging.Logger.Infof("Creating appointment for user ID: %d with doctor ID: %s on date: %s", req.UserID, req.DoctorID, req.Date)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (a appointmentService) Create(ctx context.Context, req *dto.CreateAppointmentRequest) (*dto.AppointmentResponse, error) {
+	logging.FromContext(ctx).Infof("Creating appointment for user ID: %d with doctor ID: %s on date: %s", req.UserID, req.DoctorID, req.Date)
+	grpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	availability, err := a.grpc.CheckTimeAvailability(ctx, &gen.CheckTimeAvailabilityRequest{
-		DoctorId: req.DoctorID.String(),
-		SlotTime: timestamppb.New(req.Date),
-	})
-	if err != nil {
-		logging.Logger.Errorf("Failed to check time availability for doctor ID: %s on date: %s, error: %v", req.DoctorID, req.Date, err)
+	// The slot is held while the appointment is saved and confirmed afterwards.
+	// Every failed step undoes the previous ones, an unconfirmed hold also expires on its own.
+	appointment := &model.Appointment{
+		ID:       uuid.New(),
+		UserID:   req.UserID,
+		DoctorID: req.DoctorID,
+		Date:     req.Date,
+		Status:   model.Scheduled,
+		Notes:    req.Notes,
+	}
+	if err := a.holdSlot(grpcCtx, appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to hold time slot for doctor ID: %s on date: %s, error: %v", req.DoctorID, req.Date, err)
 		return nil, err
 	}
-	if !availability.IsAvailable {
-		logging.Logger.Errorf("Time slot is not available for doctor ID: %s on date: %s with reason: %s", req.DoctorID, req.Date, availability.Reason)
-		return nil, fmt.Errorf("time slot is not available for doctor ID: %s on date: %s with reason: %s", req.DoctorID, req.Date, availability.Reason)
+	// The hold is confirmed within this call, the appointment is stored as scheduled
+	appointment.HeldUntil = nil
+
+	if err := a.repo.Create(appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to create appointment for user ID: %d with doctor ID: %s on date: %s, error: %v", req.UserID, req.DoctorID, req.Date, err)
+		a.compensateSlot(ctx, appointment)
+		return nil, fmt.Errorf("failed to create appointment: %v", err)
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	err = a.changeTimeSlotAvailability(ctx, req.DoctorID, req.Date, false)
-	if err != nil {
-		logging.Logger.Errorf("Failed to change time slot for doctor ID: %s on date: %s, error: %v", req.DoctorID, req.Date, err)
-		return nil, fmt.Errorf("failed to change time slot: %v", err)
+	if err := a.confirmSlot(grpcCtx, appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to confirm time slot for doctor ID: %s on date: %s, error: %v", req.DoctorID, req.Date, err)
+		if deleteErr := a.repo.Delete(appointment.ID); deleteErr != nil {
+			logging.FromContext(ctx).Errorf("Failed to delete unconfirmed appointment with ID: %s, error: %v", appointment.ID, deleteErr)
+		}
+		a.compensateSlot(ctx, appointment)
+		return nil, fmt.Errorf("failed to confirm time slot: %w", err)
 	}
+	return dto.AppointmentResponseFromModel(appointment), nil
+}
+
+func (a appointmentService) Hold(ctx context.Context, req *dto.CreateAppointmentRequest) (*dto.AppointmentResponse, error) {
+	logging.FromContext(ctx).Infof("Holding slot for user ID: %d with doctor ID: %s on date: %s", req.UserID, req.DoctorID, req.Date)
+	grpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
 	appointment := &model.Appointment{
+		ID:       uuid.New(),
 		UserID:   req.UserID,
 		DoctorID: req.DoctorID,
 		Date:     req.Date,
-		Status:   model.Scheduled,
+		Status:   model.Pending,
 		Notes:    req.Notes,
 	}
+	if err := a.holdSlot(grpcCtx, appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to hold time slot for doctor ID: %s on date: %s, error: %v", req.DoctorID, req.Date, err)
+		return nil, err
+	}
+
 	if err := a.repo.Create(appointment); err != nil {
-		logging.Logger.Errorf("Failed to create appointment for user ID: %d with doctor ID: %s on date: %s, error: %v", req.UserID, req.DoctorID, req.Date, err)
+		logging.FromContext(ctx).Errorf("Failed to create pending appointment for user ID: %d with doctor ID: %s on date: %s, error: %v", req.UserID, req.DoctorID, req.Date, err)
+		a.compensateSlot(ctx, appointment)
 		return nil, fmt.Errorf("failed to create appointment: %v", err)
 	}
 	return dto.AppointmentResponseFromModel(appointment), nil
 }
 
-func (a appointmentService) Delete(req *dto.AppointmentIDRequest) error {
-	logging.Logger.Infof("Deleting appointment with ID: %s", req.ID)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (a appointmentService) Confirm(ctx context.Context, req *dto.AppointmentIDRequest) (*dto.AppointmentResponse, error) {
+	logging.FromContext(ctx).Infof("Confirming appointment with ID: %s", req.ID)
+	grpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	appointment, err := a.getAppointment(req.ID)
+	appointment, err := a.getPending(ctx, req.ID)
 	if err != nil {
-		logging.Logger.Errorf("Failed to get appointment by ID: %s, error: %v", req.ID, err)
+		return nil, err
+	}
+	if !appointment.HeldUntil.After(time.Now()) {
+		return nil, ErrHoldExpired
+	}
+
+	if err := a.confirmSlot(grpcCtx, appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to confirm time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
+		return nil, fmt.Errorf("failed to confirm time slot: %w", err)
+	}
+
+	appointment.Status = model.Scheduled
+	appointment.HeldUntil = nil
+	if err := a.repo.Update(appointment); err != nil {
+		// The slot stays reserved for the appointment, confirming again only updates the record
+		logging.FromContext(ctx).Errorf("Failed to update confirmed appointment with ID: %s, error: %v", req.ID, err)
+		return nil, fmt.Errorf("failed to update appointment: %w", err)
+	}
+	logging.FromContext(ctx).Infof("Successfully confirmed appointment with ID: %s", req.ID)
+	return dto.AppointmentResponseFromModel(appointment), nil
+}
+
+func (a appointmentService) Release(ctx context.Context, req *dto.AppointmentIDRequest) error {
+	logging.FromContext(ctx).Infof("Releasing held appointment with ID: %s", req.ID)
+	grpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	appointment, err := a.getPending(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	// An expired hold has already been freed by the doctor service
+	if appointment.HeldUntil.After(time.Now()) {
+		if err := a.releaseSlot(grpcCtx, appointment); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to release time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
+			return fmt.Errorf("failed to release time slot: %w", err)
+		}
+	}
+
+	if err := a.repo.Delete(appointment.ID); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to delete released appointment with ID: %s, error: %v", req.ID, err)
+		return fmt.Errorf("repository delete failed: %w", err)
+	}
+	logging.FromContext(ctx).Infof("Successfully released appointment with ID: %s", req.ID)
+	return nil
+}
+
+func (a appointmentService) Delete(ctx context.Context, req *dto.AppointmentIDRequest) error {
+	logging.FromContext(ctx).Infof("Deleting appointment with ID: %s", req.ID)
+	grpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	appointment, err := a.getAppointment(ctx, req.ID)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to get appointment by ID: %s, error: %v", req.ID, err)
 		return fmt.Errorf("failed to get appointment: %w", err)
 	}
 
-	err = a.changeTimeSlotAvailability(ctx, appointment.DoctorID, appointment.Date, true)
+	// The slot of a canceled appointment is already free
+	if appointment.Status == model.Canceled {
+		if err := a.repo.Delete(req.ID); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to delete appointment with ID: %s, error: %v", req.ID, err)
+			return fmt.Errorf("failed to delete appointment: %w", err)
+		}
+		logging.FromContext(ctx).Infof("Successfully deleted appointment with ID: %s", req.ID)
+		return nil
+	}
+
+	err = a.releaseSlot(grpcCtx, appointment)
 	if err != nil {
-		logging.Logger.Errorf("Failed to change time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
+		logging.FromContext(ctx).Errorf("Failed to change time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
 		return fmt.Errorf("failed to change time slot: %w", err)
 	}
 
-	logging.Logger.Infof("Successfully changed time slot for doctor ID: %s on date: %s", appointment.DoctorID, appointment.Date)
-	if err := a.deleteAppointmentRecord(ctx, req.ID, appointment); err != nil {
-		logging.Logger.Errorf("Failed to delete appointment with ID: %s, error: %v", req.ID, err)
+	logging.FromContext(ctx).Infof("Successfully changed time slot for doctor ID: %s on date: %s", appointment.DoctorID, appointment.Date)
+	if err := a.deleteAppointmentRecord(grpcCtx, req.ID, appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to delete appointment with ID: %s, error: %v", req.ID, err)
 		return fmt.Errorf("failed to delete appointment: %w", err)
 	}
 
-	logging.Logger.Infof("Successfully deleted appointment with ID: %s", req.ID)
+	logging.FromContext(ctx).Infof("Successfully deleted appointment with ID: %s", req.ID)
 	return nil
 }
 
-func (a appointmentService) GetByUserID(req *dto.GetAppointmentsByUserIDRequest) (*dto.AppointmentListResponse, error) {
-	logging.Logger.Infof("Getting appointments for user ID: %d", req.UserID)
+func (a appointmentService) GetByUserID(ctx context.Context, req *dto.GetAppointmentsByUserIDRequest) (*dto.AppointmentListResponse, error) {
+	logging.FromContext(ctx).Infof("Getting appointments for user ID: %d", req.UserID)
 	appointments, err := a.repo.ListByUserID(req.UserID)
 	if err != nil {
-		logging.Logger.Errorf("Failed to get appointments for user ID: %d, error: %v", req.UserID, err)
+		logging.FromContext(ctx).Errorf("Failed to get appointments for user ID: %d, error: %v", req.UserID, err)
 		return nil, fmt.Errorf("repository get by user ID failed: %w", err)
 	}
 	if len(appointments) == 0 {
-		logging.Logger.Warnf("No appointments found for user ID: %d", req.UserID)
+		logging.FromContext(ctx).Warnf("No appointments found for user ID: %d", req.UserID)
 		return &dto.AppointmentListResponse{Appointments: []dto.AppointmentResponse{}}, nil
 	}
 	return dto.AppointmentListResponseFromModel(appointments), nil
 }
 
-func (a appointmentService) GetByID(req *dto.AppointmentIDRequest) (*dto.AppointmentResponse, error) {
-	logging.Logger.Infof("Getting appointment by ID: %s", req.ID)
-	appointment, err := a.getAppointment(req.ID)
+func (a appointmentService) GetByID(ctx context.Context, req *dto.AppointmentIDRequest) (*dto.AppointmentResponse, error) {
+	logging.FromContext(ctx).Infof("Getting appointment by ID: %s", req.ID)
+	appointment, err := a.getAppointment(ctx, req.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get appointment by ID: %w", err)
 	}
 	return dto.AppointmentResponseFromModel(appointment), nil
 }
 
-func (a appointmentService) GetByDoctorID(req *dto.GetAppointmentsByDoctorIDRequest) (*dto.AppointmentListResponse, error) {
-	logging.Logger.Infof("Getting appointments for doctor ID: %s", req.DoctorID)
+func (a appointmentService) GetByDoctorID(ctx context.Context, req *dto.GetAppointmentsByDoctorIDRequest) (*dto.AppointmentListResponse, error) {
+	logging.FromContext(ctx).Infof("Getting appointments for doctor ID: %s", req.DoctorID)
 	appointments, err := a.repo.ListByDoctorID(req.DoctorID)
 	if err != nil {
-		logging.Logger.Errorf("Failed to get appointments for doctor ID: %s, error: %v", req.DoctorID, err)
+		logging.FromContext(ctx).Errorf("Failed to get appointments for doctor ID: %s, error: %v", req.DoctorID, err)
 		return nil, fmt.Errorf("repository get by doctor ID failed: %w", err)
 	}
 	return dto.AppointmentListResponseFromModel(appointments), nil
 }
 
-func (a appointmentService) ChangeStatus(req *dto.ChangeAppointmentStatusRequest) (*dto.AppointmentResponse, error) {
-	appointment, err := a.getAppointment(req.ID)
+func (a appointmentService) ChangeStatus(ctx context.Context, req *dto.ChangeAppointmentStatusRequest) (*dto.AppointmentResponse, error) {
+	appointment, err := a.getAppointment(ctx, req.ID)
 	if err != nil {
-		logging.Logger.Errorf("Failed to get appointment by ID: %s, error: %v", req.ID, err)
+		logging.FromContext(ctx).Errorf("Failed to get appointment by ID: %s, error: %v", req.ID, err)
 		return nil, fmt.Errorf("failed to get appointment: %w", err)
 	}
 
-	logging.Logger.Infof("Changing status of appointment ID: %s from %s to %s", req.ID, appointment.Status, req.Status)
+	logging.FromContext(ctx).Infof("Changing status of appointment ID: %s from %s to %s", req.ID, appointment.Status, req.Status)
+	grpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
+	// A canceled appointment frees its slot, the doctor service also revokes its meeting.
+	// Restoring a canceled appointment takes the slot again.
+	canceling := req.Status == model.Canceled && appointment.Status != model.Canceled
+	restoring := req.Status != model.Canceled && appointment.Status == model.Canceled
+	switch {
+	case canceling:
+		if err := a.releaseSlot(grpcCtx, appointment); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to release time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
+			return nil, fmt.Errorf("failed to release time slot: %w", err)
+		}
+		appointment.HeldUntil = nil
+	case restoring:
+		if err := a.retakeSlot(grpcCtx, appointment); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to take time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
+			return nil, fmt.Errorf("failed to take time slot: %w", err)
+		}
+	}
+
+	previous := appointment.Status
 	appointment.Status = req.Status
 	if err := a.repo.Update(appointment); err != nil {
-		logging.Logger.Errorf("Failed to update appointment status for ID: %s, error: %v", req.ID, err)
+		logging.FromContext(ctx).Errorf("Failed to update appointment status for ID: %s, error: %v", req.ID, err)
+		appointment.Status = previous
+		switch {
+		case canceling:
+			a.compensateRelease(ctx, appointment)
+		case restoring:
+			a.compensateSlot(ctx, appointment)
+		}
 		return nil, fmt.Errorf("failed to update appointment status: %w", err)
 	}
-	logging.Logger.Infof("Successfully changed status of appointment ID: %s to %s", req.ID, req.Status)
+	logging.FromContext(ctx).Infof("Successfully changed status of appointment ID: %s to %s", req.ID, req.Status)
 	return dto.AppointmentResponseFromModel(appointment), nil
 }
 
-func (a appointmentService) getAppointment(id uuid.UUID) (*model.Appointment, error) {
+func (a appointmentService) getAppointment(ctx context.Context, id uuid.UUID) (*model.Appointment, error) {
 	appointment, err := a.repo.GetByID(id)
 	if err != nil {
-		logging.Logger.Errorf("Failed to get appointment by ID: %s, error: %v", id, err)
+		logging.FromContext(ctx).Errorf("Failed to get appointment by ID: %s, error: %v", id, err)
 		return nil, fmt.Errorf("repository get failed: %w", err)
 	}
 	if appointment == nil {
-		logging.Logger.Warnf("Appointment with ID: %s not found", id)
+		logging.FromContext(ctx).Warnf("Appointment with ID: %s not found", id)
 		return nil, gorm.ErrRecordNotFound
 	}
 	return appointment, nil
 }
 
+// getPending returns the appointment if it is still in checkout.
+func (a appointmentService) getPending(ctx context.Context, id uuid.UUID) (*model.Appointment, error) {
+	appointment, err := a.getAppointment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != model.Pending || appointment.HeldUntil == nil || appointment.ReservationToken == nil {
+		logging.FromContext(ctx).Warnf("Appointment with ID: %s is %s, not pending", id, appointment.Status)
+		return nil, ErrNotPending
+	}
+	return appointment, nil
+}
+
 func (a appointmentService) changeTimeSlotAvailability(ctx context.Context, doctorID uuid.UUID, date time.Time, isAvailable bool) error {
-	response, err := a.grpc.ChangeTimeSlot(ctx, &gen.ChangeTimeSlotRequest{
+	response, err := a.grpc.ChangeTimeSlot(ctx, &doctorpb.ChangeTimeSlotRequest{
 		DoctorId:    doctorID.String(),
 		SlotTime:    timestamppb.New(date),
 		IsAvailable: isAvailable,
 	})
 	if err != nil {
-		logging.Logger.Errorf("Failed to change time slot for doctor ID: %s on date: %s, error: %v", doctorID, date, err)
+		logging.FromContext(ctx).Errorf("Failed to change time slot for doctor ID: %s on date: %s, error: %v", doctorID, date, err)
 		return fmt.Errorf("grpc change time slot failed: %w", err)
 	}
 	if !response.Success {
-		logging.Logger.Errorf("Failed to change time slot for doctor ID: %s on date: %s", doctorID, date)
+		logging.FromContext(ctx).Errorf("Failed to change time slot for doctor ID: %s on date: %s", doctorID, date)
 		return fmt.Errorf("failed to change time slot for doctor ID: %s on date: %s", doctorID, date)
 	}
-	logging.Logger.Infof("Successfully changed time slot availability to %t for doctor ID: %s on date: %s", isAvailable, doctorID, date)
+	logging.FromContext(ctx).Infof("Successfully changed time slot availability to %t for doctor ID: %s on date: %s", isAvailable, doctorID, date)
+	return nil
+}
+
+// holdSlot holds the doctor slot for the appointment and stores the reservation token in it.
+func (a appointmentService) holdSlot(ctx context.Context, appointment *model.Appointment) error {
+	response, err := a.grpc.HoldSlot(ctx, &doctorpb.HoldSlotRequest{
+		DoctorId:      appointment.DoctorID.String(),
+		SlotTime:      timestamppb.New(appointment.Date),
+		AppointmentId: appointment.ID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("grpc hold slot failed: %w", err)
+	}
+	if !response.Success {
+		return fmt.Errorf("time slot is not available for doctor ID: %s on date: %s with reason: %s", appointment.DoctorID, appointment.Date, response.Reason)
+	}
+
+	token, err := uuid.Parse(response.ReservationToken)
+	if err != nil {
+		return fmt.Errorf("invalid reservation token: %w", err)
+	}
+	heldUntil := response.HeldUntil.AsTime()
+	appointment.ReservationToken = &token
+	appointment.HeldUntil = &heldUntil
+	logging.FromContext(ctx).Infof("Held time slot for doctor ID: %s on date: %s until %s", appointment.DoctorID, appointment.Date, heldUntil)
+	return nil
+}
+
+// confirmSlot turns the hold of the appointment into a permanent reservation.
+func (a appointmentService) confirmSlot(ctx context.Context, appointment *model.Appointment) error {
+	response, err := a.grpc.ConfirmSlot(ctx, &doctorpb.ConfirmSlotRequest{
+		DoctorId:         appointment.DoctorID.String(),
+		SlotTime:         timestamppb.New(appointment.Date),
+		AppointmentId:    appointment.ID.String(),
+		ReservationToken: appointment.ReservationToken.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("grpc confirm slot failed: %w", err)
+	}
+	if !response.Success {
+		return fmt.Errorf("%w: doctor ID: %s on date: %s: %s", ErrHoldExpired, appointment.DoctorID, appointment.Date, response.Message)
+	}
+	return nil
+}
+
+// compensationContext returns the context of a call undoing a failed step. It keeps the values of ctx,
+// like the request ID, but not its deadline: the step may have failed because the deadline passed,
+// and the undo must still get its own time.
+func compensationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), compensationTimeout)
+}
+
+// compensateSlot releases the slot of an appointment that could not be booked.
+// A failure is only logged, the hold expires anyway.
+func (a appointmentService) compensateSlot(ctx context.Context, appointment *model.Appointment) {
+	ctx, cancel := compensationContext(ctx)
+	defer cancel()
+	if err := a.releaseSlot(ctx, appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to release time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
+	}
+}
+
+// reserveSlot reserves the doctor slot for the appointment and stores the reservation token in it.
+func (a appointmentService) reserveSlot(ctx context.Context, appointment *model.Appointment) error {
+	response, err := a.grpc.ReserveSlot(ctx, &doctorpb.ReserveSlotRequest{
+		DoctorId:      appointment.DoctorID.String(),
+		SlotTime:      timestamppb.New(appointment.Date),
+		AppointmentId: appointment.ID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("grpc reserve slot failed: %w", err)
+	}
+	if !response.Success {
+		return fmt.Errorf("time slot is not available for doctor ID: %s on date: %s with reason: %s", appointment.DoctorID, appointment.Date, response.Reason)
+	}
+
+	token, err := uuid.Parse(response.ReservationToken)
+	if err != nil {
+		return fmt.Errorf("invalid reservation token: %w", err)
+	}
+	appointment.ReservationToken = &token
+	logging.FromContext(ctx).Infof("Reserved time slot for doctor ID: %s on date: %s for appointment ID: %s", appointment.DoctorID, appointment.Date, appointment.ID)
 	return nil
 }
 
+// releaseSlot frees the doctor slot of the appointment. Appointments booked before
+// reservations have no token, their slot is freed the old way.
+func (a appointmentService) releaseSlot(ctx context.Context, appointment *model.Appointment) error {
+	if appointment.ReservationToken == nil {
+		return a.changeTimeSlotAvailability(ctx, appointment.DoctorID, appointment.Date, true)
+	}
+
+	response, err := a.grpc.ReleaseSlot(ctx, &doctorpb.ReleaseSlotRequest{
+		DoctorId:         appointment.DoctorID.String(),
+		SlotTime:         timestamppb.New(appointment.Date),
+		AppointmentId:    appointment.ID.String(),
+		ReservationToken: appointment.ReservationToken.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("grpc release slot failed: %w", err)
+	}
+	if !response.Success {
+		return fmt.Errorf("failed to release time slot for doctor ID: %s on date: %s: %s", appointment.DoctorID, appointment.Date, response.Message)
+	}
+	return nil
+}
+
+// retakeSlot reserves the released slot of the appointment again. A new reservation gets a new token.
+func (a appointmentService) retakeSlot(ctx context.Context, appointment *model.Appointment) error {
+	if appointment.ReservationToken == nil {
+		return a.changeTimeSlotAvailability(ctx, appointment.DoctorID, appointment.Date, false)
+	}
+	return a.reserveSlot(ctx, appointment)
+}
+
+// revertRelease takes the slot back after the appointment could not be deleted,
+// the appointment is updated with the token of the new reservation.
+func (a appointmentService) revertRelease(ctx context.Context, appointment *model.Appointment) error {
+	if err := a.retakeSlot(ctx, appointment); err != nil {
+		return err
+	}
+	if appointment.ReservationToken == nil {
+		return nil
+	}
+	return a.repo.Update(appointment)
+}
+
+// compensateRelease takes the slot back after the appointment could not be canceled.
+// A failure is only logged, the appointment stays as it was but without its slot.
+func (a appointmentService) compensateRelease(ctx context.Context, appointment *model.Appointment) {
+	ctx, cancel := compensationContext(ctx)
+	defer cancel()
+	if err := a.revertRelease(ctx, appointment); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to take back time slot for doctor ID: %s on date: %s, error: %v", appointment.DoctorID, appointment.Date, err)
+	}
+}
+
 func (a appointmentService) deleteAppointmentRecord(ctx context.Context, id uuid.UUID, appointment *model.Appointment) error {
 	if err := a.repo.Delete(id); err != nil {
-		logging.Logger.Errorf("Failed to delete appointment with ID: %s, error: %v, attempting to revert time slot", id, err)
+		logging.FromContext(ctx).Errorf("Failed to delete appointment with ID: %s, error: %v, attempting to revert time slot", id, err)
 
-		if revertErr := a.changeTimeSlotAvailability(ctx, appointment.DoctorID, appointment.Date, false); revertErr != nil {
-			logging.Logger.Errorf("Failed to revert time slot for doctor ID: %s on date: %s, error: %v",
+		revertCtx, cancel := compensationContext(ctx)
+		defer cancel()
+		if revertErr := a.revertRelease(revertCtx, appointment); revertErr != nil {
+			logging.FromContext(ctx).Errorf("Failed to revert time slot for doctor ID: %s on date: %s, error: %v",
 				appointment.DoctorID, appointment.Date, revertErr)
 			return fmt.Errorf("delete failed: %v, revert also failed: %w", err, revertErr)
 		}
@@ -193,8 +475,9 @@ func (a appointmentService) deleteAppointmentRecord(ctx context.Context, id uuid
 	return nil
 }
 
-func NewAppointmentService(repo repository.AppointmentRepository) AppointmentService {
+func NewAppointmentService(repo repository.AppointmentRepository, grpc repository.GRPCAppointmentRepository) AppointmentService {
 	return &appointmentService{
 		repo: repo,
+		grpc: grpc,
 	}
 }
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"appointment/internal/dto"
+	"appointment/internal/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAppointmentRepo keeps appointments in memory, the err fields fail the matching calls.
+type fakeAppointmentRepo struct {
+	appointments map[uuid.UUID]model.Appointment
+	createErr    error
+	updateErr    error
+	deleteErr    error
+	deleted      []uuid.UUID
+}
+
+func (r *fakeAppointmentRepo) Create(appointment *model.Appointment) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.appointments[appointment.ID] = *appointment
+	return nil
+}
+
+func (r *fakeAppointmentRepo) GetByID(id uuid.UUID) (*model.Appointment, error) {
+	appointment, ok := r.appointments[id]
+	if !ok {
+		return nil, nil
+	}
+	return &appointment, nil
+}
+
+func (r *fakeAppointmentRepo) Update(appointment *model.Appointment) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	r.appointments[appointment.ID] = *appointment
+	return nil
+}
+
+func (r *fakeAppointmentRepo) Delete(id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	if r.deleteErr != nil {
+		return r.deleteErr
+	}
+	delete(r.appointments, id)
+	return nil
+}
+
+func (r *fakeAppointmentRepo) ListByUserID(int64) ([]model.Appointment, error) { return nil, nil }
+
+func (r *fakeAppointmentRepo) ListByDoctorID(uuid.UUID) ([]model.Appointment, error) { return nil, nil }
+
+// fakeDoctorClient answers the slot calls of the doctor service and records them.
+// onConfirm and onRelease run inside the calls, e.g. to cancel the caller context.
+type fakeDoctorClient struct {
+	confirmErr error
+	onConfirm  func()
+	onRelease  func()
+
+	calls      []string
+	releaseCtx []error // ctx.Err() seen by each ReleaseSlot
+	reserveCtx []error // ctx.Err() seen by each ReserveSlot
+	released   []string
+	newToken   uuid.UUID
+}
+
+func (f *fakeDoctorClient) CheckTimeAvailability(context.Context, *doctorpb.CheckTimeAvailabilityRequest) (*doctorpb.CheckTimeAvailabilityResponse, error) {
+	return &doctorpb.CheckTimeAvailabilityResponse{IsAvailable: true}, nil
+}
+
+func (f *fakeDoctorClient) GetAvailableSlots(context.Context, *doctorpb.GetAvailableSlotsRequest) (*doctorpb.GetAvailableSlotsResponse, error) {
+	return &doctorpb.GetAvailableSlotsResponse{}, nil
+}
+
+func (f *fakeDoctorClient) ChangeTimeSlot(context.Context, *doctorpb.ChangeTimeSlotRequest) (*doctorpb.ChangeTimeSlotResponse, error) {
+	f.calls = append(f.calls, "ChangeTimeSlot")
+	return &doctorpb.ChangeTimeSlotResponse{Success: true}, nil
+}
+
+func (f *fakeDoctorClient) ReserveSlot(ctx context.Context, _ *doctorpb.ReserveSlotRequest) (*doctorpb.ReserveSlotResponse, error) {
+	f.calls = append(f.calls, "ReserveSlot")
+	f.reserveCtx = append(f.reserveCtx, ctx.Err())
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return &doctorpb.ReserveSlotResponse{Success: true, ReservationToken: f.newToken.String()}, nil
+}
+
+func (f *fakeDoctorClient) ReleaseSlot(ctx context.Context, req *doctorpb.ReleaseSlotRequest) (*doctorpb.ReleaseSlotResponse, error) {
+	f.calls = append(f.calls, "ReleaseSlot")
+	f.releaseCtx = append(f.releaseCtx, ctx.Err())
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	f.released = append(f.released, req.ReservationToken)
+	if f.onRelease != nil {
+		f.onRelease()
+	}
+	return &doctorpb.ReleaseSlotResponse{Success: true}, nil
+}
+
+func (f *fakeDoctorClient) HoldSlot(context.Context, *doctorpb.HoldSlotRequest) (*doctorpb.HoldSlotResponse, error) {
+	f.calls = append(f.calls, "HoldSlot")
+	return &doctorpb.HoldSlotResponse{
+		Success:          true,
+		ReservationToken: uuid.NewString(),
+		HeldUntil:        timestamppb.New(time.Now().Add(10 * time.Minute)),
+	}, nil
+}
+
+func (f *fakeDoctorClient) ConfirmSlot(context.Context, *doctorpb.ConfirmSlotRequest) (*doctorpb.ConfirmSlotResponse, error) {
+	f.calls = append(f.calls, "ConfirmSlot")
+	if f.onConfirm != nil {
+		f.onConfirm()
+	}
+	if f.confirmErr != nil {
+		return nil, f.confirmErr
+	}
+	return &doctorpb.ConfirmSlotResponse{Success: true}, nil
+}
+
+type AppointmentServiceTestSuite struct {
+	suite.Suite
+	repo   *fakeAppointmentRepo
+	doctor *fakeDoctorClient
+	svc    AppointmentService
+}
+
+func TestAppointmentServiceSuite(t *testing.T) {
+	suite.Run(t, new(AppointmentServiceTestSuite))
+}
+
+func (s *AppointmentServiceTestSuite) SetupTest() {
+	logging.InitLogger(config.Config{Logger: config.LoggerConfig{TestMode: true}})
+	s.repo = &fakeAppointmentRepo{appointments: make(map[uuid.UUID]model.Appointment)}
+	s.doctor = &fakeDoctorClient{newToken: uuid.New()}
+	s.svc = NewAppointmentService(s.repo, s.doctor)
+}
+
+func (s *AppointmentServiceTestSuite) createRequest() *dto.CreateAppointmentRequest {
+	return &dto.CreateAppointmentRequest{UserID: 1, DoctorID: uuid.New(), Date: time.Date(2030, 1, 15, 9, 0, 0, 0, time.UTC)}
+}
+
+func (s *AppointmentServiceTestSuite) TestCreate_Success() {
+	_, err := s.svc.Create(context.Background(), s.createRequest())
+	s.Require().NoError(err)
+	s.Equal([]string{"HoldSlot", "ConfirmSlot"}, s.doctor.calls)
+	s.Require().Len(s.repo.appointments, 1)
+	for _, appointment := range s.repo.appointments {
+		s.Equal(model.Scheduled, appointment.Status)
+		s.Nil(appointment.HeldUntil)
+	}
+}
+
+func (s *AppointmentServiceTestSuite) TestCreate_ConfirmFailure() {
+	// The request is canceled while the confirmation runs, the undo must still reach the doctor service
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.doctor.confirmErr = errors.New("doctor service unavailable")
+	s.doctor.onConfirm = cancel
+
+	_, err := s.svc.Create(ctx, s.createRequest())
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to confirm time slot")
+
+	s.Require().Len(s.repo.deleted, 1, "Unconfirmed appointment should be deleted")
+	s.Empty(s.repo.appointments)
+	s.Equal([]string{"HoldSlot", "ConfirmSlot", "ReleaseSlot"}, s.doctor.calls)
+	s.Equal([]error{nil}, s.doctor.releaseCtx, "Release should get a fresh context")
+	s.Len(s.doctor.released, 1)
+}
+
+func (s *AppointmentServiceTestSuite) TestCreate_DatabaseFailure() {
+	s.repo.createErr = errors.New("db down")
+
+	_, err := s.svc.Create(context.Background(), s.createRequest())
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to create appointment")
+
+	s.Equal([]string{"HoldSlot", "ReleaseSlot"}, s.doctor.calls, "Held slot should be released, not confirmed")
+	s.Equal([]error{nil}, s.doctor.releaseCtx)
+	s.Empty(s.repo.deleted)
+}
+
+func (s *AppointmentServiceTestSuite) TestDelete_FailureRevertsRelease() {
+	token := uuid.New()
+	appointment := model.Appointment{ID: uuid.New(), UserID: 1, DoctorID: uuid.New(), Date: time.Now(), ReservationToken: &token}
+	s.repo.appointments[appointment.ID] = appointment
+	s.repo.deleteErr = errors.New("db down")
+
+	// The request is canceled once the slot is released, the slot must still be taken back
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.doctor.onRelease = cancel
+
+	err := s.svc.Delete(ctx, &dto.AppointmentIDRequest{ID: appointment.ID})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "repository delete failed")
+
+	s.Equal([]string{"ReleaseSlot", "ReserveSlot"}, s.doctor.calls)
+	s.Equal([]string{token.String()}, s.doctor.released)
+	s.Equal([]error{nil}, s.doctor.reserveCtx, "Revert should get a fresh context")
+
+	stored := s.repo.appointments[appointment.ID]
+	s.Require().NotNil(stored.ReservationToken)
+	s.Equal(s.doctor.newToken, *stored.ReservationToken, "Appointment should keep the token of the new reservation")
+}
+
+func (s *AppointmentServiceTestSuite) TestHold_ThenConfirm() {
+	held, err := s.svc.Hold(context.Background(), s.createRequest())
+	s.Require().NoError(err)
+	s.Equal(model.Pending, held.Status)
+	s.Require().NotNil(held.HeldUntil)
+	s.Equal([]string{"HoldSlot"}, s.doctor.calls, "Slot should stay held during checkout")
+
+	id := uuid.MustParse(held.ID)
+	confirmed, err := s.svc.Confirm(context.Background(), &dto.AppointmentIDRequest{ID: id})
+	s.Require().NoError(err)
+	s.Equal(model.Scheduled, confirmed.Status)
+	s.Nil(confirmed.HeldUntil)
+	s.Equal([]string{"HoldSlot", "ConfirmSlot"}, s.doctor.calls)
+	s.Equal(model.Scheduled, s.repo.appointments[id].Status)
+
+	_, err = s.svc.Confirm(context.Background(), &dto.AppointmentIDRequest{ID: id})
+	s.ErrorIs(err, ErrNotPending)
+}
+
+func (s *AppointmentServiceTestSuite) TestHold_ThenRelease() {
+	held, err := s.svc.Hold(context.Background(), s.createRequest())
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.svc.Release(context.Background(), &dto.AppointmentIDRequest{ID: uuid.MustParse(held.ID)}))
+	s.Equal([]string{"HoldSlot", "ReleaseSlot"}, s.doctor.calls)
+	s.Len(s.doctor.released, 1)
+	s.Empty(s.repo.appointments)
+}
+
+func (s *AppointmentServiceTestSuite) TestAbandonedCheckout() {
+	held, err := s.svc.Hold(context.Background(), s.createRequest())
+	s.Require().NoError(err)
+	id := uuid.MustParse(held.ID)
+
+	// The checkout is abandoned, the doctor service frees the slot once the hold ends
+	appointment := s.repo.appointments[id]
+	expired := time.Now().Add(-time.Second)
+	appointment.HeldUntil = &expired
+	s.repo.appointments[id] = appointment
+
+	_, err = s.svc.Confirm(context.Background(), &dto.AppointmentIDRequest{ID: id})
+	s.ErrorIs(err, ErrHoldExpired)
+
+	s.Require().NoError(s.svc.Release(context.Background(), &dto.AppointmentIDRequest{ID: id}))
+	s.Equal([]string{"HoldSlot"}, s.doctor.calls, "Expired hold is not released again")
+	s.Empty(s.repo.appointments)
+}
+
+// Support function for storing a confirmed appointment with a reservation token.
+func (s *AppointmentServiceTestSuite) scheduled() model.Appointment {
+	token := uuid.New()
+	appointment := model.Appointment{ID: uuid.New(), UserID: 1, DoctorID: uuid.New(), Date: time.Now(), Status: model.Scheduled, ReservationToken: &token}
+	s.repo.appointments[appointment.ID] = appointment
+	return appointment
+}
+
+func (s *AppointmentServiceTestSuite) TestChangeStatus_CancelReleasesSlot() {
+	appointment := s.scheduled()
+
+	resp, err := s.svc.ChangeStatus(context.Background(), &dto.ChangeAppointmentStatusRequest{ID: appointment.ID, Status: model.Canceled})
+	s.Require().NoError(err)
+	s.Equal(model.Canceled, resp.Status)
+	s.Equal([]string{"ReleaseSlot"}, s.doctor.calls, "Doctor service frees the slot and revokes its meeting")
+	s.Equal([]string{appointment.ReservationToken.String()}, s.doctor.released)
+
+	// Canceling again or deleting the canceled appointment doesn't touch the slot
+	_, err = s.svc.ChangeStatus(context.Background(), &dto.ChangeAppointmentStatusRequest{ID: appointment.ID, Status: model.Canceled})
+	s.Require().NoError(err)
+	s.Require().NoError(s.svc.Delete(context.Background(), &dto.AppointmentIDRequest{ID: appointment.ID}))
+	s.Equal([]string{"ReleaseSlot"}, s.doctor.calls)
+	s.Empty(s.repo.appointments)
+}
+
+func (s *AppointmentServiceTestSuite) TestChangeStatus_RestoreRetakesSlot() {
+	appointment := s.scheduled()
+	appointment.Status = model.Canceled
+	s.repo.appointments[appointment.ID] = appointment
+
+	_, err := s.svc.ChangeStatus(context.Background(), &dto.ChangeAppointmentStatusRequest{ID: appointment.ID, Status: model.Scheduled})
+	s.Require().NoError(err)
+	s.Equal([]string{"ReserveSlot"}, s.doctor.calls)
+	stored := s.repo.appointments[appointment.ID]
+	s.Equal(model.Scheduled, stored.Status)
+	s.Equal(s.doctor.newToken, *stored.ReservationToken)
+}
+
+func (s *AppointmentServiceTestSuite) TestChangeStatus_CancelFailureRetakesSlot() {
+	appointment := s.scheduled()
+	s.repo.updateErr = errors.New("db down")
+
+	_, err := s.svc.ChangeStatus(context.Background(), &dto.ChangeAppointmentStatusRequest{ID: appointment.ID, Status: model.Canceled})
+	s.Require().Error(err)
+	s.Equal([]string{"ReleaseSlot", "ReserveSlot"}, s.doctor.calls)
+	s.Equal(model.Scheduled, s.repo.appointments[appointment.ID].Status)
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/Ruletk/OnlineClinic/pkg/config"
 	"github.com/Ruletk/OnlineClinic/pkg/database"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
-	"github.com/gin-contrib/cors"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/nats-io/nats.go"
@@ -29,17 +31,25 @@ func main() {
 	}
 	logging.InitLogger(*cfg)
 
+	logging.Logger.Debug("Setting up tracing")
+	shutdownTracer, err := tracing.InitTracer(*cfg)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to initialize tracing")
+	}
+	defer func() {
+		if err := shutdownTracer(mainContext); err != nil {
+			logging.Logger.WithError(err).Error("Failed to shutdown tracer")
+		}
+	}()
+
 	logging.Logger.Debug("Creating Gin router")
 	r := gin.Default()
+	r.Use(logging.RequestIDMiddleware())
+	r.Use(tracing.GinMiddleware(cfg.Tracing.ServiceName))
+	r.Use(metrics.GinMiddleware())
 
-	logging.Logger.Debug("Setting up CORS middleware")
+	// CORS is handled by the gateway, browsers never reach the service directly.
 	r.Use(logging.GinLogger(logging.Logger), gin.Recovery())
-	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token", "Cookie"},
-		AllowCredentials: true,
-	}))
 
 	logging.Logger.Debug("Setting up NATS connection")
 	natsConn, err := nats.Connect(cfg.Nats.Url)
@@ -55,12 +65,21 @@ func main() {
 		logging.Logger.WithError(err).Fatal("Failed to connect to the database")
 		panic(err) // Also, the initialization of the app failed, without a database we can't do anything
 	}
+	if err := tracing.InstrumentGorm(db); err != nil {
+		logging.Logger.WithError(err).Error("Failed to instrument database")
+	}
+	if err := metrics.InstrumentGorm(db); err != nil {
+		logging.Logger.WithError(err).Error("Failed to register database metrics")
+	}
 
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     "redis:6379",
 		Password: "",
 		DB:       0,
 	})
+	if err := tracing.InstrumentRedis(redisClient); err != nil {
+		logging.Logger.WithError(err).Error("Failed to instrument Redis client")
+	}
 
 	logging.Logger.Debug("Starting repositories")
 	natsPublisher := nats2.NewPublisher(natsConn)
@@ -76,9 +95,9 @@ func main() {
 	logging.Logger.Debugf("Started repositories. AuthRepo: %T, SessionRepo: %T, RoleRepo: %T", authRepo, sessionRepo, roleRepo)
 
 	logging.Logger.Debug("Starting services")
-	// TODO: Add jwt settings to the config
-	//jwtService := service.NewJwtService(defaultConfig.Jwt.Algo, defaultConfig.Jwt.Secret)
-	jwtService := service.NewJwtService(jwt.SigningMethodHS256, "This is a secret key temp for avoid errors")
+	// Services that verify access tokens get the same JWT_SECRET
+	jwtSecret := config.GetEnvWithDefault("JWT_SECRET", "This is a secret key temp for avoid errors")
+	jwtService := service.NewJwtService(jwt.SigningMethodHS256, jwtSecret)
 
 	roleService := service.NewRoleService(roleRepo)
 	sessionService := service.NewSessionService(sessionRepo)
@@ -92,6 +111,14 @@ func main() {
 	router := r.Group("/")
 	authAPI.RegisterRoutes(router)
 
+	logging.Logger.Debug("Registering OpenAPI document")
+	doc := openapi.NewDocument("Auth service", "1.0.0")
+	if err := authAPI.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("Failed to build OpenAPI document")
+	}
+	r.GET("/openapi.json", doc.Handler())
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	logging.Logger.Debug("Starting server")
 	err = r.Run(cfg.Backend.ListenAddress + ":" + strconv.Itoa(cfg.Backend.ListenPort))
 
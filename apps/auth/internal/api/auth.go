@@ -82,6 +82,8 @@ func (api *AuthAPI) Login(c *gin.Context) {
 
 	if resp != nil {
 		logging.Logger.Info("User logged in successfully")
+		// Secure is added by the gateway, which knows whether it is served over TLS.
+		c.SetSameSite(http.SameSiteLaxMode)
 		c.SetCookie("token", token, 31536000, "/", "", false, true)
 		c.JSON(http.StatusOK, resp)
 		return
@@ -117,7 +119,7 @@ func (api *AuthAPI) Register(c *gin.Context) {
 	logging.Logger.Info("Registering user with email: " + req.Email)
 
 	// Register the user
-	resp, err := api.authService.Register(&req)
+	resp, err := api.authService.Register(c.Request.Context(), &req)
 	if errors.Is(err, gorm.ErrDuplicatedKey) {
 		logging.Logger.WithError(err).Error("User with this email already registered")
 		c.JSON(http.StatusConflict, messages.ApiResponse{
@@ -172,6 +174,7 @@ func (api *AuthAPI) Logout(c *gin.Context) {
 
 	logging.Logger.Info("User logged out successfully, token: " + token)
 
+	c.SetSameSite(http.SameSiteLaxMode)
 	c.SetCookie("token", "", -1, "/", "", false, true)
 	c.JSON(http.StatusOK, messages.ApiResponse{
 		Code:    http.StatusOK,
@@ -199,7 +202,7 @@ func (api *AuthAPI) ChangePassword(c *gin.Context) {
 
 	// Send an email with a token to the user
 	logging.Logger.Info("Changing password for email: " + req.Email)
-	err = api.authService.RequestChangePassword(&req)
+	err = api.authService.RequestChangePassword(c.Request.Context(), &req)
 	if err == nil {
 		logging.Logger.Info("Password change sent for email: " + req.Email)
 		domain := string([]rune(req.Email)[strings.Index(req.Email, "@")+1:])
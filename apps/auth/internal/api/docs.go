@@ -0,0 +1,91 @@
+package api
+
+import (
+	"auth/internal/messages"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+)
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+// Keep both in sync when a route changes.
+func (api *AuthAPI) RegisterDocs(doc *openapi.Document) error {
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/verify/:token", Summary: "Verify an email address", Tags: []string{"account"},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized),
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/refresh", Summary: "Exchange the token cookie for an access token", Tags: []string{"session"},
+			Responses: apiResponses(http.StatusOK, http.StatusUnauthorized),
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/login", Summary: "Log in and set the token cookie", Tags: []string{"session"},
+			Request:   messages.AuthRequest{},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError),
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/register", Summary: "Register a new account", Tags: []string{"account"},
+			Request:   messages.AuthRequest{},
+			Responses: apiResponses(http.StatusCreated, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError),
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/change-password", Summary: "Request a password change email", Tags: []string{"account"},
+			Request:   messages.PasswordChangeRequest{},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest),
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/change-password/:token", Summary: "Set a new password with the emailed token", Tags: []string{"account"},
+			Request:   messages.PasswordChange{},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized),
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/logout", Summary: "Log out and clear the token cookie", Tags: []string{"session"},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest),
+			Secured:   true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/admin/sessions/hard-delete", Summary: "Delete all soft-deleted sessions", Tags: []string{"admin"},
+			Responses: apiResponses(http.StatusOK, http.StatusInternalServerError),
+			Secured:   true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/admin/sessions/delete-inactive", Summary: "Delete expired sessions", Tags: []string{"admin"},
+			Responses: apiResponses(http.StatusOK, http.StatusInternalServerError),
+			Secured:   true,
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/role", Summary: "Create a role", Tags: []string{"role"},
+			Request:   messages.RoleRequest{},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError),
+			Secured:   true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/role", Summary: "Delete a role", Tags: []string{"role"},
+			Request:   messages.RoleRequest{},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest, http.StatusInternalServerError),
+			Secured:   true,
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/role/assign", Summary: "Assign a role to a user", Tags: []string{"role"},
+			Request:   messages.RoleAssignRequest{},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError),
+			Secured:   true,
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/role/unassign", Summary: "Remove a role from a user", Tags: []string{"role"},
+			Request:   messages.RoleAssignRequest{},
+			Responses: apiResponses(http.StatusOK, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError),
+			Secured:   true,
+		},
+	)
+}
+
+// apiResponses documents statuses that all answer with an ApiResponse, as every auth handler does.
+func apiResponses(statuses ...int) map[int]any {
+	responses := make(map[int]any, len(statuses))
+	for _, status := range statuses {
+		responses[status] = messages.ApiResponse{}
+	}
+	return responses
+}
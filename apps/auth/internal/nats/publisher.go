@@ -1,15 +1,19 @@
 package nats
 
 import (
+	"context"
 	"fmt"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
 	"github.com/Ruletk/OnlineClinic/pkg/proto/utils/gen/email"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 )
 
 type Publisher interface {
-	PublishEmailMessage(to, subject, message string) error
+	PublishEmailMessage(ctx context.Context, to, subject, message string) error
 }
 
 type NatsPublisher struct {
@@ -21,7 +25,7 @@ func NewPublisher(nc *nats.Conn) Publisher {
 	return &NatsPublisher{nc: nc}
 }
 
-func (p *NatsPublisher) PublishEmailMessage(to, subject, message string) error {
+func (p *NatsPublisher) PublishEmailMessage(ctx context.Context, to, subject, message string) error {
 	logging.Logger.Debug("Publishing email message to NATS")
 	data, err := proto.Marshal(&email.SendEmailRequest{
 		To:      to,
@@ -33,15 +37,28 @@ func (p *NatsPublisher) PublishEmailMessage(to, subject, message string) error {
 		return fmt.Errorf("failed to marshal email message: %w", err)
 	}
 	logging.Logger.Debugf("Publishing email message to NATS, email: %s", to[0:5])
-	return p.nc.Publish("email.message", data)
+	return p.publish(ctx, data)
 }
 
-func (p *NatsPublisher) publish(data []byte) error {
+func (p *NatsPublisher) publish(ctx context.Context, data []byte) error {
 	if p.nc == nil {
 		logging.Logger.Error("NATS connection is nil, cannot publish email message")
 		return fmt.Errorf("NATS connection is nil")
 	}
 
+	// Producer span, the notification service continues the trace and
+	// the request ID from the message headers
+	ctx, span := tracing.Tracer("auth/nats").Start(ctx, "email.message publish",
+		trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	msg := nats.NewMsg("email.message")
+	msg.Data = data
+	tracing.InjectNats(ctx, msg)
+	logging.InjectRequestIDNats(ctx, msg)
+
 	logging.Logger.Debugf("Publishing data to NATS, length: %d", len(data))
-	return p.nc.Publish("email.message", data)
+	err := p.nc.PublishMsg(msg)
+	metrics.NatsPublished(msg.Subject, err)
+	return err
 }
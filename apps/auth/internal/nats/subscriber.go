@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
 	"github.com/nats-io/nats.go"
 )
 
@@ -22,8 +23,8 @@ func (s *Subscriber) SubscribeUserCreated(ctx context.Context, handler func([]by
 		return fmt.Errorf("NATS connection is nil")
 	}
 
-	_, err := s.nc.Subscribe("user.created", func(msg *nats.Msg) {
+	_, err := s.nc.Subscribe("user.created", metrics.NatsHandler(func(msg *nats.Msg) {
 		handler(msg.Data)
-	})
+	}))
 	return err
 }
@@ -4,6 +4,7 @@ import (
 	"auth/internal/messages"
 	"auth/internal/nats"
 	"auth/internal/repository"
+	"context"
 	"errors"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
 	"github.com/golang-jwt/jwt/v5"
@@ -16,10 +17,10 @@ var ErrInvalidCredentials = errors.New("invalid credentials")
 
 type AuthService interface {
 	Login(req *messages.AuthRequest) (resp *messages.ApiResponse, token string, err error)
-	Register(req *messages.AuthRequest) (resp *messages.ApiResponse, err error)
+	Register(ctx context.Context, req *messages.AuthRequest) (resp *messages.ApiResponse, err error)
 	Logout(token string) error
-	SendVerificationEmail(email string) error
-	RequestChangePassword(req *messages.PasswordChangeRequest) error
+	SendVerificationEmail(ctx context.Context, email string) error
+	RequestChangePassword(ctx context.Context, req *messages.PasswordChangeRequest) error
 	ChangePassword(req *messages.PasswordChange, token string) error
 	VerifyUser(token string) error
 	GetUserData(userID int64) (*messages.AuthDataResponse, error)
@@ -85,7 +86,7 @@ func (a authService) Login(req *messages.AuthRequest) (resp *messages.ApiRespons
 }
 
 // Register creates a new user
-func (a authService) Register(req *messages.AuthRequest) (*messages.ApiResponse, error) {
+func (a authService) Register(ctx context.Context, req *messages.AuthRequest) (*messages.ApiResponse, error) {
 	logging.Logger.Info("Registering user with email: ", req.Email, "...")
 
 	_, err := a.authRepo.GetByEmail(req.Email)
@@ -111,7 +112,7 @@ func (a authService) Register(req *messages.AuthRequest) (*messages.ApiResponse,
 	logging.Logger.Debug("User with email: ", req.Email, " created successfully, id: ", user.ID)
 
 	// Send verification email after registration
-	err = a.sendVerificationEmail(user)
+	err = a.sendVerificationEmail(ctx, user)
 	if err != nil {
 		logging.Logger.WithError(err).Error("Failed to send verification email.")
 		return nil, err
@@ -130,7 +131,7 @@ func (a authService) Logout(token string) error {
 	return a.sessionService.DeleteSession(token)
 }
 
-func (a authService) SendVerificationEmail(email string) error {
+func (a authService) SendVerificationEmail(ctx context.Context, email string) error {
 	logging.Logger.Info("Sending verification email for user: ", email, "...")
 	user, err := a.authRepo.GetByEmail(email)
 	if err != nil {
@@ -138,10 +139,10 @@ func (a authService) SendVerificationEmail(email string) error {
 		return err
 	}
 
-	return a.sendVerificationEmail(user)
+	return a.sendVerificationEmail(ctx, user)
 }
 
-func (a authService) sendVerificationEmail(user *repository.Auth) error {
+func (a authService) sendVerificationEmail(ctx context.Context, user *repository.Auth) error {
 	if user.Active {
 		logging.Logger.Warn("User with email: ", user.Email, " is already verified")
 		return nil
@@ -155,7 +156,7 @@ func (a authService) sendVerificationEmail(user *repository.Auth) error {
 	}
 	logging.Logger.Debug("Token generated: ", token[:10], ". Sending verification email...")
 
-	err = a.natsPublisher.PublishEmailMessage(user.Email, "Verification email", token)
+	err = a.natsPublisher.PublishEmailMessage(ctx, user.Email, "Verification email", token)
 	if err != nil {
 		logging.Logger.WithError(err).Error("Failed to send verification email.")
 		return err
@@ -166,7 +167,7 @@ func (a authService) sendVerificationEmail(user *repository.Auth) error {
 }
 
 // RequestChangePassword requests a password change for a user. Link is sent to the user's email
-func (a authService) RequestChangePassword(req *messages.PasswordChangeRequest) error {
+func (a authService) RequestChangePassword(ctx context.Context, req *messages.PasswordChangeRequest) error {
 	logging.Logger.Debug("Sending changing password request for user with email: ", req.Email, "...")
 	user, err := a.authRepo.GetByEmail(req.Email)
 	if err != nil {
@@ -181,7 +182,7 @@ func (a authService) RequestChangePassword(req *messages.PasswordChangeRequest)
 	}
 
 	logging.Logger.Debug("Sending password reset email...")
-	err = a.natsPublisher.PublishEmailMessage(user.Email, "Password reset", token)
+	err = a.natsPublisher.PublishEmailMessage(ctx, user.Email, "Password reset", token)
 	if err != nil {
 		logging.Logger.WithError(err).Error("Failed to send password reset email.")
 		return err
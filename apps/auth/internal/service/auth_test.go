@@ -6,6 +6,7 @@ import (
 	natsmock "auth/mock/nats"
 	repositorymock "auth/mock/repository"
 	servicemock "auth/mock/service"
+	"context"
 	"errors"
 	"github.com/Ruletk/OnlineClinic/pkg/config"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
@@ -139,9 +140,9 @@ func (suite *AuthServiceTestSuite) TestRegister_Success() {
 	suite.authRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
 	suite.authRepo.On("Create", mock.AnythingOfType("*repository.Auth")).Return(nil)
 	suite.jwtService.On("GenerateVerificationToken", mock.AnythingOfType("int64")).Return("verificationtoken", nil)
-	suite.natsPublisher.On("PublishEmailMessage", req.Email, "Verification email", "verificationtoken").Return(nil)
+	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, req.Email, "Verification email", "verificationtoken").Return(nil)
 
-	resp, err := suite.service.Register(req)
+	resp, err := suite.service.Register(context.Background(), req)
 
 	suite.NoError(err)
 	suite.Equal(201, resp.Code)
@@ -156,7 +157,7 @@ func (suite *AuthServiceTestSuite) TestRegister_UserAlreadyExists() {
 
 	suite.authRepo.On("GetByEmail", req.Email).Return(&repository.Auth{}, nil)
 
-	resp, err := suite.service.Register(req)
+	resp, err := suite.service.Register(context.Background(), req)
 
 	suite.Error(err)
 	suite.Nil(resp)
@@ -172,7 +173,7 @@ func (suite *AuthServiceTestSuite) TestRegister_UnexpectedError() {
 	suite.authRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
 	suite.authRepo.On("Create", mock.AnythingOfType("*repository.Auth")).Return(errors.New("unexpected error"))
 
-	resp, err := suite.service.Register(req)
+	resp, err := suite.service.Register(context.Background(), req)
 
 	suite.Error(err)
 	suite.Nil(resp)
@@ -188,9 +189,9 @@ func (suite *AuthServiceTestSuite) TestRegister_VerificationEmailFailure() {
 	suite.authRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
 	suite.authRepo.On("Create", mock.AnythingOfType("*repository.Auth")).Return(nil)
 	suite.jwtService.On("GenerateVerificationToken", mock.AnythingOfType("int64")).Return("verificationtoken", nil)
-	suite.natsPublisher.On("PublishEmailMessage", req.Email, "Verification email", "verificationtoken").Return(errors.New("email send failure"))
+	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, req.Email, "Verification email", "verificationtoken").Return(errors.New("email send failure"))
 
-	resp, err := suite.service.Register(req)
+	resp, err := suite.service.Register(context.Background(), req)
 
 	suite.Error(err)
 	suite.Nil(resp)
@@ -231,9 +232,9 @@ func (suite *AuthServiceTestSuite) TestSendVerificationEmail_Success() {
 
 	suite.authRepo.On("GetByEmail", email).Return(user, nil)
 	suite.jwtService.On("GenerateVerificationToken", user.ID).Return("verificationtoken", nil)
-	suite.natsPublisher.On("PublishEmailMessage", email, "Verification email", "verificationtoken").Return(nil)
+	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, email, "Verification email", "verificationtoken").Return(nil)
 
-	err := suite.service.SendVerificationEmail(email)
+	err := suite.service.SendVerificationEmail(context.Background(), email)
 
 	suite.NoError(err)
 	suite.authRepo.AssertCalled(suite.T(), "GetByEmail", email)
@@ -245,7 +246,7 @@ func (suite *AuthServiceTestSuite) TestSendVerificationEmail_UserNotFound() {
 
 	suite.authRepo.On("GetByEmail", email).Return(nil, gorm.ErrRecordNotFound)
 
-	err := suite.service.SendVerificationEmail(email)
+	err := suite.service.SendVerificationEmail(context.Background(), email)
 
 	suite.Error(err)
 	suite.Equal(gorm.ErrRecordNotFound, err)
@@ -262,7 +263,7 @@ func (suite *AuthServiceTestSuite) TestSendVerificationEmail_UserAlreadyVerified
 
 	suite.authRepo.On("GetByEmail", email).Return(user, nil)
 
-	err := suite.service.SendVerificationEmail(email)
+	err := suite.service.SendVerificationEmail(context.Background(), email)
 
 	suite.NoError(err)
 	suite.authRepo.AssertCalled(suite.T(), "GetByEmail", email)
@@ -280,7 +281,7 @@ func (suite *AuthServiceTestSuite) TestSendVerificationEmail_TokenGenerationFail
 	suite.authRepo.On("GetByEmail", email).Return(user, nil)
 	suite.jwtService.On("GenerateVerificationToken", user.ID).Return("", expectedError)
 
-	err := suite.service.SendVerificationEmail(email)
+	err := suite.service.SendVerificationEmail(context.Background(), email)
 
 	suite.Error(err)
 	suite.Equal(expectedError, err)
@@ -299,9 +300,9 @@ func (suite *AuthServiceTestSuite) TestSendVerificationEmail_EmailSendingFailure
 
 	suite.authRepo.On("GetByEmail", email).Return(user, nil)
 	suite.jwtService.On("GenerateVerificationToken", user.ID).Return("verificationtoken", nil)
-	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
+	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
 
-	err := suite.service.SendVerificationEmail(email)
+	err := suite.service.SendVerificationEmail(context.Background(), email)
 
 	suite.Error(err)
 	suite.Equal(expectedError, err)
@@ -320,9 +321,9 @@ func (suite *AuthServiceTestSuite) TestChangePassword_Success() {
 
 	suite.authRepo.On("GetByEmail", req.Email).Return(user, nil)
 	suite.jwtService.On("GeneratePasswordResetToken", user.ID).Return("resettoken", nil)
-	suite.natsPublisher.On("PublishEmailMessage", req.Email, "Password reset", "resettoken").Return(nil)
+	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, req.Email, "Password reset", "resettoken").Return(nil)
 
-	err := suite.service.RequestChangePassword(req)
+	err := suite.service.RequestChangePassword(context.Background(), req)
 
 	suite.NoError(err)
 	suite.authRepo.AssertCalled(suite.T(), "GetByEmail", req.Email)
@@ -336,7 +337,7 @@ func (suite *AuthServiceTestSuite) TestChangePassword_UserNotFound() {
 
 	suite.authRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
 
-	err := suite.service.RequestChangePassword(req)
+	err := suite.service.RequestChangePassword(context.Background(), req)
 
 	suite.Error(err)
 	suite.Equal(gorm.ErrRecordNotFound, err)
@@ -356,7 +357,7 @@ func (suite *AuthServiceTestSuite) TestChangePassword_TokenGenerationFailure() {
 	suite.authRepo.On("GetByEmail", req.Email).Return(user, nil)
 	suite.jwtService.On("GeneratePasswordResetToken", user.ID).Return("", expectedError)
 
-	err := suite.service.RequestChangePassword(req)
+	err := suite.service.RequestChangePassword(context.Background(), req)
 
 	suite.Error(err)
 	suite.Equal(expectedError, err)
@@ -376,9 +377,9 @@ func (suite *AuthServiceTestSuite) TestChangePassword_EmailSendingFailure() {
 
 	suite.authRepo.On("GetByEmail", req.Email).Return(user, nil)
 	suite.jwtService.On("GeneratePasswordResetToken", user.ID).Return("resettoken", nil)
-	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
+	suite.natsPublisher.On("PublishEmailMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
 
-	err := suite.service.RequestChangePassword(req)
+	err := suite.service.RequestChangePassword(context.Background(), req)
 
 	suite.Error(err)
 	suite.Equal(expectedError, err)
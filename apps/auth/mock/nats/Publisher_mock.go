@@ -5,6 +5,7 @@
 package nats_mock
 
 import (
+	"context"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -36,16 +37,16 @@ func (_m *MockPublisher) EXPECT() *MockPublisher_Expecter {
 }
 
 // PublishEmailMessage provides a mock function for the type MockPublisher
-func (_mock *MockPublisher) PublishEmailMessage(to string, subject string, message string) error {
-	ret := _mock.Called(to, subject, message)
+func (_mock *MockPublisher) PublishEmailMessage(ctx context.Context, to string, subject string, message string) error {
+	ret := _mock.Called(ctx, to, subject, message)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PublishEmailMessage")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = returnFunc(to, subject, message)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, to, subject, message)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,16 +59,17 @@ type MockPublisher_PublishEmailMessage_Call struct {
 }
 
 // PublishEmailMessage is a helper method to define mock.On call
+//   - ctx
 //   - to
 //   - subject
 //   - message
-func (_e *MockPublisher_Expecter) PublishEmailMessage(to interface{}, subject interface{}, message interface{}) *MockPublisher_PublishEmailMessage_Call {
-	return &MockPublisher_PublishEmailMessage_Call{Call: _e.mock.On("PublishEmailMessage", to, subject, message)}
+func (_e *MockPublisher_Expecter) PublishEmailMessage(ctx interface{}, to interface{}, subject interface{}, message interface{}) *MockPublisher_PublishEmailMessage_Call {
+	return &MockPublisher_PublishEmailMessage_Call{Call: _e.mock.On("PublishEmailMessage", ctx, to, subject, message)}
 }
 
-func (_c *MockPublisher_PublishEmailMessage_Call) Run(run func(to string, subject string, message string)) *MockPublisher_PublishEmailMessage_Call {
+func (_c *MockPublisher_PublishEmailMessage_Call) Run(run func(ctx context.Context, to string, subject string, message string)) *MockPublisher_PublishEmailMessage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -77,7 +79,7 @@ func (_c *MockPublisher_PublishEmailMessage_Call) Return(err error) *MockPublish
 	return _c
 }
 
-func (_c *MockPublisher_PublishEmailMessage_Call) RunAndReturn(run func(to string, subject string, message string) error) *MockPublisher_PublishEmailMessage_Call {
+func (_c *MockPublisher_PublishEmailMessage_Call) RunAndReturn(run func(ctx context.Context, to string, subject string, message string) error) *MockPublisher_PublishEmailMessage_Call {
 	_c.Call.Return(run)
 	return _c
 }
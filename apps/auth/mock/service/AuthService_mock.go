@@ -7,6 +7,7 @@ package service_mock
 import (
 	"auth/internal/messages"
 	"auth/internal/repository"
+	"context"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -348,8 +349,8 @@ func (_c *MockAuthService_Refresh_Call) RunAndReturn(run func(token string) (str
 }
 
 // Register provides a mock function for the type MockAuthService
-func (_mock *MockAuthService) Register(req *messages.AuthRequest) (*messages.ApiResponse, error) {
-	ret := _mock.Called(req)
+func (_mock *MockAuthService) Register(ctx context.Context, req *messages.AuthRequest) (*messages.ApiResponse, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
@@ -357,18 +358,18 @@ func (_mock *MockAuthService) Register(req *messages.AuthRequest) (*messages.Api
 
 	var r0 *messages.ApiResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*messages.AuthRequest) (*messages.ApiResponse, error)); ok {
-		return returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *messages.AuthRequest) (*messages.ApiResponse, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*messages.AuthRequest) *messages.ApiResponse); ok {
-		r0 = returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *messages.AuthRequest) *messages.ApiResponse); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*messages.ApiResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*messages.AuthRequest) error); ok {
-		r1 = returnFunc(req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *messages.AuthRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -381,14 +382,15 @@ type MockAuthService_Register_Call struct {
 }
 
 // Register is a helper method to define mock.On call
+//   - ctx
 //   - req
-func (_e *MockAuthService_Expecter) Register(req interface{}) *MockAuthService_Register_Call {
-	return &MockAuthService_Register_Call{Call: _e.mock.On("Register", req)}
+func (_e *MockAuthService_Expecter) Register(ctx interface{}, req interface{}) *MockAuthService_Register_Call {
+	return &MockAuthService_Register_Call{Call: _e.mock.On("Register", ctx, req)}
 }
 
-func (_c *MockAuthService_Register_Call) Run(run func(req *messages.AuthRequest)) *MockAuthService_Register_Call {
+func (_c *MockAuthService_Register_Call) Run(run func(ctx context.Context, req *messages.AuthRequest)) *MockAuthService_Register_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*messages.AuthRequest))
+		run(args[0].(context.Context), args[1].(*messages.AuthRequest))
 	})
 	return _c
 }
@@ -398,7 +400,7 @@ func (_c *MockAuthService_Register_Call) Return(resp *messages.ApiResponse, err
 	return _c
 }
 
-func (_c *MockAuthService_Register_Call) RunAndReturn(run func(req *messages.AuthRequest) (*messages.ApiResponse, error)) *MockAuthService_Register_Call {
+func (_c *MockAuthService_Register_Call) RunAndReturn(run func(ctx context.Context, req *messages.AuthRequest) (*messages.ApiResponse, error)) *MockAuthService_Register_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -450,16 +452,16 @@ func (_c *MockAuthService_RemoveRoleFromUser_Call) RunAndReturn(run func(userID
 }
 
 // RequestChangePassword provides a mock function for the type MockAuthService
-func (_mock *MockAuthService) RequestChangePassword(req *messages.PasswordChangeRequest) error {
-	ret := _mock.Called(req)
+func (_mock *MockAuthService) RequestChangePassword(ctx context.Context, req *messages.PasswordChangeRequest) error {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RequestChangePassword")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*messages.PasswordChangeRequest) error); ok {
-		r0 = returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *messages.PasswordChangeRequest) error); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -472,14 +474,15 @@ type MockAuthService_RequestChangePassword_Call struct {
 }
 
 // RequestChangePassword is a helper method to define mock.On call
+//   - ctx
 //   - req
-func (_e *MockAuthService_Expecter) RequestChangePassword(req interface{}) *MockAuthService_RequestChangePassword_Call {
-	return &MockAuthService_RequestChangePassword_Call{Call: _e.mock.On("RequestChangePassword", req)}
+func (_e *MockAuthService_Expecter) RequestChangePassword(ctx interface{}, req interface{}) *MockAuthService_RequestChangePassword_Call {
+	return &MockAuthService_RequestChangePassword_Call{Call: _e.mock.On("RequestChangePassword", ctx, req)}
 }
 
-func (_c *MockAuthService_RequestChangePassword_Call) Run(run func(req *messages.PasswordChangeRequest)) *MockAuthService_RequestChangePassword_Call {
+func (_c *MockAuthService_RequestChangePassword_Call) Run(run func(ctx context.Context, req *messages.PasswordChangeRequest)) *MockAuthService_RequestChangePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*messages.PasswordChangeRequest))
+		run(args[0].(context.Context), args[1].(*messages.PasswordChangeRequest))
 	})
 	return _c
 }
@@ -489,22 +492,22 @@ func (_c *MockAuthService_RequestChangePassword_Call) Return(err error) *MockAut
 	return _c
 }
 
-func (_c *MockAuthService_RequestChangePassword_Call) RunAndReturn(run func(req *messages.PasswordChangeRequest) error) *MockAuthService_RequestChangePassword_Call {
+func (_c *MockAuthService_RequestChangePassword_Call) RunAndReturn(run func(ctx context.Context, req *messages.PasswordChangeRequest) error) *MockAuthService_RequestChangePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // SendVerificationEmail provides a mock function for the type MockAuthService
-func (_mock *MockAuthService) SendVerificationEmail(email string) error {
-	ret := _mock.Called(email)
+func (_mock *MockAuthService) SendVerificationEmail(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendVerificationEmail")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(email)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -517,14 +520,15 @@ type MockAuthService_SendVerificationEmail_Call struct {
 }
 
 // SendVerificationEmail is a helper method to define mock.On call
+//   - ctx
 //   - email
-func (_e *MockAuthService_Expecter) SendVerificationEmail(email interface{}) *MockAuthService_SendVerificationEmail_Call {
-	return &MockAuthService_SendVerificationEmail_Call{Call: _e.mock.On("SendVerificationEmail", email)}
+func (_e *MockAuthService_Expecter) SendVerificationEmail(ctx interface{}, email interface{}) *MockAuthService_SendVerificationEmail_Call {
+	return &MockAuthService_SendVerificationEmail_Call{Call: _e.mock.On("SendVerificationEmail", ctx, email)}
 }
 
-func (_c *MockAuthService_SendVerificationEmail_Call) Run(run func(email string)) *MockAuthService_SendVerificationEmail_Call {
+func (_c *MockAuthService_SendVerificationEmail_Call) Run(run func(ctx context.Context, email string)) *MockAuthService_SendVerificationEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -534,7 +538,7 @@ func (_c *MockAuthService_SendVerificationEmail_Call) Return(err error) *MockAut
 	return _c
 }
 
-func (_c *MockAuthService_SendVerificationEmail_Call) RunAndReturn(run func(email string) error) *MockAuthService_SendVerificationEmail_Call {
+func (_c *MockAuthService_SendVerificationEmail_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockAuthService_SendVerificationEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
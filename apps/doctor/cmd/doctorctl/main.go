@@ -0,0 +1,197 @@
+// Command doctorctl imports and exports doctor service data straight against the database.
+//
+//	doctorctl import [-dry-run] [-format csv|json] <kind> <file|->
+//	doctorctl export [-format csv|json] <kind> [file|-]
+//
+// kind is specializations, doctors or schedule-templates. Without -format the
+// format follows the file extension, JSON for stdin and stdout. The import
+// report is printed as JSON; the exit code is 1 when the file was rejected.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	_ "time/tzdata" // doctor time zones must resolve in the distroless image
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/bulk"
+	doctorconfig "github.com/Ruletk/OnlineClinic/apps/doctor/internal/config"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/database"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+
+	"gorm.io/gorm/logger"
+)
+
+const usage = `usage:
+  doctorctl import [-dry-run] [-format csv|json] <kind> <file|->
+  doctorctl export [-format csv|json] <kind> [file|-]
+
+kinds: specializations, doctors, schedule-templates
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "doctorctl:", err)
+		os.Exit(1)
+	}
+}
+
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only validate the file, save nothing")
+	formatFlag := flags.String("format", "", "csv or json, by default from the file extension")
+	flags.Parse(args)
+	if flags.NArg() != 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	kind, format, err := parseArgs(flags.Arg(0), *formatFlag, flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if path := flags.Arg(1); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	svc, err := newBulkService()
+	if err != nil {
+		return err
+	}
+	report, err := svc.Import(context.Background(), kind, format, in, *dryRun)
+	if err != nil {
+		return err
+	}
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	if err := out.Encode(report); err != nil {
+		return err
+	}
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d of %d records rejected, nothing was saved", len(report.Errors), report.Total)
+	}
+	return nil
+}
+
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	formatFlag := flags.String("format", "", "csv or json, by default from the file extension")
+	flags.Parse(args)
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	path := flags.Arg(1)
+	kind, format, err := parseArgs(flags.Arg(0), *formatFlag, path)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newBulkService()
+	if err != nil {
+		return err
+	}
+	if path == "" || path == "-" {
+		return svc.Export(context.Background(), kind, format, os.Stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := svc.Export(context.Background(), kind, format, file); err != nil {
+		file.Close()
+		return errors.Join(err, os.Remove(path))
+	}
+	return file.Close()
+}
+
+// parseArgs checks the kind and picks the format: the flag, the file extension, then JSON.
+func parseArgs(kindArg, formatFlag, path string) (bulk.Kind, bulk.Format, error) {
+	kind, err := bulk.ParseKind(kindArg)
+	if err != nil {
+		return "", "", err
+	}
+	if formatFlag == "" {
+		formatFlag = string(bulk.FormatJSON)
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			formatFlag = string(bulk.FormatCSV)
+		}
+	}
+	format, err := bulk.ParseFormat(formatFlag)
+	if err != nil {
+		return "", "", err
+	}
+	return kind, format, nil
+}
+
+// newBulkService connects to the database configured the same way as the service.
+// Logs go to stderr, stdout is left for the report and the exported file.
+func newBulkService() (service.BulkService, error) {
+	cfg, err := config.GetDefaultConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("config load: %w", err)
+	}
+	cfg.Logger.Output = os.Stderr
+	logging.InitLogger(*cfg)
+
+	db, err := database.NewPostgresDatabase(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("db connect: %w", err)
+	}
+	db.Logger = logger.New(log.New(os.Stderr, "", log.LstdFlags), logger.Config{
+		SlowThreshold:             time.Second,
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+	})
+	scheduleCfg, err := doctorconfig.LoadScheduleConfig()
+	if err != nil {
+		return nil, fmt.Errorf("schedule config load: %w", err)
+	}
+
+	// Slots of imported templates are generated right away, as after creating a template through the API
+	doctors := repository.NewDoctorRepository(db)
+	templates := service.NewScheduleTemplateService(
+		repository.NewScheduleTemplateRepository(db),
+		repository.NewScheduleSlotRepository(db),
+		repository.NewScheduleExceptionRepository(db),
+		doctors,
+		repository.NewClinicRepository(db),
+		repository.NewMedicalServiceRepository(db),
+		scheduleCfg.HorizonWeeks,
+		scheduleCfg.ClinicTimeZone,
+	)
+	return service.NewBulkService(repository.NewBulkRepository(db), templates), nil
+}
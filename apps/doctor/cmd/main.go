@@ -1,17 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"time"
+	_ "time/tzdata" // doctor time zones must resolve in the distroless image
 
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/appointment"
+	doctorconfig "github.com/Ruletk/OnlineClinic/apps/doctor/internal/config"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/handler"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/locale"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/meeting"
+	doctornats "github.com/Ruletk/OnlineClinic/apps/doctor/internal/nats"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/storage"
 	"github.com/Ruletk/OnlineClinic/pkg/config"
 	"github.com/Ruletk/OnlineClinic/pkg/database"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -24,37 +39,214 @@ func main() {
 	// 2) init logger
 	logging.InitLogger(*cfg)
 
+	// 2.1) init tracing
+	shutdownTracer, err := tracing.InitTracer(*cfg)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("tracing init failed")
+	}
+	defer func() {
+		if err := shutdownTracer(context.Background()); err != nil {
+			logging.Logger.WithError(err).Error("tracing shutdown failed")
+		}
+	}()
+
 	// 3) connect Postgres
 	db, err := database.NewPostgresDatabase(cfg)
 	if err != nil {
 		logging.Logger.WithError(err).Fatal("db connect failed")
 	}
+	if err := tracing.InstrumentGorm(db); err != nil {
+		logging.Logger.WithError(err).Error("db tracing failed, continuing without it")
+	}
+	if err := metrics.InstrumentGorm(db); err != nil {
+		logging.Logger.WithError(err).Error("db metrics failed, continuing without them")
+	}
 
-	// 4) wire up layers
-	repo := repository.NewDoctorRepository(db)
-	svc := service.NewDoctorService(repo) // one arg: repo
-	h := handler.NewDoctorHandler(svc)
-
-	// 5) setup Gin + routes
-	router := gin.Default()
-	h.RegisterRoutes(router)
-
-	// 6) optional NATS
+	// 4) optional NATS, without it time-off events are not published
+	var publisher doctornats.Publisher
 	if cfg.Nats.Url != "" {
 		nc, err := nats.Connect(cfg.Nats.Url)
 		if err != nil {
 			logging.Logger.WithError(err).Error("nats connect failed, continuing without it")
 		} else {
 			defer nc.Close()
-			// pass nc into your service or handler if you add publish logic
-			_ = nc
+			publisher = doctornats.NewPublisher(nc)
 		}
 	}
 
-	// 7) run HTTP server on configured address
+	// 5) wire up layers
+	repo := repository.NewDoctorRepository(db)
+	svc := service.NewDoctorService(repo) // one arg: repo
+	authCfg, err := doctorconfig.LoadAuthConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("auth config load failed")
+	}
+	access := handler.NewAccess(svc, authCfg.JWTSecret)
+	h := handler.NewDoctorHandler(svc, access)
+	scheduleCfg, err := doctorconfig.LoadScheduleConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("schedule config load failed")
+	}
+	meetingCfg, err := doctorconfig.LoadMeetingConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("meeting config load failed")
+	}
+	meetings, err := newMeetingProvider(meetingCfg)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("meeting provider init failed")
+	}
+	slotRepo := repository.NewScheduleSlotRepository(db)
+	slotSvc := service.NewScheduleSlotService(slotRepo, meetings, scheduleCfg.HoldTTL)
+	go releaseExpiredHolds(slotSvc, scheduleCfg.HoldSweepInterval)
+
+	exceptionRepo := repository.NewScheduleExceptionRepository(db)
+	clinicRepo := repository.NewClinicRepository(db)
+	clinicHandler := handler.NewClinicHandler(service.NewClinicService(clinicRepo, repo), access)
+	specRepo := repository.NewSpecializationRepository(db)
+	serviceRepo := repository.NewMedicalServiceRepository(db)
+	specHandler := handler.NewSpecializationHandler(service.NewSpecializationService(specRepo), access)
+	catalogHandler := handler.NewCatalogHandler(service.NewCatalogService(serviceRepo, specRepo, repo), access)
+	templateSvc := service.NewScheduleTemplateService(repository.NewScheduleTemplateRepository(db), slotRepo, exceptionRepo, repo, clinicRepo, serviceRepo, scheduleCfg.HorizonWeeks, scheduleCfg.ClinicTimeZone)
+	templateHandler := handler.NewScheduleTemplateHandler(templateSvc, access)
+	go generateSlots(templateSvc, scheduleCfg.GenerateInterval)
+	exceptionHandler := handler.NewScheduleExceptionHandler(service.NewScheduleExceptionService(exceptionRepo, slotRepo, repo, publisher, scheduleCfg.ClinicTimeZone), access)
+	bulkHandler := handler.NewBulkHandler(service.NewBulkService(repository.NewBulkRepository(db), templateSvc), access)
+
+	storageCfg, err := doctorconfig.LoadStorageConfig()
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("storage config load failed")
+	}
+	blobs, err := newBlobStore(storageCfg)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("blob store init failed")
+	}
+	profileSvc := service.NewDoctorProfileService(repo, repository.NewDoctorCredentialRepository(db), blobs, storageCfg.MaxPhotoBytes)
+	profileHandler := handler.NewDoctorProfileHandler(profileSvc, access)
+
+	// The appointment service confirms that a reviewed appointment took place
+	appointmentURL := config.GetEnvWithDefault("APPOINTMENT_URL", "http://appointment:8080")
+	appointments := appointment.NewHTTPClient(appointmentURL, 5*time.Second)
+	reviewHandler := handler.NewReviewHandler(service.NewReviewService(repository.NewReviewRepository(db), repo, appointments), access)
+	meetingHandler := handler.NewMeetingHandler(service.NewMeetingService(slotRepo, repo, appointments, meetings), access)
+
+	// 6) setup Gin + routes
+	router := gin.Default()
+	router.Use(logging.RequestIDMiddleware())
+	router.Use(tracing.GinMiddleware(cfg.Tracing.ServiceName))
+	router.Use(metrics.GinMiddleware())
+	router.Use(logging.GinLogger(logging.Logger))
+	router.Use(locale.Middleware())
+	h.RegisterRoutes(router)
+	specHandler.RegisterRoutes(router)
+	catalogHandler.RegisterRoutes(router)
+	templateHandler.RegisterRoutes(router)
+	exceptionHandler.RegisterRoutes(router)
+	clinicHandler.RegisterRoutes(router)
+	profileHandler.RegisterRoutes(router)
+	reviewHandler.RegisterRoutes(router)
+	meetingHandler.RegisterRoutes(router)
+	bulkHandler.RegisterRoutes(router)
+
+	doc := openapi.NewDocument("Doctor service", "1.0.0")
+	if err := h.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := specHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := catalogHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := templateHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := exceptionHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := clinicHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := profileHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := reviewHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := meetingHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	if err := bulkHandler.RegisterDocs(doc); err != nil {
+		logging.Logger.WithError(err).Fatal("openapi document build failed")
+	}
+	router.GET("/openapi.json", doc.Handler())
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// 7) gRPC server for the appointment service
+	grpcOpts := append(tracing.GRPCServerOptions(), metrics.GRPCServerOptions()...)
+	grpcOpts = append(grpcOpts, grpc.ChainUnaryInterceptor(logging.UnaryServerRequestIDInterceptor()))
+	grpcServer := grpc.NewServer(grpcOpts...)
+	doctorpb.RegisterDoctorServiceServer(grpcServer, handler.NewDoctorGRPCServer(slotSvc))
+
+	grpcAddr := config.GetEnvWithDefault("DOCTOR_GRPC_ADDR", ":50051")
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		logging.Logger.WithError(err).Fatal("grpc listen failed")
+	}
+	go func() {
+		logging.Logger.Infof("starting doctor gRPC server on %s", grpcAddr)
+		if err := grpcServer.Serve(lis); err != nil {
+			logging.Logger.WithError(err).Fatal("grpc server stopped unexpectedly")
+		}
+	}()
+	defer grpcServer.GracefulStop()
+
+	// 8) run HTTP server on configured address
 	addr := fmt.Sprintf("%s:%d", cfg.Backend.ListenAddress, cfg.Backend.ListenPort)
 	logging.Logger.Infof("starting doctor service on %s", addr)
 	if err := router.Run(addr); err != nil {
 		logging.Logger.WithError(err).Fatal("server stopped unexpectedly")
 	}
 }
+
+// newBlobStore opens the configured blob store for the doctor photos.
+func newBlobStore(cfg *doctorconfig.StorageConfig) (storage.BlobStore, error) {
+	switch cfg.BlobStore {
+	case doctorconfig.BlobStoreLocal:
+		return storage.NewLocalStore(cfg.LocalDir)
+	default:
+		return nil, fmt.Errorf("unknown blob store %q", cfg.BlobStore)
+	}
+}
+
+// newMeetingProvider creates the video meeting provider selected by the configuration.
+func newMeetingProvider(cfg *doctorconfig.MeetingConfig) (meeting.Provider, error) {
+	switch cfg.Provider {
+	case doctorconfig.MeetingProviderSigned:
+		return meeting.NewSignedProvider(cfg.BaseURL, []byte(cfg.Secret), cfg.LinkGrace)
+	default:
+		return nil, fmt.Errorf("unknown meeting provider %q", cfg.Provider)
+	}
+}
+
+// releaseExpiredHolds frees the slots of bookings that were never confirmed.
+func releaseExpiredHolds(svc service.ScheduleSlotService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := svc.ReleaseExpiredHolds(context.Background()); err != nil {
+			logging.Logger.WithError(err).Error("releasing expired slot holds failed")
+		}
+	}
+}
+
+// generateSlots keeps the slots of every doctor materialized for the rolling horizon.
+func generateSlots(svc service.ScheduleTemplateService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := svc.GenerateAll(context.Background()); err != nil {
+			logging.Logger.WithError(err).Error("slot generation failed")
+		}
+		<-ticker.C
+	}
+}
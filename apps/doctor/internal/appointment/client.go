@@ -0,0 +1,80 @@
+package appointment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Статусы записи в сервисе записей
+const (
+	// StatusCompleted — приём состоялся
+	StatusCompleted = "completed"
+	// StatusCanceled — запись отменена
+	StatusCanceled = "canceled"
+)
+
+// ErrNotFound возвращается, если записи нет
+var ErrNotFound = errors.New("appointment not found")
+
+// Appointment — запись на приём в том виде, в каком её отдаёт сервис записей
+type Appointment struct {
+	ID       uuid.UUID `json:"id"`
+	UserID   int64     `json:"user_id"`
+	DoctorID uuid.UUID `json:"doctor_id"`
+	Status   string    `json:"status"`
+}
+
+// Client читает записи на приём из сервиса записей
+type Client interface {
+	GetAppointment(ctx context.Context, id uuid.UUID) (*Appointment, error)
+}
+
+type httpClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPClient конструктор, baseURL — адрес сервиса записей, например "http://appointment:8080"
+func NewHTTPClient(baseURL string, timeout time.Duration) Client {
+	return &httpClient{baseURL: baseURL, client: &http.Client{Timeout: timeout}}
+}
+
+func (c *httpClient) GetAppointment(ctx context.Context, id uuid.UUID) (*Appointment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/"+url.PathEscape(id.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(logging.RequestIDHeader, logging.RequestIDFromContext(ctx))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("appointment service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("appointment service: unexpected status %d", resp.StatusCode)
+	}
+
+	var a Appointment
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return nil, fmt.Errorf("appointment service: %w", err)
+	}
+	return &a, nil
+}
@@ -0,0 +1,62 @@
+package appointment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	client Client
+	id     uuid.UUID
+	fail   bool
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (suite *ClientTestSuite) SetupTest() {
+	suite.id = uuid.New()
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case suite.fail:
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/"+suite.id.String():
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"` + suite.id.String() + `","user_id":7,"doctor_id":"` + suite.id.String() + `","status":"completed","date":"2025-07-01T10:00:00Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	suite.client = NewHTTPClient(suite.server.URL, time.Second)
+}
+
+func (suite *ClientTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *ClientTestSuite) TestGetAppointment() {
+	a, err := suite.client.GetAppointment(context.Background(), suite.id)
+	suite.Require().NoError(err)
+	suite.Equal(int64(7), a.UserID)
+	suite.Equal(suite.id, a.DoctorID)
+	suite.Equal(StatusCompleted, a.Status)
+
+	_, err = suite.client.GetAppointment(context.Background(), uuid.New())
+	suite.ErrorIs(err, ErrNotFound)
+}
+
+func (suite *ClientTestSuite) TestGetAppointment_ServiceError() {
+	suite.fail = true
+	_, err := suite.client.GetAppointment(context.Background(), suite.id)
+	suite.Error(err)
+	suite.NotErrorIs(err, ErrNotFound)
+}
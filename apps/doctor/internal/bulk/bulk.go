@@ -0,0 +1,140 @@
+// Package bulk читает и пишет файлы массового импорта и выгрузки: специализации,
+// врачей и шаблоны расписания в CSV или JSON. Записи ссылаются друг на друга по
+// внешним ID, формат выгрузки совпадает с форматом импорта.
+package bulk
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Kind — вид записей файла
+type Kind string
+
+const (
+	KindSpecializations   Kind = "specializations"
+	KindDoctors           Kind = "doctors"
+	KindScheduleTemplates Kind = "schedule-templates"
+)
+
+// ParseKind проверяет вид записей из пути или аргументов
+func ParseKind(s string) (Kind, error) {
+	switch kind := Kind(s); kind {
+	case KindSpecializations, KindDoctors, KindScheduleTemplates:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("unknown kind %q, want %s, %s or %s", s, KindSpecializations, KindDoctors, KindScheduleTemplates)
+	}
+}
+
+// Format — формат файла
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat проверяет формат файла
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case FormatCSV, FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, want %s or %s", s, FormatCSV, FormatJSON)
+	}
+}
+
+// FormatOf определяет формат по Content-Type, по умолчанию — JSON
+func FormatOf(contentType string) Format {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/csv" {
+		return FormatCSV
+	}
+	return FormatJSON
+}
+
+// ContentType — Content-Type файла в формате f
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// Error — ошибка в записи файла. Row — номер записи с единицы, строка заголовка CSV не считается.
+type Error struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Translation — название и описание специализации на одном языке
+type Translation struct {
+	Language    string `json:"language" binding:"required,bcp47_language_tag"`
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description,omitempty" binding:"max=4000"`
+}
+
+// Specialization — специализация. Родитель должен стоять в файле раньше детей или уже существовать.
+// В CSV переводы лежат в колонках name.<язык> и description.<язык>.
+type Specialization struct {
+	Row              int           `json:"-"` // заполняется при чтении
+	ExternalID       string        `json:"external_id" binding:"required,max=100"`
+	Name             string        `json:"name" binding:"required,max=255"`
+	Description      string        `json:"description,omitempty" binding:"max=4000"`
+	ParentExternalID string        `json:"parent_external_id,omitempty" binding:"max=100"`
+	Translations     []Translation `json:"translations,omitempty" binding:"max=20,dive"`
+}
+
+// Doctor — врач. Специализации и языки в CSV перечисляются через ";".
+type Doctor struct {
+	Row             int      `json:"-"` // заполняется при чтении
+	ExternalID      string   `json:"external_id" binding:"required,max=100"`
+	FirstName       string   `json:"first_name" binding:"required,max=255"`
+	LastName        string   `json:"last_name" binding:"required,max=255"`
+	Patronymic      string   `json:"patronymic,omitempty" binding:"max=255"`
+	DateOfBirth     string   `json:"date_of_birth" binding:"required,datetime=2006-01-02"`
+	Specializations []string `json:"specializations" binding:"required,min=1,max=10,dive,required"` // внешние ID специализаций
+	Status          string   `json:"status,omitempty" binding:"omitempty,oneof=ACTIVE ON_LEAVE INACTIVE"`
+	TimeZone        string   `json:"time_zone,omitempty" binding:"omitempty,timezone"`
+	Email           string   `json:"email,omitempty" binding:"omitempty,email"`
+	Phone           string   `json:"phone,omitempty" binding:"omitempty,e164"`
+	Bio             string   `json:"bio,omitempty" binding:"max=4000"`
+	ExperienceYears int      `json:"experience_years" binding:"min=0,max=80"`
+	Languages       []string `json:"languages,omitempty" binding:"max=20,dive,bcp47_language_tag"`
+}
+
+// Break — перерыв шаблона, в CSV записывается как "13:00-14:00"
+type Break struct {
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+// ScheduleTemplate — рабочие часы врача в один день недели. Филиал, кабинет и услуга
+// шаблона задаются через API и импортом не меняются.
+type ScheduleTemplate struct {
+	Row              int     `json:"-"` // заполняется при чтении
+	ExternalID       string  `json:"external_id" binding:"required,max=100"`
+	DoctorExternalID string  `json:"doctor_external_id" binding:"required,max=100"`
+	Weekday          int     `json:"weekday" binding:"min=0,max=6"` // 0 — воскресенье
+	StartTime        string  `json:"start_time" binding:"required"`
+	EndTime          string  `json:"end_time" binding:"required"`
+	SlotMinutes      int     `json:"slot_minutes" binding:"required,min=5,max=480"`
+	Breaks           []Break `json:"breaks,omitempty" binding:"dive"`
+	ValidFrom        string  `json:"valid_from" binding:"required,datetime=2006-01-02"`
+	ValidTo          string  `json:"valid_to,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	IsOnline         bool    `json:"is_online"`
+}
+
+// splitList разбирает список через ";", пустые элементы отбрасываются
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
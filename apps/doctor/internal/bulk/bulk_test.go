@@ -0,0 +1,108 @@
+package bulk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BulkTestSuite struct {
+	suite.Suite
+}
+
+func TestBulk(t *testing.T) {
+	suite.Run(t, new(BulkTestSuite))
+}
+
+func (suite *BulkTestSuite) TestSpecializations_RoundTrip() {
+	specs := []Specialization{
+		{Row: 1, ExternalID: "surgery", Name: "Хирург", Translations: []Translation{{Language: "en", Name: "Surgeon"}, {Language: "kk", Name: "Хирург", Description: "Ота жасайды"}}},
+		{Row: 2, ExternalID: "cardiac-surgery", Name: "Кардиохирург", Description: "Операции на сердце", ParentExternalID: "surgery"},
+	}
+	for _, format := range []Format{FormatCSV, FormatJSON} {
+		var buf bytes.Buffer
+		w := NewSpecializationWriter(&buf, format, []string{"en", "kk"})
+		for _, spec := range specs {
+			suite.Require().NoError(w.Write(spec))
+		}
+		suite.Require().NoError(w.Close())
+
+		got, rowErrors, err := ReadSpecializations(&buf, format)
+		suite.Require().NoError(err, format)
+		suite.Empty(rowErrors, format)
+		suite.Equal(specs, got, format)
+	}
+}
+
+func (suite *BulkTestSuite) TestScheduleTemplates_RoundTrip() {
+	templates := []ScheduleTemplate{
+		{Row: 1, ExternalID: "t1", DoctorExternalID: "d1", Weekday: 1, StartTime: "09:00", EndTime: "18:00", SlotMinutes: 30, ValidFrom: "2025-01-01",
+			Breaks: []Break{{StartTime: "13:00", EndTime: "14:00"}, {StartTime: "16:00", EndTime: "16:15"}}},
+		{Row: 2, ExternalID: "t2", DoctorExternalID: "d1", Weekday: 0, StartTime: "10:00", EndTime: "12:00", SlotMinutes: 20, ValidFrom: "2025-01-01", ValidTo: "2025-06-30", IsOnline: true},
+	}
+	for _, format := range []Format{FormatCSV, FormatJSON} {
+		var buf bytes.Buffer
+		w := NewScheduleTemplateWriter(&buf, format)
+		for _, t := range templates {
+			suite.Require().NoError(w.Write(t))
+		}
+		suite.Require().NoError(w.Close())
+
+		got, rowErrors, err := ReadScheduleTemplates(&buf, format)
+		suite.Require().NoError(err, format)
+		suite.Empty(rowErrors, format)
+		suite.Equal(templates, got, format)
+	}
+}
+
+func (suite *BulkTestSuite) TestReadDoctors_RowErrors() {
+	file := "external_id,first_name,last_name,date_of_birth,specializations,experience_years,languages\n" +
+		"d1,Иван,Петров,1980-05-01,cardio; therapy,12,ru;kk\n" +
+		"d2,Анна,Смирнова,1990-01-01,cardio,много,\n" +
+		"d3,Олег\n"
+	doctors, rowErrors, err := ReadDoctors(strings.NewReader(file), FormatCSV)
+	suite.Require().NoError(err)
+	suite.Equal([]Doctor{{
+		Row: 1, ExternalID: "d1", FirstName: "Иван", LastName: "Петров", DateOfBirth: "1980-05-01",
+		Specializations: []string{"cardio", "therapy"}, ExperienceYears: 12, Languages: []string{"ru", "kk"},
+	}}, doctors)
+	suite.Require().Len(rowErrors, 2)
+	suite.Equal(Error{Row: 2, ExternalID: "d2", Message: `experience_years: "много" is not a number`}, rowErrors[0])
+	suite.Equal(3, rowErrors[1].Row)
+
+	doctors, rowErrors, err = ReadDoctors(strings.NewReader(`[{"external_id":"d1","first_name":"Иван"},{"external_id":"d2","experience_years":"12"}]`), FormatJSON)
+	suite.Require().NoError(err)
+	suite.Equal([]Doctor{{Row: 1, ExternalID: "d1", FirstName: "Иван"}}, doctors)
+	suite.Require().Len(rowErrors, 1)
+	suite.Equal(2, rowErrors[0].Row)
+	suite.Equal("d2", rowErrors[0].ExternalID)
+}
+
+func (suite *BulkTestSuite) TestRead_Malformed() {
+	cases := map[string]struct {
+		file   string
+		format Format
+	}{
+		"not an array":   {`{"external_id":"d1"}`, FormatJSON},
+		"truncated json": {`[{"external_id":"d1"}`, FormatJSON},
+		"empty csv":      {"", FormatCSV},
+		"unknown column": {"external_id,salary\nd1,100\n", FormatCSV},
+		"no key column":  {"first_name\nИван\n", FormatCSV},
+	}
+	for name, tc := range cases {
+		_, _, err := ReadDoctors(strings.NewReader(tc.file), tc.format)
+		suite.Error(err, name)
+	}
+}
+
+func (suite *BulkTestSuite) TestWriter_Empty() {
+	var buf bytes.Buffer
+	suite.Require().NoError(NewDoctorWriter(&buf, FormatJSON).Close())
+	suite.Equal("[]\n", buf.String())
+
+	buf.Reset()
+	suite.Require().NoError(NewScheduleTemplateWriter(&buf, FormatCSV).Close())
+	suite.Equal("external_id,doctor_external_id,weekday,start_time,end_time,slot_minutes,breaks,valid_from,valid_to,is_online\n", buf.String())
+}
@@ -0,0 +1,170 @@
+package bulk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// csvLayout — раскладка записей вида T по колонкам CSV
+type csvLayout[T any] struct {
+	columns []string                 // колонки в порядке выгрузки
+	dynamic func(column string) bool // допустимые при чтении колонки сверх columns
+	decode  func(row csvRow) (T, error)
+	encode  func(rec T) []string
+}
+
+const (
+	nameColumn        = "name."
+	descriptionColumn = "description."
+)
+
+// specializationLayout раскладывает специализации; переводы выгружаются в колонки для languages
+func specializationLayout(languages []string) csvLayout[Specialization] {
+	columns := []string{"external_id", "name", "description", "parent_external_id"}
+	for _, lang := range languages {
+		columns = append(columns, nameColumn+lang, descriptionColumn+lang)
+	}
+	return csvLayout[Specialization]{
+		columns: columns,
+		dynamic: func(column string) bool {
+			lang, ok := strings.CutPrefix(column, nameColumn)
+			if !ok {
+				lang, ok = strings.CutPrefix(column, descriptionColumn)
+			}
+			return ok && lang != ""
+		},
+		decode: func(row csvRow) (Specialization, error) {
+			spec := Specialization{
+				ExternalID:       row.get("external_id"),
+				Name:             row.get("name"),
+				Description:      row.get("description"),
+				ParentExternalID: row.get("parent_external_id"),
+			}
+			// Язык попадает в переводы по первой своей колонке, пустые переводы пропускаются
+			seen := make(map[string]bool)
+			for _, column := range row.header {
+				lang, ok := strings.CutPrefix(column, nameColumn)
+				if !ok {
+					lang, ok = strings.CutPrefix(column, descriptionColumn)
+				}
+				if !ok || seen[lang] {
+					continue
+				}
+				seen[lang] = true
+				t := Translation{Language: lang, Name: row.get(nameColumn + lang), Description: row.get(descriptionColumn + lang)}
+				if t.Name != "" || t.Description != "" {
+					spec.Translations = append(spec.Translations, t)
+				}
+			}
+			return spec, nil
+		},
+		encode: func(spec Specialization) []string {
+			fields := []string{spec.ExternalID, spec.Name, spec.Description, spec.ParentExternalID}
+			for _, lang := range languages {
+				var name, description string
+				for _, t := range spec.Translations {
+					if t.Language == lang {
+						name, description = t.Name, t.Description
+					}
+				}
+				fields = append(fields, name, description)
+			}
+			return fields
+		},
+	}
+}
+
+var doctorLayout = csvLayout[Doctor]{
+	columns: []string{
+		"external_id", "first_name", "last_name", "patronymic", "date_of_birth", "specializations",
+		"status", "time_zone", "email", "phone", "bio", "experience_years", "languages",
+	},
+	decode: func(row csvRow) (Doctor, error) {
+		experience, err := parseInt(row, "experience_years")
+		if err != nil {
+			return Doctor{}, err
+		}
+		return Doctor{
+			ExternalID:      row.get("external_id"),
+			FirstName:       row.get("first_name"),
+			LastName:        row.get("last_name"),
+			Patronymic:      row.get("patronymic"),
+			DateOfBirth:     row.get("date_of_birth"),
+			Specializations: splitList(row.get("specializations")),
+			Status:          row.get("status"),
+			TimeZone:        row.get("time_zone"),
+			Email:           row.get("email"),
+			Phone:           row.get("phone"),
+			Bio:             row.get("bio"),
+			ExperienceYears: experience,
+			Languages:       splitList(row.get("languages")),
+		}, nil
+	},
+	encode: func(d Doctor) []string {
+		return []string{
+			d.ExternalID, d.FirstName, d.LastName, d.Patronymic, d.DateOfBirth, strings.Join(d.Specializations, ";"),
+			d.Status, d.TimeZone, d.Email, d.Phone, d.Bio, strconv.Itoa(d.ExperienceYears), strings.Join(d.Languages, ";"),
+		}
+	},
+}
+
+var scheduleTemplateLayout = csvLayout[ScheduleTemplate]{
+	columns: []string{
+		"external_id", "doctor_external_id", "weekday", "start_time", "end_time", "slot_minutes",
+		"breaks", "valid_from", "valid_to", "is_online",
+	},
+	decode: func(row csvRow) (ScheduleTemplate, error) {
+		t := ScheduleTemplate{
+			ExternalID:       row.get("external_id"),
+			DoctorExternalID: row.get("doctor_external_id"),
+			StartTime:        row.get("start_time"),
+			EndTime:          row.get("end_time"),
+			ValidFrom:        row.get("valid_from"),
+			ValidTo:          row.get("valid_to"),
+		}
+		var err error
+		if t.Weekday, err = parseInt(row, "weekday"); err != nil {
+			return t, err
+		}
+		if t.SlotMinutes, err = parseInt(row, "slot_minutes"); err != nil {
+			return t, err
+		}
+		if online := row.get("is_online"); online != "" {
+			if t.IsOnline, err = strconv.ParseBool(online); err != nil {
+				return t, fmt.Errorf("is_online: %q is not a boolean", online)
+			}
+		}
+		for _, item := range splitList(row.get("breaks")) {
+			start, end, ok := strings.Cut(item, "-")
+			if !ok {
+				return t, fmt.Errorf("breaks: %q is not a HH:MM-HH:MM range", item)
+			}
+			t.Breaks = append(t.Breaks, Break{StartTime: strings.TrimSpace(start), EndTime: strings.TrimSpace(end)})
+		}
+		return t, nil
+	},
+	encode: func(t ScheduleTemplate) []string {
+		breaks := make([]string, 0, len(t.Breaks))
+		for _, b := range t.Breaks {
+			breaks = append(breaks, b.StartTime+"-"+b.EndTime)
+		}
+		return []string{
+			t.ExternalID, t.DoctorExternalID, strconv.Itoa(t.Weekday), t.StartTime, t.EndTime, strconv.Itoa(t.SlotMinutes),
+			strings.Join(breaks, ";"), t.ValidFrom, t.ValidTo, strconv.FormatBool(t.IsOnline),
+		}
+	},
+}
+
+// parseInt разбирает целое из колонки, пустая колонка — ноль
+func parseInt(row csvRow, column string) (int, error) {
+	value := row.get(column)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a number", column, value)
+	}
+	return n, nil
+}
@@ -0,0 +1,132 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ReadSpecializations читает специализации. Записи, которые не удалось разобрать,
+// попадают в список ошибок; ошибка возвращается, если файл не читается целиком.
+func ReadSpecializations(r io.Reader, f Format) ([]Specialization, []Error, error) {
+	return read(r, f, specializationLayout(nil), func(s *Specialization, row int) { s.Row = row })
+}
+
+// ReadDoctors читает врачей, ошибки — как у ReadSpecializations
+func ReadDoctors(r io.Reader, f Format) ([]Doctor, []Error, error) {
+	return read(r, f, doctorLayout, func(d *Doctor, row int) { d.Row = row })
+}
+
+// ReadScheduleTemplates читает шаблоны расписания, ошибки — как у ReadSpecializations
+func ReadScheduleTemplates(r io.Reader, f Format) ([]ScheduleTemplate, []Error, error) {
+	return read(r, f, scheduleTemplateLayout, func(t *ScheduleTemplate, row int) { t.Row = row })
+}
+
+func read[T any](r io.Reader, f Format, layout csvLayout[T], setRow func(*T, int)) ([]T, []Error, error) {
+	if f == FormatCSV {
+		return readCSV(r, layout, setRow)
+	}
+	return readJSON(r, setRow)
+}
+
+// readJSON читает массив записей. Каждый элемент разбирается отдельно,
+// чтобы ошибка типа в одной записи не останавливала чтение остальных.
+func readJSON[T any](r io.Reader, setRow func(*T, int)) ([]T, []Error, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return nil, nil, fmt.Errorf("read json: %w", err)
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, nil, errors.New("read json: want an array of records")
+	}
+
+	var records []T
+	var rowErrors []Error
+	for row := 1; dec.More(); row++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, nil, fmt.Errorf("read json: record %d: %w", row, err)
+		}
+		var rec T
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			var key struct {
+				ExternalID string `json:"external_id"`
+			}
+			_ = json.Unmarshal(raw, &key)
+			rowErrors = append(rowErrors, Error{Row: row, ExternalID: key.ExternalID, Message: err.Error()})
+			continue
+		}
+		setRow(&rec, row)
+		records = append(records, rec)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, fmt.Errorf("read json: %w", err)
+	}
+	return records, rowErrors, nil
+}
+
+// readCSV читает таблицу с заголовком. Колонки ищутся по имени, их порядок не важен.
+func readCSV[T any](r io.Reader, layout csvLayout[T], setRow func(*T, int)) ([]T, []Error, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("read csv: missing header")
+		}
+		return nil, nil, fmt.Errorf("read csv: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+		if !slices.Contains(layout.columns, header[i]) && (layout.dynamic == nil || !layout.dynamic(header[i])) {
+			return nil, nil, fmt.Errorf("read csv: unknown column %q", header[i])
+		}
+	}
+	if !slices.Contains(header, "external_id") {
+		return nil, nil, errors.New(`read csv: missing column "external_id"`)
+	}
+
+	var records []T
+	var rowErrors []Error
+	for row := 1; ; row++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line := csvRow{header: header, fields: fields}
+		if err != nil {
+			if !errors.Is(err, csv.ErrFieldCount) {
+				return nil, nil, fmt.Errorf("read csv: %w", err)
+			}
+			rowErrors = append(rowErrors, Error{Row: row, ExternalID: line.get("external_id"), Message: "wrong number of fields"})
+			continue
+		}
+		rec, err := layout.decode(line)
+		if err != nil {
+			rowErrors = append(rowErrors, Error{Row: row, ExternalID: line.get("external_id"), Message: err.Error()})
+			continue
+		}
+		setRow(&rec, row)
+		records = append(records, rec)
+	}
+	return records, rowErrors, nil
+}
+
+// csvRow — строка CSV вместе с заголовком таблицы
+type csvRow struct {
+	header []string
+	fields []string
+}
+
+// get возвращает значение колонки или пустую строку, если колонки нет
+func (r csvRow) get(column string) string {
+	for i, name := range r.header {
+		if name == column && i < len(r.fields) {
+			return strings.TrimSpace(r.fields[i])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// Writer пишет записи в файл выгрузки по одной, не собирая их в памяти.
+// JSON выгружается массивом, CSV — таблицей с заголовком. После последней записи нужен Close.
+type Writer[T any] struct {
+	w      io.Writer
+	format Format
+	csv    *csv.Writer
+	layout csvLayout[T]
+	count  int
+}
+
+// NewSpecializationWriter пишет специализации; в CSV переводы выгружаются в колонки для languages
+func NewSpecializationWriter(w io.Writer, f Format, languages []string) *Writer[Specialization] {
+	return newWriter(w, f, specializationLayout(languages))
+}
+
+// NewDoctorWriter пишет врачей
+func NewDoctorWriter(w io.Writer, f Format) *Writer[Doctor] {
+	return newWriter(w, f, doctorLayout)
+}
+
+// NewScheduleTemplateWriter пишет шаблоны расписания
+func NewScheduleTemplateWriter(w io.Writer, f Format) *Writer[ScheduleTemplate] {
+	return newWriter(w, f, scheduleTemplateLayout)
+}
+
+func newWriter[T any](w io.Writer, f Format, layout csvLayout[T]) *Writer[T] {
+	writer := &Writer[T]{w: w, format: f, layout: layout}
+	if f == FormatCSV {
+		writer.csv = csv.NewWriter(w)
+	}
+	return writer
+}
+
+// Write пишет одну запись
+func (w *Writer[T]) Write(rec T) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	w.count++
+	if w.format == FormatCSV {
+		return w.csv.Write(w.layout.encode(rec))
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if w.count == 1 {
+		separator = "\n"
+	}
+	if _, err := io.WriteString(w.w, separator); err != nil {
+		return err
+	}
+	_, err = w.w.Write(data)
+	return err
+}
+
+// Close завершает файл; пустая выгрузка — пустой массив или один заголовок
+func (w *Writer[T]) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.format == FormatCSV {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	closing := "]\n"
+	if w.count > 0 {
+		closing = "\n]\n"
+	}
+	_, err := io.WriteString(w.w, closing)
+	return err
+}
+
+// start пишет заголовок CSV или открывающую скобку массива перед первой записью
+func (w *Writer[T]) start() error {
+	if w.count > 0 {
+		return nil
+	}
+	if w.format == FormatCSV {
+		return w.csv.Write(w.layout.columns)
+	}
+	_, err := io.WriteString(w.w, "[")
+	return err
+}
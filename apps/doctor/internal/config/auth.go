@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+type AuthConfig struct {
+	JWTSecret string // Key the auth service signs access tokens with
+}
+
+// LoadAuthConfig reads the access token verification settings from the environment.
+func LoadAuthConfig() (*AuthConfig, error) {
+	authConfig := AuthConfig{
+		JWTSecret: pkgconfig.GetEnvWithDefault("JWT_SECRET", ""),
+	}
+
+	if err := authConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid auth configuration: %w", err)
+	}
+
+	return &authConfig, nil
+}
+
+func (c AuthConfig) Validate() error {
+	if len(c.JWTSecret) < 32 {
+		return errors.New("jwt secret must be at least 32 characters long")
+	}
+	return nil
+}
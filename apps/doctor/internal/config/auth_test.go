@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestAuthConfig(t *testing.T) {
+	suite.Run(t, new(AuthConfigTestSuite))
+}
+
+func (suite *AuthConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *AuthConfigTestSuite) TestLoadAuthConfig_SecretRequired() {
+	_, err := LoadAuthConfig()
+	suite.ErrorContains(err, "jwt secret must be at least 32 characters long")
+
+	_ = os.Setenv("JWT_SECRET", "short")
+	_, err = LoadAuthConfig()
+	suite.ErrorContains(err, "jwt secret must be at least 32 characters long")
+}
+
+func (suite *AuthConfigTestSuite) TestLoadAuthConfig_Env() {
+	secret := strings.Repeat("s", 32)
+	_ = os.Setenv("JWT_SECRET", secret)
+	cfg, err := LoadAuthConfig()
+	suite.NoError(err)
+	suite.Equal(secret, cfg.JWTSecret)
+}
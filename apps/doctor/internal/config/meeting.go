@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+// MeetingProviderSigned issues signed, expiring room links of the self-hosted video service.
+const MeetingProviderSigned = "signed"
+
+type MeetingConfig struct {
+	Provider  string        // Video meeting provider of the online slots
+	BaseURL   string        // Rooms of the self-hosted video service are under this URL
+	Secret    string        // Key signing the room links, shared with the video service
+	LinkGrace time.Duration // How long a room link stays valid after the end of the appointment
+}
+
+// LoadMeetingConfig reads the video meeting provider configuration from the environment.
+func LoadMeetingConfig() (*MeetingConfig, error) {
+	provider := pkgconfig.GetEnvWithDefault("MEETING_PROVIDER", MeetingProviderSigned)
+	baseURL := pkgconfig.GetEnvWithDefault("MEETING_BASE_URL", "https://localhost:8443/rooms")
+	secret := pkgconfig.GetEnvWithDefault("MEETING_SECRET", "")
+	grace := pkgconfig.GetEnvWithDefault("MEETING_LINK_GRACE", "30m")
+
+	linkGrace, err := time.ParseDuration(grace)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MEETING_LINK_GRACE value: %w", err)
+	}
+
+	meetingConfig := MeetingConfig{
+		Provider:  provider,
+		BaseURL:   baseURL,
+		Secret:    secret,
+		LinkGrace: linkGrace,
+	}
+
+	if err := meetingConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid meeting configuration: %w", err)
+	}
+
+	return &meetingConfig, nil
+}
+
+func (c MeetingConfig) Validate() error {
+	var errs []error
+
+	switch c.Provider {
+	case MeetingProviderSigned:
+		if u, err := url.Parse(c.BaseURL); err != nil || !u.IsAbs() || u.Host == "" {
+			errs = append(errs, fmt.Errorf("meeting base URL must be an absolute URL, got %q", c.BaseURL))
+		}
+		if len(c.Secret) < 32 {
+			errs = append(errs, errors.New("meeting secret must be at least 32 characters long"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown meeting provider %q", c.Provider))
+	}
+	if c.LinkGrace < 0 || c.LinkGrace > 24*time.Hour {
+		errs = append(errs, fmt.Errorf("meeting link grace must be between 0 and 24h, got %s", c.LinkGrace))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MeetingConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestMeetingConfig(t *testing.T) {
+	suite.Run(t, new(MeetingConfigTestSuite))
+}
+
+func (suite *MeetingConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *MeetingConfigTestSuite) TestLoadMeetingConfig_SecretRequired() {
+	_, err := LoadMeetingConfig()
+	suite.ErrorContains(err, "meeting secret must be at least 32 characters long")
+}
+
+func (suite *MeetingConfigTestSuite) TestLoadMeetingConfig_Env() {
+	_ = os.Setenv("MEETING_SECRET", strings.Repeat("s", 32))
+	_ = os.Setenv("MEETING_BASE_URL", "https://meet.clinic.kz/rooms")
+	_ = os.Setenv("MEETING_LINK_GRACE", "1h")
+	cfg, err := LoadMeetingConfig()
+	suite.NoError(err)
+	suite.Equal(MeetingProviderSigned, cfg.Provider)
+	suite.Equal("https://meet.clinic.kz/rooms", cfg.BaseURL)
+	suite.Equal(time.Hour, cfg.LinkGrace)
+
+	_ = os.Setenv("MEETING_LINK_GRACE", "soon")
+	_, err = LoadMeetingConfig()
+	suite.ErrorContains(err, "invalid MEETING_LINK_GRACE value")
+}
+
+func (suite *MeetingConfigTestSuite) TestValidate() {
+	err := MeetingConfig{Provider: "zoom", LinkGrace: -time.Minute}.Validate()
+	suite.ErrorContains(err, `unknown meeting provider "zoom"`)
+	suite.ErrorContains(err, "meeting link grace must be between 0 and 24h")
+
+	err = MeetingConfig{Provider: MeetingProviderSigned, BaseURL: "/rooms", Secret: strings.Repeat("s", 32)}.Validate()
+	suite.ErrorContains(err, "meeting base URL must be an absolute URL")
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+type ScheduleConfig struct {
+	HorizonWeeks      int            // How many weeks ahead slots are generated from the schedule templates
+	GenerateInterval  time.Duration  // How often the slots of every doctor are regenerated
+	HoldTTL           time.Duration  // How long a slot stays held while the patient completes the booking
+	HoldSweepInterval time.Duration  // How often expired holds are released
+	ClinicTimeZone    *time.Location // Working hours of doctors without their own time zone are in this zone
+}
+
+// LoadScheduleConfig reads the slot generator, slot hold and clinic time zone configuration from the environment.
+func LoadScheduleConfig() (*ScheduleConfig, error) {
+	horizon := pkgconfig.GetEnvWithDefault("SCHEDULE_HORIZON_WEEKS", "8")
+	interval := pkgconfig.GetEnvWithDefault("SCHEDULE_GENERATE_INTERVAL", "24h")
+	holdTTL := pkgconfig.GetEnvWithDefault("SLOT_HOLD_TTL", "10m")
+	sweep := pkgconfig.GetEnvWithDefault("SLOT_HOLD_SWEEP_INTERVAL", "1m")
+	timeZone := pkgconfig.GetEnvWithDefault("CLINIC_TIME_ZONE", "UTC")
+
+	horizonWeeks, err := strconv.Atoi(horizon)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULE_HORIZON_WEEKS value: %w", err)
+	}
+	generateInterval, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULE_GENERATE_INTERVAL value: %w", err)
+	}
+	holdDuration, err := time.ParseDuration(holdTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLOT_HOLD_TTL value: %w", err)
+	}
+	sweepInterval, err := time.ParseDuration(sweep)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLOT_HOLD_SWEEP_INTERVAL value: %w", err)
+	}
+	clinicTimeZone, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CLINIC_TIME_ZONE value: %w", err)
+	}
+
+	scheduleConfig := ScheduleConfig{
+		HorizonWeeks:      horizonWeeks,
+		GenerateInterval:  generateInterval,
+		HoldTTL:           holdDuration,
+		HoldSweepInterval: sweepInterval,
+		ClinicTimeZone:    clinicTimeZone,
+	}
+
+	if err := scheduleConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid schedule configuration: %w", err)
+	}
+
+	return &scheduleConfig, nil
+}
+
+func (c ScheduleConfig) Validate() error {
+	var errs []error
+
+	if c.HorizonWeeks < 1 || c.HorizonWeeks > 52 {
+		errs = append(errs, fmt.Errorf("horizon must be between 1 and 52 weeks, got %d", c.HorizonWeeks))
+	}
+	if c.GenerateInterval < time.Minute {
+		errs = append(errs, fmt.Errorf("generate interval must be at least a minute, got %s", c.GenerateInterval))
+	}
+	if c.HoldTTL < time.Minute || c.HoldTTL > time.Hour {
+		errs = append(errs, fmt.Errorf("hold ttl must be between a minute and an hour, got %s", c.HoldTTL))
+	}
+	if c.HoldSweepInterval < time.Second {
+		errs = append(errs, fmt.Errorf("hold sweep interval must be at least a second, got %s", c.HoldSweepInterval))
+	}
+
+	return errors.Join(errs...)
+}
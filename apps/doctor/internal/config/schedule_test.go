@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ScheduleConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestScheduleConfig(t *testing.T) {
+	suite.Run(t, new(ScheduleConfigTestSuite))
+}
+
+func (suite *ScheduleConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *ScheduleConfigTestSuite) TestLoadScheduleConfig_NoEnv() {
+	cfg, err := LoadScheduleConfig()
+	suite.NoError(err)
+	suite.Equal(8, cfg.HorizonWeeks)
+	suite.Equal(24*time.Hour, cfg.GenerateInterval)
+	suite.Equal(10*time.Minute, cfg.HoldTTL)
+	suite.Equal(time.Minute, cfg.HoldSweepInterval)
+	suite.Equal(time.UTC, cfg.ClinicTimeZone)
+}
+
+func (suite *ScheduleConfigTestSuite) TestLoadScheduleConfig_Env() {
+	_ = os.Setenv("SCHEDULE_HORIZON_WEEKS", "4")
+	_ = os.Setenv("SCHEDULE_GENERATE_INTERVAL", "1h")
+	_ = os.Setenv("SLOT_HOLD_TTL", "15m")
+	_ = os.Setenv("SLOT_HOLD_SWEEP_INTERVAL", "30s")
+	_ = os.Setenv("CLINIC_TIME_ZONE", "Asia/Aqtobe")
+	cfg, err := LoadScheduleConfig()
+	suite.NoError(err)
+	suite.Equal(4, cfg.HorizonWeeks)
+	suite.Equal(time.Hour, cfg.GenerateInterval)
+	suite.Equal(15*time.Minute, cfg.HoldTTL)
+	suite.Equal(30*time.Second, cfg.HoldSweepInterval)
+	suite.Equal("Asia/Aqtobe", cfg.ClinicTimeZone.String())
+}
+
+func (suite *ScheduleConfigTestSuite) TestLoadScheduleConfig_InvalidValues() {
+	_ = os.Setenv("SCHEDULE_HORIZON_WEEKS", "eight")
+	_, err := LoadScheduleConfig()
+	suite.ErrorContains(err, "invalid SCHEDULE_HORIZON_WEEKS value")
+
+	_ = os.Setenv("SCHEDULE_HORIZON_WEEKS", "8")
+	_ = os.Setenv("SCHEDULE_GENERATE_INTERVAL", "daily")
+	_, err = LoadScheduleConfig()
+	suite.ErrorContains(err, "invalid SCHEDULE_GENERATE_INTERVAL value")
+
+	_ = os.Setenv("SCHEDULE_GENERATE_INTERVAL", "24h")
+	_ = os.Setenv("SLOT_HOLD_TTL", "ten minutes")
+	_, err = LoadScheduleConfig()
+	suite.ErrorContains(err, "invalid SLOT_HOLD_TTL value")
+
+	_ = os.Setenv("SLOT_HOLD_TTL", "10m")
+	_ = os.Setenv("CLINIC_TIME_ZONE", "Mars/Olympus_Mons")
+	_, err = LoadScheduleConfig()
+	suite.ErrorContains(err, "invalid CLINIC_TIME_ZONE value")
+}
+
+func (suite *ScheduleConfigTestSuite) TestValidate() {
+	err := ScheduleConfig{HorizonWeeks: 0, GenerateInterval: time.Second, HoldTTL: 2 * time.Hour}.Validate()
+	suite.ErrorContains(err, "horizon must be between 1 and 52 weeks")
+	suite.ErrorContains(err, "generate interval must be at least a minute")
+	suite.ErrorContains(err, "hold ttl must be between a minute and an hour")
+	suite.ErrorContains(err, "hold sweep interval must be at least a second")
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	pkgconfig "github.com/Ruletk/OnlineClinic/pkg/config"
+)
+
+// BlobStoreLocal keeps the uploaded files in a directory of the local filesystem.
+const BlobStoreLocal = "local"
+
+type StorageConfig struct {
+	BlobStore     string // Backend of the blob store holding the doctor photos
+	LocalDir      string // Directory of the local blob store
+	MaxPhotoBytes int64  // Largest accepted doctor photo
+}
+
+// LoadStorageConfig reads the blob store configuration from the environment.
+func LoadStorageConfig() (*StorageConfig, error) {
+	backend := pkgconfig.GetEnvWithDefault("BLOB_STORE", BlobStoreLocal)
+	dir := pkgconfig.GetEnvWithDefault("BLOB_STORE_DIR", "data/blobs")
+	maxPhoto := pkgconfig.GetEnvWithDefault("DOCTOR_PHOTO_MAX_BYTES", "5242880")
+
+	maxPhotoBytes, err := strconv.ParseInt(maxPhoto, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DOCTOR_PHOTO_MAX_BYTES value: %w", err)
+	}
+
+	storageConfig := StorageConfig{
+		BlobStore:     backend,
+		LocalDir:      dir,
+		MaxPhotoBytes: maxPhotoBytes,
+	}
+
+	if err := storageConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage configuration: %w", err)
+	}
+
+	return &storageConfig, nil
+}
+
+func (c StorageConfig) Validate() error {
+	var errs []error
+
+	switch c.BlobStore {
+	case BlobStoreLocal:
+		if c.LocalDir == "" {
+			errs = append(errs, errors.New("local blob store directory is required"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown blob store %q", c.BlobStore))
+	}
+	if c.MaxPhotoBytes < 1024 || c.MaxPhotoBytes > 50<<20 {
+		errs = append(errs, fmt.Errorf("max photo size must be between 1 KiB and 50 MiB, got %d bytes", c.MaxPhotoBytes))
+	}
+
+	return errors.Join(errs...)
+}
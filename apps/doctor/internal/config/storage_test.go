@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestStorageConfig(t *testing.T) {
+	suite.Run(t, new(StorageConfigTestSuite))
+}
+
+func (suite *StorageConfigTestSuite) SetupTest() {
+	os.Clearenv()
+}
+
+func (suite *StorageConfigTestSuite) TestLoadStorageConfig_NoEnv() {
+	cfg, err := LoadStorageConfig()
+	suite.NoError(err)
+	suite.Equal(BlobStoreLocal, cfg.BlobStore)
+	suite.Equal("data/blobs", cfg.LocalDir)
+	suite.Equal(int64(5<<20), cfg.MaxPhotoBytes)
+}
+
+func (suite *StorageConfigTestSuite) TestLoadStorageConfig_Env() {
+	_ = os.Setenv("BLOB_STORE_DIR", "/var/lib/doctor/blobs")
+	_ = os.Setenv("DOCTOR_PHOTO_MAX_BYTES", "1048576")
+	cfg, err := LoadStorageConfig()
+	suite.NoError(err)
+	suite.Equal("/var/lib/doctor/blobs", cfg.LocalDir)
+	suite.Equal(int64(1<<20), cfg.MaxPhotoBytes)
+
+	_ = os.Setenv("DOCTOR_PHOTO_MAX_BYTES", "big")
+	_, err = LoadStorageConfig()
+	suite.ErrorContains(err, "invalid DOCTOR_PHOTO_MAX_BYTES value")
+}
+
+func (suite *StorageConfigTestSuite) TestValidate() {
+	err := StorageConfig{BlobStore: "s3", MaxPhotoBytes: 10}.Validate()
+	suite.ErrorContains(err, `unknown blob store "s3"`)
+	suite.ErrorContains(err, "max photo size must be between 1 KiB and 50 MiB")
+
+	err = StorageConfig{BlobStore: BlobStoreLocal, MaxPhotoBytes: 1 << 20}.Validate()
+	suite.ErrorContains(err, "local blob store directory is required")
+}
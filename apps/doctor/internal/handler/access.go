@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Роли пользователей auth, от которых зависят права в сервисе
+const (
+	RoleAdmin  = "admin"
+	RoleDoctor = "doctor"
+)
+
+// Identity — пользователь запроса из access token, который шлюз передаёт в X-Access-Token
+type Identity struct {
+	UserID int64
+	Roles  []string
+}
+
+// HasRole — есть ли у пользователя роль
+func (i Identity) HasRole(role string) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+// accessClaims — поля access token, которые auth кладёт в payload
+type accessClaims struct {
+	jwt.RegisteredClaims
+	UserID json.Number `json:"userId"`
+	Roles  []string    `json:"roles"`
+}
+
+// parseIdentity читает пользователя из access token. Claims принимаются только
+// после проверки подписи ключом auth и срока действия токена.
+func parseIdentity(token string, key []byte) (*Identity, bool) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, false
+	}
+	userID, err := claims.UserID.Int64()
+	if err != nil || userID <= 0 {
+		return nil, false
+	}
+	return &Identity{UserID: userID, Roles: claims.Roles}, true
+}
+
+// Access проверяет права на данные врачей: администратор меняет всё,
+// врач — только свой профиль, расписание и исключения. Чтение открыто всем.
+type Access struct {
+	doctors service.DoctorService
+	key     []byte // ключ, которым auth подписывает access token
+}
+
+func NewAccess(doctors service.DoctorService, jwtSecret string) *Access {
+	return &Access{doctors: doctors, key: []byte(jwtSecret)}
+}
+
+// identity отвечает 401 и возвращает false, если запрос без действительного access token
+func (a *Access) identity(c *gin.Context) (*Identity, bool) {
+	id, ok := parseIdentity(c.GetHeader(openapi.AccessTokenHeader), a.key)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return nil, false
+	}
+	return id, true
+}
+
+// RequireAdmin пропускает только администраторов
+func (a *Access) RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := a.identity(c)
+		if !ok {
+			return
+		}
+		if !id.HasRole(RoleAdmin) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireDoctor пропускает администраторов и врача, чей ID в параметре пути param
+func (a *Access) RequireDoctor(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doctorID, err := uuid.Parse(c.Param(param))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+			return
+		}
+		if !a.CanEditDoctor(c, &doctorID) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// CanEditDoctor — может ли пользователь менять данные врача. nil — данные всей клиники,
+// например праздник, их меняет только администратор. При отказе ответ уже записан.
+func (a *Access) CanEditDoctor(c *gin.Context, doctorID *uuid.UUID) bool {
+	id, ok := a.identity(c)
+	if !ok {
+		return false
+	}
+	if id.HasRole(RoleAdmin) {
+		return true
+	}
+	if doctorID != nil && id.HasRole(RoleDoctor) {
+		own, err := a.doctors.GetDoctorByUserID(c.Request.Context(), id.UserID)
+		switch {
+		case err == nil && own.ID == *doctorID:
+			return true
+		case err != nil && !errors.Is(err, service.ErrNotFound):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return false
+		}
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not allowed to change this doctor"})
+	return false
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeDoctorService знает только привязку врачей к пользователям auth
+type fakeDoctorService struct {
+	service.DoctorService
+	byUser map[int64]uuid.UUID
+}
+
+func (s *fakeDoctorService) GetDoctorByUserID(_ context.Context, userID int64) (*service.DoctorDTO, error) {
+	id, ok := s.byUser[userID]
+	if !ok {
+		return nil, service.ErrNotFound
+	}
+	return &service.DoctorDTO{ID: id}, nil
+}
+
+// fakeClinicService привязывает врачей к филиалам без базы
+type fakeClinicService struct {
+	service.ClinicService
+}
+
+func (fakeClinicService) ListClinics(context.Context) ([]service.ClinicDTO, error) {
+	return []service.ClinicDTO{}, nil
+}
+
+func (fakeClinicService) AssignDoctor(context.Context, uuid.UUID, uuid.UUID) error { return nil }
+
+const testJWTSecret = "test-secret-of-the-auth-service-0001"
+
+// signedToken подписывает claims ключом key, как это делает auth
+func signedToken(key string, claims jwt.MapClaims) string {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+	return token
+}
+
+// accessToken собирает действительный access token с claims, как у auth
+func accessToken(userID int64, roles ...string) string {
+	return signedToken(testJWTSecret, jwt.MapClaims{
+		"userId": userID,
+		"roles":  roles,
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+type AccessTestSuite struct {
+	suite.Suite
+	router   *gin.Engine
+	doctorID uuid.UUID
+}
+
+func TestAccess(t *testing.T) {
+	suite.Run(t, new(AccessTestSuite))
+}
+
+func (suite *AccessTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.doctorID = uuid.New()
+	access := NewAccess(&fakeDoctorService{byUser: map[int64]uuid.UUID{7: suite.doctorID, 8: uuid.New()}}, testJWTSecret)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	suite.router = gin.New()
+	suite.router.PUT("/doctors/:id", access.RequireDoctor("id"), ok)
+	suite.router.POST("/doctors", access.RequireAdmin(), ok)
+	suite.router.POST("/holidays", func(c *gin.Context) {
+		if access.CanEditDoctor(c, nil) {
+			ok(c)
+		}
+	})
+	NewClinicHandler(fakeClinicService{}, access).RegisterRoutes(suite.router)
+}
+
+func (suite *AccessTestSuite) do(method, path, token string) int {
+	req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+	if token != "" {
+		req.Header.Set(openapi.AccessTokenHeader, token)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func (suite *AccessTestSuite) TestRequireDoctor() {
+	path := "/doctors/" + suite.doctorID.String()
+
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPut, path, ""))
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPut, path, "not-a-token"))
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodPut, path, accessToken(7, RoleDoctor)))
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodPut, path, accessToken(1, RoleAdmin)))
+
+	suite.Equal(http.StatusForbidden, suite.do(http.MethodPut, path, accessToken(8, RoleDoctor)), "another doctor")
+	suite.Equal(http.StatusForbidden, suite.do(http.MethodPut, path, accessToken(9, RoleDoctor)), "doctor without profile")
+	suite.Equal(http.StatusForbidden, suite.do(http.MethodPut, path, accessToken(7)), "linked user without the doctor role")
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPut, "/doctors/abc", accessToken(1, RoleAdmin)))
+}
+
+func (suite *AccessTestSuite) TestAdminOnly() {
+	suite.Equal(http.StatusForbidden, suite.do(http.MethodPost, "/doctors", accessToken(7, RoleDoctor)))
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodPost, "/doctors", accessToken(1, RoleAdmin)))
+
+	suite.Equal(http.StatusForbidden, suite.do(http.MethodPost, "/holidays", accessToken(7, RoleDoctor)))
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodPost, "/holidays", accessToken(1, RoleAdmin)))
+}
+
+func (suite *AccessTestSuite) TestClinicChangesAdminOnly() {
+	path := "/clinics/" + uuid.NewString() + "/doctors/" + suite.doctorID.String()
+
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPut, path, ""))
+	suite.Equal(http.StatusForbidden, suite.do(http.MethodPut, path, accessToken(7, RoleDoctor)))
+	suite.Equal(http.StatusForbidden, suite.do(http.MethodPost, "/clinics", accessToken(7, RoleDoctor)))
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodPut, path, accessToken(1, RoleAdmin)))
+
+	suite.Equal(http.StatusOK, suite.do(http.MethodGet, "/clinics", ""), "clinics are open to everyone")
+}
+
+func (suite *AccessTestSuite) TestUnverifiedTokenRejected() {
+	claims := jwt.MapClaims{"userId": 1, "roles": []string{RoleAdmin}, "exp": time.Now().Add(time.Hour).Unix()}
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"userId":1,"roles":["admin"]}`))
+
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPost, "/doctors", "e30."+payload+".sig"), "forged signature")
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPost, "/doctors", "eyJhbGciOiJub25lIn0."+payload+"."), "alg none")
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPost, "/doctors", signedToken("another-secret-of-the-auth-service", claims)), "foreign key")
+
+	claims["exp"] = time.Now().Add(-time.Minute).Unix()
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPost, "/doctors", signedToken(testJWTSecret, claims)), "expired")
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/bulk"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportBytes — наибольший размер файла импорта
+const maxImportBytes = 32 << 20
+
+type BulkHandler struct {
+	svc    service.BulkService
+	access *Access
+}
+
+func NewBulkHandler(s service.BulkService, access *Access) *BulkHandler {
+	return &BulkHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты массового импорта и выгрузки, доступные только администратору.
+// :kind — specializations, doctors или schedule-templates.
+func (h *BulkHandler) RegisterRoutes(r *gin.Engine) {
+	r.POST("/import/:kind", h.access.RequireAdmin(), h.Import)
+	r.GET("/export/:kind", h.access.RequireAdmin(), h.Export)
+}
+
+// Import — POST /import/:kind?format=csv&dry_run=true
+// Файл передаётся телом запроса. При ошибках в записях ничего не сохраняется, ответ — 422 с отчётом.
+func (h *BulkHandler) Import(c *gin.Context) {
+	kind, err := bulk.ParseKind(c.Param("kind"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	var req service.ImportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	format := bulk.FormatOf(c.ContentType())
+	if req.Format != "" {
+		format = bulk.Format(req.Format)
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	report, err := h.svc.Import(c.Request.Context(), kind, format, body, req.DryRun)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file is larger than %d bytes", tooLarge.Limit)})
+		case errors.Is(err, service.ErrInvalidImport):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// Export — GET /export/:kind?format=csv
+// Файл отдаётся потоком; ошибка посреди выгрузки обрывает файл и попадает только в лог.
+func (h *BulkHandler) Export(c *gin.Context) {
+	kind, err := bulk.ParseKind(c.Param("kind"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	var req service.ExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	format := bulk.FormatJSON
+	if req.Format != "" {
+		format = bulk.Format(req.Format)
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, kind, time.Now().UTC().Format("20060102"), format))
+	if err := h.svc.Export(c.Request.Context(), kind, format, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		logging.FromContext(c.Request.Context()).WithError(err).Errorf("Failed to export %s", kind)
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CatalogHandler struct {
+	svc    service.CatalogService
+	access *Access
+}
+
+func NewCatalogHandler(s service.CatalogService, access *Access) *CatalogHandler {
+	return &CatalogHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты каталога услуг. Действующие услуги видны всем,
+// каталог ведёт администратор.
+func (h *CatalogHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/specializations/:id/services", h.ListServices)
+	r.POST("/specializations/:id/services", h.access.RequireAdmin(), h.CreateService)
+	r.GET("/doctors/:id/services", h.ListDoctorServices)
+
+	services := r.Group("/services", h.access.RequireAdmin())
+	{
+		services.PUT("/:serviceId", h.UpdateService)
+		services.DELETE("/:serviceId", h.DeleteService)
+	}
+}
+
+// CreateService — POST /specializations/:id/services
+func (h *CatalogHandler) CreateService(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var req service.MedicalServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.CreateService(c.Request.Context(), id, req)
+	if err != nil {
+		specializationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListServices — GET /specializations/:id/services
+func (h *CatalogHandler) ListServices(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	dtos, err := h.svc.ListServices(c.Request.Context(), id)
+	if err != nil {
+		specializationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+// ListDoctorServices — GET /doctors/:id/services
+func (h *CatalogHandler) ListDoctorServices(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	dtos, err := h.svc.ListDoctorServices(c.Request.Context(), id)
+	if err != nil {
+		specializationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+// UpdateService — PUT /services/:serviceId
+func (h *CatalogHandler) UpdateService(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("serviceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid service id"})
+		return
+	}
+	var req service.MedicalServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.UpdateService(c.Request.Context(), id, req)
+	if err != nil {
+		specializationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// DeleteService — DELETE /services/:serviceId
+func (h *CatalogHandler) DeleteService(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("serviceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid service id"})
+		return
+	}
+
+	if err := h.svc.DeleteService(c.Request.Context(), id); err != nil {
+		specializationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
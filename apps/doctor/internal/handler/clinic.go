@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ClinicHandler struct {
+	svc    service.ClinicService
+	access *Access
+}
+
+func NewClinicHandler(s service.ClinicService, access *Access) *ClinicHandler {
+	return &ClinicHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты филиалов, их кабинетов и врачей.
+// Филиалы открыты всем, меняет их администратор.
+func (h *ClinicHandler) RegisterRoutes(r *gin.Engine) {
+	g := r.Group("/clinics")
+	{
+		g.POST("", h.access.RequireAdmin(), h.CreateClinic)
+		g.GET("", h.ListClinics)
+		g.GET("/:id", h.GetClinic)
+		g.POST("/:id/rooms", h.access.RequireAdmin(), h.AddRoom)
+		g.PUT("/:id/doctors/:doctorId", h.access.RequireAdmin(), h.AssignDoctor)
+		g.DELETE("/:id/doctors/:doctorId", h.access.RequireAdmin(), h.UnassignDoctor)
+	}
+}
+
+// CreateClinic — POST /clinics
+func (h *ClinicHandler) CreateClinic(c *gin.Context) {
+	var req service.CreateClinicRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.CreateClinic(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListClinics — GET /clinics
+func (h *ClinicHandler) ListClinics(c *gin.Context) {
+	dtos, err := h.svc.ListClinics(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetClinic — GET /clinics/:id
+func (h *ClinicHandler) GetClinic(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	dto, err := h.svc.GetClinic(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrClinicNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// AddRoom — POST /clinics/:id/rooms
+func (h *ClinicHandler) AddRoom(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req service.CreateRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.AddRoom(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, service.ErrClinicNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// AssignDoctor — PUT /clinics/:id/doctors/:doctorId
+func (h *ClinicHandler) AssignDoctor(c *gin.Context) {
+	id, doctorID, ok := clinicDoctorParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.AssignDoctor(c.Request.Context(), id, doctorID); err != nil {
+		if errors.Is(err, service.ErrClinicNotFound) || errors.Is(err, service.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnassignDoctor — DELETE /clinics/:id/doctors/:doctorId
+func (h *ClinicHandler) UnassignDoctor(c *gin.Context) {
+	id, doctorID, ok := clinicDoctorParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.UnassignDoctor(c.Request.Context(), id, doctorID); err != nil {
+		if errors.Is(err, service.ErrDoctorNotInClinic) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Support function for parsing the clinic and doctor IDs of a route.
+func clinicDoctorParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	doctorID, err := uuid.Parse(c.Param("doctorId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid doctor id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, doctorID, true
+}
@@ -0,0 +1,597 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+)
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *DoctorHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"doctors"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/doctors", Summary: "Create a doctor", Tags: tags,
+			Request: service.CreateDoctorRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:      service.CreateDoctorResponse{},
+				http.StatusBadRequest:   failure,
+				http.StatusUnauthorized: failure,
+				http.StatusForbidden:    failure,
+				http.StatusConflict:     failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctors", Summary: "Search doctors", Tags: tags,
+			Query: service.SearchDoctorsRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  service.DoctorListResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctors/:id", Summary: "Get a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  service.DoctorDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctors/me", Summary: "Get the doctor profile of the signed-in user", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  service.DoctorDTO{},
+				http.StatusUnauthorized:        failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodPut, Path: "/doctors/:id/user", Summary: "Link a doctor to an auth user", Tags: tags,
+			Request: service.LinkUserRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  service.DoctorDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusConflict:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/doctors/:id/user", Summary: "Unlink a doctor from its auth user", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodPut, Path: "/doctors/:id", Summary: "Update a doctor", Tags: tags,
+			Request: service.UpdateDoctorRequest{},
+			Responses: map[int]any{
+				http.StatusOK:           service.DoctorDTO{},
+				http.StatusBadRequest:   failure,
+				http.StatusUnauthorized: failure,
+				http.StatusForbidden:    failure,
+				http.StatusNotFound:     failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/doctors/:id", Summary: "Delete a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *ScheduleTemplateHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"schedule"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/doctors/:id/schedule-templates", Summary: "Create a weekly schedule template", Tags: tags,
+			Request: service.CreateScheduleTemplateRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.ScheduleTemplateDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctors/:id/schedule-templates", Summary: "List the schedule templates of a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  []service.ScheduleTemplateDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/doctors/:id/schedule-templates/:templateId", Summary: "Delete a schedule template and its free slots", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/doctors/:id/schedule-templates/generate", Summary: "Generate the slots of a doctor from the templates", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  service.GenerateSlotsResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *ScheduleExceptionHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"schedule"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/schedule-exceptions", Summary: "Create a time-off, holiday or extra hours", Tags: tags,
+			Request: service.CreateScheduleExceptionRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.CreateScheduleExceptionResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/schedule-exceptions", Summary: "List the schedule exceptions of a period", Tags: tags,
+			Query: service.ListScheduleExceptionsRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  []service.ScheduleExceptionDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/schedule-exceptions/:id", Summary: "Delete a schedule exception and release its slots", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *ClinicHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"clinics"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/clinics", Summary: "Create a clinic with its rooms", Tags: tags,
+			Request: service.CreateClinicRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.ClinicDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/clinics", Summary: "List the clinics", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  []service.ClinicDTO{},
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/clinics/:id", Summary: "Get a clinic", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  service.ClinicDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/clinics/:id/rooms", Summary: "Add a room to a clinic", Tags: tags,
+			Request: service.CreateRoomRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.RoomDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodPut, Path: "/clinics/:id/doctors/:doctorId", Summary: "Assign a doctor to a clinic", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/clinics/:id/doctors/:doctorId", Summary: "Unassign a doctor from a clinic", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+// The photo is sent and returned as the raw image body, so it has no JSON schema.
+func (h *DoctorProfileHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"doctors"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/doctors/:id/credentials", Summary: "Add a license or certificate of a doctor", Tags: tags,
+			Request: service.CreateCredentialRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.CredentialDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/doctors/:id/credentials/:credentialId", Summary: "Delete a credential of a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodPut, Path: "/doctors/:id/photo", Summary: "Upload the JPEG, PNG or WebP profile photo of a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctors/:id/photo", Summary: "Get the profile photo of a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  nil,
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/doctors/:id/photo", Summary: "Delete the profile photo of a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *ReviewHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"reviews"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/doctors/:id/reviews", Summary: "Review a completed appointment with a doctor", Tags: tags,
+			Request: service.CreateReviewRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.ReviewDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusConflict:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctors/:id/reviews", Summary: "List the approved reviews of a doctor", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  []service.ReviewDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/reviews", Summary: "List reviews by moderation status", Tags: tags,
+			Query: service.ListReviewsRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  []service.ReviewDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodPut, Path: "/reviews/:reviewId/status", Summary: "Approve or reject a review", Tags: tags,
+			Request: service.ModerateReviewRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  service.ReviewDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *MeetingHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"meetings"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/appointments/:appointmentId/meeting", Summary: "Get the video meeting link of an online appointment", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  service.MeetingDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+				http.StatusServiceUnavailable:  failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/meetings/:room/verify", Summary: "Check a meeting link before letting a participant into the room", Tags: tags,
+			Query: service.VerifyMeetingRequest{},
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusForbidden:           failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *SpecializationHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"specializations"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/specializations", Summary: "List the specialization tree in the Accept-Language language", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  []service.SpecializationDTO{},
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/specializations/:id", Summary: "Get a specialization with its translations", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  service.SpecializationDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/specializations", Summary: "Create a specialization", Tags: tags,
+			Request: service.SpecializationRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.SpecializationDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodPut, Path: "/specializations/:id", Summary: "Update a specialization and replace its translations", Tags: tags,
+			Request: service.SpecializationRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  service.SpecializationDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/specializations/:id", Summary: "Delete an unused specialization", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusConflict:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes.
+func (h *CatalogHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"services"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/specializations/:id/services", Summary: "List the offered services of a specialization", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  []service.MedicalServiceDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/specializations/:id/services", Summary: "Add a service to a specialization", Tags: tags,
+			Request: service.MedicalServiceRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             service.MedicalServiceDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/doctors/:id/services", Summary: "List the services a doctor offers", Tags: tags,
+			Responses: map[int]any{
+				http.StatusOK:                  []service.MedicalServiceDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		openapi.Operation{
+			Method: http.MethodPut, Path: "/services/:serviceId", Summary: "Update a service", Tags: tags,
+			Request: service.MedicalServiceRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  service.MedicalServiceDTO{},
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/services/:serviceId", Summary: "Delete a service", Tags: tags,
+			Responses: map[int]any{
+				http.StatusNoContent:           nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes. The import body is
+// the CSV or JSON file itself, so it has no JSON schema.
+func (h *BulkHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	tags := []string{"bulk"}
+
+	return doc.Add(
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/import/:kind", Summary: "Import specializations, doctors or schedule templates from CSV or JSON", Tags: tags,
+			Query: service.ImportRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                    service.ImportReport{},
+				http.StatusBadRequest:            failure,
+				http.StatusUnauthorized:          failure,
+				http.StatusForbidden:             failure,
+				http.StatusNotFound:              failure,
+				http.StatusRequestEntityTooLarge: failure,
+				http.StatusUnprocessableEntity:   service.ImportReport{},
+				http.StatusInternalServerError:   failure,
+			},
+			Secured: true,
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/export/:kind", Summary: "Export specializations, doctors or schedule templates as CSV or JSON", Tags: tags,
+			Query: service.ExportRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  nil,
+				http.StatusBadRequest:          failure,
+				http.StatusUnauthorized:        failure,
+				http.StatusForbidden:           failure,
+				http.StatusNotFound:            failure,
+				http.StatusInternalServerError: failure,
+			},
+			Secured: true,
+		},
+	)
+}
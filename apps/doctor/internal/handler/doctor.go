@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
 	"net/http"
 
@@ -9,21 +10,28 @@ import (
 )
 
 type DoctorHandler struct {
-	svc service.DoctorService
+	svc    service.DoctorService
+	access *Access
 }
 
-func NewDoctorHandler(s service.DoctorService) *DoctorHandler {
-	return &DoctorHandler{svc: s}
+func NewDoctorHandler(s service.DoctorService, access *Access) *DoctorHandler {
+	return &DoctorHandler{svc: s, access: access}
 }
 
-// RegisterRoutes навешивает CRUD-роуты
+// RegisterRoutes навешивает CRUD-роуты. Создаёт, удаляет и привязывает врачей к пользователям
+// администратор, свой профиль врач правит сам.
 func (h *DoctorHandler) RegisterRoutes(r *gin.Engine) {
 	docs := r.Group("/doctors")
+	admin := h.access.RequireAdmin()
 	{
-		docs.POST("", h.CreateDoctor)
+		docs.POST("", admin, h.CreateDoctor)
+		docs.GET("", h.SearchDoctors)
+		docs.GET("/me", h.GetMe)
 		docs.GET("/:id", h.GetDoctorByID)
-		docs.PUT("/:id", h.UpdateDoctor)
-		docs.DELETE("/:id", h.DeleteDoctor)
+		docs.PUT("/:id", h.access.RequireDoctor("id"), h.UpdateDoctor)
+		docs.DELETE("/:id", admin, h.DeleteDoctor)
+		docs.PUT("/:id/user", admin, h.LinkUser)
+		docs.DELETE("/:id/user", admin, h.UnlinkUser)
 	}
 }
 
@@ -38,13 +46,38 @@ func (h *DoctorHandler) CreateDoctor(c *gin.Context) {
 	resp, err := h.svc.CreateDoctor(c.Request.Context(), req)
 	if err != nil {
 		// можно разделить типы ошибок и вернуть 409/422 и т.п.
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrUserLinked) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
 	c.JSON(http.StatusCreated, resp)
 }
 
+// SearchDoctors — GET /doctors
+func (h *DoctorHandler) SearchDoctors(c *gin.Context) {
+	var req service.SearchDoctorsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.svc.SearchDoctors(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // GetDoctorByID — GET /doctors/:id
 func (h *DoctorHandler) GetDoctorByID(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -66,6 +99,76 @@ func (h *DoctorHandler) GetDoctorByID(c *gin.Context) {
 	c.JSON(http.StatusOK, dto)
 }
 
+// GetMe — GET /doctors/me, профиль врача, под которым вошёл пользователь
+func (h *DoctorHandler) GetMe(c *gin.Context) {
+	id, ok := h.access.identity(c)
+	if !ok {
+		return
+	}
+
+	dto, err := h.svc.GetDoctorByUserID(c.Request.Context(), id.UserID)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// LinkUser — PUT /doctors/:id/user, после этого пользователь с ролью doctor правит профиль сам
+func (h *DoctorHandler) LinkUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req service.LinkUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.LinkUser(c.Request.Context(), id, req.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrUserLinked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// UnlinkUser — DELETE /doctors/:id/user
+func (h *DoctorHandler) UnlinkUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.svc.UnlinkUser(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // UpdateDoctor — PUT /doctors/:id
 func (h *DoctorHandler) UpdateDoctor(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Статусы слота в TimeSlot.status
+const (
+	slotFree   = "free"
+	slotBooked = "booked"
+	slotHeld   = "held"
+)
+
+// DoctorGRPCServer реализует doctor.v1.DoctorService поверх расписания врачей
+type DoctorGRPCServer struct {
+	doctorpb.UnimplementedDoctorServiceServer
+	slots service.ScheduleSlotService
+}
+
+func NewDoctorGRPCServer(slots service.ScheduleSlotService) *DoctorGRPCServer {
+	return &DoctorGRPCServer{slots: slots}
+}
+
+// CheckTimeAvailability — свободен ли слот врача, начинающийся в slot_time
+func (s *DoctorGRPCServer) CheckTimeAvailability(ctx context.Context, req *doctorpb.CheckTimeAvailabilityRequest) (*doctorpb.CheckTimeAvailabilityResponse, error) {
+	doctorID, err := parseDoctorID(req.GetDoctorId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSlotTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "slot_time is required")
+	}
+
+	slot, err := s.slots.FindSlot(doctorID, req.GetSlotTime().AsTime())
+	switch {
+	case errors.Is(err, service.ErrSlotNotFound):
+		return &doctorpb.CheckTimeAvailabilityResponse{Reason: "no slot at this time"}, nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("Failed to find schedule slot")
+		return nil, status.Error(codes.Internal, "failed to find schedule slot")
+	case !slot.IsAvailable:
+		return &doctorpb.CheckTimeAvailabilityResponse{Reason: "already booked"}, nil
+	}
+	return &doctorpb.CheckTimeAvailabilityResponse{IsAvailable: true}, nil
+}
+
+// GetAvailableSlots — слоты врача за период со статусом free или booked
+func (s *DoctorGRPCServer) GetAvailableSlots(ctx context.Context, req *doctorpb.GetAvailableSlotsRequest) (*doctorpb.GetAvailableSlotsResponse, error) {
+	doctorID, err := parseDoctorID(req.GetDoctorId())
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to *time.Time
+	if req.GetStartDate() != nil {
+		t := req.GetStartDate().AsTime()
+		from = &t
+	}
+	if req.GetEndDate() != nil {
+		t := req.GetEndDate().AsTime()
+		to = &t
+	}
+
+	slots, err := s.slots.ListSlots(doctorID, from, to)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("Failed to list schedule slots")
+		return nil, status.Error(codes.Internal, "failed to list schedule slots")
+	}
+
+	resp := &doctorpb.GetAvailableSlotsResponse{Slots: make([]*doctorpb.TimeSlot, 0, len(slots))}
+	for _, slot := range slots {
+		resp.Slots = append(resp.Slots, toTimeSlot(slot))
+	}
+	return resp, nil
+}
+
+// ChangeTimeSlot занимает или освобождает слот врача, начинающийся в slot_time.
+// Устарело, запись занимает слот через ReserveSlot.
+func (s *DoctorGRPCServer) ChangeTimeSlot(ctx context.Context, req *doctorpb.ChangeTimeSlotRequest) (*doctorpb.ChangeTimeSlotResponse, error) {
+	doctorID, err := parseDoctorID(req.GetDoctorId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSlotTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "slot_time is required")
+	}
+
+	_, err = s.slots.SetAvailability(doctorID, req.GetSlotTime().AsTime(), req.GetIsAvailable())
+	switch {
+	case errors.Is(err, service.ErrSlotNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrSlotBooked), errors.Is(err, service.ErrReservationMismatch):
+		return &doctorpb.ChangeTimeSlotResponse{Message: err.Error()}, nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("Failed to change schedule slot")
+		return nil, status.Error(codes.Internal, "failed to change schedule slot")
+	}
+	return &doctorpb.ChangeTimeSlotResponse{Success: true, Message: "Slot updated successfully"}, nil
+}
+
+// ReserveSlot занимает слот врача под запись. Из параллельных броней одного слота
+// успешна одна, остальные получают reason "already booked".
+func (s *DoctorGRPCServer) ReserveSlot(ctx context.Context, req *doctorpb.ReserveSlotRequest) (*doctorpb.ReserveSlotResponse, error) {
+	doctorID, err := parseDoctorID(req.GetDoctorId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSlotTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "slot_time is required")
+	}
+	appointmentID, err := uuid.Parse(req.GetAppointmentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid appointment_id")
+	}
+
+	slot, err := s.slots.ReserveSlot(ctx, doctorID, req.GetSlotTime().AsTime(), appointmentID)
+	switch {
+	case errors.Is(err, service.ErrSlotNotFound):
+		return &doctorpb.ReserveSlotResponse{Reason: "no slot at this time"}, nil
+	case errors.Is(err, service.ErrSlotBooked):
+		return &doctorpb.ReserveSlotResponse{Reason: "already booked"}, nil
+	case errors.Is(err, service.ErrRoomOccupied):
+		return &doctorpb.ReserveSlotResponse{Reason: "room is occupied"}, nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("Failed to reserve schedule slot")
+		return nil, status.Error(codes.Internal, "failed to reserve schedule slot")
+	}
+	return &doctorpb.ReserveSlotResponse{Success: true, ReservationToken: slot.ReservationToken.String()}, nil
+}
+
+// ReleaseSlot освобождает слот, занятый записью appointment_id с токеном reservation_token
+func (s *DoctorGRPCServer) ReleaseSlot(ctx context.Context, req *doctorpb.ReleaseSlotRequest) (*doctorpb.ReleaseSlotResponse, error) {
+	doctorID, err := parseDoctorID(req.GetDoctorId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSlotTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "slot_time is required")
+	}
+	appointmentID, err := uuid.Parse(req.GetAppointmentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid appointment_id")
+	}
+	token, err := uuid.Parse(req.GetReservationToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid reservation_token")
+	}
+
+	err = s.slots.ReleaseSlot(ctx, doctorID, req.GetSlotTime().AsTime(), appointmentID, token)
+	switch {
+	case errors.Is(err, service.ErrSlotNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrReservationMismatch):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("Failed to release schedule slot")
+		return nil, status.Error(codes.Internal, "failed to release schedule slot")
+	}
+	return &doctorpb.ReleaseSlotResponse{Success: true, Message: "Slot released successfully"}, nil
+}
+
+// HoldSlot удерживает слот врача на время оформления записи
+func (s *DoctorGRPCServer) HoldSlot(ctx context.Context, req *doctorpb.HoldSlotRequest) (*doctorpb.HoldSlotResponse, error) {
+	doctorID, err := parseDoctorID(req.GetDoctorId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSlotTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "slot_time is required")
+	}
+	appointmentID, err := uuid.Parse(req.GetAppointmentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid appointment_id")
+	}
+
+	slot, err := s.slots.HoldSlot(doctorID, req.GetSlotTime().AsTime(), appointmentID)
+	switch {
+	case errors.Is(err, service.ErrSlotNotFound):
+		return &doctorpb.HoldSlotResponse{Reason: "no slot at this time"}, nil
+	case errors.Is(err, service.ErrSlotBooked):
+		return &doctorpb.HoldSlotResponse{Reason: "already booked"}, nil
+	case errors.Is(err, service.ErrRoomOccupied):
+		return &doctorpb.HoldSlotResponse{Reason: "room is occupied"}, nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("Failed to hold schedule slot")
+		return nil, status.Error(codes.Internal, "failed to hold schedule slot")
+	}
+
+	resp := &doctorpb.HoldSlotResponse{Success: true, ReservationToken: slot.ReservationToken.String()}
+	if slot.HeldUntil != nil {
+		resp.HeldUntil = timestamppb.New(*slot.HeldUntil)
+	}
+	return resp, nil
+}
+
+// ConfirmSlot подтверждает удержание слота записью appointment_id
+func (s *DoctorGRPCServer) ConfirmSlot(ctx context.Context, req *doctorpb.ConfirmSlotRequest) (*doctorpb.ConfirmSlotResponse, error) {
+	doctorID, err := parseDoctorID(req.GetDoctorId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetSlotTime() == nil {
+		return nil, status.Error(codes.InvalidArgument, "slot_time is required")
+	}
+	appointmentID, err := uuid.Parse(req.GetAppointmentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid appointment_id")
+	}
+	token, err := uuid.Parse(req.GetReservationToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid reservation_token")
+	}
+
+	err = s.slots.ConfirmSlot(ctx, doctorID, req.GetSlotTime().AsTime(), appointmentID, token)
+	switch {
+	case errors.Is(err, service.ErrSlotNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrHoldExpired):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrReservationMismatch):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("Failed to confirm schedule slot")
+		return nil, status.Error(codes.Internal, "failed to confirm schedule slot")
+	}
+	return &doctorpb.ConfirmSlotResponse{Success: true, Message: "Slot confirmed successfully"}, nil
+}
+
+// Support function for parsing the doctor ID of a request.
+func parseDoctorID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid doctor_id")
+	}
+	return id, nil
+}
+
+func toTimeSlot(slot model.ScheduleSlot) *doctorpb.TimeSlot {
+	state := slotFree
+	switch {
+	case slot.HeldUntil != nil:
+		state = slotHeld
+	case !slot.IsAvailable:
+		state = slotBooked
+	}
+	ts := &doctorpb.TimeSlot{
+		StartTime: timestamppb.New(slot.StartsAt),
+		EndTime:   timestamppb.New(slot.EndsAt),
+		Status:    state,
+	}
+	if slot.ClinicID != nil {
+		ts.ClinicId = slot.ClinicID.String()
+	}
+	if slot.RoomID != nil {
+		ts.RoomId = slot.RoomID.String()
+	}
+	return ts
+}
@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/meeting"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// fakeSlotRepo хранит слоты в памяти. Условные обновления идут под мьютексом,
+// как одиночный UPDATE в БД.
+type fakeSlotRepo struct {
+	repository.ScheduleSlotRepository
+	mu    sync.Mutex
+	slots []model.ScheduleSlot
+}
+
+func (r *fakeSlotRepo) FindByStart(doctorID uuid.UUID, start time.Time) (*model.ScheduleSlot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.slots {
+		if r.slots[i].DoctorID == doctorID && r.slots[i].StartsAt.Equal(start) {
+			slot := r.slots[i]
+			return &slot, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSlotRepo) ListByDoctor(doctorID uuid.UUID, _, _ *time.Time) ([]model.ScheduleSlot, error) {
+	var slots []model.ScheduleSlot
+	for _, slot := range r.slots {
+		if slot.DoctorID == doctorID {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+func (r *fakeSlotRepo) Reserve(doctorID uuid.UUID, start time.Time, res repository.Reservation, now time.Time) (*model.ScheduleSlot, error) {
+	return r.updateAt(doctorID, start, func(slot *model.ScheduleSlot) bool {
+		free := slot.IsAvailable && slot.AppointmentID == nil
+		if !free && (slot.HeldUntil == nil || slot.Held(now)) {
+			return false
+		}
+		slot.IsAvailable, slot.AppointmentID, slot.ReservationToken, slot.HeldUntil = false, &res.AppointmentID, &res.Token, res.HeldUntil
+		return true
+	})
+}
+
+func (r *fakeSlotRepo) Confirm(doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID, now time.Time) (*model.ScheduleSlot, error) {
+	return r.updateAt(doctorID, start, func(slot *model.ScheduleSlot) bool {
+		if slot.AppointmentID == nil || *slot.AppointmentID != appointmentID || *slot.ReservationToken != token || !slot.Held(now) {
+			return false
+		}
+		slot.HeldUntil = nil
+		return true
+	})
+}
+
+func (r *fakeSlotRepo) Release(doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID) (*model.ScheduleSlot, error) {
+	return r.updateAt(doctorID, start, func(slot *model.ScheduleSlot) bool {
+		if slot.AppointmentID == nil || *slot.AppointmentID != appointmentID || *slot.ReservationToken != token {
+			return false
+		}
+		slot.IsAvailable, slot.AppointmentID, slot.ReservationToken, slot.HeldUntil = true, nil, nil, nil
+		slot.MeetingRoom, slot.MeetingLink = "", ""
+		return true
+	})
+}
+
+func (r *fakeSlotRepo) SetMeeting(id, appointmentID uuid.UUID, room, link string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.slots {
+		slot := &r.slots[i]
+		if slot.ID == id && slot.AppointmentID != nil && *slot.AppointmentID == appointmentID && slot.HeldUntil == nil && slot.MeetingRoom == "" {
+			slot.MeetingRoom, slot.MeetingLink = room, link
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeSlotRepo) SetAvailable(doctorID uuid.UUID, start time.Time, available bool) (*model.ScheduleSlot, error) {
+	return r.updateAt(doctorID, start, func(slot *model.ScheduleSlot) bool {
+		if slot.IsAvailable == available || slot.AppointmentID != nil || slot.BlockedBy != nil {
+			return false
+		}
+		slot.IsAvailable = available
+		return true
+	})
+}
+
+func (r *fakeSlotRepo) updateAt(doctorID uuid.UUID, start time.Time, update func(slot *model.ScheduleSlot) bool) (*model.ScheduleSlot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.slots {
+		if r.slots[i].DoctorID == doctorID && r.slots[i].StartsAt.Equal(start) && update(&r.slots[i]) {
+			slot := r.slots[i]
+			return &slot, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type DoctorGRPCServerTestSuite struct {
+	suite.Suite
+	repo     *fakeSlotRepo
+	meetings *meeting.Fake
+	server   *DoctorGRPCServer
+	doctorID uuid.UUID
+	start    time.Time
+}
+
+func TestDoctorGRPCServer(t *testing.T) {
+	suite.Run(t, new(DoctorGRPCServerTestSuite))
+}
+
+func (suite *DoctorGRPCServerTestSuite) SetupTest() {
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	suite.doctorID = uuid.New()
+	suite.start = time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	suite.repo = &fakeSlotRepo{slots: []model.ScheduleSlot{{
+		ID:          uuid.New(),
+		DoctorID:    suite.doctorID,
+		StartsAt:    suite.start,
+		EndsAt:      suite.start.Add(30 * time.Minute),
+		IsAvailable: true,
+	}}}
+	suite.meetings = meeting.NewFake()
+	suite.server = NewDoctorGRPCServer(service.NewScheduleSlotService(suite.repo, suite.meetings, 10*time.Minute))
+}
+
+func (suite *DoctorGRPCServerTestSuite) TestBookingFlow() {
+	ctx := context.Background()
+	check := &doctorpb.CheckTimeAvailabilityRequest{
+		DoctorId: suite.doctorID.String(),
+		SlotTime: timestamppb.New(suite.start),
+	}
+
+	availability, err := suite.server.CheckTimeAvailability(ctx, check)
+	suite.Require().NoError(err)
+	suite.True(availability.IsAvailable)
+
+	changed, err := suite.server.ChangeTimeSlot(ctx, &doctorpb.ChangeTimeSlotRequest{
+		DoctorId: suite.doctorID.String(),
+		SlotTime: timestamppb.New(suite.start),
+	})
+	suite.Require().NoError(err)
+	suite.True(changed.Success)
+
+	availability, err = suite.server.CheckTimeAvailability(ctx, check)
+	suite.Require().NoError(err)
+	suite.False(availability.IsAvailable)
+	suite.Equal("already booked", availability.Reason)
+
+	// Второй раз занять тот же слот нельзя
+	changed, err = suite.server.ChangeTimeSlot(ctx, &doctorpb.ChangeTimeSlotRequest{
+		DoctorId: suite.doctorID.String(),
+		SlotTime: timestamppb.New(suite.start),
+	})
+	suite.Require().NoError(err)
+	suite.False(changed.Success)
+}
+
+func (suite *DoctorGRPCServerTestSuite) TestGetAvailableSlots() {
+	suite.repo.slots[0].IsAvailable = false
+
+	resp, err := suite.server.GetAvailableSlots(context.Background(), &doctorpb.GetAvailableSlotsRequest{
+		DoctorId: suite.doctorID.String(),
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.Slots, 1)
+	suite.True(resp.Slots[0].StartTime.AsTime().Equal(suite.start))
+	suite.True(resp.Slots[0].EndTime.AsTime().Equal(suite.start.Add(30 * time.Minute)))
+	suite.Equal("booked", resp.Slots[0].Status)
+}
+
+func (suite *DoctorGRPCServerTestSuite) TestErrors() {
+	ctx := context.Background()
+
+	_, err := suite.server.CheckTimeAvailability(ctx, &doctorpb.CheckTimeAvailabilityRequest{DoctorId: "42"})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+
+	availability, err := suite.server.CheckTimeAvailability(ctx, &doctorpb.CheckTimeAvailabilityRequest{
+		DoctorId: suite.doctorID.String(),
+		SlotTime: timestamppb.New(suite.start.Add(time.Hour)),
+	})
+	suite.Require().NoError(err)
+	suite.False(availability.IsAvailable)
+
+	_, err = suite.server.ChangeTimeSlot(ctx, &doctorpb.ChangeTimeSlotRequest{
+		DoctorId: suite.doctorID.String(),
+		SlotTime: timestamppb.New(suite.start.Add(time.Hour)),
+	})
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *DoctorGRPCServerTestSuite) TestReserveSlot_ParallelBookings() {
+	const bookings = 50
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners []string
+		losers  int
+	)
+	for i := 0; i < bookings; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := suite.server.ReserveSlot(context.Background(), &doctorpb.ReserveSlotRequest{
+				DoctorId:      suite.doctorID.String(),
+				SlotTime:      timestamppb.New(suite.start),
+				AppointmentId: uuid.NewString(),
+			})
+			suite.NoError(err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if resp.GetSuccess() {
+				winners = append(winners, resp.ReservationToken)
+			} else {
+				suite.Equal("already booked", resp.GetReason())
+				losers++
+			}
+		}()
+	}
+	wg.Wait()
+
+	suite.Len(winners, 1)
+	suite.Equal(bookings-1, losers)
+	suite.Equal(winners[0], suite.repo.slots[0].ReservationToken.String())
+}
+
+func (suite *DoctorGRPCServerTestSuite) TestReserveAndReleaseSlot() {
+	ctx := context.Background()
+	appointmentID := uuid.NewString()
+	reserve := &doctorpb.ReserveSlotRequest{
+		DoctorId:      suite.doctorID.String(),
+		SlotTime:      timestamppb.New(suite.start),
+		AppointmentId: appointmentID,
+	}
+
+	reserved, err := suite.server.ReserveSlot(ctx, reserve)
+	suite.Require().NoError(err)
+	suite.Require().True(reserved.Success)
+
+	// Повтор той же записи возвращает ту же бронь
+	again, err := suite.server.ReserveSlot(ctx, reserve)
+	suite.Require().NoError(err)
+	suite.True(again.Success)
+	suite.Equal(reserved.ReservationToken, again.ReservationToken)
+
+	// Чужой токен слот не освобождает, как и устаревший ChangeTimeSlot
+	_, err = suite.server.ReleaseSlot(ctx, &doctorpb.ReleaseSlotRequest{
+		DoctorId:         suite.doctorID.String(),
+		SlotTime:         timestamppb.New(suite.start),
+		AppointmentId:    appointmentID,
+		ReservationToken: uuid.NewString(),
+	})
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+	changed, err := suite.server.ChangeTimeSlot(ctx, &doctorpb.ChangeTimeSlotRequest{
+		DoctorId:    suite.doctorID.String(),
+		SlotTime:    timestamppb.New(suite.start),
+		IsAvailable: true,
+	})
+	suite.Require().NoError(err)
+	suite.False(changed.Success)
+
+	release := &doctorpb.ReleaseSlotRequest{
+		DoctorId:         suite.doctorID.String(),
+		SlotTime:         timestamppb.New(suite.start),
+		AppointmentId:    appointmentID,
+		ReservationToken: reserved.ReservationToken,
+	}
+	released, err := suite.server.ReleaseSlot(ctx, release)
+	suite.Require().NoError(err)
+	suite.True(released.Success)
+	suite.True(suite.repo.slots[0].IsAvailable)
+	suite.Nil(suite.repo.slots[0].AppointmentID)
+
+	// Повторное освобождение не ошибка
+	released, err = suite.server.ReleaseSlot(ctx, release)
+	suite.Require().NoError(err)
+	suite.True(released.Success)
+}
+
+func (suite *DoctorGRPCServerTestSuite) TestHoldAndConfirmSlot() {
+	ctx := context.Background()
+	appointmentID := uuid.NewString()
+
+	held, err := suite.server.HoldSlot(ctx, &doctorpb.HoldSlotRequest{
+		DoctorId:      suite.doctorID.String(),
+		SlotTime:      timestamppb.New(suite.start),
+		AppointmentId: appointmentID,
+	})
+	suite.Require().NoError(err)
+	suite.Require().True(held.Success)
+	suite.WithinDuration(time.Now().Add(10*time.Minute), held.HeldUntil.AsTime(), time.Minute)
+
+	// Удержанный слот другой пациент не займёт
+	other, err := suite.server.HoldSlot(ctx, &doctorpb.HoldSlotRequest{
+		DoctorId:      suite.doctorID.String(),
+		SlotTime:      timestamppb.New(suite.start),
+		AppointmentId: uuid.NewString(),
+	})
+	suite.Require().NoError(err)
+	suite.False(other.Success)
+
+	slots, err := suite.server.GetAvailableSlots(ctx, &doctorpb.GetAvailableSlotsRequest{DoctorId: suite.doctorID.String()})
+	suite.Require().NoError(err)
+	suite.Equal("held", slots.Slots[0].Status)
+
+	confirm := &doctorpb.ConfirmSlotRequest{
+		DoctorId:         suite.doctorID.String(),
+		SlotTime:         timestamppb.New(suite.start),
+		AppointmentId:    appointmentID,
+		ReservationToken: held.ReservationToken,
+	}
+	confirmed, err := suite.server.ConfirmSlot(ctx, confirm)
+	suite.Require().NoError(err)
+	suite.True(confirmed.Success)
+	suite.Nil(suite.repo.slots[0].HeldUntil)
+
+	// Повторное подтверждение не ошибка
+	_, err = suite.server.ConfirmSlot(ctx, confirm)
+	suite.NoError(err)
+
+	slots, err = suite.server.GetAvailableSlots(ctx, &doctorpb.GetAvailableSlotsRequest{DoctorId: suite.doctorID.String()})
+	suite.Require().NoError(err)
+	suite.Equal("booked", slots.Slots[0].Status)
+}
+
+func (suite *DoctorGRPCServerTestSuite) TestOnlineSlotMeeting() {
+	ctx := context.Background()
+	suite.repo.slots[0].IsOnline = true
+	appointmentID := uuid.NewString()
+
+	reserved, err := suite.server.ReserveSlot(ctx, &doctorpb.ReserveSlotRequest{
+		DoctorId:      suite.doctorID.String(),
+		SlotTime:      timestamppb.New(suite.start),
+		AppointmentId: appointmentID,
+	})
+	suite.Require().NoError(err)
+	suite.Require().True(reserved.Success)
+
+	// При бронировании онлайн-слота создаётся комната
+	room := suite.repo.slots[0].MeetingRoom
+	suite.Require().NotEmpty(room)
+	suite.Contains(suite.meetings.Rooms, room)
+	suite.Equal("https://meet.test/"+room, suite.repo.slots[0].MeetingLink)
+
+	// Отмена записи отзывает комнату
+	released, err := suite.server.ReleaseSlot(ctx, &doctorpb.ReleaseSlotRequest{
+		DoctorId:         suite.doctorID.String(),
+		SlotTime:         timestamppb.New(suite.start),
+		AppointmentId:    appointmentID,
+		ReservationToken: reserved.ReservationToken,
+	})
+	suite.Require().NoError(err)
+	suite.True(released.Success)
+	suite.Equal([]string{room}, suite.meetings.Revoked)
+	suite.Empty(suite.repo.slots[0].MeetingLink)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MeetingHandler struct {
+	svc    service.MeetingService
+	access *Access
+}
+
+func NewMeetingHandler(s service.MeetingService, access *Access) *MeetingHandler {
+	return &MeetingHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты видеоконсультаций. Ссылку получают пациент и врач записи,
+// проверку ссылки вызывает сервис видеосвязи.
+func (h *MeetingHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/appointments/:appointmentId/meeting", h.GetMeeting)
+	r.GET("/meetings/:room/verify", h.VerifyJoin)
+}
+
+// GetMeeting — GET /appointments/:appointmentId/meeting
+func (h *MeetingHandler) GetMeeting(c *gin.Context) {
+	user, ok := h.access.identity(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("appointmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid appointment id"})
+		return
+	}
+
+	dto, err := h.svc.GetMeeting(c.Request.Context(), id, user.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMeetingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrMeetingForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrMeetingUnavailable):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// VerifyJoin — GET /meetings/:room/verify?exp=...&sig=...
+func (h *MeetingHandler) VerifyJoin(c *gin.Context) {
+	var req service.VerifyMeetingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.VerifyJoin(c.Request.Context(), c.Param("room"), req.Expires, req.Signature); err != nil {
+		if errors.Is(err, service.ErrMeetingRevoked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DoctorProfileHandler struct {
+	svc    service.DoctorProfileService
+	access *Access
+}
+
+func NewDoctorProfileHandler(s service.DoctorProfileService, access *Access) *DoctorProfileHandler {
+	return &DoctorProfileHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты документов и фото врача, менять их может сам врач или администратор
+func (h *DoctorProfileHandler) RegisterRoutes(r *gin.Engine) {
+	g := r.Group("/doctors/:id")
+	owner := h.access.RequireDoctor("id")
+	{
+		g.POST("/credentials", owner, h.AddCredential)
+		g.DELETE("/credentials/:credentialId", owner, h.DeleteCredential)
+		g.PUT("/photo", owner, h.UploadPhoto)
+		g.GET("/photo", h.GetPhoto)
+		g.DELETE("/photo", owner, h.DeletePhoto)
+	}
+}
+
+// AddCredential — POST /doctors/:id/credentials
+func (h *DoctorProfileHandler) AddCredential(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req service.CreateCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.AddCredential(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// DeleteCredential — DELETE /doctors/:id/credentials/:credentialId
+func (h *DoctorProfileHandler) DeleteCredential(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	credentialID, err := uuid.Parse(c.Param("credentialId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid credential id"})
+		return
+	}
+
+	if err := h.svc.DeleteCredential(c.Request.Context(), id, credentialID); err != nil {
+		if errors.Is(err, service.ErrCredentialNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UploadPhoto — PUT /doctors/:id/photo, тело запроса — сам файл JPEG, PNG или WebP
+func (h *DoctorProfileHandler) UploadPhoto(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.svc.UploadPhoto(c.Request.Context(), id, c.Request.Body); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrInvalidPhoto):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPhoto — GET /doctors/:id/photo
+func (h *DoctorProfileHandler) GetPhoto(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	photo, contentType, err := h.svc.OpenPhoto(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFound) || errors.Is(err, service.ErrPhotoNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	defer photo.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, photo, nil)
+}
+
+// DeletePhoto — DELETE /doctors/:id/photo
+func (h *DoctorProfileHandler) DeletePhoto(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.svc.DeletePhoto(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrNotFound) || errors.Is(err, service.ErrPhotoNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	svc    service.ReviewService
+	access *Access
+}
+
+func NewReviewHandler(s service.ReviewService, access *Access) *ReviewHandler {
+	return &ReviewHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты отзывов. Оставить отзыв может вошедший пациент,
+// модерирует администратор, одобренные отзывы видны всем.
+func (h *ReviewHandler) RegisterRoutes(r *gin.Engine) {
+	doctors := r.Group("/doctors/:id/reviews")
+	{
+		doctors.POST("", h.CreateReview)
+		doctors.GET("", h.ListDoctorReviews)
+	}
+	moderation := r.Group("/reviews", h.access.RequireAdmin())
+	{
+		moderation.GET("", h.ListReviews)
+		moderation.PUT("/:reviewId/status", h.ModerateReview)
+	}
+}
+
+// CreateReview — POST /doctors/:id/reviews
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	user, ok := h.access.identity(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req service.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.CreateReview(c.Request.Context(), id, user.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrReviewNotAllowed):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrReviewExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListDoctorReviews — GET /doctors/:id/reviews
+func (h *ReviewHandler) ListDoctorReviews(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	dtos, err := h.svc.ListDoctorReviews(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+// ListReviews — GET /reviews?status=PENDING
+func (h *ReviewHandler) ListReviews(c *gin.Context) {
+	var req service.ListReviewsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	status := model.ReviewPending
+	if req.Status != "" {
+		status = model.ReviewStatus(req.Status)
+	}
+
+	dtos, err := h.svc.ListReviews(c.Request.Context(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+// ModerateReview — PUT /reviews/:reviewId/status
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("reviewId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	var req service.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.ModerateReview(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, service.ErrReviewNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
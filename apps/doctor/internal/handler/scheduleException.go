@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleExceptionHandler struct {
+	svc    service.ScheduleExceptionService
+	access *Access
+}
+
+func NewScheduleExceptionHandler(s service.ScheduleExceptionService, access *Access) *ScheduleExceptionHandler {
+	return &ScheduleExceptionHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты отпусков, праздников и дополнительных часов
+func (h *ScheduleExceptionHandler) RegisterRoutes(r *gin.Engine) {
+	g := r.Group("/schedule-exceptions")
+	{
+		g.POST("", h.CreateException)
+		g.GET("", h.ListExceptions)
+		g.DELETE("/:id", h.DeleteException)
+	}
+}
+
+// CreateException — POST /schedule-exceptions. Врач заводит исключения себе,
+// праздники клиники и исключения других врачей — администратор.
+func (h *ScheduleExceptionHandler) CreateException(c *gin.Context) {
+	var req service.CreateScheduleExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !h.access.CanEditDoctor(c, req.DoctorID) {
+		return
+	}
+
+	resp, err := h.svc.CreateException(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidException) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListExceptions — GET /schedule-exceptions?doctor_id=&from=&to=
+func (h *ScheduleExceptionHandler) ListExceptions(c *gin.Context) {
+	var req service.ListScheduleExceptionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dtos, err := h.svc.ListExceptions(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidException) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+// DeleteException — DELETE /schedule-exceptions/:id
+func (h *ScheduleExceptionHandler) DeleteException(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	e, err := h.svc.GetException(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrExceptionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if !h.access.CanEditDoctor(c, e.DoctorID) {
+		return
+	}
+
+	if err := h.svc.DeleteException(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrExceptionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
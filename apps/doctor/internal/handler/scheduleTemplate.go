@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleTemplateHandler struct {
+	svc    service.ScheduleTemplateService
+	access *Access
+}
+
+func NewScheduleTemplateHandler(s service.ScheduleTemplateService, access *Access) *ScheduleTemplateHandler {
+	return &ScheduleTemplateHandler{svc: s, access: access}
+}
+
+// RegisterRoutes навешивает роуты шаблонов расписания врача, менять их может сам врач или администратор
+func (h *ScheduleTemplateHandler) RegisterRoutes(r *gin.Engine) {
+	g := r.Group("/doctors/:id/schedule-templates")
+	owner := h.access.RequireDoctor("id")
+	{
+		g.POST("", owner, h.CreateTemplate)
+		g.GET("", h.ListTemplates)
+		g.DELETE("/:templateId", owner, h.DeleteTemplate)
+		g.POST("/generate", owner, h.GenerateSlots)
+	}
+}
+
+// CreateTemplate — POST /doctors/:id/schedule-templates
+func (h *ScheduleTemplateHandler) CreateTemplate(c *gin.Context) {
+	doctorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req service.CreateScheduleTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dto, err := h.svc.CreateTemplate(c.Request.Context(), doctorID, req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTemplate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListTemplates — GET /doctors/:id/schedule-templates
+func (h *ScheduleTemplateHandler) ListTemplates(c *gin.Context) {
+	doctorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	dtos, err := h.svc.ListTemplates(c.Request.Context(), doctorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos)
+}
+
+// DeleteTemplate — DELETE /doctors/:id/schedule-templates/:templateId
+func (h *ScheduleTemplateHandler) DeleteTemplate(c *gin.Context) {
+	doctorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	templateID, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template id"})
+		return
+	}
+
+	if err := h.svc.DeleteTemplate(c.Request.Context(), doctorID, templateID); err != nil {
+		if errors.Is(err, service.ErrTemplateNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GenerateSlots — POST /doctors/:id/schedule-templates/generate
+func (h *ScheduleTemplateHandler) GenerateSlots(c *gin.Context) {
+	doctorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	resp, err := h.svc.GenerateSlots(c.Request.Context(), doctorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
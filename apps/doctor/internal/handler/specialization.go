@@ -1,75 +1,114 @@
 package handler
 
 import (
-	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
-	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+	"errors"
 	"net/http"
 
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/service"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type SpecializationHandler struct {
-	svc service.SpecializationService
+	svc    service.SpecializationService
+	access *Access
 }
 
-func NewSpecializationHandler(s service.SpecializationService) *SpecializationHandler {
-	return &SpecializationHandler{svc: s}
+func NewSpecializationHandler(s service.SpecializationService, access *Access) *SpecializationHandler {
+	return &SpecializationHandler{svc: s, access: access}
 }
 
+// RegisterRoutes навешивает роуты специализаций. Дерево и карточки открыты всем
+// на языке из Accept-Language, меняет специализации администратор.
 func (h *SpecializationHandler) RegisterRoutes(r *gin.Engine) {
 	g := r.Group("/specializations")
-	g.POST("", h.create)
+	g.GET("", h.list)
 	g.GET("/:id", h.getByID)
-	g.PUT("/:id", h.update)
-	g.DELETE("/:id", h.delete)
+	g.POST("", h.access.RequireAdmin(), h.create)
+	g.PUT("/:id", h.access.RequireAdmin(), h.update)
+	g.DELETE("/:id", h.access.RequireAdmin(), h.delete)
 }
 
 func (h *SpecializationHandler) create(c *gin.Context) {
-	var req model.Specialization
+	var req service.SpecializationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	out, err := h.svc.CreateSpecialization(req)
+	out, err := h.svc.CreateSpecialization(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		specializationError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, out)
 }
 
+func (h *SpecializationHandler) list(c *gin.Context) {
+	out, err := h.svc.ListSpecializations(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, out)
+}
+
 func (h *SpecializationHandler) getByID(c *gin.Context) {
-	id, _ := uuid.Parse(c.Param("id"))
-	out, err := h.svc.GetSpecializationByID(id)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	out, err := h.svc.GetSpecializationByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		specializationError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, out)
 }
 
 func (h *SpecializationHandler) update(c *gin.Context) {
-	id, _ := uuid.Parse(c.Param("id"))
-	var req model.Specialization
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var req service.SpecializationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	req.ID = id
-	out, err := h.svc.UpdateSpecialization(req)
+	out, err := h.svc.UpdateSpecialization(c.Request.Context(), id, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		specializationError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, out)
 }
 
 func (h *SpecializationHandler) delete(c *gin.Context) {
-	id, _ := uuid.Parse(c.Param("id"))
-	if err := h.svc.DeleteSpecialization(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	if err := h.svc.DeleteSpecialization(c.Request.Context(), id); err != nil {
+		specializationError(c, err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// Support function for the specialization and catalogue handlers.
+func specializationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrSpecNotFound), errors.Is(err, service.ErrServiceNotFound), errors.Is(err, service.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidSpecialization):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSpecInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,58 @@
+// Package locale выбирает язык ответа по заголовку Accept-Language.
+package locale
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// Default — язык, на котором тексты хранятся в основных таблицах
+var Default = language.Russian
+
+type contextKey struct{}
+
+// Middleware кладёт в контекст запроса языки из Accept-Language в порядке предпочтения клиента.
+// Ответ зависит от языка, поэтому Vary называет Accept-Language для кэшей перед сервисом.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		if header := c.GetHeader("Accept-Language"); header != "" {
+			if tags, _, err := language.ParseAcceptLanguage(header); err == nil && len(tags) > 0 {
+				c.Request = c.Request.WithContext(WithLanguages(c.Request.Context(), tags))
+			}
+		}
+		c.Next()
+	}
+}
+
+// WithLanguages возвращает контекст с предпочитаемыми языками клиента
+func WithLanguages(ctx context.Context, tags []language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, tags)
+}
+
+// Languages — предпочитаемые языки клиента, пусто — клиент язык не указал
+func Languages(ctx context.Context) []language.Tag {
+	tags, _ := ctx.Value(contextKey{}).([]language.Tag)
+	return tags
+}
+
+// Match выбирает перевод для клиента из available (теги BCP 47) и возвращает его индекс.
+// -1 — клиенту лучше подходит язык по умолчанию или ни один перевод не подходит.
+func Match(prefs []language.Tag, available []string) int {
+	if len(prefs) == 0 || len(available) == 0 {
+		return -1
+	}
+	// Язык по умолчанию первый: при равном совпадении и без совпадений выбирается он
+	supported := make([]language.Tag, 0, len(available)+1)
+	supported = append(supported, Default)
+	for _, code := range available {
+		supported = append(supported, language.Make(code))
+	}
+	_, i, confidence := language.NewMatcher(supported).Match(prefs...)
+	if confidence == language.No || i == 0 {
+		return -1
+	}
+	return i - 1
+}
@@ -0,0 +1,63 @@
+package locale
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/text/language"
+)
+
+type LocaleTestSuite struct {
+	suite.Suite
+}
+
+func TestLocale(t *testing.T) {
+	suite.Run(t, new(LocaleTestSuite))
+}
+
+func (suite *LocaleTestSuite) TestMatch() {
+	available := []string{"en", "kk"}
+	cases := map[string]struct {
+		header string
+		want   int
+	}{
+		"no preference":          {"", -1},
+		"exact":                  {"kk", 1},
+		"region falls back":      {"en-US,en;q=0.9", 0},
+		"default is preferred":   {"ru-RU,en;q=0.5", -1},
+		"second choice":          {"de,kk;q=0.8", 1},
+		"no translation matches": {"de", -1},
+	}
+	for name, tc := range cases {
+		prefs, _, err := language.ParseAcceptLanguage(tc.header)
+		suite.Require().NoError(err, name)
+		suite.Equal(tc.want, Match(prefs, available), name)
+	}
+	suite.Equal(-1, Match([]language.Tag{language.English}, nil))
+}
+
+func (suite *LocaleTestSuite) TestMiddleware() {
+	gin.SetMode(gin.TestMode)
+	var got []language.Tag
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/", func(c *gin.Context) {
+		got = Languages(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "kk;q=0.5, en")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	suite.Equal([]language.Tag{language.English, language.Kazakh}, got)
+	suite.Equal("Accept-Language", w.Header().Get("Vary"))
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	suite.Empty(got)
+	suite.Empty(Languages(context.Background()))
+}
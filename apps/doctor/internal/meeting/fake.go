@@ -0,0 +1,38 @@
+package meeting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Fake — провайдер для тестов: запоминает созданные и отозванные комнаты, видеосвязи не создаёт
+type Fake struct {
+	mu      sync.Mutex
+	Err     error // если задана, Create возвращает её
+	Rooms   map[string]Request
+	Revoked []string
+}
+
+func NewFake() *Fake {
+	return &Fake{Rooms: map[string]Request{}}
+}
+
+func (f *Fake) Create(ctx context.Context, req Request) (*Meeting, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	room := uuid.NewString()
+	f.Rooms[room] = req
+	return &Meeting{Room: room, URL: "https://meet.test/" + room, ExpiresAt: req.EndsAt}, nil
+}
+
+func (f *Fake) Revoke(ctx context.Context, room string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Revoked = append(f.Revoked, room)
+	return nil
+}
@@ -0,0 +1,39 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidLink возвращается, если ссылка на комнату подделана или истекла
+var ErrInvalidLink = errors.New("invalid or expired meeting link")
+
+// Request — приём, под который создаётся комната видеосвязи
+type Request struct {
+	AppointmentID uuid.UUID
+	StartsAt      time.Time
+	EndsAt        time.Time
+}
+
+// Meeting — комната видеоконсультации и ссылка для входа в неё
+type Meeting struct {
+	Room      string
+	URL       string
+	ExpiresAt time.Time
+}
+
+// Provider создаёт и отзывает комнаты видеосвязи. Реализации подключаются в main по конфигурации.
+type Provider interface {
+	Create(ctx context.Context, req Request) (*Meeting, error)
+	// Revoke закрывает комнату, повторный отзыв не ошибка
+	Revoke(ctx context.Context, room string) error
+}
+
+// Verifier проверяет ссылку, с которой участник входит в комнату. Реализуют провайдеры,
+// чьи ссылки проверяет сам сервис врачей, а не внешний сервис видеосвязи.
+type Verifier interface {
+	Verify(room, expires, signature string) error
+}
@@ -0,0 +1,77 @@
+package meeting
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SignedProvider выдаёт ссылки на комнаты своего сервиса видеосвязи. Ссылка подписана HMAC
+// и действует до конца приёма плюс grace. Сервис видеосвязи перед входом проверяет ссылку
+// в сервисе врачей, поэтому отозванная комната закрывается сразу, а не по истечении ссылки.
+type SignedProvider struct {
+	baseURL *url.URL
+	secret  []byte
+	grace   time.Duration
+	now     func() time.Time
+}
+
+// NewSignedProvider конструктор, baseURL — адрес сервиса видеосвязи, комната добавляется к его пути
+func NewSignedProvider(baseURL string, secret []byte, grace time.Duration) (*SignedProvider, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedProvider{baseURL: u, secret: secret, grace: grace, now: time.Now}, nil
+}
+
+func (p *SignedProvider) Create(ctx context.Context, req Request) (*Meeting, error) {
+	// Имя комнаты случайное, по нему нельзя угадать чужой приём
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	room := hex.EncodeToString(b)
+	expiresAt := req.EndsAt.Add(p.grace).UTC().Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	u := p.baseURL.JoinPath(room)
+	q := u.Query()
+	q.Set("exp", expires)
+	q.Set("sig", p.sign(room, expires))
+	u.RawQuery = q.Encode()
+
+	return &Meeting{Room: room, URL: u.String(), ExpiresAt: expiresAt}, nil
+}
+
+// Revoke ничего не делает: комната закрывается тем, что сервис врачей её забывает
+// и Verify перестаёт её пропускать.
+func (p *SignedProvider) Revoke(ctx context.Context, room string) error {
+	return nil
+}
+
+// Verify проверяет подпись и срок ссылки, параметры берутся из её query: exp и sig
+func (p *SignedProvider) Verify(room, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidLink
+	}
+	if !hmac.Equal([]byte(signature), []byte(p.sign(room, expires))) {
+		return ErrInvalidLink
+	}
+	if !p.now().Before(time.Unix(unix, 0)) {
+		return ErrInvalidLink
+	}
+	return nil
+}
+
+func (p *SignedProvider) sign(room, expires string) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(room + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
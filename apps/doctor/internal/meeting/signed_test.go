@@ -0,0 +1,67 @@
+package meeting
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type SignedProviderTestSuite struct {
+	suite.Suite
+	provider *SignedProvider
+	now      time.Time
+	req      Request
+}
+
+func TestSignedProvider(t *testing.T) {
+	suite.Run(t, new(SignedProviderTestSuite))
+}
+
+func (suite *SignedProviderTestSuite) SetupTest() {
+	var err error
+	suite.provider, err = NewSignedProvider("https://meet.clinic.local/rooms/", []byte("0123456789abcdef0123456789abcdef"), 30*time.Minute)
+	suite.Require().NoError(err)
+	suite.now = time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	suite.provider.now = func() time.Time { return suite.now }
+	suite.req = Request{AppointmentID: uuid.New(), StartsAt: suite.now.Add(time.Hour), EndsAt: suite.now.Add(90 * time.Minute)}
+}
+
+// Support function for splitting a meeting link into the Verify arguments.
+func (suite *SignedProviderTestSuite) parse(link string) (string, string, string) {
+	u, err := url.Parse(link)
+	suite.Require().NoError(err)
+	return path.Base(u.Path), u.Query().Get("exp"), u.Query().Get("sig")
+}
+
+func (suite *SignedProviderTestSuite) TestCreate() {
+	m, err := suite.provider.Create(context.Background(), suite.req)
+	suite.Require().NoError(err)
+	suite.Len(m.Room, 32)
+	suite.True(strings.HasPrefix(m.URL, "https://meet.clinic.local/rooms/"+m.Room+"?"))
+	suite.Equal(suite.req.EndsAt.Add(30*time.Minute), m.ExpiresAt)
+
+	other, err := suite.provider.Create(context.Background(), suite.req)
+	suite.Require().NoError(err)
+	suite.NotEqual(m.Room, other.Room)
+}
+
+func (suite *SignedProviderTestSuite) TestVerify() {
+	m, err := suite.provider.Create(context.Background(), suite.req)
+	suite.Require().NoError(err)
+	room, exp, sig := suite.parse(m.URL)
+	suite.NoError(suite.provider.Verify(room, exp, sig))
+
+	suite.ErrorIs(suite.provider.Verify("another-room", exp, sig), ErrInvalidLink)
+	suite.ErrorIs(suite.provider.Verify(room, exp+"0", sig), ErrInvalidLink)
+	suite.ErrorIs(suite.provider.Verify(room, exp, strings.Repeat("0", len(sig))), ErrInvalidLink)
+
+	// Ссылка перестаёт действовать через grace после конца приёма
+	suite.now = m.ExpiresAt
+	suite.ErrorIs(suite.provider.Verify(room, exp, sig), ErrInvalidLink)
+}
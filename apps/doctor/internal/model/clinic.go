@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Clinic — филиал клиники. Часы шаблонов расписания в филиале идут по его часовому поясу.
+type Clinic struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name      string    `gorm:"not null"`
+	Address   string    `gorm:"default:null"`
+	TimeZone  string    `gorm:"type:varchar(64);not null"` // IANA, например "Asia/Aqtobe"
+	Rooms     []Room    `gorm:"foreignKey:ClinicID"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// Room — кабинет филиала. Capacity — сколько приёмов кабинет вмещает одновременно.
+type Room struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ClinicID uuid.UUID `gorm:"type:uuid;not null"`
+	Name     string    `gorm:"not null"`
+	Capacity int       `gorm:"not null;default:1"`
+}
+
+// DoctorClinic — врач принимает в филиале
+type DoctorClinic struct {
+	DoctorID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ClinicID uuid.UUID `gorm:"type:uuid;primaryKey"`
+}
+
+// Location — часовой пояс филиала, пояс проверяется при создании
+func (c Clinic) Location() *time.Location {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CredentialKind string
+
+const (
+	License     CredentialKind = "LICENSE"
+	Certificate CredentialKind = "CERTIFICATE"
+	Diploma     CredentialKind = "DIPLOMA"
+)
+
+// DoctorCredential — лицензия, сертификат или диплом врача
+type DoctorCredential struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	DoctorID  uuid.UUID      `gorm:"type:uuid;not null"`
+	Kind      CredentialKind `gorm:"type:varchar(20);not null"`
+	Number    string         `gorm:"not null"`
+	Issuer    string         `gorm:"not null"`
+	IssuedAt  time.Time      `gorm:"type:date;not null"`
+	ExpiresAt *time.Time     `gorm:"type:date;default:null"` // nil — бессрочный
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+}
+
+// Expired — истёк ли документ к моменту now. Документ действует по ExpiresAt включительно.
+func (c DoctorCredential) Expired(now time.Time) bool {
+	if c.ExpiresAt == nil {
+		return false
+	}
+	return !now.Before(c.ExpiresAt.AddDate(0, 0, 1))
+}
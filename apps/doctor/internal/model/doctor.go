@@ -14,15 +14,52 @@ const (
 )
 
 type Doctor struct {
-	ID               uuid.UUID      `gorm:"type:uuid;primaryKey"`
-	FirstName        string         `gorm:"not null"`
-	LastName         string         `gorm:"not null"`
-	Patronymic       string         `gorm:"default:null"` // опционально
-	DateOfBirth      time.Time      `gorm:"type:date"`
-	Specialization   Specialization `gorm:"foreignKey:SpecializationID"`
-	SpecializationID uuid.UUID      `gorm:"type:uuid"`
-	Status           DoctorStatus   `gorm:"type:varchar(20);default:'ACTIVE'"`
-	ScheduleSlots    []ScheduleSlot `gorm:"foreignKey:DoctorID"`
-	CreatedAt        time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt        time.Time      `gorm:"autoUpdateTime"`
+	ID              uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	UserID          *int64             `gorm:"uniqueIndex;default:null"` // пользователь auth, под которым врач входит; nil — профиль не привязан
+	ExternalID      *string            `gorm:"uniqueIndex;default:null"` // ключ врача во внешней системе, по нему идёт импорт
+	FirstName       string             `gorm:"not null"`
+	LastName        string             `gorm:"not null"`
+	Patronymic      string             `gorm:"default:null"` // опционально
+	DateOfBirth     time.Time          `gorm:"type:date"`
+	Specializations []Specialization   `gorm:"many2many:doctor_specializations"`
+	Status          DoctorStatus       `gorm:"type:varchar(20);default:'ACTIVE'"`
+	TimeZone        string             `gorm:"type:varchar(64);default:null"` // IANA, например "Asia/Almaty"; пусто — пояс клиники
+	Email           string             `gorm:"default:null"`
+	Phone           string             `gorm:"type:varchar(32);default:null"` // E.164
+	Bio             string             `gorm:"type:text;default:null"`
+	ExperienceYears int                `gorm:"not null;default:0"`
+	Languages       []DoctorLanguage   `gorm:"foreignKey:DoctorID"`
+	Credentials     []DoctorCredential `gorm:"foreignKey:DoctorID"`
+	PhotoKey        string             `gorm:"default:null"`       // ключ фото в хранилище файлов, пусто — фото нет
+	RatingAvg       float64            `gorm:"not null;default:0"` // средняя оценка одобренных отзывов, 0 — отзывов нет
+	RatingCount     int                `gorm:"not null;default:0"`
+	ScheduleSlots   []ScheduleSlot     `gorm:"foreignKey:DoctorID"`
+	Clinics         []Clinic           `gorm:"many2many:doctor_clinics"`
+	CreatedAt       time.Time          `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time          `gorm:"autoUpdateTime"`
+}
+
+// DoctorSpecialization — связь врача со специализацией, у врача их может быть несколько
+type DoctorSpecialization struct {
+	DoctorID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	SpecializationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+}
+
+// DoctorLanguage — язык, на котором врач ведёт приём
+type DoctorLanguage struct {
+	DoctorID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Code     string    `gorm:"type:varchar(35);primaryKey"` // тег BCP 47, например "ru" или "kk"
+}
+
+// Location — часовой пояс, в котором врач принимает. Без своего пояса
+// или с неизвестным поясом врач работает по часам клиники.
+func (d Doctor) Location(clinic *time.Location) *time.Location {
+	if d.TimeZone == "" {
+		return clinic
+	}
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		return clinic
+	}
+	return loc
 }
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// MedicalService — услуга каталога, на которую записываются к врачу со специализацией SpecializationID
+type MedicalService struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey"`
+	SpecializationID uuid.UUID `gorm:"type:uuid;not null"`
+	Name             string    `gorm:"not null"` // например, "Первичная консультация"
+	Description      string    `gorm:"type:text;default:null"`
+	DurationMinutes  int       `gorm:"not null"`                               // длина слота при записи на услугу
+	Price            int64     `gorm:"not null;default:0"`                     // в минимальных единицах валюты, для тенге — тиынах
+	Currency         string    `gorm:"type:varchar(3);not null;default:'KZT'"` // ISO 4217
+	IsActive         bool      `gorm:"not null;default:true"`                  // снятая услуга не предлагается пациентам
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
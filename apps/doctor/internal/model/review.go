@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "PENDING"
+	ReviewApproved ReviewStatus = "APPROVED"
+	ReviewRejected ReviewStatus = "REJECTED"
+)
+
+// Review — отзыв пациента о приёме у врача. В карточке и рейтинге врача
+// видны только одобренные модератором отзывы.
+type Review struct {
+	ID            uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	DoctorID      uuid.UUID    `gorm:"type:uuid;not null"`
+	AppointmentID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex"` // один отзыв на приём
+	UserID        int64        `gorm:"not null"`                       // пользователь auth, оставивший отзыв
+	Rating        int          `gorm:"not null"`                       // от 1 до 5
+	Comment       string       `gorm:"type:text;default:null"`
+	Status        ReviewStatus `gorm:"type:varchar(20);not null;default:'PENDING'"`
+	ModeratedAt   *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ExceptionKind string
+
+const (
+	Vacation   ExceptionKind = "VACATION"
+	SickLeave  ExceptionKind = "SICK_LEAVE"
+	Holiday    ExceptionKind = "HOLIDAY"     // праздник всей клиники, без врача
+	ExtraHours ExceptionKind = "EXTRA_HOURS" // разовые дополнительные часы приёма
+)
+
+// ScheduleException — отклонение от шаблонов расписания: отпуск, больничный,
+// праздник или разовые дополнительные часы. Интервал [StartsAt, EndsAt) в UTC.
+type ScheduleException struct {
+	ID          uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	DoctorID    *uuid.UUID    `gorm:"type:uuid;default:null"` // nil — вся клиника
+	Kind        ExceptionKind `gorm:"type:varchar(20);not null"`
+	StartsAt    time.Time     `gorm:"not null"`
+	EndsAt      time.Time     `gorm:"not null"`
+	Reason      string        `gorm:"default:null"`
+	SlotMinutes int           `gorm:"default:null"` // только для EXTRA_HOURS
+	CreatedAt   time.Time     `gorm:"autoCreateTime"`
+}
+
+// Blocks — закрывает ли исключение время приёма
+func (e ScheduleException) Blocks() bool {
+	return e.Kind != ExtraHours
+}
+
+// Covers — задевает ли исключение слот врача
+func (e ScheduleException) Covers(slot ScheduleSlot) bool {
+	if e.DoctorID != nil && *e.DoctorID != slot.DoctorID {
+		return false
+	}
+	return slot.StartsAt.Before(e.EndsAt) && e.StartsAt.Before(slot.EndsAt)
+}
+
+// ExtraSlots нарезает дополнительные часы на слоты, неполный хвост отбрасывается
+func (e ScheduleException) ExtraSlots() []ScheduleSlot {
+	if e.Kind != ExtraHours || e.DoctorID == nil || e.SlotMinutes <= 0 {
+		return nil
+	}
+	step := time.Duration(e.SlotMinutes) * time.Minute
+	exceptionID := e.ID
+
+	var slots []ScheduleSlot
+	for start := e.StartsAt.UTC(); !start.Add(step).After(e.EndsAt); start = start.Add(step) {
+		slots = append(slots, ScheduleSlot{
+			ID:          uuid.New(),
+			DoctorID:    *e.DoctorID,
+			StartsAt:    start,
+			EndsAt:      start.Add(step),
+			IsAvailable: true,
+			ExceptionID: &exceptionID,
+		})
+	}
+	return slots
+}
@@ -5,13 +5,30 @@ import (
 	"time"
 )
 
+// ScheduleSlot — слот приёма врача. Интервал [StartsAt, EndsAt) хранится моментами в UTC,
+// местное время считается по часовому поясу врача.
 type ScheduleSlot struct {
 	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
 	DoctorID      uuid.UUID  `gorm:"type:uuid;not null"`
-	Date          time.Time  `gorm:"type:date;not null"`
-	StartTime     time.Time  `gorm:"type:time;not null"` // например, "09:00:00"
-	EndTime       time.Time  `gorm:"type:time;not null"` // например, "09:30:00"
+	ClinicID      *uuid.UUID `gorm:"type:uuid;default:null"` // nil — онлайн-приём или слот без филиала
+	RoomID        *uuid.UUID `gorm:"type:uuid;default:null"` // кабинет филиала, его вместимость проверяется при бронировании
+	StartsAt      time.Time  `gorm:"not null"`
+	EndsAt        time.Time  `gorm:"not null"`
 	IsAvailable   bool       `gorm:"default:true"`
 	AppointmentID *uuid.UUID `gorm:"type:uuid;default:null"` // ссылка на внешний сервис (nullable)
-	MeetingLink   string     `gorm:"default:null"`           // для онлайн-консультаций
+	IsOnline      bool       `gorm:"not null;default:false"` // видеоконсультация, при бронировании создаётся комната
+	MeetingLink   string     `gorm:"default:null"`           // ссылка на комнату видеосвязи, показывается только участникам записи
+	MeetingRoom   string     `gorm:"default:null"`           // комната провайдера видеосвязи, по ней ссылка отзывается
+	ServiceID     *uuid.UUID `gorm:"type:uuid;default:null"` // услуга каталога, под длительность которой нарезан слот
+	TemplateID    *uuid.UUID `gorm:"type:uuid;default:null"` // шаблон, из которого слот сгенерирован
+	ExceptionID   *uuid.UUID `gorm:"type:uuid;default:null"` // дополнительные часы, из которых слот сгенерирован
+	BlockedBy     *uuid.UUID `gorm:"type:uuid;default:null"` // отпуск или праздник, закрывший свободный слот
+
+	ReservationToken *uuid.UUID `gorm:"type:uuid;default:null"` // выдаётся при бронировании, нужен для освобождения
+	HeldUntil        *time.Time `gorm:"default:null"`           // временное удержание до подтверждения записи
+}
+
+// Held — слот удержан под незавершённую запись и удержание ещё не истекло
+func (s ScheduleSlot) Held(now time.Time) bool {
+	return s.HeldUntil != nil && now.Before(*s.HeldUntil)
 }
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ScheduleTemplate — рабочие часы врача в один день недели, по ним генерируются слоты
+type ScheduleTemplate struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	DoctorID    uuid.UUID       `gorm:"type:uuid;not null"`
+	ExternalID  *string         `gorm:"uniqueIndex;default:null"` // ключ во внешней системе, по нему идёт импорт
+	ClinicID    *uuid.UUID      `gorm:"type:uuid;default:null"`   // филиал, по часам которого идёт шаблон
+	RoomID      *uuid.UUID      `gorm:"type:uuid;default:null"`   // кабинет филиала
+	IsOnline    bool            `gorm:"not null;default:false"`   // приём по видеосвязи, без кабинета
+	ServiceID   *uuid.UUID      `gorm:"type:uuid;default:null"`   // услуга каталога, её длительность задаёт SlotMinutes
+	Weekday     time.Weekday    `gorm:"not null"`                 // 0 — воскресенье
+	StartTime   TimeOfDay       `gorm:"type:time;not null"`
+	EndTime     TimeOfDay       `gorm:"type:time;not null"`
+	SlotMinutes int             `gorm:"not null"`
+	Breaks      []ScheduleBreak `gorm:"foreignKey:TemplateID"`
+	ValidFrom   time.Time       `gorm:"type:date;not null"`
+	ValidTo     *time.Time      `gorm:"type:date;default:null"` // nil — бессрочно
+	CreatedAt   time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime"`
+}
+
+// ScheduleBreak — перерыв внутри рабочих часов шаблона
+type ScheduleBreak struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TemplateID uuid.UUID `gorm:"type:uuid;not null"`
+	StartTime  TimeOfDay `gorm:"type:time;not null"`
+	EndTime    TimeOfDay `gorm:"type:time;not null"`
+}
+
+// ValidOn — действует ли шаблон в эту дату
+func (t ScheduleTemplate) ValidOn(date time.Time) bool {
+	day := date.Format(time.DateOnly)
+	if day < t.ValidFrom.Format(time.DateOnly) {
+		return false
+	}
+	return t.ValidTo == nil || day <= t.ValidTo.Format(time.DateOnly)
+}
+
+// SlotsOn нарезает рабочие часы на слоты в указанную дату по часам зоны loc, пропуская перерывы.
+// Слоты, не помещающиеся целиком до конца рабочих часов, отбрасываются. В день перехода на
+// летнее время слоты, начинающиеся в пропущенный час, не создаются, а повторяющийся при
+// переходе назад час даёт слоты один раз.
+func (t ScheduleTemplate) SlotsOn(date time.Time, loc *time.Location) []ScheduleSlot {
+	if date.Weekday() != t.Weekday || !t.ValidOn(date) || t.SlotMinutes <= 0 {
+		return nil
+	}
+	step := TimeOfDay(time.Duration(t.SlotMinutes) * time.Minute)
+	templateID := t.ID
+
+	var slots []ScheduleSlot
+	for start := t.StartTime; start+step <= t.EndTime; {
+		end := start + step
+		if b, ok := t.breakOverlapping(start, end); ok {
+			// Следующий слот начинается сразу после перерыва
+			start = b.EndTime
+			continue
+		}
+		startsAt, ok := start.In(date, loc)
+		if ok {
+			slots = append(slots, ScheduleSlot{
+				DoctorID:    t.DoctorID,
+				ClinicID:    t.ClinicID,
+				RoomID:      t.RoomID,
+				IsOnline:    t.IsOnline,
+				ServiceID:   t.ServiceID,
+				StartsAt:    startsAt.UTC(),
+				EndsAt:      startsAt.Add(time.Duration(step)).UTC(),
+				IsAvailable: true,
+				TemplateID:  &templateID,
+			})
+		}
+		start = end
+	}
+	return slots
+}
+
+func (t ScheduleTemplate) breakOverlapping(start, end TimeOfDay) (ScheduleBreak, bool) {
+	for _, b := range t.Breaks {
+		if start < b.EndTime && b.StartTime < end {
+			return b, true
+		}
+	}
+	return ScheduleBreak{}, false
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScheduleTemplateTestSuite struct {
+	suite.Suite
+	template ScheduleTemplate
+	monday   time.Time
+}
+
+func TestScheduleTemplate(t *testing.T) {
+	suite.Run(t, new(ScheduleTemplateTestSuite))
+}
+
+func (suite *ScheduleTemplateTestSuite) SetupTest() {
+	suite.monday = time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	validTo := suite.monday.AddDate(0, 0, 7)
+	suite.template = ScheduleTemplate{
+		ID:          uuid.New(),
+		DoctorID:    uuid.New(),
+		Weekday:     time.Monday,
+		StartTime:   Clock(9, 0),
+		EndTime:     Clock(12, 10),
+		SlotMinutes: 30,
+		Breaks:      []ScheduleBreak{{StartTime: Clock(10, 15), EndTime: Clock(10, 45)}},
+		ValidFrom:   suite.monday,
+		ValidTo:     &validTo,
+	}
+}
+
+func (suite *ScheduleTemplateTestSuite) TestSlotsOn() {
+	slots := suite.template.SlotsOn(suite.monday, time.UTC)
+
+	var starts []string
+	for _, slot := range slots {
+		starts = append(starts, slot.StartsAt.Format("15:04")+"-"+slot.EndsAt.Format("15:04"))
+		suite.Equal(suite.template.ID, *slot.TemplateID)
+		suite.True(slot.IsAvailable)
+	}
+	// 10:00-10:30 задевает перерыв, после него слоты идут от 10:45, хвост 11:45-12:10 короче слота
+	suite.Equal([]string{"09:00-09:30", "09:30-10:00", "10:45-11:15", "11:15-11:45"}, starts)
+}
+
+func (suite *ScheduleTemplateTestSuite) TestSlotsOn_OtherDays() {
+	suite.Empty(suite.template.SlotsOn(suite.monday.AddDate(0, 0, 1), time.UTC), "wrong weekday")
+	suite.Empty(suite.template.SlotsOn(suite.monday.AddDate(0, 0, -7), time.UTC), "before valid_from")
+	suite.Empty(suite.template.SlotsOn(suite.monday.AddDate(0, 0, 14), time.UTC), "after valid_to")
+	suite.NotEmpty(suite.template.SlotsOn(suite.monday.AddDate(0, 0, 7), time.UTC), "valid_to is inclusive")
+}
+
+func (suite *ScheduleTemplateTestSuite) TestSlotsOn_TimeZone() {
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	suite.Require().NoError(err)
+
+	slots := suite.template.SlotsOn(suite.monday, almaty)
+	suite.Require().NotEmpty(slots)
+	// 09:00 в Алматы (UTC+5) — 04:00 UTC
+	suite.Equal(time.Date(2025, 6, 9, 4, 0, 0, 0, time.UTC), slots[0].StartsAt)
+	suite.Equal(time.UTC, slots[0].StartsAt.Location())
+}
+
+func (suite *ScheduleTemplateTestSuite) TestSlotsOn_DaylightSaving() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	suite.Require().NoError(err)
+	night := ScheduleTemplate{
+		ID:          uuid.New(),
+		Weekday:     time.Sunday,
+		StartTime:   Clock(1, 0),
+		EndTime:     Clock(4, 0),
+		SlotMinutes: 30,
+		ValidFrom:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// 30 марта в 02:00 часы переводят на 03:00: слотов 02:00 и 02:30 нет,
+	// слот 01:30 длится полчаса и кончается в 03:00 по новому времени
+	spring := night.SlotsOn(time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC), berlin)
+	var starts []string
+	for _, slot := range spring {
+		starts = append(starts, slot.StartsAt.In(berlin).Format("15:04"))
+		suite.Equal(30*time.Minute, slot.EndsAt.Sub(slot.StartsAt))
+	}
+	suite.Equal([]string{"01:00", "01:30", "03:00", "03:30"}, starts)
+
+	// 26 октября час с 02:00 до 03:00 повторяется, слоты по-прежнему не пересекаются
+	autumn := night.SlotsOn(time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC), berlin)
+	suite.Len(autumn, 6)
+	for i := 1; i < len(autumn); i++ {
+		suite.False(autumn[i].StartsAt.Before(autumn[i-1].EndsAt), "slot %d overlaps the previous one", i)
+	}
+}
+
+func (suite *ScheduleTemplateTestSuite) TestTimeOfDay() {
+	t, err := ParseTimeOfDay("09:05")
+	suite.NoError(err)
+	suite.Equal(Clock(9, 5), t)
+
+	var scanned TimeOfDay
+	suite.NoError(scanned.Scan("17:30:00.000000"))
+	suite.Equal(Clock(17, 30), scanned)
+	value, err := scanned.Value()
+	suite.NoError(err)
+	suite.Equal("17:30:00", value)
+
+	_, err = ParseTimeOfDay("25:00")
+	suite.Error(err)
+}
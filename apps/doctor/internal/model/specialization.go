@@ -2,8 +2,21 @@ package model
 
 import "github.com/google/uuid"
 
+// Specialization — узел дерева специализаций, например "Хирург" → "Кардиохирург".
+// Name и Description заданы на языке клиники по умолчанию, остальные языки — в Translations.
 type Specialization struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Name        string    `gorm:"unique;not null"` // например, "Кардиолог"
-	Description string    `gorm:"default:null"`
+	ID           uuid.UUID                   `gorm:"type:uuid;primaryKey"`
+	ParentID     *uuid.UUID                  `gorm:"type:uuid;default:null"`   // nil — корень дерева
+	ExternalID   *string                     `gorm:"uniqueIndex;default:null"` // ключ во внешней системе, по нему идёт импорт
+	Name         string                      `gorm:"unique;not null"`          // например, "Кардиолог"
+	Description  string                      `gorm:"default:null"`
+	Translations []SpecializationTranslation `gorm:"foreignKey:SpecializationID"`
+}
+
+// SpecializationTranslation — название и описание специализации на другом языке
+type SpecializationTranslation struct {
+	SpecializationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Language         string    `gorm:"type:varchar(35);primaryKey"` // тег BCP 47, например "kk" или "en"
+	Name             string    `gorm:"not null"`
+	Description      string    `gorm:"default:null"`
 }
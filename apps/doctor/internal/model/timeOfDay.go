@@ -0,0 +1,97 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimeOfDay — время суток без даты, хранится в колонках TIME.
+// Драйвер отдаёт TIME строкой, поэтому time.Time для таких колонок не сканируется.
+type TimeOfDay time.Duration
+
+// ParseTimeOfDay разбирает "15:04" или "15:04:05"
+func ParseTimeOfDay(value string) (TimeOfDay, error) {
+	for _, layout := range []string{"15:04", time.TimeOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Clock(t.Hour(), t.Minute()) + TimeOfDay(time.Duration(t.Second())*time.Second), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time of day %q, want HH:MM", value)
+}
+
+// Clock — время суток из часов и минут
+func Clock(hour, minute int) TimeOfDay {
+	return TimeOfDay(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+// In — момент этого времени по часам зоны loc в указанную дату. ok == false, если таких
+// часов в эту дату нет: при переходе на летнее время стрелки переводят вперёд.
+// Время, которое при переходе назад повторяется, даёт один из двух моментов.
+func (t TimeOfDay) In(date time.Time, loc *time.Location) (at time.Time, ok bool) {
+	d := time.Duration(t)
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	at = time.Date(date.Year(), date.Month(), date.Day(), h, m, s, 0, loc)
+	// Несуществующее время time.Date сдвигает на час, часы при этом не совпадают
+	return at, at.Hour() == h && at.Minute() == m
+}
+
+// String форматирует как "15:04", секунды добавляются, только если они есть
+func (t TimeOfDay) String() string {
+	d := time.Duration(t)
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	if s != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+func (t TimeOfDay) Value() (driver.Value, error) {
+	d := time.Duration(t)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)), nil
+}
+
+func (t *TimeOfDay) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	case time.Time:
+		*t = Clock(v.Hour(), v.Minute()) + TimeOfDay(time.Duration(v.Second())*time.Second)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into TimeOfDay", src)
+	}
+}
+
+func (t *TimeOfDay) parse(value string) error {
+	// Postgres может добавить доли секунды
+	if len(value) > len(time.TimeOnly) {
+		value = value[:len(time.TimeOnly)]
+	}
+	parsed, err := ParseTimeOfDay(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := ParseTimeOfDay(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
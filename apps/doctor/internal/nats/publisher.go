@@ -0,0 +1,56 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// TimeOffCreatedSubject — тема событий о закрытом времени приёма, слушает сервис записей
+const TimeOffCreatedSubject = "doctor.timeoff.created"
+
+type Publisher interface {
+	PublishTimeOffCreated(ctx context.Context, event *doctorpb.TimeOffCreated) error
+}
+
+type NatsPublisher struct {
+	nc *nats.Conn
+}
+
+func NewPublisher(nc *nats.Conn) Publisher {
+	return &NatsPublisher{nc: nc}
+}
+
+func (p *NatsPublisher) PublishTimeOffCreated(ctx context.Context, event *doctorpb.TimeOffCreated) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal time-off event: %w", err)
+	}
+	return p.publish(ctx, TimeOffCreatedSubject, data)
+}
+
+func (p *NatsPublisher) publish(ctx context.Context, subject string, data []byte) error {
+	if p.nc == nil {
+		return fmt.Errorf("NATS connection is nil")
+	}
+
+	ctx, span := tracing.Tracer("doctor/nats").Start(ctx, subject+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	tracing.InjectNats(ctx, msg)
+	logging.InjectRequestIDNats(ctx, msg)
+
+	err := p.nc.PublishMsg(msg)
+	metrics.NatsPublished(subject, err)
+	return err
+}
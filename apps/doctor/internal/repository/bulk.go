@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BulkRepository — доступ к хранилищу для массового импорта и выгрузки
+type BulkRepository interface {
+	// InTransaction выполняет импорт в одной транзакции: ошибка fn откатывает всё
+	InTransaction(fn func(tx BulkStore) error) error
+	// ListSpecializations возвращает все специализации с переводами
+	ListSpecializations() ([]model.Specialization, error)
+	// DoctorKeys возвращает ключи врачей для выгрузки: внешний ID, а без него — ID
+	DoctorKeys() (map[uuid.UUID]string, error)
+	// EachDoctor отдаёт врачей со специализациями и языками пачками по batch
+	EachDoctor(batch int, fn func(docs []model.Doctor) error) error
+	// EachTemplate отдаёт шаблоны с перерывами пачками по batch
+	EachTemplate(batch int, fn func(templates []model.ScheduleTemplate) error) error
+}
+
+// BulkStore — операции импорта внутри транзакции. Записи ищутся по ключу: внешнему ID,
+// а у записей без внешнего ID — по ID, так что выгрузку можно импортировать обратно.
+type BulkStore interface {
+	FindSpecialization(key string) (*model.Specialization, error)
+	SpecializationByName(name string) (*model.Specialization, error)
+	// SaveSpecialization создаёт или обновляет специализацию и заменяет её переводы
+	SaveSpecialization(s *model.Specialization, created bool) error
+	// SpecializationParents возвращает родителя каждой специализации
+	SpecializationParents() (map[uuid.UUID]*uuid.UUID, error)
+	FindDoctor(key string) (*model.Doctor, error)
+	// SaveDoctor создаёт или обновляет врача и заменяет его специализации и языки.
+	// Пользователь auth, фото, документы и рейтинг при обновлении не меняются.
+	SaveDoctor(d *model.Doctor, created bool) error
+	FindTemplate(key string) (*model.ScheduleTemplate, error)
+	ListTemplates(doctorID uuid.UUID) ([]model.ScheduleTemplate, error)
+	// SaveTemplate создаёт или обновляет шаблон и заменяет его перерывы
+	SaveTemplate(t *model.ScheduleTemplate, created bool) error
+}
+
+// keyCondition ищет запись по внешнему ID или по ID, если внешнего нет
+const keyCondition = "(external_id = ? OR (external_id IS NULL AND id::text = ?))"
+
+type bulkRepo struct {
+	db *gorm.DB
+}
+
+// NewBulkRepository конструктор
+func NewBulkRepository(db *gorm.DB) BulkRepository {
+	return &bulkRepo{db: db}
+}
+
+func (r *bulkRepo) InTransaction(fn func(tx BulkStore) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&bulkRepo{db: tx})
+	})
+}
+
+func (r *bulkRepo) ListSpecializations() ([]model.Specialization, error) {
+	var specs []model.Specialization
+	if err := r.db.Preload("Translations", orderByLanguage).Order("name").Find(&specs).Error; err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+func (r *bulkRepo) DoctorKeys() (map[uuid.UUID]string, error) {
+	var rows []struct {
+		ID         uuid.UUID
+		ExternalID *string
+	}
+	if err := r.db.Model(&model.Doctor{}).Select("id", "external_id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	keys := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		keys[row.ID] = row.ID.String()
+		if row.ExternalID != nil {
+			keys[row.ID] = *row.ExternalID
+		}
+	}
+	return keys, nil
+}
+
+func (r *bulkRepo) EachDoctor(batch int, fn func(docs []model.Doctor) error) error {
+	var docs []model.Doctor
+	return r.db.Preload("Specializations", orderByName).Preload("Languages").
+		FindInBatches(&docs, batch, func(*gorm.DB, int) error { return fn(docs) }).Error
+}
+
+func (r *bulkRepo) EachTemplate(batch int, fn func(templates []model.ScheduleTemplate) error) error {
+	var templates []model.ScheduleTemplate
+	return r.db.Preload("Breaks", func(db *gorm.DB) *gorm.DB { return db.Order("start_time") }).
+		FindInBatches(&templates, batch, func(*gorm.DB, int) error { return fn(templates) }).Error
+}
+
+func (r *bulkRepo) FindSpecialization(key string) (*model.Specialization, error) {
+	var s model.Specialization
+	if err := r.db.Preload("Translations", orderByLanguage).Where(keyCondition, key, key).First(&s).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *bulkRepo) SpecializationByName(name string) (*model.Specialization, error) {
+	var s model.Specialization
+	if err := r.db.First(&s, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *bulkRepo) SaveSpecialization(s *model.Specialization, created bool) error {
+	tx := r.db.Omit(clause.Associations)
+	if created {
+		tx = tx.Create(s)
+	} else {
+		tx = tx.Save(s)
+	}
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := r.db.Delete(&model.SpecializationTranslation{}, "specialization_id = ?", s.ID).Error; err != nil {
+		return err
+	}
+	if len(s.Translations) == 0 {
+		return nil
+	}
+	return r.db.Create(&s.Translations).Error
+}
+
+func (r *bulkRepo) SpecializationParents() (map[uuid.UUID]*uuid.UUID, error) {
+	var specs []model.Specialization
+	if err := r.db.Select("id", "parent_id").Find(&specs).Error; err != nil {
+		return nil, err
+	}
+	parents := make(map[uuid.UUID]*uuid.UUID, len(specs))
+	for _, s := range specs {
+		parents[s.ID] = s.ParentID
+	}
+	return parents, nil
+}
+
+func (r *bulkRepo) FindDoctor(key string) (*model.Doctor, error) {
+	var doc model.Doctor
+	if err := r.db.Where(keyCondition, key, key).First(&doc).Error; err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (r *bulkRepo) SaveDoctor(d *model.Doctor, created bool) error {
+	if created {
+		if err := r.db.Omit(clause.Associations).Create(d).Error; err != nil {
+			return err
+		}
+		return saveProfileLinks(r.db, d)
+	}
+
+	if err := r.db.Model(d).
+		Select("ExternalID", "FirstName", "LastName", "Patronymic", "DateOfBirth", "Status", "TimeZone", "Email", "Phone", "Bio", "ExperienceYears").
+		Updates(d).Error; err != nil {
+		return err
+	}
+	if err := r.db.Delete(&model.DoctorSpecialization{}, "doctor_id = ?", d.ID).Error; err != nil {
+		return err
+	}
+	if err := r.db.Delete(&model.DoctorLanguage{}, "doctor_id = ?", d.ID).Error; err != nil {
+		return err
+	}
+	return saveProfileLinks(r.db, d)
+}
+
+func (r *bulkRepo) FindTemplate(key string) (*model.ScheduleTemplate, error) {
+	var t model.ScheduleTemplate
+	if err := r.db.Where(keyCondition, key, key).First(&t).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *bulkRepo) ListTemplates(doctorID uuid.UUID) ([]model.ScheduleTemplate, error) {
+	var templates []model.ScheduleTemplate
+	if err := r.db.Where("doctor_id = ?", doctorID).Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *bulkRepo) SaveTemplate(t *model.ScheduleTemplate, created bool) error {
+	if created {
+		return r.db.Create(t).Error
+	}
+	if err := r.db.Omit(clause.Associations).Save(t).Error; err != nil {
+		return err
+	}
+	if err := r.db.Delete(&model.ScheduleBreak{}, "template_id = ?", t.ID).Error; err != nil {
+		return err
+	}
+	if len(t.Breaks) == 0 {
+		return nil
+	}
+	return r.db.Create(&t.Breaks).Error
+}
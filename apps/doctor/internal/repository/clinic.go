@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClinicRepository описывает доступ к филиалам, их кабинетам и врачам
+type ClinicRepository interface {
+	Create(c *model.Clinic) error
+	// GetByID возвращает филиал вместе с кабинетами
+	GetByID(id uuid.UUID) (*model.Clinic, error)
+	List() ([]model.Clinic, error)
+	CreateRoom(room *model.Room) error
+	GetRoom(id uuid.UUID) (*model.Room, error)
+	// AssignDoctor добавляет врача в филиал, повторное добавление ничего не меняет
+	AssignDoctor(clinicID, doctorID uuid.UUID) error
+	UnassignDoctor(clinicID, doctorID uuid.UUID) (int64, error)
+	// HasDoctor — принимает ли врач в филиале
+	HasDoctor(clinicID, doctorID uuid.UUID) (bool, error)
+}
+
+type clinicRepo struct {
+	db *gorm.DB
+}
+
+func NewClinicRepository(db *gorm.DB) ClinicRepository {
+	return &clinicRepo{db: db}
+}
+
+// Create сохраняет филиал вместе с кабинетами
+func (r *clinicRepo) Create(c *model.Clinic) error {
+	return r.db.Create(c).Error
+}
+
+func (r *clinicRepo) GetByID(id uuid.UUID) (*model.Clinic, error) {
+	var c model.Clinic
+	if err := r.db.Preload("Rooms", orderByName).First(&c, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *clinicRepo) List() ([]model.Clinic, error) {
+	var clinics []model.Clinic
+	if err := r.db.Preload("Rooms", orderByName).Order("name").Find(&clinics).Error; err != nil {
+		return nil, err
+	}
+	return clinics, nil
+}
+
+func (r *clinicRepo) CreateRoom(room *model.Room) error {
+	return r.db.Create(room).Error
+}
+
+func (r *clinicRepo) GetRoom(id uuid.UUID) (*model.Room, error) {
+	var room model.Room
+	if err := r.db.First(&room, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+func (r *clinicRepo) AssignDoctor(clinicID, doctorID uuid.UUID) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.DoctorClinic{DoctorID: doctorID, ClinicID: clinicID}).Error
+}
+
+func (r *clinicRepo) UnassignDoctor(clinicID, doctorID uuid.UUID) (int64, error) {
+	res := r.db.Delete(&model.DoctorClinic{}, "clinic_id = ? AND doctor_id = ?", clinicID, doctorID)
+	return res.RowsAffected, res.Error
+}
+
+func (r *clinicRepo) HasDoctor(clinicID, doctorID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.DoctorClinic{}).
+		Where("clinic_id = ? AND doctor_id = ?", clinicID, doctorID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func orderByName(db *gorm.DB) *gorm.DB {
+	return db.Order("name")
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DoctorCredentialRepository описывает доступ к лицензиям и сертификатам врачей
+type DoctorCredentialRepository interface {
+	Create(c *model.DoctorCredential) error
+	// Delete удаляет документ врача и возвращает число удалённых записей
+	Delete(doctorID, id uuid.UUID) (int64, error)
+}
+
+type doctorCredentialRepo struct {
+	db *gorm.DB
+}
+
+func NewDoctorCredentialRepository(db *gorm.DB) DoctorCredentialRepository {
+	return &doctorCredentialRepo{db: db}
+}
+
+func (r *doctorCredentialRepo) Create(c *model.DoctorCredential) error {
+	return r.db.Create(c).Error
+}
+
+func (r *doctorCredentialRepo) Delete(doctorID, id uuid.UUID) (int64, error) {
+	res := r.db.Delete(&model.DoctorCredential{}, "id = ? AND doctor_id = ?", id, doctorID)
+	return res.RowsAffected, res.Error
+}
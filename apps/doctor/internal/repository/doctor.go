@@ -1,17 +1,66 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DoctorSort — порядок выдачи при поиске врачей
+type DoctorSort string
+
+const (
+	SortByName      DoctorSort = "name"       // last_name, first_name
+	SortByCreatedAt DoctorSort = "created_at" // дата добавления
+	SortByRating    DoctorSort = "rating"     // средний балл, при равном — число отзывов
 )
 
+// DoctorFilter — условия поиска, пустые поля не ограничивают выборку
+type DoctorFilter struct {
+	SpecializationID *uuid.UUID // вместе с дочерними специализациями
+	ClinicID         *uuid.UUID // врач принимает в филиале, свободный слот ищется там же
+	Status           *model.DoctorStatus
+	Name             string     // подстрока имени, фамилии или отчества, без учёта регистра
+	AvailableFrom    *time.Time // есть свободный слот, начинающийся в [AvailableFrom, AvailableTo)
+	AvailableTo      *time.Time
+}
+
+// DoctorCursor — позиция последнего врача предыдущей страницы.
+// Заполняются поля ключа сортировки и ID, который делает ключ уникальным.
+type DoctorCursor struct {
+	LastName    string
+	FirstName   string
+	CreatedAt   time.Time
+	RatingAvg   float64
+	RatingCount int
+	ID          uuid.UUID
+}
+
+// DoctorQuery — параметры поиска врачей
+type DoctorQuery struct {
+	Filter DoctorFilter
+	Sort   DoctorSort
+	Desc   bool
+	After  *DoctorCursor // nil для первой страницы
+	Limit  int
+}
+
 // DoctorRepository описывает доступ к хранилищу врачей
 type DoctorRepository interface {
 	Create(doc *model.Doctor) error
 	GetByID(id uuid.UUID) (*model.Doctor, error)
 	Update(doc *model.Doctor) error
 	Delete(id uuid.UUID) error
+	Search(q DoctorQuery) ([]model.Doctor, error)
+	// UpdatePhoto записывает ключ фото врача, пустой ключ убирает фото
+	UpdatePhoto(id uuid.UUID, key string) error
+	GetByUserID(userID int64) (*model.Doctor, error)
+	// UpdateUser привязывает врача к пользователю auth, nil отвязывает
+	UpdateUser(id uuid.UUID, userID *int64) error
 }
 
 type doctorRepo struct {
@@ -23,14 +72,24 @@ func NewDoctorRepository(db *gorm.DB) DoctorRepository {
 	return &doctorRepo{db: db}
 }
 
+// Create сохраняет врача вместе с его специализациями и языками.
+// Специализации должны существовать, сами они не создаются.
 func (r *doctorRepo) Create(doc *model.Doctor) error {
-	return r.db.Create(doc).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(doc).Error; err != nil {
+			return err
+		}
+		return saveProfileLinks(tx, doc)
+	})
 }
 
 func (r *doctorRepo) GetByID(id uuid.UUID) (*model.Doctor, error) {
 	var doc model.Doctor
 	if err := r.db.
-		Preload("Specialization").
+		Preload("Specializations", orderByName).
+		Preload("Specializations.Translations", orderByLanguage).
+		Preload("Languages").
+		Preload("Credentials", func(db *gorm.DB) *gorm.DB { return db.Order("issued_at") }).
 		Preload("ScheduleSlots").
 		First(&doc, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -38,11 +97,164 @@ func (r *doctorRepo) GetByID(id uuid.UUID) (*model.Doctor, error) {
 	return &doc, nil
 }
 
+// Update сохраняет поля врача и заменяет его специализации и языки.
+// Документы врача меняются отдельно через DoctorCredentialRepository.
 func (r *doctorRepo) Update(doc *model.Doctor) error {
-	return r.db.Save(doc).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Рейтинг пересчитывается при модерации отзывов, здесь он может быть устаревшим
+		if err := tx.Omit(clause.Associations, "RatingAvg", "RatingCount").Save(doc).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.DoctorSpecialization{}, "doctor_id = ?", doc.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.DoctorLanguage{}, "doctor_id = ?", doc.ID).Error; err != nil {
+			return err
+		}
+		return saveProfileLinks(tx, doc)
+	})
+}
+
+func (r *doctorRepo) GetByUserID(userID int64) (*model.Doctor, error) {
+	var doc model.Doctor
+	if err := r.db.
+		Preload("Specializations", orderByName).
+		Preload("Specializations.Translations", orderByLanguage).
+		Preload("Languages").
+		First(&doc, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (r *doctorRepo) UpdateUser(id uuid.UUID, userID *int64) error {
+	res := r.db.Model(&model.Doctor{}).Where("id = ?", id).Update("user_id", userID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *doctorRepo) UpdatePhoto(id uuid.UUID, key string) error {
+	var value any
+	if key != "" {
+		value = key
+	}
+	res := r.db.Model(&model.Doctor{}).Where("id = ?", id).Update("photo_key", value)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 func (r *doctorRepo) Delete(id uuid.UUID) error {
 	return r.db.
 		Delete(&model.Doctor{}, "id = ?", id).Error
 }
+
+// Search возвращает до q.Limit врачей со специализациями и языками, страница продолжается после q.After.
+// Пагинация по ключу (keyset), индексы idx_doctors_name_id, idx_doctors_created_at_id
+// и idx_doctors_rating_id покрывают сортировку, поэтому глубокие страницы не медленнее первой.
+func (r *doctorRepo) Search(q DoctorQuery) ([]model.Doctor, error) {
+	tx := r.db.Model(&model.Doctor{}).Preload("Specializations", orderByName).Preload("Specializations.Translations", orderByLanguage).Preload("Languages")
+
+	f := q.Filter
+	if f.SpecializationID != nil {
+		// Врачи дочерних специализаций тоже подходят: в поиске хирурга есть кардиохирурги
+		tx = tx.Where(`EXISTS (SELECT 1 FROM doctor_specializations WHERE doctor_specializations.doctor_id = doctors.id AND doctor_specializations.specialization_id IN (
+			WITH RECURSIVE tree AS (
+				SELECT id FROM specializations WHERE id = ?
+				UNION ALL
+				SELECT specializations.id FROM specializations JOIN tree ON specializations.parent_id = tree.id
+			)
+			SELECT id FROM tree))`, *f.SpecializationID)
+	}
+	if f.ClinicID != nil {
+		tx = tx.Where("EXISTS (SELECT 1 FROM doctor_clinics WHERE doctor_clinics.doctor_id = doctors.id AND doctor_clinics.clinic_id = ?)", *f.ClinicID)
+	}
+	if f.Status != nil {
+		tx = tx.Where("doctors.status = ?", *f.Status)
+	}
+	if f.Name != "" {
+		pattern := "%" + escapeLike(strings.ToLower(f.Name)) + "%"
+		tx = tx.Where("LOWER(doctors.first_name || ' ' || doctors.last_name || ' ' || COALESCE(doctors.patronymic, '')) LIKE ? ESCAPE '\\'", pattern)
+	}
+	if f.AvailableFrom != nil || f.AvailableTo != nil {
+		slots := r.db.Model(&model.ScheduleSlot{}).
+			Select("1").
+			Where("schedule_slots.doctor_id = doctors.id AND schedule_slots.is_available")
+		if f.AvailableFrom != nil {
+			slots = slots.Where("schedule_slots.starts_at >= ?", f.AvailableFrom.UTC())
+		}
+		if f.AvailableTo != nil {
+			slots = slots.Where("schedule_slots.starts_at < ?", f.AvailableTo.UTC())
+		}
+		if f.ClinicID != nil {
+			slots = slots.Where("schedule_slots.clinic_id = ?", *f.ClinicID)
+		}
+		tx = tx.Where("EXISTS (?)", slots)
+	}
+
+	op, dir := ">", "ASC"
+	if q.Desc {
+		op, dir = "<", "DESC"
+	}
+	switch q.Sort {
+	case SortByCreatedAt:
+		if q.After != nil {
+			tx = tx.Where("(doctors.created_at, doctors.id) "+op+" (?, ?)", q.After.CreatedAt, q.After.ID)
+		}
+		tx = tx.Order("doctors.created_at " + dir).Order("doctors.id " + dir)
+	case SortByRating:
+		if q.After != nil {
+			tx = tx.Where("(doctors.rating_avg, doctors.rating_count, doctors.id) "+op+" (?, ?, ?)",
+				q.After.RatingAvg, q.After.RatingCount, q.After.ID)
+		}
+		tx = tx.Order("doctors.rating_avg " + dir).Order("doctors.rating_count " + dir).Order("doctors.id " + dir)
+	default:
+		if q.After != nil {
+			tx = tx.Where("(doctors.last_name, doctors.first_name, doctors.id) "+op+" (?, ?, ?)",
+				q.After.LastName, q.After.FirstName, q.After.ID)
+		}
+		tx = tx.Order("doctors.last_name " + dir).Order("doctors.first_name " + dir).Order("doctors.id " + dir)
+	}
+
+	var docs []model.Doctor
+	if err := tx.Limit(q.Limit).Find(&docs).Error; err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Support function for Create and Update.
+func saveProfileLinks(tx *gorm.DB, doc *model.Doctor) error {
+	if len(doc.Specializations) > 0 {
+		links := make([]model.DoctorSpecialization, 0, len(doc.Specializations))
+		for _, spec := range doc.Specializations {
+			links = append(links, model.DoctorSpecialization{DoctorID: doc.ID, SpecializationID: spec.ID})
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+			return err
+		}
+	}
+	if len(doc.Languages) > 0 {
+		for i := range doc.Languages {
+			doc.Languages[i].DoctorID = doc.ID
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&doc.Languages).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeLike экранирует спецсимволы LIKE, чтобы подстрока искалась буквально
+func escapeLike(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(s)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MedicalServiceRepository — каталог услуг по специализациям
+type MedicalServiceRepository interface {
+	Create(s *model.MedicalService) error
+	GetByID(id uuid.UUID) (*model.MedicalService, error)
+	Update(s *model.MedicalService) error
+	Delete(id uuid.UUID) error
+	// ListBySpecializations возвращает услуги специализаций по имени, activeOnly — только действующие
+	ListBySpecializations(ids []uuid.UUID, activeOnly bool) ([]model.MedicalService, error)
+}
+
+type medicalServiceRepo struct {
+	db *gorm.DB
+}
+
+// NewMedicalServiceRepository конструктор
+func NewMedicalServiceRepository(db *gorm.DB) MedicalServiceRepository {
+	return &medicalServiceRepo{db: db}
+}
+
+func (r *medicalServiceRepo) Create(s *model.MedicalService) error {
+	return r.db.Create(s).Error
+}
+
+func (r *medicalServiceRepo) GetByID(id uuid.UUID) (*model.MedicalService, error) {
+	var s model.MedicalService
+	if err := r.db.First(&s, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *medicalServiceRepo) Update(s *model.MedicalService) error {
+	return r.db.Save(s).Error
+}
+
+func (r *medicalServiceRepo) Delete(id uuid.UUID) error {
+	return r.db.Delete(&model.MedicalService{}, "id = ?", id).Error
+}
+
+func (r *medicalServiceRepo) ListBySpecializations(ids []uuid.UUID, activeOnly bool) ([]model.MedicalService, error) {
+	services := []model.MedicalService{}
+	if len(ids) == 0 {
+		return services, nil
+	}
+	tx := r.db.Where("specialization_id IN ?", ids)
+	if activeOnly {
+		tx = tx.Where("is_active")
+	}
+	if err := tx.Order("name").Order("id").Find(&services).Error; err != nil {
+		return nil, err
+	}
+	return services, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReviewRepository описывает доступ к отзывам о врачах
+type ReviewRepository interface {
+	// Create сохраняет отзыв и возвращает false, если на этот приём отзыв уже есть
+	Create(r *model.Review) (bool, error)
+	GetByID(id uuid.UUID) (*model.Review, error)
+	// ListByDoctor возвращает отзывы врача в статусе status, новые первыми
+	ListByDoctor(doctorID uuid.UUID, status model.ReviewStatus) ([]model.Review, error)
+	// ListByStatus возвращает отзывы в статусе status, старые первыми — в порядке очереди модерации
+	ListByStatus(status model.ReviewStatus) ([]model.Review, error)
+	// UpdateStatus меняет статус отзыва и пересчитывает рейтинг врача
+	UpdateStatus(id uuid.UUID, status model.ReviewStatus, at time.Time) (*model.Review, error)
+}
+
+type reviewRepo struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepo{db: db}
+}
+
+func (r *reviewRepo) Create(review *model.Review) (bool, error) {
+	res := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "appointment_id"}}, DoNothing: true}).Create(review)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *reviewRepo) GetByID(id uuid.UUID) (*model.Review, error) {
+	var review model.Review
+	if err := r.db.First(&review, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepo) ListByDoctor(doctorID uuid.UUID, status model.ReviewStatus) ([]model.Review, error) {
+	var reviews []model.Review
+	err := r.db.
+		Where("doctor_id = ? AND status = ?", doctorID, status).
+		Order("created_at DESC").
+		Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *reviewRepo) ListByStatus(status model.ReviewStatus) ([]model.Review, error) {
+	var reviews []model.Review
+	err := r.db.
+		Where("status = ?", status).
+		Order("created_at").
+		Find(&reviews).Error
+	return reviews, err
+}
+
+// UpdateStatus в одной транзакции меняет статус и пересчитывает средний балл и число
+// одобренных отзывов врача, поэтому рейтинг не расходится с отзывами.
+func (r *reviewRepo) UpdateStatus(id uuid.UUID, status model.ReviewStatus, at time.Time) (*model.Review, error) {
+	var review model.Review
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&review).Updates(map[string]any{"status": status, "moderated_at": at}).Error; err != nil {
+			return err
+		}
+		// Блокировка врача упорядочивает параллельные пересчёты одного рейтинга
+		if err := tx.Exec("SELECT 1 FROM doctors WHERE id = ? FOR UPDATE", review.DoctorID).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE doctors SET
+			rating_avg = COALESCE((SELECT AVG(rating) FROM reviews WHERE doctor_id = ? AND status = ?), 0),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE doctor_id = ? AND status = ?)
+			WHERE id = ?`,
+			review.DoctorID, model.ReviewApproved, review.DoctorID, model.ReviewApproved, review.DoctorID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
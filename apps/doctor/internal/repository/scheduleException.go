@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduleExceptionRepository описывает доступ к отпускам, праздникам и дополнительным часам
+type ScheduleExceptionRepository interface {
+	Create(e *model.ScheduleException) error
+	GetByID(id uuid.UUID) (*model.ScheduleException, error)
+	// List возвращает исключения врача и праздники клиники, пересекающие [from, to).
+	// Без врача возвращаются только праздники.
+	List(doctorID *uuid.UUID, from, to time.Time) ([]model.ScheduleException, error)
+	Delete(id uuid.UUID) error
+}
+
+type scheduleExceptionRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduleExceptionRepository(db *gorm.DB) ScheduleExceptionRepository {
+	return &scheduleExceptionRepo{db: db}
+}
+
+func (r *scheduleExceptionRepo) Create(e *model.ScheduleException) error {
+	return r.db.Create(e).Error
+}
+
+func (r *scheduleExceptionRepo) GetByID(id uuid.UUID) (*model.ScheduleException, error) {
+	var e model.ScheduleException
+	if err := r.db.First(&e, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *scheduleExceptionRepo) List(doctorID *uuid.UUID, from, to time.Time) ([]model.ScheduleException, error) {
+	tx := r.db.Where("starts_at < ? AND ends_at > ?", to.UTC(), from.UTC())
+	if doctorID != nil {
+		tx = tx.Where("doctor_id = ? OR doctor_id IS NULL", *doctorID)
+	} else {
+		tx = tx.Where("doctor_id IS NULL")
+	}
+
+	var exceptions []model.ScheduleException
+	if err := tx.Order("starts_at").Find(&exceptions).Error; err != nil {
+		return nil, err
+	}
+	return exceptions, nil
+}
+
+func (r *scheduleExceptionRepo) Delete(id uuid.UUID) error {
+	return r.db.Delete(&model.ScheduleException{}, "id = ?", id).Error
+}
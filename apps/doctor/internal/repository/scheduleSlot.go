@@ -1,16 +1,80 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrSlotOverlap возвращается, если бронь пересекается с другой бронью врача.
+// Проверяется ограничением schedule_slots_no_double_booking.
+var ErrSlotOverlap = errors.New("slot overlaps another booked slot")
+
+// ErrRoomFull возвращается, если в кабинете слота на это время уже столько приёмов, сколько он вмещает
+var ErrRoomFull = errors.New("room is fully booked at this time")
+
+// exclusionViolation — код ошибки Postgres при нарушении EXCLUDE ограничения
+const exclusionViolation = "23P01"
+
+// Reservation — бронь слота записью. У временного удержания задан HeldUntil,
+// подтверждённая бронь бессрочна.
+type Reservation struct {
+	AppointmentID uuid.UUID
+	Token         uuid.UUID
+	HeldUntil     *time.Time
+}
+
 type ScheduleSlotRepository interface {
 	Create(slot *model.ScheduleSlot) error
 	GetByID(id uuid.UUID) (*model.ScheduleSlot, error)
 	Update(slot *model.ScheduleSlot) error
 	Delete(id uuid.UUID) error
+	// FindByStart ищет слот врача, начинающийся ровно в момент start
+	FindByStart(doctorID uuid.UUID, start time.Time) (*model.ScheduleSlot, error)
+	// ListByDoctor возвращает слоты врача, начинающиеся в [from, to); границы необязательны
+	ListByDoctor(doctorID uuid.UUID, from, to *time.Time) ([]model.ScheduleSlot, error)
+	// CreateMissing вставляет слоты, пропуская те, что уже есть у врача с тем же началом
+	CreateMissing(slots []model.ScheduleSlot) (int64, error)
+	// DeleteFree удаляет слоты по ID, если они свободны и не привязаны к записи
+	DeleteFree(ids []uuid.UUID) (int64, error)
+	// DeleteFreeByTemplate удаляет свободные слоты шаблона, начинающиеся не раньше from
+	DeleteFreeByTemplate(templateID uuid.UUID, from time.Time) (int64, error)
+	// ListOverlapping возвращает слоты, пересекающие [from, to); без врача — слоты всех врачей
+	ListOverlapping(doctorID *uuid.UUID, from, to time.Time) ([]model.ScheduleSlot, error)
+	// Block закрывает слоты по ID, запоминая закрывшее их исключение. Занятый слот остаётся
+	// за записью, но после её отмены или истечения удержания не открывается
+	Block(ids []uuid.UUID, exceptionID uuid.UUID) (int64, error)
+	// Unblock снимает исключение со слотов; открываются только слоты без записи
+	Unblock(exceptionID uuid.UUID) (int64, error)
+	// DeleteFreeByException удаляет свободные слоты, сгенерированные из дополнительных часов
+	DeleteFreeByException(exceptionID uuid.UUID) (int64, error)
+	// Reserve одним условным UPDATE занимает под запись свободный слот или слот с истёкшим
+	// к now удержанием. Если слота нет или он занят, возвращает gorm.ErrRecordNotFound,
+	// если кабинет слота на это время заполнен — ErrRoomFull.
+	Reserve(doctorID uuid.UUID, start time.Time, res Reservation, now time.Time) (*model.ScheduleSlot, error)
+	// Confirm делает удержание бессрочным, если оно этой записи с этим токеном и не истекло к now,
+	// иначе возвращает gorm.ErrRecordNotFound
+	Confirm(doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID, now time.Time) (*model.ScheduleSlot, error)
+	// Release освобождает слот, только если он занят этой записью с этим токеном,
+	// иначе возвращает gorm.ErrRecordNotFound
+	Release(doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID) (*model.ScheduleSlot, error)
+	// ReleaseExpired освобождает слоты, удержание которых истекло к now
+	ReleaseExpired(now time.Time) (int64, error)
+	// FindByAppointment ищет слот, занятый записью
+	FindByAppointment(appointmentID uuid.UUID) (*model.ScheduleSlot, error)
+	// FindByMeetingRoom ищет занятый слот с комнатой видеосвязи room
+	FindByMeetingRoom(room string) (*model.ScheduleSlot, error)
+	// SetMeeting записывает комнату и ссылку в слот, подтверждённо занятый записью и ещё без комнаты.
+	// Возвращает false, если слот уже освобождён или комната у него есть.
+	SetMeeting(id, appointmentID uuid.UUID, room, link string) (bool, error)
+	// SetAvailable меняет доступность слота без записи и не закрытого исключением,
+	// если она ещё не такая, иначе возвращает gorm.ErrRecordNotFound
+	SetAvailable(doctorID uuid.UUID, start time.Time, available bool) (*model.ScheduleSlot, error)
 }
 
 type scheduleslotRepo struct {
@@ -40,3 +104,220 @@ func (r *scheduleslotRepo) Update(slot *model.ScheduleSlot) error {
 func (r *scheduleslotRepo) Delete(id uuid.UUID) error {
 	return r.db.Delete(&model.ScheduleSlot{}, "id = ?", id).Error
 }
+
+func (r *scheduleslotRepo) FindByStart(doctorID uuid.UUID, start time.Time) (*model.ScheduleSlot, error) {
+	var slot model.ScheduleSlot
+	err := r.db.Where("doctor_id = ? AND starts_at = ?", doctorID, start.UTC()).First(&slot).Error
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (r *scheduleslotRepo) ListByDoctor(doctorID uuid.UUID, from, to *time.Time) ([]model.ScheduleSlot, error) {
+	tx := r.db.Where("doctor_id = ?", doctorID)
+	if from != nil {
+		tx = tx.Where("starts_at >= ?", from.UTC())
+	}
+	if to != nil {
+		tx = tx.Where("starts_at < ?", to.UTC())
+	}
+
+	var slots []model.ScheduleSlot
+	if err := tx.Order("starts_at").Find(&slots).Error; err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+func (r *scheduleslotRepo) CreateMissing(slots []model.ScheduleSlot) (int64, error) {
+	if len(slots) == 0 {
+		return 0, nil
+	}
+	res := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "doctor_id"}, {Name: "starts_at"}},
+		DoNothing: true,
+	}).Create(&slots)
+	return res.RowsAffected, res.Error
+}
+
+func (r *scheduleslotRepo) DeleteFree(ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	res := r.db.Where("id IN ? AND appointment_id IS NULL AND is_available", ids).Delete(&model.ScheduleSlot{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *scheduleslotRepo) DeleteFreeByTemplate(templateID uuid.UUID, from time.Time) (int64, error) {
+	res := r.db.
+		Where("template_id = ? AND starts_at >= ? AND appointment_id IS NULL AND is_available", templateID, from.UTC()).
+		Delete(&model.ScheduleSlot{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *scheduleslotRepo) ListOverlapping(doctorID *uuid.UUID, from, to time.Time) ([]model.ScheduleSlot, error) {
+	tx := r.db.Where("starts_at < ? AND ends_at > ?", to.UTC(), from.UTC())
+	if doctorID != nil {
+		tx = tx.Where("doctor_id = ?", *doctorID)
+	}
+
+	var slots []model.ScheduleSlot
+	if err := tx.Order("starts_at").Find(&slots).Error; err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+func (r *scheduleslotRepo) Block(ids []uuid.UUID, exceptionID uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	res := r.db.Model(&model.ScheduleSlot{}).
+		Where("id IN ? AND blocked_by IS NULL", ids).
+		Updates(map[string]any{"is_available": false, "blocked_by": exceptionID})
+	return res.RowsAffected, res.Error
+}
+
+func (r *scheduleslotRepo) Unblock(exceptionID uuid.UUID) (int64, error) {
+	res := r.db.Model(&model.ScheduleSlot{}).
+		Where("blocked_by = ?", exceptionID).
+		Updates(map[string]any{"is_available": gorm.Expr("appointment_id IS NULL"), "blocked_by": nil})
+	return res.RowsAffected, res.Error
+}
+
+func (r *scheduleslotRepo) DeleteFreeByException(exceptionID uuid.UUID) (int64, error) {
+	res := r.db.
+		Where("exception_id = ? AND appointment_id IS NULL AND is_available", exceptionID).
+		Delete(&model.ScheduleSlot{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *scheduleslotRepo) Reserve(doctorID uuid.UUID, start time.Time, res Reservation, now time.Time) (*model.ScheduleSlot, error) {
+	var slot *model.ScheduleSlot
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := roomHasSpace(tx, doctorID, start, now); err != nil {
+			return err
+		}
+		var err error
+		slot, err = (&scheduleslotRepo{db: tx}).updateAt(doctorID, start, "blocked_by IS NULL AND ((is_available AND appointment_id IS NULL) OR held_until <= ?)", []any{now.UTC()}, map[string]any{
+			"is_available":      false,
+			"appointment_id":    res.AppointmentID,
+			"reservation_token": res.Token,
+			"held_until":        res.HeldUntil,
+		})
+		return err
+	})
+	return slot, err
+}
+
+// roomHasSpace проверяет, что в кабинете слота есть место на его время. Строка кабинета
+// блокируется до конца транзакции, поэтому брони одного кабинета проверяются по очереди.
+// Слот без кабинета места не требует.
+// Support function for Reserve.
+func roomHasSpace(tx *gorm.DB, doctorID uuid.UUID, start time.Time, now time.Time) error {
+	var slot model.ScheduleSlot
+	if err := tx.Where("doctor_id = ? AND starts_at = ?", doctorID, start.UTC()).First(&slot).Error; err != nil {
+		return err
+	}
+	if slot.RoomID == nil {
+		return nil
+	}
+
+	var room model.Room
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&room, "id = ?", *slot.RoomID).Error; err != nil {
+		return err
+	}
+	var booked int64
+	err := tx.Model(&model.ScheduleSlot{}).
+		Where("room_id = ? AND id <> ? AND appointment_id IS NOT NULL", room.ID, slot.ID).
+		Where("held_until IS NULL OR held_until > ?", now.UTC()).
+		Where("starts_at < ? AND ends_at > ?", slot.EndsAt, slot.StartsAt).
+		Count(&booked).Error
+	if err != nil {
+		return err
+	}
+	if booked >= int64(room.Capacity) {
+		return ErrRoomFull
+	}
+	return nil
+}
+
+func (r *scheduleslotRepo) Confirm(doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID, now time.Time) (*model.ScheduleSlot, error) {
+	return r.updateAt(doctorID, start, "appointment_id = ? AND reservation_token = ? AND held_until > ?", []any{appointmentID, token, now.UTC()}, map[string]any{
+		"held_until": nil,
+	})
+}
+
+func (r *scheduleslotRepo) Release(doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID) (*model.ScheduleSlot, error) {
+	return r.updateAt(doctorID, start, "appointment_id = ? AND reservation_token = ?", []any{appointmentID, token}, releasedSlot())
+}
+
+func (r *scheduleslotRepo) ReleaseExpired(now time.Time) (int64, error) {
+	res := r.db.Model(&model.ScheduleSlot{}).Where("held_until <= ?", now.UTC()).Updates(releasedSlot())
+	return res.RowsAffected, res.Error
+}
+
+// releasedSlot — значения слота после снятия брони; слот внутри исключения остаётся закрытым
+func releasedSlot() map[string]any {
+	return map[string]any{
+		"is_available":      gorm.Expr("blocked_by IS NULL"),
+		"appointment_id":    nil,
+		"reservation_token": nil,
+		"held_until":        nil,
+		"meeting_link":      nil,
+		"meeting_room":      nil,
+	}
+}
+
+func (r *scheduleslotRepo) FindByAppointment(appointmentID uuid.UUID) (*model.ScheduleSlot, error) {
+	var slot model.ScheduleSlot
+	if err := r.db.Where("appointment_id = ?", appointmentID).First(&slot).Error; err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (r *scheduleslotRepo) FindByMeetingRoom(room string) (*model.ScheduleSlot, error) {
+	var slot model.ScheduleSlot
+	if err := r.db.Where("meeting_room = ? AND appointment_id IS NOT NULL", room).First(&slot).Error; err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (r *scheduleslotRepo) SetMeeting(id, appointmentID uuid.UUID, room, link string) (bool, error) {
+	res := r.db.Model(&model.ScheduleSlot{}).
+		Where("id = ? AND appointment_id = ? AND held_until IS NULL AND meeting_room IS NULL", id, appointmentID).
+		Updates(map[string]any{"meeting_room": room, "meeting_link": link})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *scheduleslotRepo) SetAvailable(doctorID uuid.UUID, start time.Time, available bool) (*model.ScheduleSlot, error) {
+	return r.updateAt(doctorID, start, "is_available <> ? AND appointment_id IS NULL AND blocked_by IS NULL", []any{available}, map[string]any{
+		"is_available": available,
+	})
+}
+
+// updateAt атомарно обновляет слот врача, начинающийся в start, если выполнено условие.
+// Проверка и запись идут одним запросом, поэтому из параллельных вызовов проходит один.
+// Support function for Reserve, Release and SetAvailable.
+func (r *scheduleslotRepo) updateAt(doctorID uuid.UUID, start time.Time, cond string, args []any, values map[string]any) (*model.ScheduleSlot, error) {
+	var slot model.ScheduleSlot
+	res := r.db.Model(&slot).Clauses(clause.Returning{}).
+		Where("doctor_id = ? AND starts_at = ?", doctorID, start.UTC()).
+		Where(cond, args...).
+		Updates(values)
+
+	var pgErr *pgconn.PgError
+	if errors.As(res.Error, &pgErr) && pgErr.Code == exclusionViolation {
+		return nil, ErrSlotOverlap
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &slot, nil
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDatabaseEnv — DSN базы с применёнными миграциями, в CI её поднимает workflow test-apps.
+// Без неё тесты на живой базе пропускаются, условные UPDATE проверяет ScheduleSlotUpdateTestSuite.
+const testDatabaseEnv = "DOCTOR_TEST_DATABASE_DSN"
+
+type ScheduleSlotRepositoryTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	repo     ScheduleSlotRepository
+	doctorID uuid.UUID
+	specID   uuid.UUID
+	start    time.Time
+}
+
+func TestScheduleSlotRepository(t *testing.T) {
+	suite.Run(t, new(ScheduleSlotRepositoryTestSuite))
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) SetupSuite() {
+	dsn := os.Getenv(testDatabaseEnv)
+	if dsn == "" {
+		suite.T().Skipf("%s is not set", testDatabaseEnv)
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	suite.Require().NoError(err)
+	suite.db = db
+	suite.repo = NewScheduleSlotRepository(db)
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) SetupTest() {
+	spec := model.Specialization{ID: uuid.New(), Name: "test-" + uuid.NewString()}
+	suite.Require().NoError(suite.db.Create(&spec).Error)
+	doctor := model.Doctor{ID: uuid.New(), FirstName: "Test", LastName: "Doctor", Specializations: []model.Specialization{spec}}
+	suite.Require().NoError(NewDoctorRepository(suite.db).Create(&doctor))
+	suite.doctorID = doctor.ID
+	suite.specID = spec.ID
+
+	suite.start = time.Date(2030, 1, 15, 9, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.repo.Create(&model.ScheduleSlot{
+		ID:          uuid.New(),
+		DoctorID:    suite.doctorID,
+		StartsAt:    suite.start,
+		EndsAt:      suite.start.Add(30 * time.Minute),
+		IsAvailable: true,
+	}))
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) TearDownTest() {
+	suite.db.Delete(&model.ScheduleSlot{}, "doctor_id = ?", suite.doctorID)
+	suite.db.Delete(&model.Doctor{}, "id = ?", suite.doctorID)
+	suite.db.Delete(&model.Specialization{}, "id = ?", suite.specID)
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) TestReserve_ParallelBookings() {
+	const bookings = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners []uuid.UUID
+	)
+	for i := 0; i < bookings; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			appointmentID := uuid.New()
+			_, err := suite.repo.Reserve(suite.doctorID, suite.start, Reservation{AppointmentID: appointmentID, Token: uuid.New()}, time.Now())
+			if err == nil {
+				mu.Lock()
+				winners = append(winners, appointmentID)
+				mu.Unlock()
+				return
+			}
+			suite.ErrorIs(err, gorm.ErrRecordNotFound)
+		}()
+	}
+	wg.Wait()
+
+	suite.Require().Len(winners, 1)
+	slot, err := suite.repo.FindByStart(suite.doctorID, suite.start)
+	suite.Require().NoError(err)
+	suite.False(slot.IsAvailable)
+	suite.Equal(winners[0], *slot.AppointmentID)
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) TestReserve_OverlappingSlot() {
+	_, err := suite.repo.Reserve(suite.doctorID, suite.start, Reservation{AppointmentID: uuid.New(), Token: uuid.New()}, time.Now())
+	suite.Require().NoError(err)
+
+	// Ручной слот 9:15–9:45 пересекается с занятым 9:00–9:30
+	overlapping := model.ScheduleSlot{
+		ID:          uuid.New(),
+		DoctorID:    suite.doctorID,
+		StartsAt:    suite.start.Add(15 * time.Minute),
+		EndsAt:      suite.start.Add(45 * time.Minute),
+		IsAvailable: true,
+	}
+	suite.Require().NoError(suite.repo.Create(&overlapping))
+
+	_, err = suite.repo.Reserve(suite.doctorID, overlapping.StartsAt, Reservation{AppointmentID: uuid.New(), Token: uuid.New()}, time.Now())
+	suite.ErrorIs(err, ErrSlotOverlap)
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) TestRelease_RequiresToken() {
+	appointmentID, token := uuid.New(), uuid.New()
+	_, err := suite.repo.Reserve(suite.doctorID, suite.start, Reservation{AppointmentID: appointmentID, Token: token}, time.Now())
+	suite.Require().NoError(err)
+
+	_, err = suite.repo.Release(suite.doctorID, suite.start, appointmentID, uuid.New())
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	slot, err := suite.repo.Release(suite.doctorID, suite.start, appointmentID, token)
+	suite.Require().NoError(err)
+	suite.True(slot.IsAvailable)
+	suite.Nil(slot.ReservationToken)
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) TestHold_ExpiresAndIsTakenOver() {
+	now := time.Now()
+	heldUntil := now.Add(10 * time.Minute)
+	first := Reservation{AppointmentID: uuid.New(), Token: uuid.New(), HeldUntil: &heldUntil}
+	_, err := suite.repo.Reserve(suite.doctorID, suite.start, first, now)
+	suite.Require().NoError(err)
+
+	// Пока удержание действует, слот не занять
+	second := Reservation{AppointmentID: uuid.New(), Token: uuid.New()}
+	_, err = suite.repo.Reserve(suite.doctorID, suite.start, second, now)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	// После истечения подтвердить нельзя, а другой пациент может занять слот
+	later := heldUntil.Add(time.Second)
+	_, err = suite.repo.Confirm(suite.doctorID, suite.start, first.AppointmentID, first.Token, later)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+	slot, err := suite.repo.Reserve(suite.doctorID, suite.start, second, later)
+	suite.Require().NoError(err)
+	suite.Equal(second.AppointmentID, *slot.AppointmentID)
+	suite.Nil(slot.HeldUntil)
+}
+
+func (suite *ScheduleSlotRepositoryTestSuite) TestReleaseExpired() {
+	now := time.Now()
+	heldUntil := now.Add(time.Minute)
+	_, err := suite.repo.Reserve(suite.doctorID, suite.start, Reservation{AppointmentID: uuid.New(), Token: uuid.New(), HeldUntil: &heldUntil}, now)
+	suite.Require().NoError(err)
+
+	released, err := suite.repo.ReleaseExpired(now)
+	suite.Require().NoError(err)
+	suite.Zero(released)
+
+	released, err = suite.repo.ReleaseExpired(heldUntil)
+	suite.Require().NoError(err)
+	suite.EqualValues(1, released)
+
+	slot, err := suite.repo.FindByStart(suite.doctorID, suite.start)
+	suite.Require().NoError(err)
+	suite.True(slot.IsAvailable)
+	suite.Nil(slot.AppointmentID)
+	suite.Nil(slot.HeldUntil)
+}
+
+// ScheduleSlotUpdateTestSuite проверяет условные UPDATE слотов без базы: текст запроса
+// и разбор его результата. Поведение под нагрузкой проверяет ScheduleSlotRepositoryTestSuite.
+type ScheduleSlotUpdateTestSuite struct {
+	suite.Suite
+	conn     *stubConn
+	repo     ScheduleSlotRepository
+	doctorID uuid.UUID
+	start    time.Time
+}
+
+func TestScheduleSlotUpdate(t *testing.T) {
+	suite.Run(t, new(ScheduleSlotUpdateTestSuite))
+}
+
+func (suite *ScheduleSlotUpdateTestSuite) SetupTest() {
+	suite.conn = &stubConn{}
+	db, err := newStubDB(suite.conn)
+	suite.Require().NoError(err)
+	suite.repo = NewScheduleSlotRepository(db)
+	suite.doctorID = uuid.New()
+	suite.start = time.Date(2030, 1, 15, 9, 0, 0, 0, time.UTC)
+}
+
+// respond отвечает на чтение слота строкой без кабинета, а на UPDATE — результатом update.
+// Support function for the tests.
+func (suite *ScheduleSlotUpdateTestSuite) respond(update func() stubResult) {
+	suite.conn.respond = func(query string) stubResult {
+		if strings.HasPrefix(query, "SELECT") {
+			return stubResult{
+				columns: []string{"id", "doctor_id", "room_id"},
+				rows:    [][]driver.Value{{uuid.NewString(), suite.doctorID.String(), nil}},
+			}
+		}
+		return update()
+	}
+}
+
+// Support function for the tests.
+func (suite *ScheduleSlotUpdateTestSuite) lastQuery() string {
+	suite.Require().NotEmpty(suite.conn.queries)
+	return suite.conn.queries[len(suite.conn.queries)-1]
+}
+
+func (suite *ScheduleSlotUpdateTestSuite) TestReserve_SingleConditionalUpdate() {
+	appointmentID := uuid.New()
+	suite.respond(func() stubResult {
+		return stubResult{
+			columns: []string{"id", "appointment_id", "is_available"},
+			rows:    [][]driver.Value{{uuid.NewString(), appointmentID.String(), false}},
+		}
+	})
+
+	slot, err := suite.repo.Reserve(suite.doctorID, suite.start, Reservation{AppointmentID: appointmentID, Token: uuid.New()}, time.Now())
+	suite.Require().NoError(err)
+	suite.Equal(appointmentID, *slot.AppointmentID, "Slot should be read from RETURNING")
+	suite.False(slot.IsAvailable)
+
+	// Проверка свободности — часть самого UPDATE, отдельного чтения перед записью нет
+	query := suite.lastQuery()
+	suite.True(strings.HasPrefix(query, `UPDATE "schedule_slots" SET`), query)
+	suite.Contains(query, "WHERE (doctor_id = $5 AND starts_at = $6) AND (blocked_by IS NULL AND ((is_available AND appointment_id IS NULL) OR held_until <= $7))")
+	suite.Contains(query, "RETURNING *")
+}
+
+func (suite *ScheduleSlotUpdateTestSuite) TestReserve_ParallelBookingsOneRowAffected() {
+	// Postgres обновляет строку одним UPDATE за раз: условию удовлетворяет только первый
+	booked := false
+	suite.respond(func() stubResult {
+		if booked {
+			return stubResult{columns: []string{"id"}}
+		}
+		booked = true
+		return stubResult{columns: []string{"id"}, rows: [][]driver.Value{{uuid.NewString()}}}
+	})
+
+	const bookings = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners int
+		losers  []error
+	)
+	for i := 0; i < bookings; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.repo.Reserve(suite.doctorID, suite.start, Reservation{AppointmentID: uuid.New(), Token: uuid.New()}, time.Now())
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				winners++
+				return
+			}
+			losers = append(losers, err)
+		}()
+	}
+	wg.Wait()
+
+	suite.Equal(1, winners)
+	suite.Len(losers, bookings-1)
+	for _, err := range losers {
+		suite.ErrorIs(err, gorm.ErrRecordNotFound, "Zero rows affected should mean the slot is taken")
+	}
+}
+
+func (suite *ScheduleSlotUpdateTestSuite) TestReserve_ExclusionViolationIsOverlap() {
+	suite.respond(func() stubResult {
+		return stubResult{err: &pgconn.PgError{Code: exclusionViolation}}
+	})
+
+	_, err := suite.repo.Reserve(suite.doctorID, suite.start, Reservation{AppointmentID: uuid.New(), Token: uuid.New()}, time.Now())
+	suite.ErrorIs(err, ErrSlotOverlap)
+}
+
+func (suite *ScheduleSlotUpdateTestSuite) TestRelease_RequiresTokenInUpdate() {
+	appointmentID, token := uuid.New(), uuid.New()
+	suite.respond(func() stubResult { return stubResult{columns: []string{"id"}} })
+
+	_, err := suite.repo.Release(suite.doctorID, suite.start, appointmentID, token)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	query := suite.lastQuery()
+	suite.Contains(query, "AND (appointment_id = $")
+	suite.Contains(query, "AND reservation_token = $")
+	suite.Contains(query, `"is_available"=blocked_by IS NULL`, "Slot inside time off should stay closed")
+	args := suite.conn.args[len(suite.conn.args)-1]
+	suite.Equal(token.String(), args[len(args)-1].Value, "Token should be bound as the last condition")
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduleTemplateRepository описывает доступ к шаблонам расписания
+type ScheduleTemplateRepository interface {
+	Create(t *model.ScheduleTemplate) error
+	GetByID(id uuid.UUID) (*model.ScheduleTemplate, error)
+	ListByDoctor(doctorID uuid.UUID) ([]model.ScheduleTemplate, error)
+	Delete(id uuid.UUID) error
+	// DoctorIDs возвращает врачей, у которых есть хотя бы один шаблон
+	DoctorIDs() ([]uuid.UUID, error)
+}
+
+type scheduleTemplateRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduleTemplateRepository(db *gorm.DB) ScheduleTemplateRepository {
+	return &scheduleTemplateRepo{db: db}
+}
+
+// Create сохраняет шаблон вместе с перерывами
+func (r *scheduleTemplateRepo) Create(t *model.ScheduleTemplate) error {
+	return r.db.Create(t).Error
+}
+
+func (r *scheduleTemplateRepo) GetByID(id uuid.UUID) (*model.ScheduleTemplate, error) {
+	var t model.ScheduleTemplate
+	if err := r.db.Preload("Breaks").First(&t, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *scheduleTemplateRepo) ListByDoctor(doctorID uuid.UUID) ([]model.ScheduleTemplate, error) {
+	var templates []model.ScheduleTemplate
+	err := r.db.Preload("Breaks").
+		Where("doctor_id = ?", doctorID).
+		Order("weekday").Order("start_time").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Delete удаляет шаблон, перерывы удаляются каскадом в БД
+func (r *scheduleTemplateRepo) Delete(id uuid.UUID) error {
+	return r.db.Delete(&model.ScheduleTemplate{}, "id = ?", id).Error
+}
+
+func (r *scheduleTemplateRepo) DoctorIDs() ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.Model(&model.ScheduleTemplate{}).Distinct().Pluck("doctor_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
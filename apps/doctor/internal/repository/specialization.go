@@ -4,13 +4,19 @@ import (
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SpecializationRepository interface {
 	Create(s *model.Specialization) error
 	GetByID(id uuid.UUID) (*model.Specialization, error)
+	// List возвращает все специализации с переводами, упорядоченные по имени
+	List() ([]model.Specialization, error)
+	// Update сохраняет специализацию и заменяет её переводы. Внешний ID меняется только импортом.
 	Update(s *model.Specialization) error
 	Delete(id uuid.UUID) error
+	// InUse — у специализации есть дочерние специализации, врачи или услуги
+	InUse(id uuid.UUID) (bool, error)
 }
 
 type specializationRepo struct {
@@ -27,16 +33,48 @@ func (r *specializationRepo) Create(s *model.Specialization) error {
 
 func (r *specializationRepo) GetByID(id uuid.UUID) (*model.Specialization, error) {
 	var s model.Specialization
-	if err := r.db.First(&s, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Translations", orderByLanguage).First(&s, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &s, nil
 }
 
+func (r *specializationRepo) List() ([]model.Specialization, error) {
+	var specs []model.Specialization
+	if err := r.db.Preload("Translations", orderByLanguage).Order("name").Find(&specs).Error; err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
 func (r *specializationRepo) Update(s *model.Specialization) error {
-	return r.db.Save(s).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations, "ExternalID").Save(s).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.SpecializationTranslation{}, "specialization_id = ?", s.ID).Error; err != nil {
+			return err
+		}
+		if len(s.Translations) == 0 {
+			return nil
+		}
+		return tx.Create(&s.Translations).Error
+	})
 }
 
 func (r *specializationRepo) Delete(id uuid.UUID) error {
 	return r.db.Delete(&model.Specialization{}, "id = ?", id).Error
 }
+
+func (r *specializationRepo) InUse(id uuid.UUID) (bool, error) {
+	var used bool
+	err := r.db.Raw(`SELECT EXISTS (SELECT 1 FROM specializations WHERE parent_id = @id)
+		OR EXISTS (SELECT 1 FROM doctor_specializations WHERE specialization_id = @id)
+		OR EXISTS (SELECT 1 FROM medical_services WHERE specialization_id = @id)`,
+		map[string]any{"id": id}).Scan(&used).Error
+	return used, err
+}
+
+func orderByLanguage(db *gorm.DB) *gorm.DB {
+	return db.Order("language")
+}
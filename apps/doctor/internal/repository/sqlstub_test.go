@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// stubResult — ответ stubConn на один запрос: строки результата или ошибка
+type stubResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+// stubConn — соединение database/sql без базы. Ответ на запрос выбирает respond,
+// текст запросов запоминается, так SQL репозитория проверяется без Postgres.
+type stubConn struct {
+	mu      sync.Mutex
+	respond func(query string) stubResult
+	queries []string
+	args    [][]driver.NamedValue
+}
+
+// newStubDB открывает gorm с диалектом Postgres поверх conn
+func newStubDB(conn *stubConn) (*gorm.DB, error) {
+	return gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(stubConnector{conn: conn})}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+}
+
+// Support function for QueryContext and ExecContext.
+func (c *stubConn) next(query string, args []driver.NamedValue) stubResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, args)
+	if c.respond == nil {
+		return stubResult{}
+	}
+	return c.respond(query)
+}
+
+func (c *stubConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result := c.next(query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+	return &stubRows{columns: result.columns, rows: result.rows}, nil
+}
+
+func (c *stubConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result := c.next(query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+	return driver.RowsAffected(len(result.rows)), nil
+}
+
+func (c *stubConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("stubConn: prepared statements are not supported")
+}
+
+func (c *stubConn) Close() error              { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) { return stubTx{}, nil }
+
+type stubTx struct{}
+
+func (stubTx) Commit() error   { return nil }
+func (stubTx) Rollback() error { return nil }
+
+type stubRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string { return r.columns }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type stubConnector struct {
+	conn *stubConn
+}
+
+func (c stubConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c stubConnector) Driver() driver.Driver                        { return stubDriver{conn: c.conn} }
+
+type stubDriver struct {
+	conn *stubConn
+}
+
+func (d stubDriver) Open(string) (driver.Conn, error) { return d.conn, nil }
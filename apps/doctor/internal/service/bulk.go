@@ -0,0 +1,555 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/bulk"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidImport возвращается, если файл импорта не читается целиком
+var ErrInvalidImport = errors.New("invalid import file")
+
+// errImportRollback откатывает транзакцию пробного или ошибочного импорта
+var errImportRollback = errors.New("import rolled back")
+
+// exportBatch — сколько записей выгрузки читается из БД за раз
+const exportBatch = 500
+
+// ImportReport — итог импорта. При ошибках и в пробном запуске ничего не сохраняется,
+// Created и Updated тогда показывают, что изменилось бы.
+type ImportReport struct {
+	Kind    bulk.Kind    `json:"kind"`
+	DryRun  bool         `json:"dry_run"`
+	Total   int          `json:"total"`
+	Created int          `json:"created"`
+	Updated int          `json:"updated"`
+	Errors  []bulk.Error `json:"errors"`
+}
+
+// reject добавляет ошибку записи в отчёт
+func (r *ImportReport) reject(row int, key, message string) {
+	r.Errors = append(r.Errors, bulk.Error{Row: row, ExternalID: key, Message: message})
+}
+
+// count учитывает созданную или обновлённую запись
+func (r *ImportReport) count(created bool) {
+	if created {
+		r.Created++
+	} else {
+		r.Updated++
+	}
+}
+
+// BulkService импортирует и выгружает специализации, врачей и шаблоны расписания.
+// Записи сопоставляются по внешнему ID, поэтому повторный импорт того же файла ничего не меняет.
+type BulkService interface {
+	// Import загружает файл целиком в одной транзакции: при любой ошибке записи не сохраняется ничего,
+	// а в отчёте перечислены все ошибки. dryRun только проверяет файл.
+	Import(ctx context.Context, kind bulk.Kind, format bulk.Format, r io.Reader, dryRun bool) (*ImportReport, error)
+	// Export пишет все записи вида kind в w, не собирая их в памяти
+	Export(ctx context.Context, kind bulk.Kind, format bulk.Format, w io.Writer) error
+}
+
+type bulkService struct {
+	repo      repository.BulkRepository
+	templates ScheduleTemplateService
+	validate  *validator.Validate
+}
+
+// NewBulkService конструктор, templates нужен для генерации слотов по импортированным шаблонам
+func NewBulkService(repo repository.BulkRepository, templates ScheduleTemplateService) BulkService {
+	// Записи файла проверяются теми же тегами binding, что и запросы API
+	validate := validator.New()
+	validate.SetTagName("binding")
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return &bulkService{repo: repo, templates: templates, validate: validate}
+}
+
+func (s *bulkService) Import(ctx context.Context, kind bulk.Kind, format bulk.Format, r io.Reader, dryRun bool) (*ImportReport, error) {
+	report := &ImportReport{Kind: kind, DryRun: dryRun, Errors: []bulk.Error{}}
+	var doctorIDs []uuid.UUID
+	var err error
+	switch kind {
+	case bulk.KindSpecializations:
+		err = s.importSpecializations(r, format, report)
+	case bulk.KindDoctors:
+		err = s.importDoctors(r, format, report)
+	case bulk.KindScheduleTemplates:
+		doctorIDs, err = s.importTemplates(r, format, report)
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidImport, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(report.Errors, func(a, b bulk.Error) int { return a.Row - b.Row })
+
+	if len(report.Errors) == 0 && !dryRun {
+		// Шаблоны уже сохранены, отставшие слоты догонит периодическая генерация
+		for _, doctorID := range doctorIDs {
+			if _, err := s.templates.GenerateSlots(ctx, doctorID); err != nil {
+				logging.FromContext(ctx).WithError(err).Errorf("Failed to generate slots of doctor %s after import", doctorID)
+			}
+		}
+	}
+	logging.FromContext(ctx).Infof("Imported %s: %d records, %d created, %d updated, %d errors, dry run %t",
+		kind, report.Total, report.Created, report.Updated, len(report.Errors), dryRun)
+	return report, nil
+}
+
+// transaction выполняет импорт и откатывает его при ошибках записей или в пробном запуске
+func (s *bulkService) transaction(report *ImportReport, fn func(tx repository.BulkStore) error) error {
+	err := s.repo.InTransaction(func(tx repository.BulkStore) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if len(report.Errors) > 0 || report.DryRun {
+			return errImportRollback
+		}
+		return nil
+	})
+	if errors.Is(err, errImportRollback) {
+		return nil
+	}
+	return err
+}
+
+func (s *bulkService) importSpecializations(r io.Reader, format bulk.Format, report *ImportReport) error {
+	records, rowErrors, err := bulk.ReadSpecializations(r, format)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	report.Total = len(records) + len(rowErrors)
+	report.Errors = append(report.Errors, rowErrors...)
+
+	seen := make(map[string]bool, len(records))
+	return s.transaction(report, func(tx repository.BulkStore) error {
+		parents, err := tx.SpecializationParents()
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			if msg := s.check(rec, rec.ExternalID, seen); msg != "" {
+				report.reject(rec.Row, rec.ExternalID, msg)
+				continue
+			}
+
+			existing, err := tx.FindSpecialization(rec.ExternalID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			created := existing == nil
+			id := uuid.New()
+			if !created {
+				id = existing.ID
+			}
+
+			req := SpecializationRequest{Name: rec.Name, Description: rec.Description}
+			if rec.ParentExternalID != "" {
+				parent, err := tx.FindSpecialization(rec.ParentExternalID)
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					report.reject(rec.Row, rec.ExternalID, fmt.Sprintf("parent %q not found, it must exist or come earlier in the file", rec.ParentExternalID))
+					continue
+				} else if err != nil {
+					return err
+				}
+				req.ParentID = &parent.ID
+			}
+			for _, t := range rec.Translations {
+				req.Translations = append(req.Translations, SpecializationTranslationDTO{Language: t.Language, Name: t.Name, Description: t.Description})
+			}
+			spec, err := specializationFromRequest(id, req)
+			if err != nil {
+				report.reject(rec.Row, rec.ExternalID, err.Error())
+				continue
+			}
+			if descendantOf(parents, spec.ParentID, id) {
+				report.reject(rec.Row, rec.ExternalID, "parent would create a cycle")
+				continue
+			}
+			other, err := tx.SpecializationByName(spec.Name)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if other != nil && other.ID != id {
+				report.reject(rec.Row, rec.ExternalID, fmt.Sprintf("name %q is taken by another specialization", spec.Name))
+				continue
+			}
+
+			spec.ExternalID = &rec.ExternalID
+			if err := tx.SaveSpecialization(spec, created); err != nil {
+				return err
+			}
+			parents[id] = spec.ParentID
+			report.count(created)
+		}
+		return nil
+	})
+}
+
+func (s *bulkService) importDoctors(r io.Reader, format bulk.Format, report *ImportReport) error {
+	records, rowErrors, err := bulk.ReadDoctors(r, format)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	report.Total = len(records) + len(rowErrors)
+	report.Errors = append(report.Errors, rowErrors...)
+
+	seen := make(map[string]bool, len(records))
+	return s.transaction(report, func(tx repository.BulkStore) error {
+	records:
+		for _, rec := range records {
+			if msg := s.check(rec, rec.ExternalID, seen); msg != "" {
+				report.reject(rec.Row, rec.ExternalID, msg)
+				continue
+			}
+
+			var specIDs []uuid.UUID
+			for _, key := range rec.Specializations {
+				spec, err := tx.FindSpecialization(key)
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					report.reject(rec.Row, rec.ExternalID, fmt.Sprintf("specialization %q not found", key))
+					continue records
+				} else if err != nil {
+					return err
+				}
+				if !slices.Contains(specIDs, spec.ID) {
+					specIDs = append(specIDs, spec.ID)
+				}
+			}
+
+			doc, err := tx.FindDoctor(rec.ExternalID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			created := doc == nil
+			if created {
+				doc = &model.Doctor{ID: uuid.New(), Status: model.Active}
+			}
+			// Формат даты уже проверен тегом datetime
+			doc.DateOfBirth, _ = time.Parse(time.DateOnly, rec.DateOfBirth)
+			doc.ExternalID = &rec.ExternalID
+			doc.FirstName = rec.FirstName
+			doc.LastName = rec.LastName
+			doc.Patronymic = rec.Patronymic
+			if rec.Status != "" {
+				doc.Status = model.DoctorStatus(rec.Status)
+			}
+			doc.TimeZone = rec.TimeZone
+			doc.Email = rec.Email
+			doc.Phone = rec.Phone
+			doc.Bio = rec.Bio
+			doc.ExperienceYears = rec.ExperienceYears
+			doc.Specializations = specializationRefs(specIDs)
+			doc.Languages = languageRefs(rec.Languages)
+
+			if err := tx.SaveDoctor(doc, created); err != nil {
+				return err
+			}
+			report.count(created)
+		}
+		return nil
+	})
+}
+
+// importTemplates возвращает врачей, чьи шаблоны изменились
+func (s *bulkService) importTemplates(r io.Reader, format bulk.Format, report *ImportReport) ([]uuid.UUID, error) {
+	records, rowErrors, err := bulk.ReadScheduleTemplates(r, format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	report.Total = len(records) + len(rowErrors)
+	report.Errors = append(report.Errors, rowErrors...)
+
+	seen := make(map[string]bool, len(records))
+	var doctorIDs []uuid.UUID
+	err = s.transaction(report, func(tx repository.BulkStore) error {
+		for _, rec := range records {
+			if msg := s.check(rec, rec.ExternalID, seen); msg != "" {
+				report.reject(rec.Row, rec.ExternalID, msg)
+				continue
+			}
+
+			doc, err := tx.FindDoctor(rec.DoctorExternalID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				report.reject(rec.Row, rec.ExternalID, fmt.Sprintf("doctor %q not found", rec.DoctorExternalID))
+				continue
+			} else if err != nil {
+				return err
+			}
+			existing, err := tx.FindTemplate(rec.ExternalID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			created := existing == nil
+
+			req := CreateScheduleTemplateRequest{
+				IsOnline:    rec.IsOnline,
+				Weekday:     rec.Weekday,
+				StartTime:   rec.StartTime,
+				EndTime:     rec.EndTime,
+				SlotMinutes: rec.SlotMinutes,
+			}
+			// Форматы дат уже проверены тегом datetime
+			req.ValidFrom, _ = time.Parse(time.DateOnly, rec.ValidFrom)
+			if rec.ValidTo != "" {
+				validTo, _ := time.Parse(time.DateOnly, rec.ValidTo)
+				req.ValidTo = &validTo
+			}
+			for _, b := range rec.Breaks {
+				req.Breaks = append(req.Breaks, ScheduleBreakDTO{StartTime: b.StartTime, EndTime: b.EndTime})
+			}
+			if !created {
+				if existing.DoctorID != doc.ID {
+					report.reject(rec.Row, rec.ExternalID, "template belongs to another doctor")
+					continue
+				}
+				// Филиал, кабинет и услуга задаются через API, импорт их сохраняет
+				req.ClinicID, req.RoomID, req.ServiceID = existing.ClinicID, existing.RoomID, existing.ServiceID
+				if existing.ServiceID != nil && rec.SlotMinutes != existing.SlotMinutes {
+					report.reject(rec.Row, rec.ExternalID, fmt.Sprintf("slot_minutes differs from the %d minutes of the template's service", existing.SlotMinutes))
+					continue
+				}
+			}
+
+			t, err := templateFromRequest(doc.ID, req)
+			if err != nil {
+				report.reject(rec.Row, rec.ExternalID, err.Error())
+				continue
+			}
+			if !created {
+				t.ID, t.CreatedAt = existing.ID, existing.CreatedAt
+				for i := range t.Breaks {
+					t.Breaks[i].TemplateID = t.ID
+				}
+			}
+			others, err := tx.ListTemplates(doc.ID)
+			if err != nil {
+				return err
+			}
+			if i := slices.IndexFunc(others, func(other model.ScheduleTemplate) bool {
+				return other.ID != t.ID && overlaps(t, &other)
+			}); i >= 0 {
+				report.reject(rec.Row, rec.ExternalID, fmt.Sprintf("overlaps template %s", others[i].ID))
+				continue
+			}
+
+			t.ExternalID = &rec.ExternalID
+			if err := tx.SaveTemplate(t, created); err != nil {
+				return err
+			}
+			if !slices.Contains(doctorIDs, doc.ID) {
+				doctorIDs = append(doctorIDs, doc.ID)
+			}
+			report.count(created)
+		}
+		return nil
+	})
+	return doctorIDs, err
+}
+
+// check проверяет запись по тегам binding и уникальность её внешнего ID в файле.
+// Возвращает текст ошибки или пустую строку.
+func (s *bulkService) check(rec any, key string, seen map[string]bool) string {
+	if err := s.validate.Struct(rec); err != nil {
+		var invalid validator.ValidationErrors
+		if !errors.As(err, &invalid) {
+			return err.Error()
+		}
+		messages := make([]string, 0, len(invalid))
+		for _, fe := range invalid {
+			messages = append(messages, fmt.Sprintf("%s failed on the '%s' tag", fe.Field(), fe.Tag()))
+		}
+		return strings.Join(messages, "; ")
+	}
+	if seen[key] {
+		return "duplicate external_id in the file"
+	}
+	seen[key] = true
+	return ""
+}
+
+// descendantOf — parent совпадает с id или лежит в его поддереве
+func descendantOf(parents map[uuid.UUID]*uuid.UUID, parent *uuid.UUID, id uuid.UUID) bool {
+	for depth := 0; parent != nil && depth <= len(parents); depth++ {
+		if *parent == id {
+			return true
+		}
+		parent = parents[*parent]
+	}
+	return parent != nil
+}
+
+func (s *bulkService) Export(ctx context.Context, kind bulk.Kind, format bulk.Format, w io.Writer) error {
+	switch kind {
+	case bulk.KindSpecializations:
+		return s.exportSpecializations(format, w)
+	case bulk.KindDoctors:
+		return s.exportDoctors(format, w)
+	case bulk.KindScheduleTemplates:
+		return s.exportTemplates(format, w)
+	default:
+		return fmt.Errorf("unknown kind %q", kind)
+	}
+}
+
+// exportSpecializations выгружает родителей раньше детей, чтобы файл можно было импортировать обратно
+func (s *bulkService) exportSpecializations(format bulk.Format, w io.Writer) error {
+	specs, err := s.repo.ListSpecializations()
+	if err != nil {
+		return err
+	}
+	keys := specializationKeys(specs)
+	var languages []string
+	for _, spec := range specs {
+		for _, t := range spec.Translations {
+			if !slices.Contains(languages, t.Language) {
+				languages = append(languages, t.Language)
+			}
+		}
+	}
+	slices.Sort(languages)
+
+	children := make(map[uuid.UUID][]*model.Specialization)
+	var order []*model.Specialization
+	for i := range specs {
+		spec := &specs[i]
+		if spec.ParentID == nil || keys[*spec.ParentID] == "" {
+			order = append(order, spec)
+		} else {
+			children[*spec.ParentID] = append(children[*spec.ParentID], spec)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		order = append(order, children[order[i].ID]...)
+		delete(children, order[i].ID)
+	}
+
+	out := bulk.NewSpecializationWriter(w, format, languages)
+	for _, spec := range order {
+		rec := bulk.Specialization{ExternalID: keys[spec.ID], Name: spec.Name, Description: spec.Description}
+		if spec.ParentID != nil {
+			rec.ParentExternalID = keys[*spec.ParentID]
+		}
+		for _, t := range spec.Translations {
+			rec.Translations = append(rec.Translations, bulk.Translation{Language: t.Language, Name: t.Name, Description: t.Description})
+		}
+		if err := out.Write(rec); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+func (s *bulkService) exportDoctors(format bulk.Format, w io.Writer) error {
+	specs, err := s.repo.ListSpecializations()
+	if err != nil {
+		return err
+	}
+	specKeys := specializationKeys(specs)
+
+	out := bulk.NewDoctorWriter(w, format)
+	err = s.repo.EachDoctor(exportBatch, func(docs []model.Doctor) error {
+		for _, doc := range docs {
+			rec := bulk.Doctor{
+				ExternalID:      recordKey(doc.ExternalID, doc.ID),
+				FirstName:       doc.FirstName,
+				LastName:        doc.LastName,
+				Patronymic:      doc.Patronymic,
+				DateOfBirth:     doc.DateOfBirth.Format(time.DateOnly),
+				Status:          string(doc.Status),
+				TimeZone:        doc.TimeZone,
+				Email:           doc.Email,
+				Phone:           doc.Phone,
+				Bio:             doc.Bio,
+				ExperienceYears: doc.ExperienceYears,
+				Languages:       languageCodes(doc.Languages),
+			}
+			for _, spec := range doc.Specializations {
+				rec.Specializations = append(rec.Specializations, specKeys[spec.ID])
+			}
+			if err := out.Write(rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func (s *bulkService) exportTemplates(format bulk.Format, w io.Writer) error {
+	doctorKeys, err := s.repo.DoctorKeys()
+	if err != nil {
+		return err
+	}
+
+	out := bulk.NewScheduleTemplateWriter(w, format)
+	err = s.repo.EachTemplate(exportBatch, func(templates []model.ScheduleTemplate) error {
+		for _, t := range templates {
+			rec := bulk.ScheduleTemplate{
+				ExternalID:       recordKey(t.ExternalID, t.ID),
+				DoctorExternalID: doctorKeys[t.DoctorID],
+				Weekday:          int(t.Weekday),
+				StartTime:        t.StartTime.String(),
+				EndTime:          t.EndTime.String(),
+				SlotMinutes:      t.SlotMinutes,
+				ValidFrom:        t.ValidFrom.Format(time.DateOnly),
+				IsOnline:         t.IsOnline,
+			}
+			if t.ValidTo != nil {
+				rec.ValidTo = t.ValidTo.Format(time.DateOnly)
+			}
+			for _, b := range t.Breaks {
+				rec.Breaks = append(rec.Breaks, bulk.Break{StartTime: b.StartTime.String(), EndTime: b.EndTime.String()})
+			}
+			if err := out.Write(rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// recordKey — ключ записи в выгрузке: внешний ID, а без него — ID
+func recordKey(externalID *string, id uuid.UUID) string {
+	if externalID != nil {
+		return *externalID
+	}
+	return id.String()
+}
+
+// Support function for exportSpecializations and exportDoctors.
+func specializationKeys(specs []model.Specialization) map[uuid.UUID]string {
+	keys := make(map[uuid.UUID]string, len(specs))
+	for _, spec := range specs {
+		keys[spec.ID] = recordKey(spec.ExternalID, spec.ID)
+	}
+	return keys
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/bulk"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakeBulkRepo хранит записи в памяти, ошибка в транзакции возвращает прежнее состояние
+type fakeBulkRepo struct {
+	specs     []model.Specialization
+	doctors   []model.Doctor
+	templates []model.ScheduleTemplate
+}
+
+func (r *fakeBulkRepo) InTransaction(fn func(tx repository.BulkStore) error) error {
+	saved := fakeBulkRepo{specs: slices.Clone(r.specs), doctors: slices.Clone(r.doctors), templates: slices.Clone(r.templates)}
+	if err := fn(r); err != nil {
+		*r = saved
+		return err
+	}
+	return nil
+}
+
+func (r *fakeBulkRepo) ListSpecializations() ([]model.Specialization, error) {
+	return slices.Clone(r.specs), nil
+}
+
+func (r *fakeBulkRepo) DoctorKeys() (map[uuid.UUID]string, error) {
+	keys := make(map[uuid.UUID]string)
+	for _, doc := range r.doctors {
+		keys[doc.ID] = recordKey(doc.ExternalID, doc.ID)
+	}
+	return keys, nil
+}
+
+func (r *fakeBulkRepo) EachDoctor(batch int, fn func(docs []model.Doctor) error) error {
+	return fn(r.doctors)
+}
+
+func (r *fakeBulkRepo) EachTemplate(batch int, fn func(templates []model.ScheduleTemplate) error) error {
+	return fn(r.templates)
+}
+
+// Support function for the fake lookups by key.
+func keyMatches(externalID *string, id uuid.UUID, key string) bool {
+	return recordKey(externalID, id) == key
+}
+
+func (r *fakeBulkRepo) FindSpecialization(key string) (*model.Specialization, error) {
+	for _, s := range r.specs {
+		if keyMatches(s.ExternalID, s.ID, key) {
+			return &s, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeBulkRepo) SpecializationByName(name string) (*model.Specialization, error) {
+	for _, s := range r.specs {
+		if s.Name == name {
+			return &s, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeBulkRepo) SaveSpecialization(s *model.Specialization, created bool) error {
+	if created {
+		r.specs = append(r.specs, *s)
+		return nil
+	}
+	for i := range r.specs {
+		if r.specs[i].ID == s.ID {
+			r.specs[i] = *s
+		}
+	}
+	return nil
+}
+
+func (r *fakeBulkRepo) SpecializationParents() (map[uuid.UUID]*uuid.UUID, error) {
+	parents := make(map[uuid.UUID]*uuid.UUID)
+	for _, s := range r.specs {
+		parents[s.ID] = s.ParentID
+	}
+	return parents, nil
+}
+
+func (r *fakeBulkRepo) FindDoctor(key string) (*model.Doctor, error) {
+	for _, doc := range r.doctors {
+		if keyMatches(doc.ExternalID, doc.ID, key) {
+			return &doc, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeBulkRepo) SaveDoctor(d *model.Doctor, created bool) error {
+	if created {
+		r.doctors = append(r.doctors, *d)
+		return nil
+	}
+	for i := range r.doctors {
+		if r.doctors[i].ID == d.ID {
+			r.doctors[i] = *d
+		}
+	}
+	return nil
+}
+
+func (r *fakeBulkRepo) FindTemplate(key string) (*model.ScheduleTemplate, error) {
+	for _, t := range r.templates {
+		if keyMatches(t.ExternalID, t.ID, key) {
+			return &t, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeBulkRepo) ListTemplates(doctorID uuid.UUID) ([]model.ScheduleTemplate, error) {
+	var templates []model.ScheduleTemplate
+	for _, t := range r.templates {
+		if t.DoctorID == doctorID {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+func (r *fakeBulkRepo) SaveTemplate(t *model.ScheduleTemplate, created bool) error {
+	if created {
+		r.templates = append(r.templates, *t)
+		return nil
+	}
+	for i := range r.templates {
+		if r.templates[i].ID == t.ID {
+			r.templates[i] = *t
+		}
+	}
+	return nil
+}
+
+// fakeSlotGenerator запоминает врачей, для которых генерировались слоты
+type fakeSlotGenerator struct {
+	ScheduleTemplateService
+	generated []uuid.UUID
+}
+
+func (g *fakeSlotGenerator) GenerateSlots(ctx context.Context, doctorID uuid.UUID) (*GenerateSlotsResponse, error) {
+	g.generated = append(g.generated, doctorID)
+	return &GenerateSlotsResponse{}, nil
+}
+
+type BulkServiceTestSuite struct {
+	suite.Suite
+	repo      *fakeBulkRepo
+	generator *fakeSlotGenerator
+	service   BulkService
+}
+
+func TestBulkService(t *testing.T) {
+	suite.Run(t, new(BulkServiceTestSuite))
+}
+
+func (suite *BulkServiceTestSuite) SetupTest() {
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	suite.repo = &fakeBulkRepo{}
+	suite.generator = &fakeSlotGenerator{}
+	suite.service = NewBulkService(suite.repo, suite.generator)
+}
+
+// Support function for importing a file and requiring it to be readable.
+func (suite *BulkServiceTestSuite) importFile(kind bulk.Kind, format bulk.Format, file string, dryRun bool) *ImportReport {
+	report, err := suite.service.Import(context.Background(), kind, format, strings.NewReader(file), dryRun)
+	suite.Require().NoError(err)
+	return report
+}
+
+const specializationsFile = `[
+	{"external_id": "surgery", "name": "Хирург", "translations": [{"language": "en", "name": "Surgeon"}]},
+	{"external_id": "cardiac-surgery", "name": "Кардиохирург", "parent_external_id": "surgery"}
+]`
+
+func (suite *BulkServiceTestSuite) TestImportSpecializations_DryRunAndUpsert() {
+	report := suite.importFile(bulk.KindSpecializations, bulk.FormatJSON, specializationsFile, true)
+	suite.Equal(ImportReport{Kind: bulk.KindSpecializations, DryRun: true, Total: 2, Created: 2, Errors: []bulk.Error{}}, *report)
+	suite.Empty(suite.repo.specs, "dry run saves nothing")
+
+	report = suite.importFile(bulk.KindSpecializations, bulk.FormatJSON, specializationsFile, false)
+	suite.Equal(2, report.Created)
+	suite.Require().Len(suite.repo.specs, 2)
+	surgery := suite.repo.specs[0]
+	suite.Equal("surgery", *surgery.ExternalID)
+	suite.Equal(surgery.ID, *suite.repo.specs[1].ParentID)
+
+	// Повторный импорт обновляет те же записи
+	report = suite.importFile(bulk.KindSpecializations, bulk.FormatJSON, specializationsFile, false)
+	suite.Equal(0, report.Created)
+	suite.Equal(2, report.Updated)
+	suite.Len(suite.repo.specs, 2)
+	suite.Equal(surgery.ID, suite.repo.specs[0].ID)
+}
+
+func (suite *BulkServiceTestSuite) TestImportSpecializations_Cycle() {
+	suite.importFile(bulk.KindSpecializations, bulk.FormatJSON, specializationsFile, false)
+
+	report := suite.importFile(bulk.KindSpecializations, bulk.FormatJSON,
+		`[{"external_id": "surgery", "name": "Хирург", "parent_external_id": "cardiac-surgery"}]`, false)
+	suite.Equal([]bulk.Error{{Row: 1, ExternalID: "surgery", Message: "parent would create a cycle"}}, report.Errors)
+	suite.Nil(suite.repo.specs[0].ParentID)
+}
+
+func (suite *BulkServiceTestSuite) TestImportDoctors_ErrorsRollBack() {
+	suite.importFile(bulk.KindSpecializations, bulk.FormatJSON, specializationsFile, false)
+	file := "external_id,first_name,last_name,date_of_birth,specializations,email\n" +
+		"d1,Иван,Петров,1980-05-01,surgery,ivan@example.com\n" +
+		"d2,Анна,Смирнова,1990-01-01,dentistry,\n" +
+		"d1,Иван,Петров,1980-05-01,surgery,\n" +
+		"d3,Олег,,1985-02-03,surgery,not-an-email\n"
+
+	report := suite.importFile(bulk.KindDoctors, bulk.FormatCSV, file, false)
+	suite.Equal(4, report.Total)
+	suite.Equal([]bulk.Error{
+		{Row: 2, ExternalID: "d2", Message: `specialization "dentistry" not found`},
+		{Row: 3, ExternalID: "d1", Message: "duplicate external_id in the file"},
+		{Row: 4, ExternalID: "d3", Message: "last_name failed on the 'required' tag; email failed on the 'email' tag"},
+	}, report.Errors)
+	suite.Empty(suite.repo.doctors, "one bad record rolls back the whole file")
+
+	report = suite.importFile(bulk.KindDoctors, bulk.FormatCSV, strings.Join(strings.Split(file, "\n")[:2], "\n"), false)
+	suite.Empty(report.Errors)
+	suite.Require().Len(suite.repo.doctors, 1)
+	suite.Equal(model.Active, suite.repo.doctors[0].Status)
+	suite.Equal(suite.repo.specs[0].ID, suite.repo.doctors[0].Specializations[0].ID)
+}
+
+func (suite *BulkServiceTestSuite) TestImportTemplates() {
+	doctorID := uuid.New()
+	key := "d1"
+	suite.repo.doctors = []model.Doctor{{ID: doctorID, ExternalID: &key}}
+	file := `[
+		{"external_id": "t1", "doctor_external_id": "d1", "weekday": 1, "start_time": "09:00", "end_time": "13:00", "slot_minutes": 30, "valid_from": "2025-01-01",
+			"breaks": [{"start_time": "11:00", "end_time": "11:30"}]},
+		{"external_id": "t2", "doctor_external_id": "d1", "weekday": 1, "start_time": "14:00", "end_time": "18:00", "slot_minutes": 30, "valid_from": "2025-01-01"}
+	]`
+
+	report := suite.importFile(bulk.KindScheduleTemplates, bulk.FormatJSON, file, false)
+	suite.Empty(report.Errors)
+	suite.Equal(2, report.Created)
+	suite.Require().Len(suite.repo.templates, 2)
+	suite.Equal([]uuid.UUID{doctorID}, suite.generator.generated)
+	suite.Len(suite.repo.templates[0].Breaks, 1)
+
+	// Сдвиг часов обновляет шаблон на месте, но пересечение с соседним отклоняется
+	report = suite.importFile(bulk.KindScheduleTemplates, bulk.FormatJSON,
+		`[{"external_id": "t1", "doctor_external_id": "d1", "weekday": 1, "start_time": "09:00", "end_time": "15:00", "slot_minutes": 30, "valid_from": "2025-01-01"}]`, false)
+	suite.Require().Len(report.Errors, 1)
+	suite.Contains(report.Errors[0].Message, "overlaps template")
+
+	report = suite.importFile(bulk.KindScheduleTemplates, bulk.FormatJSON,
+		`[{"external_id": "t1", "doctor_external_id": "d1", "weekday": 1, "start_time": "08:00", "end_time": "12:00", "slot_minutes": 30, "valid_from": "2025-01-01"}]`, false)
+	suite.Empty(report.Errors)
+	suite.Equal(1, report.Updated)
+	suite.Equal("08:00", suite.repo.templates[0].StartTime.String())
+	suite.Empty(suite.repo.templates[0].Breaks)
+}
+
+func (suite *BulkServiceTestSuite) TestImport_Malformed() {
+	_, err := suite.service.Import(context.Background(), bulk.KindDoctors, bulk.FormatJSON, strings.NewReader(`{"external_id": "d1"}`), false)
+	suite.ErrorIs(err, ErrInvalidImport)
+}
+
+func (suite *BulkServiceTestSuite) TestExportSpecializations_ParentsFirst() {
+	suite.importFile(bulk.KindSpecializations, bulk.FormatJSON, specializationsFile, false)
+	// Без внешнего ID ключом выгрузки служит ID
+	child := model.Specialization{ID: uuid.New(), ParentID: &suite.repo.specs[1].ID, Name: "Детский кардиохирург"}
+	suite.repo.specs = append([]model.Specialization{child}, suite.repo.specs...)
+
+	var buf bytes.Buffer
+	suite.Require().NoError(suite.service.Export(context.Background(), bulk.KindSpecializations, bulk.FormatCSV, &buf))
+	suite.Equal("external_id,name,description,parent_external_id,name.en,description.en\n"+
+		"surgery,Хирург,,,Surgeon,\n"+
+		"cardiac-surgery,Кардиохирург,,surgery,,\n"+
+		child.ID.String()+",Детский кардиохирург,,cardiac-surgery,,\n", buf.String())
+
+	// Выгрузка импортируется обратно без изменений
+	report := suite.importFile(bulk.KindSpecializations, bulk.FormatCSV, buf.String(), false)
+	suite.Empty(report.Errors)
+	suite.Equal(3, report.Updated)
+}
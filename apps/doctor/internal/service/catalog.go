@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrServiceNotFound возвращается, если услуги нет в каталоге
+var ErrServiceNotFound = errors.New("medical service not found")
+
+// defaultCurrency — валюта цен, если она не указана
+const defaultCurrency = "KZT"
+
+// CatalogService управляет каталогом услуг. Услуги принадлежат специализациям,
+// врач оказывает услуги своих специализаций.
+type CatalogService interface {
+	CreateService(ctx context.Context, specializationID uuid.UUID, req MedicalServiceRequest) (*MedicalServiceDTO, error)
+	// ListServices возвращает действующие услуги специализации
+	ListServices(ctx context.Context, specializationID uuid.UUID) ([]MedicalServiceDTO, error)
+	UpdateService(ctx context.Context, id uuid.UUID, req MedicalServiceRequest) (*MedicalServiceDTO, error)
+	DeleteService(ctx context.Context, id uuid.UUID) error
+	// ListDoctorServices возвращает действующие услуги по специализациям врача
+	ListDoctorServices(ctx context.Context, doctorID uuid.UUID) ([]MedicalServiceDTO, error)
+}
+
+type catalogService struct {
+	services repository.MedicalServiceRepository
+	specs    repository.SpecializationRepository
+	doctors  repository.DoctorRepository
+}
+
+// NewCatalogService конструктор
+func NewCatalogService(services repository.MedicalServiceRepository, specs repository.SpecializationRepository, doctors repository.DoctorRepository) CatalogService {
+	return &catalogService{services: services, specs: specs, doctors: doctors}
+}
+
+func (s *catalogService) CreateService(ctx context.Context, specializationID uuid.UUID, req MedicalServiceRequest) (*MedicalServiceDTO, error) {
+	if _, err := s.specs.GetByID(specializationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSpecNotFound
+		}
+		return nil, err
+	}
+
+	svc := &model.MedicalService{ID: uuid.New(), SpecializationID: specializationID}
+	applyServiceRequest(svc, req)
+	if err := s.services.Create(svc); err != nil {
+		return nil, err
+	}
+	dto := toMedicalServiceDTO(svc)
+	return &dto, nil
+}
+
+func (s *catalogService) ListServices(ctx context.Context, specializationID uuid.UUID) ([]MedicalServiceDTO, error) {
+	if _, err := s.specs.GetByID(specializationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSpecNotFound
+		}
+		return nil, err
+	}
+	services, err := s.services.ListBySpecializations([]uuid.UUID{specializationID}, true)
+	if err != nil {
+		return nil, err
+	}
+	return toMedicalServiceDTOs(services), nil
+}
+
+func (s *catalogService) UpdateService(ctx context.Context, id uuid.UUID, req MedicalServiceRequest) (*MedicalServiceDTO, error) {
+	svc, err := s.services.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrServiceNotFound
+		}
+		return nil, err
+	}
+	// Длительность меняется только для новых шаблонов, уже нарезанные слоты остаются прежними
+	applyServiceRequest(svc, req)
+	if err := s.services.Update(svc); err != nil {
+		return nil, err
+	}
+	dto := toMedicalServiceDTO(svc)
+	return &dto, nil
+}
+
+func (s *catalogService) DeleteService(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.services.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrServiceNotFound
+		}
+		return err
+	}
+	return s.services.Delete(id)
+}
+
+func (s *catalogService) ListDoctorServices(ctx context.Context, doctorID uuid.UUID) ([]MedicalServiceDTO, error) {
+	doc, err := s.doctors.GetByID(doctorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(doc.Specializations))
+	for _, spec := range doc.Specializations {
+		ids = append(ids, spec.ID)
+	}
+	services, err := s.services.ListBySpecializations(ids, true)
+	if err != nil {
+		return nil, err
+	}
+	return toMedicalServiceDTOs(services), nil
+}
+
+// Support function for CreateService and UpdateService.
+func applyServiceRequest(svc *model.MedicalService, req MedicalServiceRequest) {
+	svc.Name = req.Name
+	svc.Description = req.Description
+	svc.DurationMinutes = req.DurationMinutes
+	svc.Price = req.Price
+	svc.Currency = req.Currency
+	if svc.Currency == "" {
+		svc.Currency = defaultCurrency
+	}
+	svc.IsActive = req.IsActive == nil || *req.IsActive
+}
+
+func toMedicalServiceDTO(svc *model.MedicalService) MedicalServiceDTO {
+	return MedicalServiceDTO{
+		ID:               svc.ID,
+		SpecializationID: svc.SpecializationID,
+		Name:             svc.Name,
+		Description:      svc.Description,
+		DurationMinutes:  svc.DurationMinutes,
+		Price:            svc.Price,
+		Currency:         svc.Currency,
+		IsActive:         svc.IsActive,
+	}
+}
+
+// Support function for ListServices and ListDoctorServices.
+func toMedicalServiceDTOs(services []model.MedicalService) []MedicalServiceDTO {
+	dtos := make([]MedicalServiceDTO, 0, len(services))
+	for i := range services {
+		dtos = append(dtos, toMedicalServiceDTO(&services[i]))
+	}
+	return dtos
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakeServiceRepo хранит каталог услуг в памяти
+type fakeServiceRepo struct {
+	services []model.MedicalService
+}
+
+func (r *fakeServiceRepo) Create(s *model.MedicalService) error {
+	r.services = append(r.services, *s)
+	return nil
+}
+
+func (r *fakeServiceRepo) GetByID(id uuid.UUID) (*model.MedicalService, error) {
+	for i := range r.services {
+		if r.services[i].ID == id {
+			svc := r.services[i]
+			return &svc, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeServiceRepo) Update(s *model.MedicalService) error {
+	for i := range r.services {
+		if r.services[i].ID == s.ID {
+			r.services[i] = *s
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *fakeServiceRepo) Delete(id uuid.UUID) error {
+	for i := range r.services {
+		if r.services[i].ID == id {
+			r.services = append(r.services[:i], r.services[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeServiceRepo) ListBySpecializations(ids []uuid.UUID, activeOnly bool) ([]model.MedicalService, error) {
+	services := []model.MedicalService{}
+	for _, svc := range r.services {
+		for _, id := range ids {
+			if svc.SpecializationID == id && (svc.IsActive || !activeOnly) {
+				services = append(services, svc)
+			}
+		}
+	}
+	return services, nil
+}
+
+type CatalogServiceTestSuite struct {
+	suite.Suite
+	services *fakeServiceRepo
+	specs    *fakeSpecRepo
+	doctors  *fakeDoctorRepo
+	service  CatalogService
+	specID   uuid.UUID
+	doctorID uuid.UUID
+}
+
+func TestCatalogService(t *testing.T) {
+	suite.Run(t, new(CatalogServiceTestSuite))
+}
+
+func (suite *CatalogServiceTestSuite) SetupTest() {
+	suite.specID = uuid.New()
+	suite.doctorID = uuid.New()
+	suite.services = &fakeServiceRepo{}
+	suite.specs = &fakeSpecRepo{specs: []model.Specialization{{ID: suite.specID, Name: "Кардиолог"}, {ID: uuid.New(), Name: "Терапевт"}}}
+	suite.doctors = &fakeDoctorRepo{docs: []model.Doctor{{ID: suite.doctorID, Specializations: []model.Specialization{{ID: suite.specID}}}}}
+	suite.service = NewCatalogService(suite.services, suite.specs, suite.doctors)
+}
+
+func (suite *CatalogServiceTestSuite) TestCreateService() {
+	ctx := context.Background()
+
+	dto, err := suite.service.CreateService(ctx, suite.specID, MedicalServiceRequest{Name: "Консультация", DurationMinutes: 45, Price: 1500000})
+	suite.Require().NoError(err)
+	suite.Equal("KZT", dto.Currency)
+	suite.True(dto.IsActive)
+	suite.Equal(45, dto.DurationMinutes)
+
+	_, err = suite.service.CreateService(ctx, uuid.New(), MedicalServiceRequest{Name: "Консультация", DurationMinutes: 45})
+	suite.ErrorIs(err, ErrSpecNotFound)
+}
+
+func (suite *CatalogServiceTestSuite) TestListDoctorServices() {
+	ctx := context.Background()
+	inactive := false
+	consultation, err := suite.service.CreateService(ctx, suite.specID, MedicalServiceRequest{Name: "Консультация", DurationMinutes: 30})
+	suite.Require().NoError(err)
+	ecg, err := suite.service.CreateService(ctx, suite.specID, MedicalServiceRequest{Name: "ЭКГ", DurationMinutes: 15})
+	suite.Require().NoError(err)
+	_, err = suite.service.CreateService(ctx, suite.specs.specs[1].ID, MedicalServiceRequest{Name: "Осмотр", DurationMinutes: 20})
+	suite.Require().NoError(err)
+
+	// Снятая услуга пропадает из списков
+	_, err = suite.service.UpdateService(ctx, ecg.ID, MedicalServiceRequest{Name: "ЭКГ", DurationMinutes: 15, IsActive: &inactive})
+	suite.Require().NoError(err)
+
+	services, err := suite.service.ListDoctorServices(ctx, suite.doctorID)
+	suite.Require().NoError(err)
+	suite.Equal([]MedicalServiceDTO{*consultation}, services)
+
+	_, err = suite.service.ListDoctorServices(ctx, uuid.New())
+	suite.ErrorIs(err, ErrNotFound)
+	_, err = suite.service.UpdateService(ctx, uuid.New(), MedicalServiceRequest{Name: "Нет", DurationMinutes: 15})
+	suite.ErrorIs(err, ErrServiceNotFound)
+	suite.ErrorIs(suite.service.DeleteService(ctx, uuid.New()), ErrServiceNotFound)
+}
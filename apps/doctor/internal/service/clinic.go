@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrClinicNotFound возвращается, если филиала нет
+var ErrClinicNotFound = errors.New("clinic not found")
+
+// ErrDoctorNotInClinic возвращается при откреплении врача, который в филиале не принимает
+var ErrDoctorNotInClinic = errors.New("doctor is not assigned to the clinic")
+
+// ClinicService управляет филиалами, их кабинетами и врачами
+type ClinicService interface {
+	CreateClinic(ctx context.Context, req CreateClinicRequest) (*ClinicDTO, error)
+	GetClinic(ctx context.Context, id uuid.UUID) (*ClinicDTO, error)
+	ListClinics(ctx context.Context) ([]ClinicDTO, error)
+	AddRoom(ctx context.Context, clinicID uuid.UUID, req CreateRoomRequest) (*RoomDTO, error)
+	// AssignDoctor приписывает врача к филиалу, после этого ему можно задать шаблоны в филиале
+	AssignDoctor(ctx context.Context, clinicID, doctorID uuid.UUID) error
+	// UnassignDoctor открепляет врача. Шаблоны и слоты врача в филиале остаются,
+	// их удаляют отдельно.
+	UnassignDoctor(ctx context.Context, clinicID, doctorID uuid.UUID) error
+}
+
+type clinicService struct {
+	clinics repository.ClinicRepository
+	doctors repository.DoctorRepository
+}
+
+// NewClinicService конструктор
+func NewClinicService(clinics repository.ClinicRepository, doctors repository.DoctorRepository) ClinicService {
+	return &clinicService{clinics: clinics, doctors: doctors}
+}
+
+func (s *clinicService) CreateClinic(ctx context.Context, req CreateClinicRequest) (*ClinicDTO, error) {
+	c := &model.Clinic{
+		ID:       uuid.New(),
+		Name:     req.Name,
+		Address:  req.Address,
+		TimeZone: req.TimeZone,
+	}
+	for _, room := range req.Rooms {
+		c.Rooms = append(c.Rooms, roomFromRequest(c.ID, room))
+	}
+	if err := s.clinics.Create(c); err != nil {
+		return nil, err
+	}
+	dto := toClinicDTO(c)
+	return &dto, nil
+}
+
+func (s *clinicService) GetClinic(ctx context.Context, id uuid.UUID) (*ClinicDTO, error) {
+	c, err := s.getClinic(id)
+	if err != nil {
+		return nil, err
+	}
+	dto := toClinicDTO(c)
+	return &dto, nil
+}
+
+func (s *clinicService) ListClinics(ctx context.Context) ([]ClinicDTO, error) {
+	clinics, err := s.clinics.List()
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]ClinicDTO, 0, len(clinics))
+	for i := range clinics {
+		dtos = append(dtos, toClinicDTO(&clinics[i]))
+	}
+	return dtos, nil
+}
+
+func (s *clinicService) AddRoom(ctx context.Context, clinicID uuid.UUID, req CreateRoomRequest) (*RoomDTO, error) {
+	if _, err := s.getClinic(clinicID); err != nil {
+		return nil, err
+	}
+	room := roomFromRequest(clinicID, req)
+	if err := s.clinics.CreateRoom(&room); err != nil {
+		return nil, err
+	}
+	dto := toRoomDTO(room)
+	return &dto, nil
+}
+
+func (s *clinicService) AssignDoctor(ctx context.Context, clinicID, doctorID uuid.UUID) error {
+	if _, err := s.getClinic(clinicID); err != nil {
+		return err
+	}
+	if _, err := s.doctors.GetByID(doctorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return s.clinics.AssignDoctor(clinicID, doctorID)
+}
+
+func (s *clinicService) UnassignDoctor(ctx context.Context, clinicID, doctorID uuid.UUID) error {
+	removed, err := s.clinics.UnassignDoctor(clinicID, doctorID)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrDoctorNotInClinic
+	}
+	return nil
+}
+
+func (s *clinicService) getClinic(id uuid.UUID) (*model.Clinic, error) {
+	c, err := s.clinics.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClinicNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func roomFromRequest(clinicID uuid.UUID, req CreateRoomRequest) model.Room {
+	capacity := req.Capacity
+	if capacity == 0 {
+		capacity = 1
+	}
+	return model.Room{ID: uuid.New(), ClinicID: clinicID, Name: req.Name, Capacity: capacity}
+}
+
+func toClinicDTO(c *model.Clinic) ClinicDTO {
+	dto := ClinicDTO{
+		ID:       c.ID,
+		Name:     c.Name,
+		Address:  c.Address,
+		TimeZone: c.TimeZone,
+		Rooms:    make([]RoomDTO, 0, len(c.Rooms)),
+	}
+	for _, room := range c.Rooms {
+		dto.Rooms = append(dto.Rooms, toRoomDTO(room))
+	}
+	return dto
+}
+
+func toRoomDTO(room model.Room) RoomDTO {
+	return RoomDTO{ID: room.ID, Name: room.Name, Capacity: room.Capacity}
+}
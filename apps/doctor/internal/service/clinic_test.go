@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakeClinicRepo хранит филиалы, кабинеты и приписку врачей в памяти
+type fakeClinicRepo struct {
+	repository.ClinicRepository
+	clinics []model.Clinic
+	rooms   []model.Room
+	doctors map[model.DoctorClinic]bool
+}
+
+func (r *fakeClinicRepo) Create(c *model.Clinic) error {
+	r.clinics = append(r.clinics, *c)
+	r.rooms = append(r.rooms, c.Rooms...)
+	return nil
+}
+
+func (r *fakeClinicRepo) GetByID(id uuid.UUID) (*model.Clinic, error) {
+	for _, c := range r.clinics {
+		if c.ID == id {
+			c.Rooms = nil
+			for _, room := range r.rooms {
+				if room.ClinicID == id {
+					c.Rooms = append(c.Rooms, room)
+				}
+			}
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeClinicRepo) CreateRoom(room *model.Room) error {
+	r.rooms = append(r.rooms, *room)
+	return nil
+}
+
+func (r *fakeClinicRepo) GetRoom(id uuid.UUID) (*model.Room, error) {
+	for _, room := range r.rooms {
+		if room.ID == id {
+			return &room, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeClinicRepo) AssignDoctor(clinicID, doctorID uuid.UUID) error {
+	if r.doctors == nil {
+		r.doctors = make(map[model.DoctorClinic]bool)
+	}
+	r.doctors[model.DoctorClinic{DoctorID: doctorID, ClinicID: clinicID}] = true
+	return nil
+}
+
+func (r *fakeClinicRepo) UnassignDoctor(clinicID, doctorID uuid.UUID) (int64, error) {
+	key := model.DoctorClinic{DoctorID: doctorID, ClinicID: clinicID}
+	if !r.doctors[key] {
+		return 0, nil
+	}
+	delete(r.doctors, key)
+	return 1, nil
+}
+
+func (r *fakeClinicRepo) HasDoctor(clinicID, doctorID uuid.UUID) (bool, error) {
+	return r.doctors[model.DoctorClinic{DoctorID: doctorID, ClinicID: clinicID}], nil
+}
+
+type ClinicServiceTestSuite struct {
+	suite.Suite
+	clinics  *fakeClinicRepo
+	doctors  *fakeDoctorRepo
+	service  ClinicService
+	doctorID uuid.UUID
+}
+
+func TestClinicService(t *testing.T) {
+	suite.Run(t, new(ClinicServiceTestSuite))
+}
+
+func (suite *ClinicServiceTestSuite) SetupTest() {
+	suite.clinics = &fakeClinicRepo{}
+	suite.doctorID = uuid.New()
+	suite.doctors = &fakeDoctorRepo{docs: []model.Doctor{{ID: suite.doctorID}}}
+	suite.service = NewClinicService(suite.clinics, suite.doctors)
+}
+
+func (suite *ClinicServiceTestSuite) TestCreateClinic_DefaultRoomCapacity() {
+	dto, err := suite.service.CreateClinic(context.Background(), CreateClinicRequest{
+		Name:     "Центральный",
+		TimeZone: "Asia/Aqtobe",
+		Rooms:    []CreateRoomRequest{{Name: "101"}, {Name: "Процедурная", Capacity: 3}},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(dto.Rooms, 2)
+	suite.Equal(1, dto.Rooms[0].Capacity)
+	suite.Equal(3, dto.Rooms[1].Capacity)
+
+	room, err := suite.service.AddRoom(context.Background(), dto.ID, CreateRoomRequest{Name: "102"})
+	suite.Require().NoError(err)
+	suite.Equal(1, room.Capacity)
+
+	got, err := suite.service.GetClinic(context.Background(), dto.ID)
+	suite.Require().NoError(err)
+	suite.Len(got.Rooms, 3)
+}
+
+func (suite *ClinicServiceTestSuite) TestAssignDoctor() {
+	clinic, err := suite.service.CreateClinic(context.Background(), CreateClinicRequest{Name: "Западный", TimeZone: "Asia/Aqtobe"})
+	suite.Require().NoError(err)
+
+	suite.ErrorIs(suite.service.AssignDoctor(context.Background(), uuid.New(), suite.doctorID), ErrClinicNotFound)
+	suite.ErrorIs(suite.service.AssignDoctor(context.Background(), clinic.ID, uuid.New()), ErrNotFound)
+	suite.ErrorIs(suite.service.UnassignDoctor(context.Background(), clinic.ID, suite.doctorID), ErrDoctorNotInClinic)
+
+	suite.Require().NoError(suite.service.AssignDoctor(context.Background(), clinic.ID, suite.doctorID))
+	suite.True(suite.clinics.doctors[model.DoctorClinic{DoctorID: suite.doctorID, ClinicID: clinic.ID}])
+	suite.NoError(suite.service.UnassignDoctor(context.Background(), clinic.ID, suite.doctorID))
+}
@@ -2,32 +2,61 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/locale"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/language"
 	"gorm.io/gorm"
 )
 
 // ErrNotFound возвращается, если врач не найден
 var ErrNotFound = errors.New("doctor not found")
 
+// ErrUserLinked возвращается, если пользователь уже привязан к другому врачу
+var ErrUserLinked = errors.New("user is linked to another doctor")
+
+// ErrInvalidSearch возвращается при неверных параметрах поиска: сортировке, диапазоне дат или курсоре
+var ErrInvalidSearch = errors.New("invalid search parameters")
+
+// errInvalidCursor — курсор повреждён или выдан для другой сортировки
+var errInvalidCursor = fmt.Errorf("%w: invalid cursor", ErrInvalidSearch)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
 // DoctorService описывает бизнес-логику над врачами
 type DoctorService interface {
 	CreateDoctor(ctx context.Context, req CreateDoctorRequest) (*CreateDoctorResponse, error)
 	GetDoctorByID(ctx context.Context, id uuid.UUID) (*DoctorDTO, error)
 	UpdateDoctor(ctx context.Context, req UpdateDoctorRequest) (*DoctorDTO, error)
 	DeleteDoctor(ctx context.Context, id uuid.UUID) (*DeleteDoctorResponse, error)
+	SearchDoctors(ctx context.Context, req SearchDoctorsRequest) (*DoctorListResponse, error)
+	// GetDoctorByUserID возвращает профиль врача, привязанный к пользователю auth
+	GetDoctorByUserID(ctx context.Context, userID int64) (*DoctorDTO, error)
+	// LinkUser привязывает врача к пользователю auth, прежняя привязка врача заменяется
+	LinkUser(ctx context.Context, id uuid.UUID, userID int64) (*DoctorDTO, error)
+	UnlinkUser(ctx context.Context, id uuid.UUID) error
 }
 
 type doctorService struct {
 	repo repository.DoctorRepository
+	now  func() time.Time
 }
 
 // NewDoctorService конструктор
 func NewDoctorService(repo repository.DoctorRepository) DoctorService {
-	return &doctorService{repo: repo}
+	return &doctorService{repo: repo, now: time.Now}
 }
 
 func (s *doctorService) CreateDoctor(ctx context.Context, req CreateDoctorRequest) (*CreateDoctorResponse, error) {
@@ -38,15 +67,28 @@ func (s *doctorService) CreateDoctor(ctx context.Context, req CreateDoctorReques
 	}
 
 	doc := &model.Doctor{
-		FirstName:        req.FirstName,
-		LastName:         req.LastName,
-		Patronymic:       pat,
-		DateOfBirth:      req.DateOfBirth,
-		SpecializationID: req.SpecializationID,
+		ID:              uuid.New(),
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		Patronymic:      pat,
+		DateOfBirth:     req.DateOfBirth,
+		Specializations: specializationRefs(req.SpecializationIDs),
+		TimeZone:        req.TimeZone,
+		Email:           req.Email,
+		Phone:           req.Phone,
+		Bio:             req.Bio,
+		ExperienceYears: req.ExperienceYears,
+		Languages:       languageRefs(req.Languages),
+		UserID:          req.UserID,
 		// В ваших моделях константа называется Active, а не StatusActive
 		Status: model.Active,
 	}
 
+	if req.UserID != nil {
+		if err := s.checkUserFree(*req.UserID, doc.ID); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.repo.Create(doc); err != nil {
 		return nil, err
 	}
@@ -55,14 +97,21 @@ func (s *doctorService) CreateDoctor(ctx context.Context, req CreateDoctorReques
 	patPtr := doc.Patronymic
 
 	return &CreateDoctorResponse{
-		ID:               doc.ID,
-		FirstName:        doc.FirstName,
-		LastName:         doc.LastName,
-		Patronymic:       &patPtr,
-		DateOfBirth:      doc.DateOfBirth,
-		SpecializationID: doc.SpecializationID,
-		Status:           string(doc.Status),
-		CreatedAt:        doc.CreatedAt,
+		ID:                doc.ID,
+		FirstName:         doc.FirstName,
+		LastName:          doc.LastName,
+		Patronymic:        &patPtr,
+		DateOfBirth:       doc.DateOfBirth,
+		SpecializationIDs: req.SpecializationIDs,
+		Status:            string(doc.Status),
+		TimeZone:          doc.TimeZone,
+		Email:             doc.Email,
+		Phone:             doc.Phone,
+		Bio:               doc.Bio,
+		ExperienceYears:   doc.ExperienceYears,
+		Languages:         languageCodes(doc.Languages),
+		UserID:            doc.UserID,
+		CreatedAt:         doc.CreatedAt,
 	}, nil
 }
 
@@ -75,18 +124,8 @@ func (s *doctorService) GetDoctorByID(ctx context.Context, id uuid.UUID) (*Docto
 		return nil, err
 	}
 
-	// Передадим указатель на Patronymic
-	patPtr := doc.Patronymic
-
-	return &DoctorDTO{
-		ID:               doc.ID,
-		FirstName:        doc.FirstName,
-		LastName:         doc.LastName,
-		Patronymic:       &patPtr,
-		DateOfBirth:      doc.DateOfBirth,
-		SpecializationID: doc.SpecializationID,
-		Status:           string(doc.Status),
-	}, nil
+	dto := toDoctorDTO(doc, s.now(), locale.Languages(ctx))
+	return &dto, nil
 }
 
 func (s *doctorService) UpdateDoctor(ctx context.Context, req UpdateDoctorRequest) (*DoctorDTO, error) {
@@ -105,24 +144,76 @@ func (s *doctorService) UpdateDoctor(ctx context.Context, req UpdateDoctorReques
 		existing.Patronymic = *req.Patronymic
 	}
 	existing.DateOfBirth = req.DateOfBirth
-	existing.SpecializationID = req.SpecializationID
+	existing.Specializations = specializationRefs(req.SpecializationIDs)
 	existing.Status = model.DoctorStatus(req.Status)
+	// Слоты в новом поясе появятся при следующей генерации по шаблонам
+	existing.TimeZone = req.TimeZone
+	existing.Email = req.Email
+	existing.Phone = req.Phone
+	existing.Bio = req.Bio
+	existing.ExperienceYears = req.ExperienceYears
+	existing.Languages = languageRefs(req.Languages)
 
 	if err := s.repo.Update(existing); err != nil {
 		return nil, err
 	}
 
-	patPtr := existing.Patronymic
+	// Перечитываем врача, чтобы отдать названия новых специализаций
+	if existing, err = s.repo.GetByID(req.ID); err != nil {
+		return nil, err
+	}
+	dto := toDoctorDTO(existing, s.now(), locale.Languages(ctx))
+	return &dto, nil
+}
 
-	return &DoctorDTO{
-		ID:               existing.ID,
-		FirstName:        existing.FirstName,
-		LastName:         existing.LastName,
-		Patronymic:       &patPtr,
-		DateOfBirth:      existing.DateOfBirth,
-		SpecializationID: existing.SpecializationID,
-		Status:           string(existing.Status),
-	}, nil
+func (s *doctorService) GetDoctorByUserID(ctx context.Context, userID int64) (*DoctorDTO, error) {
+	doc, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	dto := toDoctorDTO(doc, s.now(), locale.Languages(ctx))
+	return &dto, nil
+}
+
+func (s *doctorService) LinkUser(ctx context.Context, id uuid.UUID, userID int64) (*DoctorDTO, error) {
+	if err := s.checkUserFree(userID, id); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateUser(id, &userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return s.GetDoctorByID(ctx, id)
+}
+
+func (s *doctorService) UnlinkUser(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.UpdateUser(id, nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkUserFree — пользователь не привязан ни к какому врачу, кроме doctorID
+func (s *doctorService) checkUserFree(userID int64, doctorID uuid.UUID) error {
+	linked, err := s.repo.GetByUserID(userID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil
+	case err != nil:
+		return err
+	case linked.ID != doctorID:
+		return fmt.Errorf("%w: user %d", ErrUserLinked, userID)
+	}
+	return nil
 }
 
 func (s *doctorService) DeleteDoctor(ctx context.Context, id uuid.UUID) (*DeleteDoctorResponse, error) {
@@ -131,3 +222,187 @@ func (s *doctorService) DeleteDoctor(ctx context.Context, id uuid.UUID) (*Delete
 	}
 	return &DeleteDoctorResponse{Success: true}, nil
 }
+
+// SearchDoctors ищет врачей по фильтрам и отдаёт одну страницу.
+// Курсор непрозрачен для клиента и действителен только для той сортировки, с которой был выдан.
+func (s *doctorService) SearchDoctors(ctx context.Context, req SearchDoctorsRequest) (*DoctorListResponse, error) {
+	if req.Sort == "" {
+		req.Sort = string(repository.SortByName)
+	}
+	desc := strings.HasPrefix(req.Sort, "-")
+	sortBy := repository.DoctorSort(strings.TrimPrefix(req.Sort, "-"))
+	if sortBy != repository.SortByName && sortBy != repository.SortByCreatedAt && sortBy != repository.SortByRating {
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidSearch, req.Sort)
+	}
+	if req.AvailableFrom != nil && req.AvailableTo != nil && req.AvailableTo.Before(*req.AvailableFrom) {
+		return nil, fmt.Errorf("%w: available_to is before available_from", ErrInvalidSearch)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	q := repository.DoctorQuery{
+		Filter: repository.DoctorFilter{
+			Name:          strings.TrimSpace(req.Name),
+			AvailableFrom: req.AvailableFrom,
+			AvailableTo:   req.AvailableTo,
+		},
+		Sort: sortBy,
+		Desc: desc,
+		// Лишняя запись показывает, есть ли следующая страница
+		Limit: limit + 1,
+	}
+	if req.SpecializationID != "" {
+		specID, err := uuid.Parse(req.SpecializationID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid specialization_id", ErrInvalidSearch)
+		}
+		q.Filter.SpecializationID = &specID
+	}
+	if req.ClinicID != "" {
+		clinicID, err := uuid.Parse(req.ClinicID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid clinic_id", ErrInvalidSearch)
+		}
+		q.Filter.ClinicID = &clinicID
+	}
+	if req.Status != "" {
+		status := model.DoctorStatus(req.Status)
+		q.Filter.Status = &status
+	}
+	if req.Cursor != "" {
+		after, err := decodeCursor(req.Cursor, req.Sort)
+		if err != nil {
+			return nil, err
+		}
+		q.After = after
+	}
+
+	docs, err := s.repo.Search(q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DoctorListResponse{Items: make([]DoctorDTO, 0, min(len(docs), limit))}
+	if len(docs) > limit {
+		docs = docs[:limit]
+		if resp.NextCursor, err = encodeCursor(&docs[limit-1], req.Sort); err != nil {
+			return nil, err
+		}
+	}
+	for i := range docs {
+		resp.Items = append(resp.Items, toDoctorDTO(&docs[i], s.now(), locale.Languages(ctx)))
+	}
+	return resp, nil
+}
+
+// searchCursor — содержимое курсора, Sort связывает его с порядком выдачи
+type searchCursor struct {
+	Sort        string    `json:"s"`
+	LastName    string    `json:"l,omitempty"`
+	FirstName   string    `json:"f,omitempty"`
+	CreatedAt   time.Time `json:"c,omitempty"`
+	RatingAvg   float64   `json:"r,omitempty"`
+	RatingCount int       `json:"n,omitempty"`
+	ID          uuid.UUID `json:"i"`
+}
+
+func encodeCursor(doc *model.Doctor, sort string) (string, error) {
+	c := searchCursor{Sort: sort, ID: doc.ID}
+	switch repository.DoctorSort(strings.TrimPrefix(sort, "-")) {
+	case repository.SortByCreatedAt:
+		c.CreatedAt = doc.CreatedAt
+	case repository.SortByRating:
+		c.RatingAvg, c.RatingCount = doc.RatingAvg, doc.RatingCount
+	default:
+		c.LastName, c.FirstName = doc.LastName, doc.FirstName
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(value, sort string) (*repository.DoctorCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var c searchCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort {
+		return nil, errInvalidCursor
+	}
+	return &repository.DoctorCursor{
+		LastName:    c.LastName,
+		FirstName:   c.FirstName,
+		CreatedAt:   c.CreatedAt,
+		RatingAvg:   c.RatingAvg,
+		RatingCount: c.RatingCount,
+		ID:          c.ID,
+	}, nil
+}
+
+// toDoctorDTO переводит модель в формат отдачи. Специализации, языки и документы
+// включаются те, что подгружены; истечение документов считается на момент now,
+// специализации переводятся на языки langs.
+func toDoctorDTO(doc *model.Doctor, now time.Time, langs []language.Tag) DoctorDTO {
+	patPtr := doc.Patronymic
+	dto := DoctorDTO{
+		ID:              doc.ID,
+		FirstName:       doc.FirstName,
+		LastName:        doc.LastName,
+		Patronymic:      &patPtr,
+		DateOfBirth:     doc.DateOfBirth,
+		Specializations: make([]SpecializationDTO, 0, len(doc.Specializations)),
+		Status:          string(doc.Status),
+		TimeZone:        doc.TimeZone,
+		Email:           doc.Email,
+		Phone:           doc.Phone,
+		Bio:             doc.Bio,
+		ExperienceYears: doc.ExperienceYears,
+		Languages:       languageCodes(doc.Languages),
+		UserID:          doc.UserID,
+		Rating:          math.Round(doc.RatingAvg*100) / 100,
+		ReviewCount:     doc.RatingCount,
+	}
+	for i := range doc.Specializations {
+		dto.Specializations = append(dto.Specializations, toSpecializationDTO(&doc.Specializations[i], langs))
+	}
+	for _, c := range doc.Credentials {
+		dto.Credentials = append(dto.Credentials, toCredentialDTO(c, now))
+	}
+	if doc.PhotoKey != "" {
+		dto.PhotoURL = photoURL(doc.ID)
+	}
+	return dto
+}
+
+// Support function for CreateDoctor and UpdateDoctor.
+func specializationRefs(ids []uuid.UUID) []model.Specialization {
+	specs := make([]model.Specialization, 0, len(ids))
+	for _, id := range ids {
+		specs = append(specs, model.Specialization{ID: id})
+	}
+	return specs
+}
+
+// Support function for CreateDoctor and UpdateDoctor.
+func languageRefs(codes []string) []model.DoctorLanguage {
+	langs := make([]model.DoctorLanguage, 0, len(codes))
+	for _, code := range codes {
+		langs = append(langs, model.DoctorLanguage{Code: code})
+	}
+	return langs
+}
+
+func languageCodes(langs []model.DoctorLanguage) []string {
+	codes := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		codes = append(codes, lang.Code)
+	}
+	return codes
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakeDoctorRepo отдаёт заранее заданных врачей и запоминает последний запрос
+type fakeDoctorRepo struct {
+	repository.DoctorRepository
+	docs  []model.Doctor
+	query repository.DoctorQuery
+}
+
+func (r *fakeDoctorRepo) Search(q repository.DoctorQuery) ([]model.Doctor, error) {
+	r.query = q
+	return r.docs[:min(len(r.docs), q.Limit)], nil
+}
+
+func (r *fakeDoctorRepo) Update(doc *model.Doctor) error {
+	for i := range r.docs {
+		if r.docs[i].ID == doc.ID {
+			r.docs[i] = *doc
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *fakeDoctorRepo) UpdatePhoto(id uuid.UUID, key string) error {
+	for i := range r.docs {
+		if r.docs[i].ID == id {
+			r.docs[i].PhotoKey = key
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *fakeDoctorRepo) GetByUserID(userID int64) (*model.Doctor, error) {
+	for i := range r.docs {
+		if r.docs[i].UserID != nil && *r.docs[i].UserID == userID {
+			return &r.docs[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeDoctorRepo) UpdateUser(id uuid.UUID, userID *int64) error {
+	for i := range r.docs {
+		if r.docs[i].ID == id {
+			r.docs[i].UserID = userID
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *fakeDoctorRepo) GetByID(id uuid.UUID) (*model.Doctor, error) {
+	for i := range r.docs {
+		if r.docs[i].ID == id {
+			return &r.docs[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type DoctorServiceTestSuite struct {
+	suite.Suite
+	repo    *fakeDoctorRepo
+	service DoctorService
+}
+
+func TestDoctorService(t *testing.T) {
+	suite.Run(t, new(DoctorServiceTestSuite))
+}
+
+func (suite *DoctorServiceTestSuite) SetupTest() {
+	spec := model.Specialization{ID: uuid.New(), Name: "Кардиолог"}
+	suite.repo = &fakeDoctorRepo{}
+	for i, name := range []string{"Иванов", "Петров", "Сидоров"} {
+		suite.repo.docs = append(suite.repo.docs, model.Doctor{
+			ID:              uuid.New(),
+			FirstName:       "Иван",
+			LastName:        name,
+			Specializations: []model.Specialization{spec},
+			Status:          model.Active,
+			CreatedAt:       time.Date(2025, 6, 1+i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+	suite.service = NewDoctorService(suite.repo)
+}
+
+func (suite *DoctorServiceTestSuite) TestSearchDoctors_Defaults() {
+	resp, err := suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{})
+	suite.Require().NoError(err)
+
+	suite.Equal(repository.SortByName, suite.repo.query.Sort)
+	suite.False(suite.repo.query.Desc)
+	suite.Equal(defaultSearchLimit+1, suite.repo.query.Limit)
+	suite.Nil(suite.repo.query.After)
+
+	suite.Len(resp.Items, 3)
+	suite.Empty(resp.NextCursor)
+	suite.Require().Len(resp.Items[0].Specializations, 1)
+	suite.Equal("Кардиолог", resp.Items[0].Specializations[0].Name)
+	suite.Empty(resp.Items[0].PhotoURL)
+}
+
+func (suite *DoctorServiceTestSuite) TestSearchDoctors_NextPage() {
+	status := string(model.Active)
+	resp, err := suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{
+		Status: status,
+		Name:   "  иван ",
+		Sort:   "-created_at",
+		Limit:  2,
+	})
+	suite.Require().NoError(err)
+	suite.Len(resp.Items, 2)
+	suite.Require().NotEmpty(resp.NextCursor)
+
+	suite.Equal(repository.SortByCreatedAt, suite.repo.query.Sort)
+	suite.True(suite.repo.query.Desc)
+	suite.Equal("иван", suite.repo.query.Filter.Name)
+	suite.Equal(model.Active, *suite.repo.query.Filter.Status)
+
+	_, err = suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{
+		Sort:   "-created_at",
+		Limit:  2,
+		Cursor: resp.NextCursor,
+	})
+	suite.Require().NoError(err)
+	last := suite.repo.docs[1]
+	suite.Require().NotNil(suite.repo.query.After)
+	suite.Equal(last.ID, suite.repo.query.After.ID)
+	suite.True(last.CreatedAt.Equal(suite.repo.query.After.CreatedAt))
+}
+
+func (suite *DoctorServiceTestSuite) TestSearchDoctors_ByRating() {
+	suite.repo.docs[0].RatingAvg, suite.repo.docs[0].RatingCount = 4.666666, 3
+	suite.repo.docs[1].RatingAvg, suite.repo.docs[1].RatingCount = 4.5, 2
+
+	resp, err := suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{Sort: "-rating", Limit: 1})
+	suite.Require().NoError(err)
+	suite.Equal(repository.SortByRating, suite.repo.query.Sort)
+	suite.True(suite.repo.query.Desc)
+	suite.Require().Len(resp.Items, 1)
+	suite.Equal(4.67, resp.Items[0].Rating)
+	suite.Equal(3, resp.Items[0].ReviewCount)
+
+	_, err = suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{Sort: "-rating", Cursor: resp.NextCursor})
+	suite.Require().NoError(err)
+	suite.Require().NotNil(suite.repo.query.After)
+	suite.Equal(suite.repo.docs[0].ID, suite.repo.query.After.ID)
+	suite.Equal(4.666666, suite.repo.query.After.RatingAvg)
+	suite.Equal(3, suite.repo.query.After.RatingCount)
+}
+
+func (suite *DoctorServiceTestSuite) TestSearchDoctors_CursorBoundToSort() {
+	resp, err := suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{Limit: 1})
+	suite.Require().NoError(err)
+
+	_, err = suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{
+		Sort:   "created_at",
+		Cursor: resp.NextCursor,
+	})
+	suite.ErrorIs(err, ErrInvalidSearch)
+
+	_, err = suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{Cursor: "not a cursor"})
+	suite.ErrorIs(err, ErrInvalidSearch)
+}
+
+func (suite *DoctorServiceTestSuite) TestSearchDoctors_InvalidDateRange() {
+	from := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, -1)
+	_, err := suite.service.SearchDoctors(context.Background(), SearchDoctorsRequest{
+		AvailableFrom: &from,
+		AvailableTo:   &to,
+	})
+	suite.ErrorIs(err, ErrInvalidSearch)
+}
+
+func (suite *DoctorServiceTestSuite) TestUpdateDoctor_Profile() {
+	doc := suite.repo.docs[0]
+	specs := []uuid.UUID{uuid.New(), uuid.New()}
+	dto, err := suite.service.UpdateDoctor(context.Background(), UpdateDoctorRequest{
+		ID:                doc.ID,
+		FirstName:         doc.FirstName,
+		LastName:          doc.LastName,
+		SpecializationIDs: specs,
+		Status:            string(model.Active),
+		Email:             "ivanov@clinic.kz",
+		Bio:               "Кардиолог высшей категории",
+		ExperienceYears:   12,
+		Languages:         []string{"ru", "kk"},
+	})
+	suite.Require().NoError(err)
+
+	suite.Equal("ivanov@clinic.kz", dto.Email)
+	suite.Equal(12, dto.ExperienceYears)
+	suite.Equal([]string{"ru", "kk"}, dto.Languages)
+	suite.Require().Len(dto.Specializations, 2)
+	suite.Equal(specs[0], dto.Specializations[0].ID)
+	suite.Equal(specs[1], dto.Specializations[1].ID)
+}
+
+func (suite *DoctorServiceTestSuite) TestLinkUser() {
+	first, second := suite.repo.docs[0].ID, suite.repo.docs[1].ID
+
+	dto, err := suite.service.LinkUser(context.Background(), first, 42)
+	suite.Require().NoError(err)
+	suite.Equal(int64(42), *dto.UserID)
+
+	// Повторная привязка того же пользователя к тому же врачу не ошибка
+	_, err = suite.service.LinkUser(context.Background(), first, 42)
+	suite.NoError(err)
+	_, err = suite.service.LinkUser(context.Background(), second, 42)
+	suite.ErrorIs(err, ErrUserLinked)
+	_, err = suite.service.LinkUser(context.Background(), uuid.New(), 43)
+	suite.ErrorIs(err, ErrNotFound)
+
+	me, err := suite.service.GetDoctorByUserID(context.Background(), 42)
+	suite.Require().NoError(err)
+	suite.Equal(first, me.ID)
+
+	suite.Require().NoError(suite.service.UnlinkUser(context.Background(), first))
+	_, err = suite.service.GetDoctorByUserID(context.Background(), 42)
+	suite.ErrorIs(err, ErrNotFound)
+}
@@ -8,48 +8,349 @@ import (
 
 // CreateDoctorRequest — входящие данные для создания
 type CreateDoctorRequest struct {
-	FirstName        string    `json:"first_name" validate:"required"`
-	LastName         string    `json:"last_name"  validate:"required"`
-	Patronymic       *string   `json:"patronymic"`
-	DateOfBirth      time.Time `json:"date_of_birth" validate:"required"`
-	SpecializationID uuid.UUID `json:"specialization_id" validate:"required"`
+	FirstName         string      `json:"first_name" validate:"required"`
+	LastName          string      `json:"last_name"  validate:"required"`
+	Patronymic        *string     `json:"patronymic"`
+	DateOfBirth       time.Time   `json:"date_of_birth" validate:"required"`
+	SpecializationIDs []uuid.UUID `json:"specialization_ids" binding:"required,min=1,max=10"`
+	TimeZone          string      `json:"time_zone" binding:"omitempty,timezone"` // IANA, пусто — пояс клиники
+	Email             string      `json:"email" binding:"omitempty,email"`
+	Phone             string      `json:"phone" binding:"omitempty,e164"`
+	Bio               string      `json:"bio" binding:"max=4000"`
+	ExperienceYears   int         `json:"experience_years" binding:"min=0,max=80"`
+	Languages         []string    `json:"languages" binding:"max=20,dive,bcp47_language_tag"` // теги BCP 47, например "ru", "kk"
+	UserID            *int64      `json:"user_id" binding:"omitempty,min=1"`                  // пользователь auth с ролью doctor
 }
 
 // CreateDoctorResponse — возвращаем клиенту после создания
 type CreateDoctorResponse struct {
-	ID               uuid.UUID `json:"id"`
-	FirstName        string    `json:"first_name"`
-	LastName         string    `json:"last_name"`
-	Patronymic       *string   `json:"patronymic"`
-	DateOfBirth      time.Time `json:"date_of_birth"`
-	SpecializationID uuid.UUID `json:"specialization_id"`
-	Status           string    `json:"status"`
-	CreatedAt        time.Time `json:"created_at"`
+	ID                uuid.UUID   `json:"id"`
+	FirstName         string      `json:"first_name"`
+	LastName          string      `json:"last_name"`
+	Patronymic        *string     `json:"patronymic"`
+	DateOfBirth       time.Time   `json:"date_of_birth"`
+	SpecializationIDs []uuid.UUID `json:"specialization_ids"`
+	Status            string      `json:"status"`
+	TimeZone          string      `json:"time_zone,omitempty"`
+	Email             string      `json:"email,omitempty"`
+	Phone             string      `json:"phone,omitempty"`
+	Bio               string      `json:"bio,omitempty"`
+	ExperienceYears   int         `json:"experience_years"`
+	Languages         []string    `json:"languages"`
+	UserID            *int64      `json:"user_id,omitempty"`
+	CreatedAt         time.Time   `json:"created_at"`
 }
 
 // DoctorDTO — общий формат отдачи врача
 type DoctorDTO struct {
+	ID              uuid.UUID           `json:"id"`
+	FirstName       string              `json:"first_name"`
+	LastName        string              `json:"last_name"`
+	Patronymic      *string             `json:"patronymic"`
+	DateOfBirth     time.Time           `json:"date_of_birth"`
+	Specializations []SpecializationDTO `json:"specializations"`
+	Status          string              `json:"status"`
+	TimeZone        string              `json:"time_zone,omitempty"`
+	Email           string              `json:"email,omitempty"`
+	Phone           string              `json:"phone,omitempty"`
+	Bio             string              `json:"bio,omitempty"`
+	ExperienceYears int                 `json:"experience_years"`
+	Languages       []string            `json:"languages"`
+	Credentials     []CredentialDTO     `json:"credentials,omitempty"` // только в карточке врача, не в поиске
+	PhotoURL        string              `json:"photo_url,omitempty"`
+	UserID          *int64              `json:"user_id,omitempty"`
+	Rating          float64             `json:"rating"` // средняя оценка одобренных отзывов, округлена до сотых
+	ReviewCount     int                 `json:"review_count"`
+}
+
+// LinkUserRequest — пользователь auth, под которым врач будет входить
+type LinkUserRequest struct {
+	UserID int64 `json:"user_id" binding:"required,min=1"`
+}
+
+// CredentialDTO — лицензия, сертификат или диплом врача
+type CredentialDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	Kind      string     `json:"kind"`
+	Number    string     `json:"number"`
+	Issuer    string     `json:"issuer"`
+	IssuedAt  time.Time  `json:"issued_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Expired   bool       `json:"expired"`
+}
+
+// CreateCredentialRequest — документ врача. Без expires_at документ бессрочный.
+type CreateCredentialRequest struct {
+	Kind      string     `json:"kind" binding:"required,oneof=LICENSE CERTIFICATE DIPLOMA"`
+	Number    string     `json:"number" binding:"required,max=100"`
+	Issuer    string     `json:"issuer" binding:"required,max=255"`
+	IssuedAt  time.Time  `json:"issued_at" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at" binding:"omitempty,gtfield=IssuedAt"`
+}
+
+// SpecializationDTO — специализация на языке клиента: в дереве специализаций, карточке и составе врача
+type SpecializationDTO struct {
+	ID           uuid.UUID                      `json:"id"`
+	ParentID     *uuid.UUID                     `json:"parent_id,omitempty"`
+	Name         string                         `json:"name"`
+	Description  string                         `json:"description,omitempty"`
+	Language     string                         `json:"language"`               // язык name и description
+	Children     []SpecializationDTO            `json:"children,omitempty"`     // только в дереве специализаций
+	Translations []SpecializationTranslationDTO `json:"translations,omitempty"` // только в карточке специализации
+}
+
+// SpecializationTranslationDTO — название и описание специализации на одном языке
+type SpecializationTranslationDTO struct {
+	Language    string `json:"language" binding:"required,bcp47_language_tag"` // например, "kk" или "en"
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=4000"`
+}
+
+// SpecializationRequest — специализация для создания и изменения. Name и Description — на языке
+// клиники по умолчанию, переводы заменяются целиком.
+type SpecializationRequest struct {
+	Name         string                         `json:"name" binding:"required,max=255"`
+	Description  string                         `json:"description" binding:"max=4000"`
+	ParentID     *uuid.UUID                     `json:"parent_id"`
+	Translations []SpecializationTranslationDTO `json:"translations" binding:"max=20,dive"`
+}
+
+// MedicalServiceRequest — услуга каталога. Цена в минимальных единицах валюты, для тенге — тиынах.
+type MedicalServiceRequest struct {
+	Name            string `json:"name" binding:"required,max=255"`
+	Description     string `json:"description" binding:"max=4000"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=5,max=480"`
+	Price           int64  `json:"price" binding:"min=0"`
+	Currency        string `json:"currency" binding:"omitempty,iso4217"` // по умолчанию KZT
+	IsActive        *bool  `json:"is_active"`                            // по умолчанию услуга действует
+}
+
+// MedicalServiceDTO — услуга каталога в ответах
+type MedicalServiceDTO struct {
 	ID               uuid.UUID `json:"id"`
-	FirstName        string    `json:"first_name"`
-	LastName         string    `json:"last_name"`
-	Patronymic       *string   `json:"patronymic"`
-	DateOfBirth      time.Time `json:"date_of_birth"`
 	SpecializationID uuid.UUID `json:"specialization_id"`
-	Status           string    `json:"status"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	DurationMinutes  int       `json:"duration_minutes"`
+	Price            int64     `json:"price"`
+	Currency         string    `json:"currency"`
+	IsActive         bool      `json:"is_active"`
+}
+
+// SearchDoctorsRequest — фильтры, сортировка и страница поиска врачей
+type SearchDoctorsRequest struct {
+	SpecializationID string     `form:"specialization_id" binding:"omitempty,uuid"` // gin не умеет биндить uuid.UUID из query
+	ClinicID         string     `form:"clinic_id" binding:"omitempty,uuid"`
+	Status           string     `form:"status" binding:"omitempty,oneof=ACTIVE ON_LEAVE INACTIVE"`
+	Name             string     `form:"name"`
+	AvailableFrom    *time.Time `form:"available_from"` // RFC 3339 со смещением
+	AvailableTo      *time.Time `form:"available_to"`
+	Sort             string     `form:"sort" binding:"omitempty,oneof=name -name created_at -created_at rating -rating"`
+	Cursor           string     `form:"cursor"`
+	Limit            int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// DoctorListResponse — страница результатов поиска.
+// NextCursor пуст на последней странице.
+type DoctorListResponse struct {
+	Items      []DoctorDTO `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 // UpdateDoctorRequest — для обновления
 type UpdateDoctorRequest struct {
-	ID               uuid.UUID `json:"id" validate:"required"`
-	FirstName        string    `json:"first_name" validate:"required"`
-	LastName         string    `json:"last_name"  validate:"required"`
-	Patronymic       *string   `json:"patronymic"`
-	DateOfBirth      time.Time `json:"date_of_birth" validate:"required"`
-	SpecializationID uuid.UUID `json:"specialization_id" validate:"required"`
-	Status           string    `json:"status" validate:"oneof=ACTIVE ON_LEAVE INACTIVE"`
+	ID                uuid.UUID   `json:"id" validate:"required"`
+	FirstName         string      `json:"first_name" validate:"required"`
+	LastName          string      `json:"last_name"  validate:"required"`
+	Patronymic        *string     `json:"patronymic"`
+	DateOfBirth       time.Time   `json:"date_of_birth" validate:"required"`
+	SpecializationIDs []uuid.UUID `json:"specialization_ids" binding:"required,min=1,max=10"`
+	Status            string      `json:"status" validate:"oneof=ACTIVE ON_LEAVE INACTIVE"`
+	TimeZone          string      `json:"time_zone" binding:"omitempty,timezone"`
+	Email             string      `json:"email" binding:"omitempty,email"`
+	Phone             string      `json:"phone" binding:"omitempty,e164"`
+	Bio               string      `json:"bio" binding:"max=4000"`
+	ExperienceYears   int         `json:"experience_years" binding:"min=0,max=80"`
+	Languages         []string    `json:"languages" binding:"max=20,dive,bcp47_language_tag"`
 }
 
 // DeleteDoctorResponse — ответ на удаление
 type DeleteDoctorResponse struct {
 	Success bool `json:"success"`
 }
+
+// ScheduleBreakDTO — перерыв в рабочих часах, время в формате "HH:MM"
+type ScheduleBreakDTO struct {
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+// CreateScheduleTemplateRequest — рабочие часы врача в один день недели.
+// Часы указываются по времени филиала, без филиала — по времени врача.
+type CreateScheduleTemplateRequest struct {
+	ClinicID    *uuid.UUID         `json:"clinic_id"`                     // врач должен быть приписан к филиалу
+	RoomID      *uuid.UUID         `json:"room_id"`                       // кабинет филиала, только вместе с clinic_id
+	IsOnline    bool               `json:"is_online"`                     // видеоконсультации, без кабинета
+	ServiceID   *uuid.UUID         `json:"service_id"`                    // услуга специализации врача
+	Weekday     int                `json:"weekday" binding:"min=0,max=6"` // 0 — воскресенье
+	StartTime   string             `json:"start_time" binding:"required"`
+	EndTime     string             `json:"end_time" binding:"required"`
+	SlotMinutes int                `json:"slot_minutes" binding:"omitempty,min=5,max=480"` // с service_id по умолчанию длительность услуги
+	Breaks      []ScheduleBreakDTO `json:"breaks" binding:"dive"`
+	ValidFrom   time.Time          `json:"valid_from" binding:"required"`
+	ValidTo     *time.Time         `json:"valid_to"`
+}
+
+// ScheduleTemplateDTO — шаблон расписания в ответах
+type ScheduleTemplateDTO struct {
+	ID          uuid.UUID          `json:"id"`
+	DoctorID    uuid.UUID          `json:"doctor_id"`
+	ClinicID    *uuid.UUID         `json:"clinic_id,omitempty"`
+	RoomID      *uuid.UUID         `json:"room_id,omitempty"`
+	IsOnline    bool               `json:"is_online"`
+	ServiceID   *uuid.UUID         `json:"service_id,omitempty"`
+	Weekday     int                `json:"weekday"`
+	StartTime   string             `json:"start_time"`
+	EndTime     string             `json:"end_time"`
+	SlotMinutes int                `json:"slot_minutes"`
+	Breaks      []ScheduleBreakDTO `json:"breaks"`
+	ValidFrom   time.Time          `json:"valid_from"`
+	ValidTo     *time.Time         `json:"valid_to"`
+}
+
+// GenerateSlotsResponse — итог генерации слотов по шаблонам
+type GenerateSlotsResponse struct {
+	Created int64 `json:"created"`
+	Removed int64 `json:"removed"`
+}
+
+// CreateScheduleExceptionRequest — отпуск, больничный, праздник клиники или дополнительные часы.
+// Праздник задаётся без врача, остальные виды — для конкретного врача.
+type CreateScheduleExceptionRequest struct {
+	DoctorID    *uuid.UUID `json:"doctor_id"`
+	Kind        string     `json:"kind" binding:"required,oneof=VACATION SICK_LEAVE HOLIDAY EXTRA_HOURS"`
+	StartsAt    time.Time  `json:"starts_at" binding:"required"`
+	EndsAt      time.Time  `json:"ends_at" binding:"required"`
+	Reason      string     `json:"reason"`
+	SlotMinutes int        `json:"slot_minutes" binding:"omitempty,min=5,max=480"` // только для EXTRA_HOURS
+}
+
+// ListScheduleExceptionsRequest — исключения врача и праздники клиники за период
+type ListScheduleExceptionsRequest struct {
+	DoctorID string    `form:"doctor_id" binding:"omitempty,uuid"` // пусто — только праздники клиники
+	From     time.Time `form:"from" binding:"required"`
+	To       time.Time `form:"to" binding:"required"`
+}
+
+// ScheduleExceptionDTO — исключение из расписания в ответах
+type ScheduleExceptionDTO struct {
+	ID          uuid.UUID  `json:"id"`
+	DoctorID    *uuid.UUID `json:"doctor_id"`
+	Kind        string     `json:"kind"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at"`
+	Reason      string     `json:"reason,omitempty"`
+	SlotMinutes int        `json:"slot_minutes,omitempty"`
+}
+
+// ConflictDTO — занятый слот, попавший в закрытое время
+type ConflictDTO struct {
+	DoctorID      uuid.UUID  `json:"doctor_id"`
+	SlotID        uuid.UUID  `json:"slot_id"`
+	SlotTime      time.Time  `json:"slot_time"`
+	AppointmentID *uuid.UUID `json:"appointment_id"`
+}
+
+// CreateScheduleExceptionResponse — созданное исключение и его влияние на слоты
+type CreateScheduleExceptionResponse struct {
+	Exception    ScheduleExceptionDTO `json:"exception"`
+	BlockedSlots int64                `json:"blocked_slots"`
+	CreatedSlots int64                `json:"created_slots"`
+	Conflicts    []ConflictDTO        `json:"conflicts"`
+}
+
+// CreateRoomRequest — кабинет филиала
+type CreateRoomRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Capacity int    `json:"capacity" binding:"omitempty,min=1,max=100"` // по умолчанию один приём за раз
+}
+
+// CreateClinicRequest — филиал с кабинетами
+type CreateClinicRequest struct {
+	Name     string              `json:"name" binding:"required"`
+	Address  string              `json:"address"`
+	TimeZone string              `json:"time_zone" binding:"required,timezone"` // IANA, например "Asia/Aqtobe"
+	Rooms    []CreateRoomRequest `json:"rooms" binding:"dive"`
+}
+
+// RoomDTO — кабинет в ответах
+type RoomDTO struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Capacity int       `json:"capacity"`
+}
+
+// ClinicDTO — филиал в ответах
+type ClinicDTO struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Address  string    `json:"address,omitempty"`
+	TimeZone string    `json:"time_zone"`
+	Rooms    []RoomDTO `json:"rooms"`
+}
+
+// CreateReviewRequest — отзыв о состоявшемся приёме
+type CreateReviewRequest struct {
+	AppointmentID uuid.UUID `json:"appointment_id" binding:"required"`
+	Rating        int       `json:"rating" binding:"required,min=1,max=5"`
+	Comment       string    `json:"comment" binding:"max=2000"`
+}
+
+// ModerateReviewRequest — решение модератора по отзыву
+type ModerateReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=APPROVED REJECTED"`
+}
+
+// ListReviewsRequest — очередь модерации, по умолчанию ждущие решения отзывы
+type ListReviewsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=PENDING APPROVED REJECTED"`
+}
+
+// ReviewDTO — отзыв в ответах
+type ReviewDTO struct {
+	ID            uuid.UUID  `json:"id"`
+	DoctorID      uuid.UUID  `json:"doctor_id"`
+	AppointmentID uuid.UUID  `json:"appointment_id"`
+	UserID        int64      `json:"user_id"`
+	Rating        int        `json:"rating"`
+	Comment       string     `json:"comment,omitempty"`
+	Status        string     `json:"status"`
+	ModeratedAt   *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// MeetingDTO — ссылка на видеоконсультацию записи
+type MeetingDTO struct {
+	AppointmentID uuid.UUID `json:"appointment_id"`
+	DoctorID      uuid.UUID `json:"doctor_id"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	URL           string    `json:"url"`
+}
+
+// VerifyMeetingRequest — параметры ссылки на комнату, с которой участник входит в видеосвязь
+type VerifyMeetingRequest struct {
+	Expires   string `form:"exp" binding:"required"`
+	Signature string `form:"sig" binding:"required"`
+}
+
+// ImportRequest — параметры импорта, без format формат берётся из Content-Type
+type ImportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"`
+	DryRun bool   `form:"dry_run"` // только проверить файл
+}
+
+// ExportRequest — параметры выгрузки, по умолчанию JSON
+type ExportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"`
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/appointment"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/meeting"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrMeetingNotFound возвращается, если у записи нет онлайн-слота с подтверждённой бронью
+var ErrMeetingNotFound = errors.New("meeting not found")
+
+// ErrMeetingForbidden возвращается, если пользователь не пациент и не врач записи
+var ErrMeetingForbidden = errors.New("meeting is available only to the patient and the doctor of the appointment")
+
+// ErrMeetingUnavailable возвращается, если провайдер видеосвязи не смог создать комнату
+var ErrMeetingUnavailable = errors.New("meeting provider is unavailable")
+
+// ErrMeetingRevoked возвращается при входе по ссылке, которая подделана, истекла или отозвана
+var ErrMeetingRevoked = errors.New("meeting link is invalid or revoked")
+
+// MeetingService выдаёт ссылки на видеоконсультации участникам записи
+type MeetingService interface {
+	// GetMeeting — ссылка на видеоконсультацию записи для её пациента или врача.
+	// Если при бронировании комнату создать не удалось, она создаётся здесь.
+	GetMeeting(ctx context.Context, appointmentID uuid.UUID, userID int64) (*MeetingDTO, error)
+	// VerifyJoin проверяет ссылку, с которой участник входит в комнату room.
+	// Её вызывает сервис видеосвязи, ссылка отозванной комнаты не проходит.
+	VerifyJoin(ctx context.Context, room, expires, signature string) error
+}
+
+type meetingService struct {
+	slots        repository.ScheduleSlotRepository
+	doctors      repository.DoctorRepository
+	appointments appointment.Client
+	meetings     meeting.Provider
+}
+
+// NewMeetingService конструктор
+func NewMeetingService(slots repository.ScheduleSlotRepository, doctors repository.DoctorRepository, appointments appointment.Client, meetings meeting.Provider) MeetingService {
+	return &meetingService{slots: slots, doctors: doctors, appointments: appointments, meetings: meetings}
+}
+
+func (s *meetingService) GetMeeting(ctx context.Context, appointmentID uuid.UUID, userID int64) (*MeetingDTO, error) {
+	slot, err := s.slots.FindByAppointment(appointmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMeetingNotFound
+		}
+		return nil, err
+	}
+	if !slot.IsOnline || slot.HeldUntil != nil {
+		return nil, ErrMeetingNotFound
+	}
+	if err := s.checkParticipant(ctx, slot, userID); err != nil {
+		return nil, err
+	}
+
+	if slot.MeetingLink == "" {
+		provisionMeeting(ctx, s.slots, s.meetings, slot)
+		// Перечитываем слот: комнату мог записать параллельный запрос
+		if slot, err = s.slots.FindByAppointment(appointmentID); err != nil {
+			return nil, err
+		}
+		if slot.MeetingLink == "" {
+			return nil, ErrMeetingUnavailable
+		}
+	}
+	return &MeetingDTO{
+		AppointmentID: appointmentID,
+		DoctorID:      slot.DoctorID,
+		StartsAt:      slot.StartsAt,
+		EndsAt:        slot.EndsAt,
+		URL:           slot.MeetingLink,
+	}, nil
+}
+
+// checkParticipant — запись слота не отменена, а пользователь врач этого слота или пациент записи.
+// У отменённой записи видеоконсультации нет ни для кого, даже если слот ещё не освобождён.
+func (s *meetingService) checkParticipant(ctx context.Context, slot *model.ScheduleSlot, userID int64) error {
+	a, err := s.appointments.GetAppointment(ctx, *slot.AppointmentID)
+	switch {
+	case errors.Is(err, appointment.ErrNotFound):
+		return ErrMeetingNotFound
+	case err != nil:
+		return err
+	case a.Status == appointment.StatusCanceled:
+		return ErrMeetingNotFound
+	case a.DoctorID != slot.DoctorID:
+		return ErrMeetingForbidden
+	case a.UserID == userID:
+		return nil
+	}
+
+	doc, err := s.doctors.GetByUserID(userID)
+	switch {
+	case err == nil && doc.ID == slot.DoctorID:
+		return nil
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+	return ErrMeetingForbidden
+}
+
+func (s *meetingService) VerifyJoin(ctx context.Context, room, expires, signature string) error {
+	verifier, ok := s.meetings.(meeting.Verifier)
+	if !ok {
+		// Ссылки такого провайдера проверяет сам сервис видеосвязи
+		return ErrMeetingRevoked
+	}
+	if err := verifier.Verify(room, expires, signature); err != nil {
+		return ErrMeetingRevoked
+	}
+	if _, err := s.slots.FindByMeetingRoom(room); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMeetingRevoked
+		}
+		return err
+	}
+	return nil
+}
+
+// provisionMeeting создаёт комнату для онлайн-слота с подтверждённой бронью, если её ещё нет.
+// Бронь от ошибок провайдера не зависит: они пишутся в лог, а комната досоздаётся позже.
+// Support function for ReserveSlot, ConfirmSlot and GetMeeting.
+func provisionMeeting(ctx context.Context, slots repository.ScheduleSlotRepository, meetings meeting.Provider, slot *model.ScheduleSlot) {
+	if !slot.IsOnline || slot.AppointmentID == nil || slot.HeldUntil != nil || slot.MeetingRoom != "" {
+		return
+	}
+	log := logging.FromContext(ctx).WithField("appointmentId", slot.AppointmentID.String())
+
+	m, err := meetings.Create(ctx, meeting.Request{AppointmentID: *slot.AppointmentID, StartsAt: slot.StartsAt, EndsAt: slot.EndsAt})
+	if err != nil {
+		log.WithError(err).Error("Failed to create meeting room")
+		return
+	}
+	saved, err := slots.SetMeeting(slot.ID, *slot.AppointmentID, m.Room, m.URL)
+	if err != nil {
+		log.WithError(err).Error("Failed to save meeting room")
+	}
+	if !saved {
+		// Бронь сняли или комнату уже создал параллельный запрос, эта комната не нужна
+		revokeMeeting(ctx, meetings, m.Room)
+		return
+	}
+	slot.MeetingRoom, slot.MeetingLink = m.Room, m.URL
+}
+
+// Support function for ReleaseSlot and provisionMeeting.
+func revokeMeeting(ctx context.Context, meetings meeting.Provider, room string) {
+	if err := meetings.Revoke(ctx, room); err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("room", room).Error("Failed to revoke meeting room")
+	}
+}
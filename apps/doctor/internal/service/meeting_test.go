@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/appointment"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/meeting"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakeMeetingSlots хранит слоты с записями и их комнаты
+type fakeMeetingSlots struct {
+	repository.ScheduleSlotRepository
+	slots []model.ScheduleSlot
+}
+
+func (r *fakeMeetingSlots) FindByAppointment(appointmentID uuid.UUID) (*model.ScheduleSlot, error) {
+	for i := range r.slots {
+		if r.slots[i].AppointmentID != nil && *r.slots[i].AppointmentID == appointmentID {
+			slot := r.slots[i]
+			return &slot, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeMeetingSlots) FindByMeetingRoom(room string) (*model.ScheduleSlot, error) {
+	for i := range r.slots {
+		if r.slots[i].MeetingRoom == room && r.slots[i].AppointmentID != nil {
+			slot := r.slots[i]
+			return &slot, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeMeetingSlots) SetMeeting(id, appointmentID uuid.UUID, room, link string) (bool, error) {
+	for i := range r.slots {
+		slot := &r.slots[i]
+		if slot.ID == id && slot.AppointmentID != nil && *slot.AppointmentID == appointmentID && slot.HeldUntil == nil && slot.MeetingRoom == "" {
+			slot.MeetingRoom, slot.MeetingLink = room, link
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type MeetingServiceTestSuite struct {
+	suite.Suite
+	slots         *fakeMeetingSlots
+	doctors       *fakeDoctorRepo
+	appointments  fakeAppointments
+	meetings      *meeting.SignedProvider
+	service       MeetingService
+	doctorID      uuid.UUID
+	appointmentID uuid.UUID
+}
+
+func TestMeetingService(t *testing.T) {
+	suite.Run(t, new(MeetingServiceTestSuite))
+}
+
+func (suite *MeetingServiceTestSuite) SetupTest() {
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	doctorUser := int64(3)
+	suite.doctorID = uuid.New()
+	suite.appointmentID = uuid.New()
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+
+	suite.doctors = &fakeDoctorRepo{docs: []model.Doctor{{ID: suite.doctorID, UserID: &doctorUser}}}
+	suite.slots = &fakeMeetingSlots{slots: []model.ScheduleSlot{{
+		ID:            uuid.New(),
+		DoctorID:      suite.doctorID,
+		StartsAt:      start,
+		EndsAt:        start.Add(30 * time.Minute),
+		IsOnline:      true,
+		AppointmentID: &suite.appointmentID,
+	}}}
+	suite.appointments = fakeAppointments{suite.appointmentID: {ID: suite.appointmentID, UserID: 7, DoctorID: suite.doctorID}}
+
+	var err error
+	suite.meetings, err = meeting.NewSignedProvider("https://meet.test/rooms", []byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	suite.Require().NoError(err)
+	suite.service = NewMeetingService(suite.slots, suite.doctors, suite.appointments, suite.meetings)
+}
+
+// Support function for splitting a meeting link into room, expiry and signature.
+func (suite *MeetingServiceTestSuite) parseLink(link string) (string, string, string) {
+	u, err := url.Parse(link)
+	suite.Require().NoError(err)
+	return path.Base(u.Path), u.Query().Get("exp"), u.Query().Get("sig")
+}
+
+func (suite *MeetingServiceTestSuite) TestGetMeeting() {
+	ctx := context.Background()
+
+	// Комната создаётся при первом запросе и дальше не меняется
+	patient, err := suite.service.GetMeeting(ctx, suite.appointmentID, 7)
+	suite.Require().NoError(err)
+	suite.Equal(suite.doctorID, patient.DoctorID)
+	suite.NotEmpty(patient.URL)
+
+	doctor, err := suite.service.GetMeeting(ctx, suite.appointmentID, 3)
+	suite.Require().NoError(err)
+	suite.Equal(patient.URL, doctor.URL)
+
+	_, err = suite.service.GetMeeting(ctx, suite.appointmentID, 8)
+	suite.ErrorIs(err, ErrMeetingForbidden)
+	_, err = suite.service.GetMeeting(ctx, uuid.New(), 7)
+	suite.ErrorIs(err, ErrMeetingNotFound)
+}
+
+func (suite *MeetingServiceTestSuite) TestGetMeeting_NotOnline() {
+	ctx := context.Background()
+
+	suite.slots.slots[0].IsOnline = false
+	_, err := suite.service.GetMeeting(ctx, suite.appointmentID, 7)
+	suite.ErrorIs(err, ErrMeetingNotFound)
+
+	// Неподтверждённая бронь ссылки не получает
+	heldUntil := time.Now().Add(time.Minute)
+	suite.slots.slots[0].IsOnline = true
+	suite.slots.slots[0].HeldUntil = &heldUntil
+	_, err = suite.service.GetMeeting(ctx, suite.appointmentID, 7)
+	suite.ErrorIs(err, ErrMeetingNotFound)
+}
+
+// Отменённая запись ссылку не получает, пока слот ещё не освобождён
+func (suite *MeetingServiceTestSuite) TestGetMeeting_Canceled() {
+	ctx := context.Background()
+	a := suite.appointments[suite.appointmentID]
+	a.Status = appointment.StatusCanceled
+	suite.appointments[suite.appointmentID] = a
+
+	_, err := suite.service.GetMeeting(ctx, suite.appointmentID, 7)
+	suite.ErrorIs(err, ErrMeetingNotFound)
+	_, err = suite.service.GetMeeting(ctx, suite.appointmentID, 3)
+	suite.ErrorIs(err, ErrMeetingNotFound)
+	suite.Empty(suite.slots.slots[0].MeetingRoom, "Room should not be created for a canceled appointment")
+}
+
+func (suite *MeetingServiceTestSuite) TestGetMeeting_ProviderError() {
+	fake := meeting.NewFake()
+	fake.Err = errors.New("provider is down")
+	service := NewMeetingService(suite.slots, suite.doctors, suite.appointments, fake)
+
+	_, err := service.GetMeeting(context.Background(), suite.appointmentID, 7)
+	suite.ErrorIs(err, ErrMeetingUnavailable)
+}
+
+func (suite *MeetingServiceTestSuite) TestVerifyJoin() {
+	ctx := context.Background()
+	dto, err := suite.service.GetMeeting(ctx, suite.appointmentID, 7)
+	suite.Require().NoError(err)
+	room, exp, sig := suite.parseLink(dto.URL)
+
+	suite.NoError(suite.service.VerifyJoin(ctx, room, exp, sig))
+	suite.ErrorIs(suite.service.VerifyJoin(ctx, room, exp, "deadbeef"), ErrMeetingRevoked)
+
+	// После отмены записи комната больше не принимается
+	suite.slots.slots[0].AppointmentID = nil
+	suite.slots.slots[0].MeetingRoom, suite.slots.slots[0].MeetingLink = "", ""
+	suite.ErrorIs(suite.service.VerifyJoin(ctx, room, exp, sig), ErrMeetingRevoked)
+}
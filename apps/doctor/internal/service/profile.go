@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrCredentialNotFound возвращается, если у врача нет такого документа
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// ErrPhotoNotFound возвращается, если у врача нет фото
+var ErrPhotoNotFound = errors.New("photo not found")
+
+// ErrInvalidPhoto возвращается, если фото слишком большое или не JPEG, PNG или WebP
+var ErrInvalidPhoto = errors.New("invalid photo")
+
+// photoExtensions — принимаемые форматы фото, тип определяется по содержимому файла
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// DoctorProfileService управляет документами и фото врача
+type DoctorProfileService interface {
+	AddCredential(ctx context.Context, doctorID uuid.UUID, req CreateCredentialRequest) (*CredentialDTO, error)
+	DeleteCredential(ctx context.Context, doctorID, credentialID uuid.UUID) error
+	// UploadPhoto заменяет фото врача, прежний файл удаляется из хранилища
+	UploadPhoto(ctx context.Context, doctorID uuid.UUID, photo io.Reader) error
+	// OpenPhoto отдаёт фото врача и его MIME-тип, читатель закрывает вызывающий
+	OpenPhoto(ctx context.Context, doctorID uuid.UUID) (io.ReadCloser, string, error)
+	DeletePhoto(ctx context.Context, doctorID uuid.UUID) error
+}
+
+type doctorProfileService struct {
+	doctors       repository.DoctorRepository
+	credentials   repository.DoctorCredentialRepository
+	blobs         storage.BlobStore
+	maxPhotoBytes int64
+	now           func() time.Time
+}
+
+// NewDoctorProfileService конструктор, maxPhotoBytes — наибольший размер фото
+func NewDoctorProfileService(doctors repository.DoctorRepository, credentials repository.DoctorCredentialRepository, blobs storage.BlobStore, maxPhotoBytes int64) DoctorProfileService {
+	return &doctorProfileService{
+		doctors:       doctors,
+		credentials:   credentials,
+		blobs:         blobs,
+		maxPhotoBytes: maxPhotoBytes,
+		now:           time.Now,
+	}
+}
+
+func (s *doctorProfileService) AddCredential(ctx context.Context, doctorID uuid.UUID, req CreateCredentialRequest) (*CredentialDTO, error) {
+	if _, err := s.doctor(doctorID); err != nil {
+		return nil, err
+	}
+	c := &model.DoctorCredential{
+		ID:        uuid.New(),
+		DoctorID:  doctorID,
+		Kind:      model.CredentialKind(req.Kind),
+		Number:    req.Number,
+		Issuer:    req.Issuer,
+		IssuedAt:  req.IssuedAt,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.credentials.Create(c); err != nil {
+		return nil, err
+	}
+	dto := toCredentialDTO(*c, s.now())
+	return &dto, nil
+}
+
+func (s *doctorProfileService) DeleteCredential(ctx context.Context, doctorID, credentialID uuid.UUID) error {
+	removed, err := s.credentials.Delete(doctorID, credentialID)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrCredentialNotFound
+	}
+	return nil
+}
+
+func (s *doctorProfileService) UploadPhoto(ctx context.Context, doctorID uuid.UUID, photo io.Reader) error {
+	doc, err := s.doctor(doctorID)
+	if err != nil {
+		return err
+	}
+	previous := doc.PhotoKey
+
+	// Лишний байт показывает, что фото больше допустимого
+	data, err := io.ReadAll(io.LimitReader(photo, s.maxPhotoBytes+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > s.maxPhotoBytes {
+		return fmt.Errorf("%w: larger than %d bytes", ErrInvalidPhoto, s.maxPhotoBytes)
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := photoExtensions[contentType]
+	if !ok {
+		return fmt.Errorf("%w: unsupported type %s", ErrInvalidPhoto, contentType)
+	}
+
+	// Новый ключ на каждую загрузку, старое фото не перезаписывается, пока врач на него ссылается
+	key := fmt.Sprintf("doctors/%s/photo-%s%s", doctorID, uuid.New(), ext)
+	if err := s.blobs.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	if err := s.doctors.UpdatePhoto(doctorID, key); err != nil {
+		_ = s.blobs.Delete(ctx, key)
+		return err
+	}
+	if previous != "" {
+		// Осиротевший файл безвреден, ошибку удаления не отдаём клиенту
+		_ = s.blobs.Delete(ctx, previous)
+	}
+	return nil
+}
+
+func (s *doctorProfileService) OpenPhoto(ctx context.Context, doctorID uuid.UUID) (io.ReadCloser, string, error) {
+	doc, err := s.doctor(doctorID)
+	if err != nil {
+		return nil, "", err
+	}
+	if doc.PhotoKey == "" {
+		return nil, "", ErrPhotoNotFound
+	}
+	r, err := s.blobs.Open(ctx, doc.PhotoKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, "", ErrPhotoNotFound
+		}
+		return nil, "", err
+	}
+	return r, photoContentType(doc.PhotoKey), nil
+}
+
+func (s *doctorProfileService) DeletePhoto(ctx context.Context, doctorID uuid.UUID) error {
+	doc, err := s.doctor(doctorID)
+	if err != nil {
+		return err
+	}
+	key := doc.PhotoKey
+	if key == "" {
+		return ErrPhotoNotFound
+	}
+	if err := s.doctors.UpdatePhoto(doctorID, ""); err != nil {
+		return err
+	}
+	return s.blobs.Delete(ctx, key)
+}
+
+func (s *doctorProfileService) doctor(id uuid.UUID) (*model.Doctor, error) {
+	doc, err := s.doctors.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return doc, nil
+}
+
+// photoURL — адрес, по которому отдаётся фото врача
+func photoURL(doctorID uuid.UUID) string {
+	return "/doctors/" + doctorID.String() + "/photo"
+}
+
+func photoContentType(key string) string {
+	for contentType, ext := range photoExtensions {
+		if path.Ext(key) == ext {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
+
+func toCredentialDTO(c model.DoctorCredential, now time.Time) CredentialDTO {
+	return CredentialDTO{
+		ID:        c.ID,
+		Kind:      string(c.Kind),
+		Number:    c.Number,
+		Issuer:    c.Issuer,
+		IssuedAt:  c.IssuedAt,
+		ExpiresAt: c.ExpiresAt,
+		Expired:   c.Expired(now),
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// pngHeader — начало PNG-файла, по нему определяется тип фото
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// fakeBlobStore хранит файлы в памяти
+type fakeBlobStore struct {
+	blobs map[string][]byte
+}
+
+func (s *fakeBlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *fakeBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, storage.ErrBlobNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeBlobStore) Delete(ctx context.Context, key string) error {
+	delete(s.blobs, key)
+	return nil
+}
+
+// fakeCredentialRepo хранит документы врачей в памяти
+type fakeCredentialRepo struct {
+	repository.DoctorCredentialRepository
+	credentials []model.DoctorCredential
+}
+
+func (r *fakeCredentialRepo) Create(c *model.DoctorCredential) error {
+	r.credentials = append(r.credentials, *c)
+	return nil
+}
+
+func (r *fakeCredentialRepo) Delete(doctorID, id uuid.UUID) (int64, error) {
+	for i, c := range r.credentials {
+		if c.ID == id && c.DoctorID == doctorID {
+			r.credentials = append(r.credentials[:i], r.credentials[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+type DoctorProfileServiceTestSuite struct {
+	suite.Suite
+	doctors     *fakeDoctorRepo
+	credentials *fakeCredentialRepo
+	blobs       *fakeBlobStore
+	service     *doctorProfileService
+	doctorID    uuid.UUID
+}
+
+func TestDoctorProfileService(t *testing.T) {
+	suite.Run(t, new(DoctorProfileServiceTestSuite))
+}
+
+func (suite *DoctorProfileServiceTestSuite) SetupTest() {
+	suite.doctorID = uuid.New()
+	suite.doctors = &fakeDoctorRepo{docs: []model.Doctor{{ID: suite.doctorID}}}
+	suite.credentials = &fakeCredentialRepo{}
+	suite.blobs = &fakeBlobStore{blobs: make(map[string][]byte)}
+	suite.service = NewDoctorProfileService(suite.doctors, suite.credentials, suite.blobs, 64).(*doctorProfileService)
+	suite.service.now = func() time.Time { return time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC) }
+}
+
+func (suite *DoctorProfileServiceTestSuite) TestUploadPhoto_ReplacesPrevious() {
+	ctx := context.Background()
+	suite.Require().NoError(suite.service.UploadPhoto(ctx, suite.doctorID, bytes.NewReader(pngHeader)))
+	first := suite.doctors.docs[0].PhotoKey
+	suite.Require().NotEmpty(first)
+	suite.True(strings.HasSuffix(first, ".png"))
+
+	suite.Require().NoError(suite.service.UploadPhoto(ctx, suite.doctorID, bytes.NewReader(pngHeader)))
+	second := suite.doctors.docs[0].PhotoKey
+	suite.NotEqual(first, second)
+	suite.NotContains(suite.blobs.blobs, first)
+
+	photo, contentType, err := suite.service.OpenPhoto(ctx, suite.doctorID)
+	suite.Require().NoError(err)
+	defer photo.Close()
+	suite.Equal("image/png", contentType)
+
+	dto := toDoctorDTO(&suite.doctors.docs[0], suite.service.now(), nil)
+	suite.Equal("/doctors/"+suite.doctorID.String()+"/photo", dto.PhotoURL)
+
+	suite.Require().NoError(suite.service.DeletePhoto(ctx, suite.doctorID))
+	suite.Empty(suite.doctors.docs[0].PhotoKey)
+	suite.Empty(suite.blobs.blobs)
+	_, _, err = suite.service.OpenPhoto(ctx, suite.doctorID)
+	suite.ErrorIs(err, ErrPhotoNotFound)
+}
+
+func (suite *DoctorProfileServiceTestSuite) TestUploadPhoto_Rejected() {
+	ctx := context.Background()
+	err := suite.service.UploadPhoto(ctx, suite.doctorID, strings.NewReader("<html>not a photo</html>"))
+	suite.ErrorIs(err, ErrInvalidPhoto)
+
+	tooLarge := append(append([]byte{}, pngHeader...), make([]byte, 64)...)
+	err = suite.service.UploadPhoto(ctx, suite.doctorID, bytes.NewReader(tooLarge))
+	suite.ErrorIs(err, ErrInvalidPhoto)
+
+	err = suite.service.UploadPhoto(ctx, uuid.New(), bytes.NewReader(pngHeader))
+	suite.ErrorIs(err, ErrNotFound)
+	suite.Empty(suite.blobs.blobs)
+}
+
+func (suite *DoctorProfileServiceTestSuite) TestCredentials() {
+	ctx := context.Background()
+	expires := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	current, err := suite.service.AddCredential(ctx, suite.doctorID, CreateCredentialRequest{
+		Kind:      string(model.License),
+		Number:    "KZ-123",
+		Issuer:    "Минздрав РК",
+		IssuedAt:  time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+		ExpiresAt: &expires,
+	})
+	suite.Require().NoError(err)
+	suite.False(current.Expired, "valid through the expiry day")
+
+	expires = expires.AddDate(0, 0, -1)
+	expired, err := suite.service.AddCredential(ctx, suite.doctorID, CreateCredentialRequest{
+		Kind:      string(model.Certificate),
+		Number:    "C-1",
+		Issuer:    "НЦХ",
+		IssuedAt:  time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC),
+		ExpiresAt: &expires,
+	})
+	suite.Require().NoError(err)
+	suite.True(expired.Expired)
+
+	_, err = suite.service.AddCredential(ctx, uuid.New(), CreateCredentialRequest{Kind: string(model.Diploma)})
+	suite.ErrorIs(err, ErrNotFound)
+
+	suite.NoError(suite.service.DeleteCredential(ctx, suite.doctorID, expired.ID))
+	suite.ErrorIs(suite.service.DeleteCredential(ctx, uuid.New(), current.ID), ErrCredentialNotFound)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/appointment"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrReviewNotFound возвращается, если отзыва нет
+var ErrReviewNotFound = errors.New("review not found")
+
+// ErrReviewNotAllowed возвращается, если у пользователя нет состоявшегося приёма у врача
+var ErrReviewNotAllowed = errors.New("review requires a completed appointment with the doctor")
+
+// ErrReviewExists возвращается при повторном отзыве на тот же приём
+var ErrReviewExists = errors.New("appointment already has a review")
+
+// ReviewService управляет отзывами пациентов и их модерацией
+type ReviewService interface {
+	// CreateReview оставляет отзыв пользователя userID о приёме у врача. Отзыв ждёт
+	// модерации и до одобрения не виден и не влияет на рейтинг.
+	CreateReview(ctx context.Context, doctorID uuid.UUID, userID int64, req CreateReviewRequest) (*ReviewDTO, error)
+	// ListDoctorReviews — одобренные отзывы о враче, новые первыми
+	ListDoctorReviews(ctx context.Context, doctorID uuid.UUID) ([]ReviewDTO, error)
+	// ListReviews — отзывы в статусе status для модерации, старые первыми
+	ListReviews(ctx context.Context, status model.ReviewStatus) ([]ReviewDTO, error)
+	ModerateReview(ctx context.Context, id uuid.UUID, req ModerateReviewRequest) (*ReviewDTO, error)
+}
+
+type reviewService struct {
+	reviews      repository.ReviewRepository
+	doctors      repository.DoctorRepository
+	appointments appointment.Client
+	now          func() time.Time
+}
+
+// NewReviewService конструктор, appointments проверяет приёмы, о которых оставляют отзывы
+func NewReviewService(reviews repository.ReviewRepository, doctors repository.DoctorRepository, appointments appointment.Client) ReviewService {
+	return &reviewService{
+		reviews:      reviews,
+		doctors:      doctors,
+		appointments: appointments,
+		now:          time.Now,
+	}
+}
+
+func (s *reviewService) CreateReview(ctx context.Context, doctorID uuid.UUID, userID int64, req CreateReviewRequest) (*ReviewDTO, error) {
+	if _, err := s.doctors.GetByID(doctorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	a, err := s.appointments.GetAppointment(ctx, req.AppointmentID)
+	if err != nil {
+		if errors.Is(err, appointment.ErrNotFound) {
+			return nil, fmt.Errorf("%w: appointment not found", ErrReviewNotAllowed)
+		}
+		return nil, err
+	}
+	if a.UserID != userID || a.DoctorID != doctorID {
+		return nil, fmt.Errorf("%w: appointment belongs to another patient or doctor", ErrReviewNotAllowed)
+	}
+	if a.Status != appointment.StatusCompleted {
+		return nil, fmt.Errorf("%w: appointment is %s", ErrReviewNotAllowed, a.Status)
+	}
+
+	r := &model.Review{
+		ID:            uuid.New(),
+		DoctorID:      doctorID,
+		AppointmentID: req.AppointmentID,
+		UserID:        userID,
+		Rating:        req.Rating,
+		Comment:       req.Comment,
+		Status:        model.ReviewPending,
+	}
+	created, err := s.reviews.Create(r)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrReviewExists
+	}
+	dto := toReviewDTO(r)
+	return &dto, nil
+}
+
+func (s *reviewService) ListDoctorReviews(ctx context.Context, doctorID uuid.UUID) ([]ReviewDTO, error) {
+	reviews, err := s.reviews.ListByDoctor(doctorID, model.ReviewApproved)
+	if err != nil {
+		return nil, err
+	}
+	return toReviewDTOs(reviews), nil
+}
+
+func (s *reviewService) ListReviews(ctx context.Context, status model.ReviewStatus) ([]ReviewDTO, error) {
+	reviews, err := s.reviews.ListByStatus(status)
+	if err != nil {
+		return nil, err
+	}
+	return toReviewDTOs(reviews), nil
+}
+
+// ModerateReview одобряет или отклоняет отзыв. Решение можно пересмотреть,
+// рейтинг врача пересчитывается при каждом изменении.
+func (s *reviewService) ModerateReview(ctx context.Context, id uuid.UUID, req ModerateReviewRequest) (*ReviewDTO, error) {
+	r, err := s.reviews.UpdateStatus(id, model.ReviewStatus(req.Status), s.now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, err
+	}
+	dto := toReviewDTO(r)
+	return &dto, nil
+}
+
+func toReviewDTOs(reviews []model.Review) []ReviewDTO {
+	dtos := make([]ReviewDTO, 0, len(reviews))
+	for i := range reviews {
+		dtos = append(dtos, toReviewDTO(&reviews[i]))
+	}
+	return dtos
+}
+
+func toReviewDTO(r *model.Review) ReviewDTO {
+	return ReviewDTO{
+		ID:            r.ID,
+		DoctorID:      r.DoctorID,
+		AppointmentID: r.AppointmentID,
+		UserID:        r.UserID,
+		Rating:        r.Rating,
+		Comment:       r.Comment,
+		Status:        string(r.Status),
+		ModeratedAt:   r.ModeratedAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
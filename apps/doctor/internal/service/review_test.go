@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/appointment"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakeReviewRepo хранит отзывы в памяти и пересчитывает рейтинг врачей в fakeDoctorRepo
+type fakeReviewRepo struct {
+	repository.ReviewRepository
+	reviews []model.Review
+	doctors *fakeDoctorRepo
+}
+
+func (r *fakeReviewRepo) Create(review *model.Review) (bool, error) {
+	for _, existing := range r.reviews {
+		if existing.AppointmentID == review.AppointmentID {
+			return false, nil
+		}
+	}
+	r.reviews = append(r.reviews, *review)
+	return true, nil
+}
+
+func (r *fakeReviewRepo) ListByDoctor(doctorID uuid.UUID, status model.ReviewStatus) ([]model.Review, error) {
+	var reviews []model.Review
+	for _, review := range r.reviews {
+		if review.DoctorID == doctorID && review.Status == status {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews, nil
+}
+
+func (r *fakeReviewRepo) UpdateStatus(id uuid.UUID, status model.ReviewStatus, at time.Time) (*model.Review, error) {
+	for i := range r.reviews {
+		if r.reviews[i].ID != id {
+			continue
+		}
+		r.reviews[i].Status = status
+		r.reviews[i].ModeratedAt = &at
+
+		doc, _ := r.doctors.GetByID(r.reviews[i].DoctorID)
+		sum, count := 0, 0
+		for _, review := range r.reviews {
+			if review.DoctorID == doc.ID && review.Status == model.ReviewApproved {
+				sum += review.Rating
+				count++
+			}
+		}
+		doc.RatingAvg, doc.RatingCount = 0, count
+		if count > 0 {
+			doc.RatingAvg = float64(sum) / float64(count)
+		}
+		review := r.reviews[i]
+		return &review, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// fakeAppointments отдаёт записи на приём по ID
+type fakeAppointments map[uuid.UUID]appointment.Appointment
+
+func (f fakeAppointments) GetAppointment(ctx context.Context, id uuid.UUID) (*appointment.Appointment, error) {
+	a, ok := f[id]
+	if !ok {
+		return nil, appointment.ErrNotFound
+	}
+	return &a, nil
+}
+
+type ReviewServiceTestSuite struct {
+	suite.Suite
+	doctors      *fakeDoctorRepo
+	reviews      *fakeReviewRepo
+	appointments fakeAppointments
+	service      ReviewService
+	doctorID     uuid.UUID
+}
+
+func TestReviewService(t *testing.T) {
+	suite.Run(t, new(ReviewServiceTestSuite))
+}
+
+func (suite *ReviewServiceTestSuite) SetupTest() {
+	suite.doctorID = uuid.New()
+	suite.doctors = &fakeDoctorRepo{docs: []model.Doctor{{ID: suite.doctorID, FirstName: "Иван", LastName: "Иванов"}}}
+	suite.reviews = &fakeReviewRepo{doctors: suite.doctors}
+	suite.appointments = fakeAppointments{}
+	suite.service = NewReviewService(suite.reviews, suite.doctors, suite.appointments)
+}
+
+// Support function for adding an appointment of user 7 with the doctor.
+func (suite *ReviewServiceTestSuite) appointment(status string) uuid.UUID {
+	id := uuid.New()
+	suite.appointments[id] = appointment.Appointment{ID: id, UserID: 7, DoctorID: suite.doctorID, Status: status}
+	return id
+}
+
+func (suite *ReviewServiceTestSuite) TestCreateReview() {
+	ctx := context.Background()
+	completed := suite.appointment(appointment.StatusCompleted)
+
+	dto, err := suite.service.CreateReview(ctx, suite.doctorID, 7, CreateReviewRequest{AppointmentID: completed, Rating: 5, Comment: "Спасибо"})
+	suite.Require().NoError(err)
+	suite.Equal(string(model.ReviewPending), dto.Status)
+	suite.Equal(int64(7), dto.UserID)
+
+	_, err = suite.service.CreateReview(ctx, suite.doctorID, 7, CreateReviewRequest{AppointmentID: completed, Rating: 1})
+	suite.ErrorIs(err, ErrReviewExists)
+
+	// До модерации отзыв не виден
+	reviews, err := suite.service.ListDoctorReviews(ctx, suite.doctorID)
+	suite.Require().NoError(err)
+	suite.Empty(reviews)
+}
+
+func (suite *ReviewServiceTestSuite) TestCreateReview_NotAllowed() {
+	ctx := context.Background()
+	completed := suite.appointment(appointment.StatusCompleted)
+
+	cases := map[string]struct {
+		doctorID      uuid.UUID
+		userID        int64
+		appointmentID uuid.UUID
+	}{
+		"scheduled appointment": {suite.doctorID, 7, suite.appointment("scheduled")},
+		"canceled appointment":  {suite.doctorID, 7, suite.appointment("canceled")},
+		"another patient":       {suite.doctorID, 8, completed},
+		"unknown appointment":   {suite.doctorID, 7, uuid.New()},
+	}
+	for name, tc := range cases {
+		_, err := suite.service.CreateReview(ctx, tc.doctorID, tc.userID, CreateReviewRequest{AppointmentID: tc.appointmentID, Rating: 4})
+		suite.ErrorIs(err, ErrReviewNotAllowed, name)
+	}
+
+	other := uuid.New()
+	suite.doctors.docs = append(suite.doctors.docs, model.Doctor{ID: other})
+	_, err := suite.service.CreateReview(ctx, other, 7, CreateReviewRequest{AppointmentID: completed, Rating: 4})
+	suite.ErrorIs(err, ErrReviewNotAllowed, "appointment with another doctor")
+
+	_, err = suite.service.CreateReview(ctx, uuid.New(), 7, CreateReviewRequest{AppointmentID: completed, Rating: 4})
+	suite.ErrorIs(err, ErrNotFound)
+	suite.Empty(suite.reviews.reviews)
+}
+
+func (suite *ReviewServiceTestSuite) TestModerateReview() {
+	ctx := context.Background()
+	var ids []uuid.UUID
+	for _, rating := range []int{5, 4, 2} {
+		dto, err := suite.service.CreateReview(ctx, suite.doctorID, 7, CreateReviewRequest{
+			AppointmentID: suite.appointment(appointment.StatusCompleted),
+			Rating:        rating,
+		})
+		suite.Require().NoError(err)
+		ids = append(ids, dto.ID)
+	}
+
+	for _, id := range ids[:2] {
+		_, err := suite.service.ModerateReview(ctx, id, ModerateReviewRequest{Status: string(model.ReviewApproved)})
+		suite.Require().NoError(err)
+	}
+	dto, err := suite.service.ModerateReview(ctx, ids[2], ModerateReviewRequest{Status: string(model.ReviewRejected)})
+	suite.Require().NoError(err)
+	suite.NotNil(dto.ModeratedAt)
+
+	doc := suite.doctors.docs[0]
+	suite.Equal(4.5, doc.RatingAvg)
+	suite.Equal(2, doc.RatingCount)
+
+	reviews, err := suite.service.ListDoctorReviews(ctx, suite.doctorID)
+	suite.Require().NoError(err)
+	suite.Len(reviews, 2)
+
+	_, err = suite.service.ModerateReview(ctx, uuid.New(), ModerateReviewRequest{Status: string(model.ReviewApproved)})
+	suite.ErrorIs(err, ErrReviewNotFound)
+}
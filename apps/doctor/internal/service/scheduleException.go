@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	doctornats "github.com/Ruletk/OnlineClinic/apps/doctor/internal/nats"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// ErrExceptionNotFound возвращается, если исключения из расписания нет
+var ErrExceptionNotFound = errors.New("schedule exception not found")
+
+// ErrInvalidException возвращается при неверном виде, интервале или враче исключения
+var ErrInvalidException = errors.New("invalid schedule exception")
+
+// ScheduleExceptionService управляет отпусками, праздниками и дополнительными часами
+type ScheduleExceptionService interface {
+	// CreateException закрывает свободные слоты в интервале и возвращает занятые,
+	// для дополнительных часов — создаёт слоты
+	CreateException(ctx context.Context, req CreateScheduleExceptionRequest) (*CreateScheduleExceptionResponse, error)
+	ListExceptions(ctx context.Context, req ListScheduleExceptionsRequest) ([]ScheduleExceptionDTO, error)
+	GetException(ctx context.Context, id uuid.UUID) (*ScheduleExceptionDTO, error)
+	// DeleteException открывает закрытые исключением слоты или удаляет свободные слоты дополнительных часов
+	DeleteException(ctx context.Context, id uuid.UUID) error
+}
+
+type scheduleExceptionService struct {
+	exceptions repository.ScheduleExceptionRepository
+	slots      repository.ScheduleSlotRepository
+	doctors    repository.DoctorRepository
+	publisher  doctornats.Publisher // nil, если NATS недоступен
+	clinic     *time.Location       // пояс врачей без своего часового пояса
+}
+
+// NewScheduleExceptionService конструктор, publisher может быть nil,
+// clinic — часовой пояс врачей, у которых нет своего
+func NewScheduleExceptionService(exceptions repository.ScheduleExceptionRepository, slots repository.ScheduleSlotRepository, doctors repository.DoctorRepository, publisher doctornats.Publisher, clinic *time.Location) ScheduleExceptionService {
+	return &scheduleExceptionService{
+		exceptions: exceptions,
+		slots:      slots,
+		doctors:    doctors,
+		publisher:  publisher,
+		clinic:     clinic,
+	}
+}
+
+func (s *scheduleExceptionService) CreateException(ctx context.Context, req CreateScheduleExceptionRequest) (*CreateScheduleExceptionResponse, error) {
+	loc := s.clinic
+	if model.ExceptionKind(req.Kind) == model.ExtraHours && req.DoctorID != nil {
+		doc, err := s.doctors.GetByID(*req.DoctorID)
+		switch {
+		case err == nil:
+			loc = doc.Location(s.clinic)
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, err
+		}
+	}
+	e, err := exceptionFromRequest(req, loc)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.exceptions.Create(e); err != nil {
+		return nil, err
+	}
+
+	resp := &CreateScheduleExceptionResponse{Exception: toScheduleExceptionDTO(e), Conflicts: []ConflictDTO{}}
+	if !e.Blocks() {
+		if resp.CreatedSlots, err = s.slots.CreateMissing(e.ExtraSlots()); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	affected, err := s.slots.ListOverlapping(e.DoctorID, e.StartsAt, e.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	var closed []uuid.UUID
+	for _, slot := range affected {
+		switch {
+		case slot.AppointmentID == nil && slot.IsAvailable:
+			closed = append(closed, slot.ID)
+		case slot.BlockedBy == nil:
+			// Занят записью; закрытые другим исключением слоты конфликтом не считаются.
+			// Занятый слот тоже помечается исключением, чтобы не открыться после отмены записи
+			if slot.AppointmentID != nil {
+				closed = append(closed, slot.ID)
+			}
+			resp.Conflicts = append(resp.Conflicts, ConflictDTO{
+				DoctorID:      slot.DoctorID,
+				SlotID:        slot.ID,
+				SlotTime:      slot.StartsAt,
+				AppointmentID: slot.AppointmentID,
+			})
+		}
+	}
+	if resp.BlockedSlots, err = s.slots.Block(closed, e.ID); err != nil {
+		return nil, err
+	}
+
+	s.publishTimeOff(ctx, e, resp.Conflicts)
+	return resp, nil
+}
+
+func (s *scheduleExceptionService) ListExceptions(ctx context.Context, req ListScheduleExceptionsRequest) ([]ScheduleExceptionDTO, error) {
+	if !req.From.Before(req.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidException)
+	}
+	var doctorID *uuid.UUID
+	if req.DoctorID != "" {
+		id, err := uuid.Parse(req.DoctorID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid doctor_id", ErrInvalidException)
+		}
+		doctorID = &id
+	}
+	exceptions, err := s.exceptions.List(doctorID, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]ScheduleExceptionDTO, 0, len(exceptions))
+	for i := range exceptions {
+		dtos = append(dtos, toScheduleExceptionDTO(&exceptions[i]))
+	}
+	return dtos, nil
+}
+
+func (s *scheduleExceptionService) GetException(ctx context.Context, id uuid.UUID) (*ScheduleExceptionDTO, error) {
+	e, err := s.exceptions.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExceptionNotFound
+		}
+		return nil, err
+	}
+	dto := toScheduleExceptionDTO(e)
+	return &dto, nil
+}
+
+func (s *scheduleExceptionService) DeleteException(ctx context.Context, id uuid.UUID) error {
+	e, err := s.exceptions.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrExceptionNotFound
+		}
+		return err
+	}
+
+	if e.Blocks() {
+		_, err = s.slots.Unblock(id)
+	} else {
+		_, err = s.slots.DeleteFreeByException(id)
+	}
+	if err != nil {
+		return err
+	}
+	return s.exceptions.Delete(id)
+}
+
+// publishTimeOff сообщает сервису записей о закрытом времени. Исключение уже сохранено,
+// поэтому ошибка публикации только логируется.
+func (s *scheduleExceptionService) publishTimeOff(ctx context.Context, e *model.ScheduleException, conflicts []ConflictDTO) {
+	if s.publisher == nil {
+		logging.FromContext(ctx).Warnf("NATS is not configured, time-off %s is not published", e.ID)
+		return
+	}
+
+	event := &doctorpb.TimeOffCreated{
+		ExceptionId: e.ID.String(),
+		Kind:        string(e.Kind),
+		StartsAt:    timestamppb.New(e.StartsAt),
+		EndsAt:      timestamppb.New(e.EndsAt),
+		Reason:      e.Reason,
+	}
+	if e.DoctorID != nil {
+		event.DoctorId = e.DoctorID.String()
+	}
+	for _, c := range conflicts {
+		slot := &doctorpb.ConflictingSlot{
+			DoctorId: c.DoctorID.String(),
+			SlotId:   c.SlotID.String(),
+			SlotTime: timestamppb.New(c.SlotTime),
+		}
+		if c.AppointmentID != nil {
+			slot.AppointmentId = c.AppointmentID.String()
+		}
+		event.Conflicts = append(event.Conflicts, slot)
+	}
+
+	if err := s.publisher.PublishTimeOffCreated(ctx, event); err != nil {
+		logging.FromContext(ctx).WithError(err).Errorf("Failed to publish time-off %s", e.ID)
+	}
+}
+
+// exceptionFromRequest проверяет запрос, loc — часовой пояс врача, в котором считаются сутки дополнительных часов
+func exceptionFromRequest(req CreateScheduleExceptionRequest, loc *time.Location) (*model.ScheduleException, error) {
+	kind := model.ExceptionKind(req.Kind)
+	startsAt, endsAt := req.StartsAt.UTC(), req.EndsAt.UTC()
+
+	if !startsAt.Before(endsAt) {
+		return nil, fmt.Errorf("%w: starts_at must be before ends_at", ErrInvalidException)
+	}
+	if kind == model.Holiday && req.DoctorID != nil {
+		return nil, fmt.Errorf("%w: a holiday applies to the whole clinic, doctor_id must be empty", ErrInvalidException)
+	}
+	if kind != model.Holiday && req.DoctorID == nil {
+		return nil, fmt.Errorf("%w: doctor_id is required for %s", ErrInvalidException, kind)
+	}
+	if kind == model.ExtraHours {
+		if req.SlotMinutes == 0 {
+			return nil, fmt.Errorf("%w: slot_minutes is required for extra hours", ErrInvalidException)
+		}
+		// Дополнительные часы — часть одного рабочего дня врача. Сутки берутся в его поясе:
+		// вечер по местному времени в UTC может прийтись уже на следующую дату
+		if startsAt.In(loc).Format(time.DateOnly) != endsAt.Add(-time.Nanosecond).In(loc).Format(time.DateOnly) {
+			return nil, fmt.Errorf("%w: extra hours must start and end on the same day", ErrInvalidException)
+		}
+	}
+
+	return &model.ScheduleException{
+		ID:          uuid.New(),
+		DoctorID:    req.DoctorID,
+		Kind:        kind,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Reason:      req.Reason,
+		SlotMinutes: req.SlotMinutes,
+	}, nil
+}
+
+func toScheduleExceptionDTO(e *model.ScheduleException) ScheduleExceptionDTO {
+	return ScheduleExceptionDTO{
+		ID:          e.ID,
+		DoctorID:    e.DoctorID,
+		Kind:        string(e.Kind),
+		StartsAt:    e.StartsAt,
+		EndsAt:      e.EndsAt,
+		Reason:      e.Reason,
+		SlotMinutes: e.SlotMinutes,
+	}
+}
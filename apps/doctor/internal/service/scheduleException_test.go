@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	doctorpb "github.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+func (r *fakeExceptionRepo) Create(e *model.ScheduleException) error {
+	r.exceptions = append(r.exceptions, *e)
+	return nil
+}
+
+func (r *fakeExceptionRepo) GetByID(id uuid.UUID) (*model.ScheduleException, error) {
+	for _, e := range r.exceptions {
+		if e.ID == id {
+			return &e, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeExceptionRepo) Delete(id uuid.UUID) error {
+	kept := r.exceptions[:0]
+	for _, e := range r.exceptions {
+		if e.ID != id {
+			kept = append(kept, e)
+		}
+	}
+	r.exceptions = kept
+	return nil
+}
+
+func (r *fakeSlotStore) ListOverlapping(doctorID *uuid.UUID, from, to time.Time) ([]model.ScheduleSlot, error) {
+	var slots []model.ScheduleSlot
+	for _, slot := range r.slots {
+		if doctorID != nil && slot.DoctorID != *doctorID {
+			continue
+		}
+		if slot.StartsAt.Before(to) && from.Before(slot.EndsAt) {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+func (r *fakeSlotStore) Block(ids []uuid.UUID, exceptionID uuid.UUID) (int64, error) {
+	var blocked int64
+	for i := range r.slots {
+		if containsID(ids, r.slots[i].ID) && r.slots[i].BlockedBy == nil {
+			r.slots[i].IsAvailable = false
+			r.slots[i].BlockedBy = &exceptionID
+			blocked++
+		}
+	}
+	return blocked, nil
+}
+
+func (r *fakeSlotStore) Unblock(exceptionID uuid.UUID) (int64, error) {
+	var unblocked int64
+	for i := range r.slots {
+		if r.slots[i].BlockedBy != nil && *r.slots[i].BlockedBy == exceptionID {
+			r.slots[i].IsAvailable = r.slots[i].AppointmentID == nil
+			r.slots[i].BlockedBy = nil
+			unblocked++
+		}
+	}
+	return unblocked, nil
+}
+
+// fakePublisher запоминает опубликованные события
+type fakePublisher struct {
+	events []*doctorpb.TimeOffCreated
+}
+
+func (p *fakePublisher) PublishTimeOffCreated(_ context.Context, event *doctorpb.TimeOffCreated) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+type ScheduleExceptionServiceTestSuite struct {
+	suite.Suite
+	exceptions *fakeExceptionRepo
+	slots      *fakeSlotStore
+	publisher  *fakePublisher
+	doctors    *fakeDoctorRepo
+	service    ScheduleExceptionService
+	doctorID   uuid.UUID
+	day        time.Time
+}
+
+func TestScheduleExceptionService(t *testing.T) {
+	suite.Run(t, new(ScheduleExceptionServiceTestSuite))
+}
+
+func (suite *ScheduleExceptionServiceTestSuite) SetupTest() {
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	suite.exceptions = &fakeExceptionRepo{}
+	suite.publisher = &fakePublisher{}
+	suite.doctorID = uuid.New()
+	suite.day = time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	// Три слота с 9:00: свободный, занятый записью и свободный у другого врача
+	appointmentID := uuid.New()
+	suite.slots = &fakeSlotStore{slots: []model.ScheduleSlot{
+		{ID: uuid.New(), DoctorID: suite.doctorID, StartsAt: suite.at(9, 0), EndsAt: suite.at(9, 30), IsAvailable: true},
+		{ID: uuid.New(), DoctorID: suite.doctorID, StartsAt: suite.at(9, 30), EndsAt: suite.at(10, 0), AppointmentID: &appointmentID},
+		{ID: uuid.New(), DoctorID: uuid.New(), StartsAt: suite.at(9, 0), EndsAt: suite.at(9, 30), IsAvailable: true},
+	}}
+	suite.doctors = &fakeDoctorRepo{docs: []model.Doctor{{ID: suite.doctorID}}}
+	suite.service = NewScheduleExceptionService(suite.exceptions, suite.slots, suite.doctors, suite.publisher, time.UTC)
+}
+
+// at — момент в тестовый день, в UTC
+func (suite *ScheduleExceptionServiceTestSuite) at(hour, minute int) time.Time {
+	return suite.day.Add(time.Duration(model.Clock(hour, minute)))
+}
+
+func (suite *ScheduleExceptionServiceTestSuite) TestCreateException_Vacation() {
+	resp, err := suite.service.CreateException(context.Background(), CreateScheduleExceptionRequest{
+		DoctorID: &suite.doctorID,
+		Kind:     string(model.Vacation),
+		StartsAt: suite.day,
+		EndsAt:   suite.day.AddDate(0, 0, 1),
+	})
+	suite.Require().NoError(err)
+
+	suite.EqualValues(2, resp.BlockedSlots)
+	suite.False(suite.slots.slots[0].IsAvailable)
+	suite.Equal(resp.Exception.ID, *suite.slots.slots[1].BlockedBy, "booked slot is marked too")
+	suite.True(suite.slots.slots[2].IsAvailable, "other doctors are not affected")
+
+	suite.Require().Len(resp.Conflicts, 1)
+	suite.Equal(suite.slots.slots[1].ID, resp.Conflicts[0].SlotID)
+	suite.Equal(suite.slots.slots[1].AppointmentID, resp.Conflicts[0].AppointmentID)
+
+	suite.Require().Len(suite.publisher.events, 1)
+	event := suite.publisher.events[0]
+	suite.Equal(resp.Exception.ID.String(), event.ExceptionId)
+	suite.Equal(suite.doctorID.String(), event.DoctorId)
+	suite.Require().Len(event.Conflicts, 1)
+	suite.Equal(suite.slots.slots[1].AppointmentID.String(), event.Conflicts[0].AppointmentId)
+	suite.True(event.Conflicts[0].SlotTime.AsTime().Equal(suite.day.Add(9*time.Hour + 30*time.Minute)))
+}
+
+func (suite *ScheduleExceptionServiceTestSuite) TestCreateException_HolidayBlocksEveryDoctor() {
+	resp, err := suite.service.CreateException(context.Background(), CreateScheduleExceptionRequest{
+		Kind:     string(model.Holiday),
+		StartsAt: suite.day,
+		EndsAt:   suite.day.AddDate(0, 0, 1),
+	})
+	suite.Require().NoError(err)
+
+	suite.EqualValues(3, resp.BlockedSlots)
+	suite.Len(resp.Conflicts, 1)
+	suite.Empty(suite.publisher.events[0].DoctorId)
+}
+
+func (suite *ScheduleExceptionServiceTestSuite) TestDeleteException_ReleasesSlots() {
+	resp, err := suite.service.CreateException(context.Background(), CreateScheduleExceptionRequest{
+		DoctorID: &suite.doctorID,
+		Kind:     string(model.SickLeave),
+		StartsAt: suite.day.Add(9 * time.Hour),
+		EndsAt:   suite.day.Add(10 * time.Hour),
+	})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.service.DeleteException(context.Background(), resp.Exception.ID))
+	suite.True(suite.slots.slots[0].IsAvailable)
+	suite.Nil(suite.slots.slots[0].BlockedBy)
+	suite.Nil(suite.slots.slots[1].BlockedBy)
+	suite.False(suite.slots.slots[1].IsAvailable, "booked slot stays booked")
+	suite.Empty(suite.exceptions.exceptions)
+
+	suite.ErrorIs(suite.service.DeleteException(context.Background(), resp.Exception.ID), ErrExceptionNotFound)
+}
+
+// Удержание внутри отпуска истекает, но слот не открывается до удаления исключения
+func (suite *ScheduleExceptionServiceTestSuite) TestCreateException_ExpiredHoldStaysClosed() {
+	heldUntil := suite.at(8, 0)
+	held := &suite.slots.slots[0]
+	appointmentID, token := uuid.New(), uuid.New()
+	held.IsAvailable, held.AppointmentID, held.ReservationToken, held.HeldUntil = false, &appointmentID, &token, &heldUntil
+
+	resp, err := suite.service.CreateException(context.Background(), CreateScheduleExceptionRequest{
+		DoctorID: &suite.doctorID,
+		Kind:     string(model.Vacation),
+		StartsAt: suite.day,
+		EndsAt:   suite.day.AddDate(0, 0, 1),
+	})
+	suite.Require().NoError(err)
+	suite.Len(resp.Conflicts, 2)
+
+	released, err := suite.slots.ReleaseExpired(suite.at(9, 0))
+	suite.Require().NoError(err)
+	suite.EqualValues(1, released)
+	suite.False(suite.slots.slots[0].IsAvailable)
+	_, err = suite.slots.Reserve(suite.doctorID, suite.at(9, 0), repository.Reservation{AppointmentID: uuid.New(), Token: uuid.New()}, suite.at(9, 0))
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	suite.Require().NoError(suite.service.DeleteException(context.Background(), resp.Exception.ID))
+	suite.True(suite.slots.slots[0].IsAvailable)
+}
+
+func (suite *ScheduleExceptionServiceTestSuite) TestCreateException_ExtraHours() {
+	resp, err := suite.service.CreateException(context.Background(), CreateScheduleExceptionRequest{
+		DoctorID:    &suite.doctorID,
+		Kind:        string(model.ExtraHours),
+		StartsAt:    suite.day.Add(18 * time.Hour),
+		EndsAt:      suite.day.Add(19*time.Hour + 10*time.Minute),
+		SlotMinutes: 30,
+	})
+	suite.Require().NoError(err)
+
+	// Неполный хвост в 10 минут отбрасывается
+	suite.EqualValues(2, resp.CreatedSlots)
+	suite.Empty(suite.publisher.events)
+	created := suite.slots.slots[3:]
+	suite.Require().Len(created, 2)
+	suite.Equal(suite.at(18, 30), created[1].StartsAt)
+	suite.Equal(resp.Exception.ID, *created[1].ExceptionID)
+}
+
+func (suite *ScheduleExceptionServiceTestSuite) TestCreateException_Invalid() {
+	cases := map[string]CreateScheduleExceptionRequest{
+		"holiday with doctor": {
+			DoctorID: &suite.doctorID, Kind: string(model.Holiday),
+			StartsAt: suite.day, EndsAt: suite.day.AddDate(0, 0, 1),
+		},
+		"vacation without doctor": {
+			Kind:     string(model.Vacation),
+			StartsAt: suite.day, EndsAt: suite.day.AddDate(0, 0, 1),
+		},
+		"empty interval": {
+			DoctorID: &suite.doctorID, Kind: string(model.Vacation),
+			StartsAt: suite.day, EndsAt: suite.day,
+		},
+		"extra hours over midnight": {
+			DoctorID: &suite.doctorID, Kind: string(model.ExtraHours), SlotMinutes: 30,
+			StartsAt: suite.day.Add(23 * time.Hour), EndsAt: suite.day.Add(25 * time.Hour),
+		},
+	}
+	for name, req := range cases {
+		_, err := suite.service.CreateException(context.Background(), req)
+		suite.ErrorIs(err, ErrInvalidException, name)
+	}
+	suite.Empty(suite.exceptions.exceptions)
+}
+
+// Сутки дополнительных часов считаются в поясе врача, а не в UTC
+func (suite *ScheduleExceptionServiceTestSuite) TestCreateException_ExtraHoursLocalDay() {
+	suite.doctors.docs[0].TimeZone = "Asia/Almaty"
+	loc, err := time.LoadLocation("Asia/Almaty")
+	suite.Require().NoError(err)
+	local := time.Date(2025, 6, 10, 0, 0, 0, 0, loc)
+
+	// С 3:00 до 6:00 по Алматы — один день, хотя в UTC начало приходится на предыдущие сутки
+	_, err = suite.service.CreateException(context.Background(), CreateScheduleExceptionRequest{
+		DoctorID:    &suite.doctorID,
+		Kind:        string(model.ExtraHours),
+		StartsAt:    local.Add(3 * time.Hour),
+		EndsAt:      local.Add(6 * time.Hour),
+		SlotMinutes: 30,
+	})
+	suite.NoError(err)
+
+	// С 23:00 до 1:00 по Алматы переходит через полночь, хотя в UTC это одни сутки
+	_, err = suite.service.CreateException(context.Background(), CreateScheduleExceptionRequest{
+		DoctorID:    &suite.doctorID,
+		Kind:        string(model.ExtraHours),
+		StartsAt:    local.Add(23 * time.Hour),
+		EndsAt:      local.Add(25 * time.Hour),
+		SlotMinutes: 30,
+	})
+	suite.ErrorIs(err, ErrInvalidException)
+}
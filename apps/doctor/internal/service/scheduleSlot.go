@@ -1,28 +1,67 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/meeting"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 var ErrSlotNotFound = errors.New("schedule slot not found")
 
+// ErrSlotBooked возвращается при попытке занять уже занятый слот
+var ErrSlotBooked = errors.New("schedule slot is already booked")
+
+// ErrReservationMismatch возвращается, если слот занят другой записью или токен не совпадает
+var ErrReservationMismatch = errors.New("schedule slot is reserved by another appointment")
+
+// ErrRoomOccupied возвращается, если кабинет слота на это время занят приёмами других врачей
+var ErrRoomOccupied = errors.New("room is occupied at this time")
+
+// ErrHoldExpired возвращается при подтверждении удержания, которое истекло или уже снято
+var ErrHoldExpired = errors.New("schedule slot hold has expired")
+
 type ScheduleSlotService interface {
 	CreateSlot(req model.ScheduleSlot) (*model.ScheduleSlot, error)
 	GetSlotByID(id uuid.UUID) (*model.ScheduleSlot, error)
 	UpdateSlot(req model.ScheduleSlot) (*model.ScheduleSlot, error)
 	DeleteSlot(id uuid.UUID) error
+	FindSlot(doctorID uuid.UUID, start time.Time) (*model.ScheduleSlot, error)
+	ListSlots(doctorID uuid.UUID, from, to *time.Time) ([]model.ScheduleSlot, error)
+	SetAvailability(doctorID uuid.UUID, start time.Time, available bool) (*model.ScheduleSlot, error)
+	// ReserveSlot занимает слот под запись и выдаёт токен брони. Повторный вызов
+	// с той же записью возвращает ту же бронь. Для онлайн-слота создаётся комната видеосвязи.
+	ReserveSlot(ctx context.Context, doctorID uuid.UUID, start time.Time, appointmentID uuid.UUID) (*model.ScheduleSlot, error)
+	// HoldSlot временно удерживает слот под запись, пока пациент заполняет данные.
+	// Неподтверждённое удержание снимается через holdTTL.
+	HoldSlot(doctorID uuid.UUID, start time.Time, appointmentID uuid.UUID) (*model.ScheduleSlot, error)
+	// ConfirmSlot превращает удержание в бессрочную бронь. Повторное подтверждение не ошибка.
+	// Для онлайн-слота создаётся комната видеосвязи.
+	ConfirmSlot(ctx context.Context, doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID) error
+	// ReleaseSlot освобождает слот записи по токену и отзывает его комнату видеосвязи.
+	// Освобождение свободного слота не ошибка.
+	ReleaseSlot(ctx context.Context, doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID) error
+	// ReleaseExpiredHolds освобождает слоты с истёкшим удержанием
+	ReleaseExpiredHolds(ctx context.Context) (int64, error)
 }
 
 type scheduleslotService struct {
-	repo repository.ScheduleSlotRepository
+	repo     repository.ScheduleSlotRepository
+	meetings meeting.Provider
+	holdTTL  time.Duration
+	now      func() time.Time
 }
 
-func NewScheduleSlotService(r repository.ScheduleSlotRepository) ScheduleSlotService {
-	return &scheduleslotService{repo: r}
+// NewScheduleSlotService конструктор, holdTTL — сколько слот удерживается до подтверждения записи,
+// meetings создаёт комнаты для онлайн-слотов
+func NewScheduleSlotService(r repository.ScheduleSlotRepository, meetings meeting.Provider, holdTTL time.Duration) ScheduleSlotService {
+	return &scheduleslotService{repo: r, meetings: meetings, holdTTL: holdTTL, now: time.Now}
 }
 
 func (s *scheduleslotService) CreateSlot(req model.ScheduleSlot) (*model.ScheduleSlot, error) {
@@ -54,3 +93,149 @@ func (s *scheduleslotService) UpdateSlot(req model.ScheduleSlot) (*model.Schedul
 func (s *scheduleslotService) DeleteSlot(id uuid.UUID) error {
 	return s.repo.Delete(id)
 }
+
+// FindSlot возвращает слот врача, начинающийся в start
+func (s *scheduleslotService) FindSlot(doctorID uuid.UUID, start time.Time) (*model.ScheduleSlot, error) {
+	slot, err := s.repo.FindByStart(doctorID, start)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSlotNotFound
+		}
+		return nil, err
+	}
+	return slot, nil
+}
+
+// ListSlots возвращает все слоты врача за период, и свободные, и занятые
+func (s *scheduleslotService) ListSlots(doctorID uuid.UUID, from, to *time.Time) ([]model.ScheduleSlot, error) {
+	return s.repo.ListByDoctor(doctorID, from, to)
+}
+
+// SetAvailability занимает или освобождает слот без записи. Освобождение свободного слота не ошибка,
+// чтобы отмену можно было повторить. Слоты, занятые записью, меняются только через ReleaseSlot.
+func (s *scheduleslotService) SetAvailability(doctorID uuid.UUID, start time.Time, available bool) (*model.ScheduleSlot, error) {
+	slot, err := s.repo.SetAvailable(doctorID, start, available)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return slot, err
+	}
+
+	// Ничего не обновилось: слота нет, он уже в нужном состоянии, занят записью или закрыт исключением
+	slot, err = s.FindSlot(doctorID, start)
+	switch {
+	case err != nil:
+		return nil, err
+	case !available:
+		return nil, ErrSlotBooked
+	case slot.AppointmentID != nil:
+		return nil, ErrReservationMismatch
+	case !slot.IsAvailable:
+		return nil, ErrSlotBooked
+	}
+	return slot, nil
+}
+
+func (s *scheduleslotService) ReserveSlot(ctx context.Context, doctorID uuid.UUID, start time.Time, appointmentID uuid.UUID) (*model.ScheduleSlot, error) {
+	slot, err := s.reserve(doctorID, start, appointmentID, nil)
+	if err != nil {
+		return nil, err
+	}
+	provisionMeeting(ctx, s.repo, s.meetings, slot)
+	return slot, nil
+}
+
+func (s *scheduleslotService) HoldSlot(doctorID uuid.UUID, start time.Time, appointmentID uuid.UUID) (*model.ScheduleSlot, error) {
+	heldUntil := s.now().UTC().Add(s.holdTTL)
+	return s.reserve(doctorID, start, appointmentID, &heldUntil)
+}
+
+// reserve занимает слот записью, бессрочно или до heldUntil.
+// Support function for ReserveSlot and HoldSlot.
+func (s *scheduleslotService) reserve(doctorID uuid.UUID, start time.Time, appointmentID uuid.UUID, heldUntil *time.Time) (*model.ScheduleSlot, error) {
+	now := s.now()
+	res := repository.Reservation{AppointmentID: appointmentID, Token: uuid.New(), HeldUntil: heldUntil}
+	slot, err := s.repo.Reserve(doctorID, start, res, now)
+	switch {
+	case errors.Is(err, repository.ErrSlotOverlap):
+		return nil, ErrSlotBooked
+	case errors.Is(err, repository.ErrRoomFull):
+		return nil, ErrRoomOccupied
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return slot, err
+	}
+
+	// Повтор запроса той же записью возвращает её действующую бронь
+	slot, err = s.FindSlot(doctorID, start)
+	if err != nil {
+		return nil, err
+	}
+	ours := slot.AppointmentID != nil && *slot.AppointmentID == appointmentID
+	if ours && (slot.HeldUntil == nil || slot.Held(now)) {
+		return slot, nil
+	}
+	return nil, ErrSlotBooked
+}
+
+func (s *scheduleslotService) ConfirmSlot(ctx context.Context, doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID) error {
+	confirmed, err := s.repo.Confirm(doctorID, start, appointmentID, token, s.now())
+	if err == nil {
+		provisionMeeting(ctx, s.repo, s.meetings, confirmed)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	slot, err := s.FindSlot(doctorID, start)
+	if err != nil {
+		return err
+	}
+	if slot.AppointmentID == nil || *slot.AppointmentID != appointmentID {
+		// Удержание истекло и слот освобождён или уже занят другой записью
+		return ErrHoldExpired
+	}
+	switch {
+	case slot.ReservationToken == nil || *slot.ReservationToken != token:
+		return ErrReservationMismatch
+	case slot.HeldUntil != nil:
+		return ErrHoldExpired
+	}
+	// Повторное подтверждение досоздаёт комнату, если в первый раз провайдер был недоступен
+	provisionMeeting(ctx, s.repo, s.meetings, slot)
+	return nil
+}
+
+func (s *scheduleslotService) ReleaseSlot(ctx context.Context, doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID) error {
+	// Комнату запоминаем до освобождения, после него слот её уже не хранит
+	slot, err := s.FindSlot(doctorID, start)
+	if err != nil {
+		return err
+	}
+	room := slot.MeetingRoom
+
+	_, err = s.repo.Release(doctorID, start, appointmentID, token)
+	if err == nil && room != "" {
+		revokeMeeting(ctx, s.meetings, room)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	slot, err = s.FindSlot(doctorID, start)
+	if err != nil {
+		return err
+	}
+	if slot.AppointmentID != nil {
+		return ErrReservationMismatch
+	}
+	return nil
+}
+
+func (s *scheduleslotService) ReleaseExpiredHolds(ctx context.Context) (int64, error) {
+	released, err := s.repo.ReleaseExpired(s.now())
+	if err != nil {
+		return 0, err
+	}
+	if released > 0 {
+		logging.FromContext(ctx).Infof("Released %d slots with expired holds", released)
+	}
+	return released, nil
+}
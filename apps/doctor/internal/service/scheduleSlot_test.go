@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/meeting"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+func (r *fakeSlotStore) FindByStart(doctorID uuid.UUID, start time.Time) (*model.ScheduleSlot, error) {
+	for _, slot := range r.slots {
+		if slot.DoctorID == doctorID && slot.StartsAt.Equal(start) {
+			return &slot, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSlotStore) Reserve(doctorID uuid.UUID, start time.Time, res repository.Reservation, now time.Time) (*model.ScheduleSlot, error) {
+	for i := range r.slots {
+		slot := &r.slots[i]
+		if slot.DoctorID != doctorID || !slot.StartsAt.Equal(start) {
+			continue
+		}
+		free := slot.IsAvailable && slot.AppointmentID == nil
+		if slot.BlockedBy != nil || (!free && (slot.HeldUntil == nil || slot.Held(now))) {
+			break
+		}
+		slot.IsAvailable, slot.AppointmentID, slot.ReservationToken, slot.HeldUntil = false, &res.AppointmentID, &res.Token, res.HeldUntil
+		reserved := *slot
+		return &reserved, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSlotStore) Confirm(doctorID uuid.UUID, start time.Time, appointmentID, token uuid.UUID, now time.Time) (*model.ScheduleSlot, error) {
+	for i := range r.slots {
+		slot := &r.slots[i]
+		if slot.DoctorID == doctorID && slot.StartsAt.Equal(start) && slot.AppointmentID != nil &&
+			*slot.AppointmentID == appointmentID && *slot.ReservationToken == token && slot.Held(now) {
+			slot.HeldUntil = nil
+			confirmed := *slot
+			return &confirmed, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSlotStore) ReleaseExpired(now time.Time) (int64, error) {
+	var released int64
+	for i := range r.slots {
+		slot := &r.slots[i]
+		if slot.HeldUntil != nil && !now.Before(*slot.HeldUntil) {
+			slot.IsAvailable, slot.AppointmentID, slot.ReservationToken, slot.HeldUntil = slot.BlockedBy == nil, nil, nil, nil
+			released++
+		}
+	}
+	return released, nil
+}
+
+type ScheduleSlotServiceTestSuite struct {
+	suite.Suite
+	slots    *fakeSlotStore
+	service  *scheduleslotService
+	now      time.Time
+	doctorID uuid.UUID
+	start    time.Time
+}
+
+func TestScheduleSlotService(t *testing.T) {
+	suite.Run(t, new(ScheduleSlotServiceTestSuite))
+}
+
+func (suite *ScheduleSlotServiceTestSuite) SetupTest() {
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	suite.doctorID = uuid.New()
+	suite.start = time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	suite.now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	suite.slots = &fakeSlotStore{slots: []model.ScheduleSlot{{
+		ID:          uuid.New(),
+		DoctorID:    suite.doctorID,
+		StartsAt:    suite.start,
+		EndsAt:      suite.start.Add(30 * time.Minute),
+		IsAvailable: true,
+	}}}
+
+	suite.service = NewScheduleSlotService(suite.slots, meeting.NewFake(), 10*time.Minute).(*scheduleslotService)
+	suite.service.now = func() time.Time { return suite.now }
+}
+
+func (suite *ScheduleSlotServiceTestSuite) TestHoldSlot_ExpiredHoldCannotBeConfirmed() {
+	appointmentID := uuid.New()
+	held, err := suite.service.HoldSlot(suite.doctorID, suite.start, appointmentID)
+	suite.Require().NoError(err)
+	suite.Equal(suite.now.Add(10*time.Minute), *held.HeldUntil)
+
+	suite.now = suite.now.Add(11 * time.Minute)
+	err = suite.service.ConfirmSlot(context.Background(), suite.doctorID, suite.start, appointmentID, *held.ReservationToken)
+	suite.ErrorIs(err, ErrHoldExpired)
+
+	// Истёкшее удержание занимает следующий пациент ещё до фоновой очистки
+	other, err := suite.service.HoldSlot(suite.doctorID, suite.start, uuid.New())
+	suite.Require().NoError(err)
+	suite.NotEqual(*held.ReservationToken, *other.ReservationToken)
+}
+
+func (suite *ScheduleSlotServiceTestSuite) TestHoldSlot_RepeatReturnsSameHold() {
+	appointmentID := uuid.New()
+	held, err := suite.service.HoldSlot(suite.doctorID, suite.start, appointmentID)
+	suite.Require().NoError(err)
+
+	again, err := suite.service.HoldSlot(suite.doctorID, suite.start, appointmentID)
+	suite.Require().NoError(err)
+	suite.Equal(*held.ReservationToken, *again.ReservationToken)
+
+	_, err = suite.service.HoldSlot(suite.doctorID, suite.start, uuid.New())
+	suite.ErrorIs(err, ErrSlotBooked)
+}
+
+func (suite *ScheduleSlotServiceTestSuite) TestReleaseExpiredHolds() {
+	held, err := suite.service.HoldSlot(suite.doctorID, suite.start, uuid.New())
+	suite.Require().NoError(err)
+
+	released, err := suite.service.ReleaseExpiredHolds(context.Background())
+	suite.Require().NoError(err)
+	suite.Zero(released)
+
+	suite.now = *held.HeldUntil
+	released, err = suite.service.ReleaseExpiredHolds(context.Background())
+	suite.Require().NoError(err)
+	suite.EqualValues(1, released)
+	suite.True(suite.slots.slots[0].IsAvailable)
+	suite.Nil(suite.slots.slots[0].AppointmentID)
+}
+
+func (suite *ScheduleSlotServiceTestSuite) TestConfirmSlot_WrongToken() {
+	appointmentID := uuid.New()
+	_, err := suite.service.HoldSlot(suite.doctorID, suite.start, appointmentID)
+	suite.Require().NoError(err)
+
+	err = suite.service.ConfirmSlot(context.Background(), suite.doctorID, suite.start, appointmentID, uuid.New())
+	suite.ErrorIs(err, ErrReservationMismatch)
+	suite.NotNil(suite.slots.slots[0].HeldUntil)
+}
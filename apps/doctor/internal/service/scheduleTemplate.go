@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrTemplateNotFound возвращается, если шаблона нет или он принадлежит другому врачу
+var ErrTemplateNotFound = errors.New("schedule template not found")
+
+// ErrInvalidTemplate возвращается при неверных часах, перерывах или датах шаблона
+var ErrInvalidTemplate = errors.New("invalid schedule template")
+
+// ScheduleTemplateService управляет шаблонами расписания и генерирует по ним слоты
+type ScheduleTemplateService interface {
+	CreateTemplate(ctx context.Context, doctorID uuid.UUID, req CreateScheduleTemplateRequest) (*ScheduleTemplateDTO, error)
+	ListTemplates(ctx context.Context, doctorID uuid.UUID) ([]ScheduleTemplateDTO, error)
+	DeleteTemplate(ctx context.Context, doctorID, id uuid.UUID) error
+	// GenerateSlots приводит слоты врача на горизонт вперёд в соответствие с его шаблонами.
+	// Повторный запуск ничего не меняет, занятые слоты не трогаются.
+	GenerateSlots(ctx context.Context, doctorID uuid.UUID) (*GenerateSlotsResponse, error)
+	// GenerateAll запускает GenerateSlots для всех врачей с шаблонами
+	GenerateAll(ctx context.Context) error
+}
+
+type scheduleTemplateService struct {
+	templates  repository.ScheduleTemplateRepository
+	slots      repository.ScheduleSlotRepository
+	exceptions repository.ScheduleExceptionRepository
+	doctors    repository.DoctorRepository
+	clinics    repository.ClinicRepository
+	services   repository.MedicalServiceRepository
+	horizon    int            // дней вперёд, включая сегодня
+	clinic     *time.Location // пояс врачей без своего часового пояса
+	now        func() time.Time
+}
+
+// NewScheduleTemplateService конструктор, horizonWeeks — на сколько недель вперёд генерировать слоты,
+// clinic — часовой пояс клиники по умолчанию. Часы шаблона в филиале — местное время филиала,
+// часы шаблона без филиала — местное время врача.
+func NewScheduleTemplateService(templates repository.ScheduleTemplateRepository, slots repository.ScheduleSlotRepository, exceptions repository.ScheduleExceptionRepository, doctors repository.DoctorRepository, clinics repository.ClinicRepository, services repository.MedicalServiceRepository, horizonWeeks int, clinic *time.Location) ScheduleTemplateService {
+	return &scheduleTemplateService{
+		templates:  templates,
+		slots:      slots,
+		exceptions: exceptions,
+		doctors:    doctors,
+		clinics:    clinics,
+		services:   services,
+		horizon:    horizonWeeks * 7,
+		clinic:     clinic,
+		now:        time.Now,
+	}
+}
+
+func (s *scheduleTemplateService) CreateTemplate(ctx context.Context, doctorID uuid.UUID, req CreateScheduleTemplateRequest) (*ScheduleTemplateDTO, error) {
+	if err := s.applyService(doctorID, &req); err != nil {
+		return nil, err
+	}
+	t, err := templateFromRequest(doctorID, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPlace(t); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.templates.ListByDoctor(doctorID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if overlaps(t, &other) {
+			return nil, fmt.Errorf("%w: overlaps template %s", ErrInvalidTemplate, other.ID)
+		}
+	}
+
+	if err := s.templates.Create(t); err != nil {
+		return nil, err
+	}
+	s.regenerate(ctx, doctorID)
+
+	dto := toScheduleTemplateDTO(t)
+	return &dto, nil
+}
+
+func (s *scheduleTemplateService) ListTemplates(ctx context.Context, doctorID uuid.UUID) ([]ScheduleTemplateDTO, error) {
+	templates, err := s.templates.ListByDoctor(doctorID)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]ScheduleTemplateDTO, 0, len(templates))
+	for i := range templates {
+		dtos = append(dtos, toScheduleTemplateDTO(&templates[i]))
+	}
+	return dtos, nil
+}
+
+// DeleteTemplate удаляет шаблон и его будущие свободные слоты, занятые слоты остаются
+func (s *scheduleTemplateService) DeleteTemplate(ctx context.Context, doctorID, id uuid.UUID) error {
+	t, err := s.templates.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTemplateNotFound
+		}
+		return err
+	}
+	if t.DoctorID != doctorID {
+		return ErrTemplateNotFound
+	}
+
+	loc, err := s.location(doctorID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.slots.DeleteFreeByTemplate(id, midnight(s.today(loc), loc)); err != nil {
+		return err
+	}
+	return s.templates.Delete(id)
+}
+
+func (s *scheduleTemplateService) GenerateSlots(ctx context.Context, doctorID uuid.UUID) (*GenerateSlotsResponse, error) {
+	templates, err := s.templates.ListByDoctor(doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := s.location(doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Дни горизонта считаются по часам врача, слоты хранятся моментами в UTC
+	from := s.today(loc)
+	to := from.AddDate(0, 0, s.horizon-1)
+	windowStart, windowEnd := midnight(from, loc), midnight(to.AddDate(0, 0, 1), loc)
+
+	locations, err := s.templateLocations(templates, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Отпуска и праздники закрывают часы шаблонов
+	exceptions, err := s.exceptions.List(&doctorID, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Филиал может жить в другом поясе, поэтому дни берутся с запасом,
+	// а слоты — только попадающие в горизонт
+	wanted := make(map[slotKey]model.ScheduleSlot)
+	for day := from.AddDate(0, 0, -1); !day.After(to.AddDate(0, 0, 1)); day = day.AddDate(0, 0, 1) {
+		for i, t := range templates {
+			for _, slot := range t.SlotsOn(day, locations[i]) {
+				inWindow := !slot.StartsAt.Before(windowStart) && slot.StartsAt.Before(windowEnd)
+				if inWindow && !blocked(slot, exceptions) {
+					wanted[keyOf(slot)] = slot
+				}
+			}
+		}
+	}
+
+	existing, err := s.slots.ListByDoctor(doctorID, &windowStart, &windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Свободные сгенерированные слоты, которых больше нет в шаблонах, удаляются.
+	// Остальные слоты — ручные, занятые или актуальные — остаются как есть.
+	present := make(map[slotKey]bool, len(existing))
+	var stale []uuid.UUID
+	for _, slot := range existing {
+		want, ok := wanted[keyOf(slot)]
+		generated := slot.TemplateID != nil && slot.AppointmentID == nil && slot.IsAvailable
+		if generated && (!ok || !want.EndsAt.Equal(slot.EndsAt)) {
+			stale = append(stale, slot.ID)
+			continue
+		}
+		present[keyOf(slot)] = true
+	}
+
+	var missing []model.ScheduleSlot
+	for key, slot := range wanted {
+		if !present[key] {
+			slot.ID = uuid.New()
+			missing = append(missing, slot)
+		}
+	}
+
+	removed, err := s.slots.DeleteFree(stale)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.slots.CreateMissing(missing)
+	if err != nil {
+		return nil, err
+	}
+
+	if created > 0 || removed > 0 {
+		logging.FromContext(ctx).Infof("Regenerated slots of doctor %s: %d created, %d removed", doctorID, created, removed)
+	}
+	return &GenerateSlotsResponse{Created: created, Removed: removed}, nil
+}
+
+func (s *scheduleTemplateService) GenerateAll(ctx context.Context) error {
+	doctorIDs, err := s.templates.DoctorIDs()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, doctorID := range doctorIDs {
+		if _, err := s.GenerateSlots(ctx, doctorID); err != nil {
+			errs = append(errs, fmt.Errorf("doctor %s: %w", doctorID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// regenerate обновляет слоты после изменения шаблонов. Ошибка не критична,
+// периодическая генерация догонит.
+func (s *scheduleTemplateService) regenerate(ctx context.Context, doctorID uuid.UUID) {
+	if _, err := s.GenerateSlots(ctx, doctorID); err != nil {
+		logging.FromContext(ctx).WithError(err).Errorf("Failed to generate slots of doctor %s", doctorID)
+	}
+}
+
+// location — часовой пояс врача, для неизвестного врача — пояс клиники
+func (s *scheduleTemplateService) location(doctorID uuid.UUID) (*time.Location, error) {
+	doc, err := s.doctors.GetByID(doctorID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.clinic, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.Location(s.clinic), nil
+}
+
+// templateLocations — часовой пояс каждого шаблона: филиала или, без филиала, врача
+func (s *scheduleTemplateService) templateLocations(templates []model.ScheduleTemplate, doctor *time.Location) ([]*time.Location, error) {
+	clinics := make(map[uuid.UUID]*time.Location)
+	locations := make([]*time.Location, len(templates))
+	for i, t := range templates {
+		if t.ClinicID == nil {
+			locations[i] = doctor
+			continue
+		}
+		loc, ok := clinics[*t.ClinicID]
+		if !ok {
+			c, err := s.clinics.GetByID(*t.ClinicID)
+			if err != nil {
+				return nil, fmt.Errorf("clinic %s: %w", *t.ClinicID, err)
+			}
+			loc = c.Location()
+			clinics[*t.ClinicID] = loc
+		}
+		locations[i] = loc
+	}
+	return locations, nil
+}
+
+// checkPlace — врач приписан к филиалу шаблона, а кабинет принадлежит этому филиалу
+func (s *scheduleTemplateService) checkPlace(t *model.ScheduleTemplate) error {
+	if t.ClinicID == nil {
+		if t.RoomID != nil {
+			return fmt.Errorf("%w: room_id requires clinic_id", ErrInvalidTemplate)
+		}
+		return nil
+	}
+	assigned, err := s.clinics.HasDoctor(*t.ClinicID, t.DoctorID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return fmt.Errorf("%w: doctor is not assigned to clinic %s", ErrInvalidTemplate, *t.ClinicID)
+	}
+	if t.RoomID == nil {
+		return nil
+	}
+	room, err := s.clinics.GetRoom(*t.RoomID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && room.ClinicID != *t.ClinicID) {
+		return fmt.Errorf("%w: room %s is not in clinic %s", ErrInvalidTemplate, *t.RoomID, *t.ClinicID)
+	}
+	return err
+}
+
+// applyService берёт длину слота из услуги шаблона. Услуга должна действовать
+// и относиться к одной из специализаций врача.
+func (s *scheduleTemplateService) applyService(doctorID uuid.UUID, req *CreateScheduleTemplateRequest) error {
+	if req.ServiceID == nil {
+		if req.SlotMinutes == 0 {
+			return fmt.Errorf("%w: slot_minutes or service_id is required", ErrInvalidTemplate)
+		}
+		return nil
+	}
+	svc, err := s.services.GetByID(*req.ServiceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !svc.IsActive) {
+		return fmt.Errorf("%w: service %s is not offered", ErrInvalidTemplate, *req.ServiceID)
+	}
+	if err != nil {
+		return err
+	}
+	doc, err := s.doctors.GetByID(doctorID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(doc.Specializations, func(spec model.Specialization) bool { return spec.ID == svc.SpecializationID }) {
+		return fmt.Errorf("%w: service %s is not in the doctor's specializations", ErrInvalidTemplate, svc.ID)
+	}
+	if req.SlotMinutes != 0 && req.SlotMinutes != svc.DurationMinutes {
+		return fmt.Errorf("%w: slot_minutes differs from the %d minutes of the service", ErrInvalidTemplate, svc.DurationMinutes)
+	}
+	req.SlotMinutes = svc.DurationMinutes
+	return nil
+}
+
+// today — сегодняшняя дата по часам зоны loc. Даты, как и в шаблонах, — полночь в UTC.
+func (s *scheduleTemplateService) today(loc *time.Location) time.Time {
+	now := s.now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// midnight — момент начала дня date по часам зоны loc
+func midnight(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+func blocked(slot model.ScheduleSlot, exceptions []model.ScheduleException) bool {
+	for _, e := range exceptions {
+		if e.Blocks() && e.Covers(slot) {
+			return true
+		}
+	}
+	return false
+}
+
+// slotKey — слот однозначно определяется моментом начала у одного врача
+type slotKey int64
+
+func keyOf(slot model.ScheduleSlot) slotKey {
+	return slotKey(slot.StartsAt.UnixNano())
+}
+
+func templateFromRequest(doctorID uuid.UUID, req CreateScheduleTemplateRequest) (*model.ScheduleTemplate, error) {
+	start, err := model.ParseTimeOfDay(req.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	end, err := model.ParseTimeOfDay(req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	if start >= end {
+		return nil, fmt.Errorf("%w: start_time must be before end_time", ErrInvalidTemplate)
+	}
+	if time.Duration(end-start) < time.Duration(req.SlotMinutes)*time.Minute {
+		return nil, fmt.Errorf("%w: working hours are shorter than one slot", ErrInvalidTemplate)
+	}
+	if req.ValidTo != nil && req.ValidTo.Before(req.ValidFrom) {
+		return nil, fmt.Errorf("%w: valid_to is before valid_from", ErrInvalidTemplate)
+	}
+	if req.IsOnline && req.RoomID != nil {
+		return nil, fmt.Errorf("%w: online template cannot have a room", ErrInvalidTemplate)
+	}
+
+	t := &model.ScheduleTemplate{
+		ID:          uuid.New(),
+		DoctorID:    doctorID,
+		ClinicID:    req.ClinicID,
+		RoomID:      req.RoomID,
+		IsOnline:    req.IsOnline,
+		ServiceID:   req.ServiceID,
+		Weekday:     time.Weekday(req.Weekday),
+		StartTime:   start,
+		EndTime:     end,
+		SlotMinutes: req.SlotMinutes,
+		ValidFrom:   req.ValidFrom,
+		ValidTo:     req.ValidTo,
+	}
+	for _, b := range req.Breaks {
+		breakStart, err := model.ParseTimeOfDay(b.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		breakEnd, err := model.ParseTimeOfDay(b.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		if breakStart >= breakEnd || breakStart < start || breakEnd > end {
+			return nil, fmt.Errorf("%w: break %s-%s is outside working hours", ErrInvalidTemplate, b.StartTime, b.EndTime)
+		}
+		t.Breaks = append(t.Breaks, model.ScheduleBreak{
+			ID:         uuid.New(),
+			TemplateID: t.ID,
+			StartTime:  breakStart,
+			EndTime:    breakEnd,
+		})
+	}
+	return t, nil
+}
+
+// overlaps — шаблоны одного дня недели пересекаются и по часам, и по датам действия
+func overlaps(a, b *model.ScheduleTemplate) bool {
+	if a.Weekday != b.Weekday || a.StartTime >= b.EndTime || b.StartTime >= a.EndTime {
+		return false
+	}
+	if a.ValidTo != nil && a.ValidTo.Before(b.ValidFrom) {
+		return false
+	}
+	if b.ValidTo != nil && b.ValidTo.Before(a.ValidFrom) {
+		return false
+	}
+	return true
+}
+
+func toScheduleTemplateDTO(t *model.ScheduleTemplate) ScheduleTemplateDTO {
+	dto := ScheduleTemplateDTO{
+		ID:          t.ID,
+		DoctorID:    t.DoctorID,
+		ClinicID:    t.ClinicID,
+		RoomID:      t.RoomID,
+		IsOnline:    t.IsOnline,
+		ServiceID:   t.ServiceID,
+		Weekday:     int(t.Weekday),
+		StartTime:   t.StartTime.String(),
+		EndTime:     t.EndTime.String(),
+		SlotMinutes: t.SlotMinutes,
+		Breaks:      make([]ScheduleBreakDTO, 0, len(t.Breaks)),
+		ValidFrom:   t.ValidFrom,
+		ValidTo:     t.ValidTo,
+	}
+	for _, b := range t.Breaks {
+		dto.Breaks = append(dto.Breaks, ScheduleBreakDTO{StartTime: b.StartTime.String(), EndTime: b.EndTime.String()})
+	}
+	return dto
+}
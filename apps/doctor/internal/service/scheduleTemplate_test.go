@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeTemplateRepo хранит шаблоны в памяти
+type fakeTemplateRepo struct {
+	repository.ScheduleTemplateRepository
+	templates []model.ScheduleTemplate
+}
+
+func (r *fakeTemplateRepo) Create(t *model.ScheduleTemplate) error {
+	r.templates = append(r.templates, *t)
+	return nil
+}
+
+func (r *fakeTemplateRepo) ListByDoctor(doctorID uuid.UUID) ([]model.ScheduleTemplate, error) {
+	var templates []model.ScheduleTemplate
+	for _, t := range r.templates {
+		if t.DoctorID == doctorID {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+// fakeSlotStore хранит слоты в памяти с той же уникальностью, что и индекс в БД
+type fakeSlotStore struct {
+	repository.ScheduleSlotRepository
+	slots []model.ScheduleSlot
+}
+
+func (r *fakeSlotStore) ListByDoctor(doctorID uuid.UUID, _, _ *time.Time) ([]model.ScheduleSlot, error) {
+	var slots []model.ScheduleSlot
+	for _, slot := range r.slots {
+		if slot.DoctorID == doctorID {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+func (r *fakeSlotStore) CreateMissing(slots []model.ScheduleSlot) (int64, error) {
+	var created int64
+	for _, slot := range slots {
+		duplicate := false
+		for _, other := range r.slots {
+			if other.DoctorID == slot.DoctorID && keyOf(other) == keyOf(slot) {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			r.slots = append(r.slots, slot)
+			created++
+		}
+	}
+	return created, nil
+}
+
+func (r *fakeSlotStore) DeleteFree(ids []uuid.UUID) (int64, error) {
+	var removed int64
+	kept := r.slots[:0]
+	for _, slot := range r.slots {
+		free := slot.AppointmentID == nil && slot.IsAvailable
+		if free && containsID(ids, slot.ID) {
+			removed++
+			continue
+		}
+		kept = append(kept, slot)
+	}
+	r.slots = kept
+	return removed, nil
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeExceptionRepo хранит исключения в памяти
+type fakeExceptionRepo struct {
+	repository.ScheduleExceptionRepository
+	exceptions []model.ScheduleException
+}
+
+func (r *fakeExceptionRepo) List(doctorID *uuid.UUID, from, to time.Time) ([]model.ScheduleException, error) {
+	var exceptions []model.ScheduleException
+	for _, e := range r.exceptions {
+		ownOrClinic := e.DoctorID == nil || (doctorID != nil && *e.DoctorID == *doctorID)
+		if ownOrClinic && e.StartsAt.Before(to) && from.Before(e.EndsAt) {
+			exceptions = append(exceptions, e)
+		}
+	}
+	return exceptions, nil
+}
+
+type ScheduleTemplateServiceTestSuite struct {
+	suite.Suite
+	templates  *fakeTemplateRepo
+	slots      *fakeSlotStore
+	exceptions *fakeExceptionRepo
+	doctors    *fakeDoctorRepo
+	clinics    *fakeClinicRepo
+	services   *fakeServiceRepo
+	service    *scheduleTemplateService
+	doctorID   uuid.UUID
+	monday     time.Time
+}
+
+func TestScheduleTemplateService(t *testing.T) {
+	suite.Run(t, new(ScheduleTemplateServiceTestSuite))
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) SetupTest() {
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			TestMode: true,
+		},
+	})
+	suite.templates = &fakeTemplateRepo{}
+	suite.slots = &fakeSlotStore{}
+	suite.exceptions = &fakeExceptionRepo{}
+	suite.doctorID = uuid.New()
+	suite.doctors = &fakeDoctorRepo{docs: []model.Doctor{{ID: suite.doctorID}}}
+	suite.clinics = &fakeClinicRepo{}
+	suite.monday = time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+
+	suite.services = &fakeServiceRepo{}
+	suite.service = NewScheduleTemplateService(suite.templates, suite.slots, suite.exceptions, suite.doctors, suite.clinics, suite.services, 2, time.UTC).(*scheduleTemplateService)
+	suite.service.now = func() time.Time { return suite.monday.Add(8 * time.Hour) }
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) createTemplate(req CreateScheduleTemplateRequest) *ScheduleTemplateDTO {
+	dto, err := suite.service.CreateTemplate(context.Background(), suite.doctorID, req)
+	suite.Require().NoError(err)
+	return dto
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) TestCreateTemplate_GeneratesHorizon() {
+	suite.createTemplate(CreateScheduleTemplateRequest{
+		Weekday:     int(time.Monday),
+		StartTime:   "09:00",
+		EndTime:     "10:00",
+		SlotMinutes: 30,
+		ValidFrom:   suite.monday,
+	})
+
+	// Два понедельника в горизонте из двух недель, по два слота в каждом
+	suite.Len(suite.slots.slots, 4)
+
+	resp, err := suite.service.GenerateSlots(context.Background(), suite.doctorID)
+	suite.Require().NoError(err)
+	suite.Equal(&GenerateSlotsResponse{}, resp, "second run must be a no-op")
+	suite.Len(suite.slots.slots, 4)
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) TestGenerateSlots_KeepsBookedSlots() {
+	suite.createTemplate(CreateScheduleTemplateRequest{
+		Weekday:     int(time.Monday),
+		StartTime:   "09:00",
+		EndTime:     "10:00",
+		SlotMinutes: 30,
+		ValidFrom:   suite.monday,
+	})
+	appointmentID := uuid.New()
+	suite.slots.slots[0].AppointmentID = &appointmentID
+	suite.slots.slots[0].IsAvailable = false
+	booked := suite.slots.slots[0]
+
+	// Шаблон пропал: свободные слоты уходят, занятый остаётся
+	suite.templates.templates = nil
+	resp, err := suite.service.GenerateSlots(context.Background(), suite.doctorID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), resp.Removed)
+	suite.Equal([]model.ScheduleSlot{booked}, suite.slots.slots)
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) TestCreateTemplate_Invalid() {
+	valid := CreateScheduleTemplateRequest{
+		Weekday:     int(time.Monday),
+		StartTime:   "09:00",
+		EndTime:     "12:00",
+		SlotMinutes: 30,
+		ValidFrom:   suite.monday,
+	}
+	suite.createTemplate(valid)
+
+	overlapping := valid
+	overlapping.StartTime = "11:00"
+	overlapping.EndTime = "13:00"
+
+	backwards := valid
+	backwards.StartTime, backwards.EndTime = "12:00", "09:00"
+
+	outsideBreak := valid
+	outsideBreak.Weekday = int(time.Tuesday)
+	outsideBreak.Breaks = []ScheduleBreakDTO{{StartTime: "08:00", EndTime: "09:30"}}
+
+	for name, req := range map[string]CreateScheduleTemplateRequest{
+		"overlapping":   overlapping,
+		"backwards":     backwards,
+		"outside break": outsideBreak,
+	} {
+		_, err := suite.service.CreateTemplate(context.Background(), suite.doctorID, req)
+		suite.ErrorIs(err, ErrInvalidTemplate, name)
+	}
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) TestGenerateSlots_SkipsHolidays() {
+	// Праздник всей клиники на первый понедельник
+	suite.exceptions.exceptions = []model.ScheduleException{{
+		ID:       uuid.New(),
+		Kind:     model.Holiday,
+		StartsAt: suite.monday,
+		EndsAt:   suite.monday.AddDate(0, 0, 1),
+	}}
+	suite.createTemplate(CreateScheduleTemplateRequest{
+		Weekday:     int(time.Monday),
+		StartTime:   "09:00",
+		EndTime:     "10:00",
+		SlotMinutes: 30,
+		ValidFrom:   suite.monday,
+	})
+
+	suite.Require().Len(suite.slots.slots, 2)
+	for _, slot := range suite.slots.slots {
+		suite.Equal(suite.monday.AddDate(0, 0, 7), slot.StartsAt.Truncate(24*time.Hour))
+	}
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) TestGenerateSlots_DoctorTimeZone() {
+	suite.doctors.docs[0].TimeZone = "Asia/Almaty"
+	suite.createTemplate(CreateScheduleTemplateRequest{
+		Weekday:     int(time.Monday),
+		StartTime:   "09:00",
+		EndTime:     "10:00",
+		SlotMinutes: 30,
+		ValidFrom:   suite.monday,
+	})
+
+	// 09:00 по Алматы (UTC+5) хранится как 04:00 UTC
+	suite.Require().Len(suite.slots.slots, 4)
+	starts := make(map[time.Time]bool)
+	for _, slot := range suite.slots.slots {
+		starts[slot.StartsAt] = true
+	}
+	suite.True(starts[suite.monday.Add(4*time.Hour)])
+	suite.True(starts[suite.monday.Add(4*time.Hour+30*time.Minute)])
+	suite.True(starts[suite.monday.AddDate(0, 0, 7).Add(4*time.Hour)])
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) TestCreateTemplate_Clinic() {
+	room := model.Room{ID: uuid.New(), Name: "101", Capacity: 1}
+	clinic := model.Clinic{ID: uuid.New(), Name: "Актобе", TimeZone: "Asia/Aqtobe"}
+	room.ClinicID = clinic.ID
+	suite.Require().NoError(suite.clinics.Create(&clinic))
+	suite.Require().NoError(suite.clinics.CreateRoom(&room))
+	otherRoom := uuid.New()
+
+	req := CreateScheduleTemplateRequest{
+		ClinicID:    &clinic.ID,
+		RoomID:      &room.ID,
+		Weekday:     int(time.Monday),
+		StartTime:   "09:00",
+		EndTime:     "10:00",
+		SlotMinutes: 30,
+		ValidFrom:   suite.monday,
+	}
+	_, err := suite.service.CreateTemplate(context.Background(), suite.doctorID, req)
+	suite.ErrorIs(err, ErrInvalidTemplate, "doctor is not assigned")
+
+	suite.Require().NoError(suite.clinics.AssignDoctor(clinic.ID, suite.doctorID))
+	wrongRoom := req
+	wrongRoom.RoomID = &otherRoom
+	_, err = suite.service.CreateTemplate(context.Background(), suite.doctorID, wrongRoom)
+	suite.ErrorIs(err, ErrInvalidTemplate, "room of another clinic")
+	online := req
+	online.IsOnline = true
+	_, err = suite.service.CreateTemplate(context.Background(), suite.doctorID, online)
+	suite.ErrorIs(err, ErrInvalidTemplate, "online slot in a room")
+
+	suite.createTemplate(req)
+
+	// Часы шаблона идут по времени филиала: 09:00 в Актобе (UTC+5) — 04:00 UTC
+	suite.Require().Len(suite.slots.slots, 4)
+	for _, slot := range suite.slots.slots {
+		suite.Equal(clinic.ID, *slot.ClinicID)
+		suite.Equal(room.ID, *slot.RoomID)
+		suite.Contains([]int{4}, slot.StartsAt.Hour())
+	}
+}
+
+func (suite *ScheduleTemplateServiceTestSuite) TestCreateTemplate_ServiceDuration() {
+	specID := uuid.New()
+	suite.doctors.docs[0].Specializations = []model.Specialization{{ID: specID}}
+	consultation := model.MedicalService{ID: uuid.New(), SpecializationID: specID, Name: "Консультация", DurationMinutes: 45, IsActive: true}
+	foreign := model.MedicalService{ID: uuid.New(), SpecializationID: uuid.New(), Name: "Осмотр", DurationMinutes: 20, IsActive: true}
+	suite.services.services = []model.MedicalService{consultation, foreign}
+
+	req := CreateScheduleTemplateRequest{
+		ServiceID: &consultation.ID,
+		Weekday:   int(time.Monday),
+		StartTime: "09:00",
+		EndTime:   "12:00",
+		ValidFrom: suite.monday,
+	}
+	cases := map[string]func(r *CreateScheduleTemplateRequest){
+		"no slot length":           func(r *CreateScheduleTemplateRequest) { r.ServiceID = nil },
+		"other specialization":     func(r *CreateScheduleTemplateRequest) { r.ServiceID = &foreign.ID },
+		"slot length differs":      func(r *CreateScheduleTemplateRequest) { r.SlotMinutes = 30 },
+		"service not in catalogue": func(r *CreateScheduleTemplateRequest) { missing := uuid.New(); r.ServiceID = &missing },
+	}
+	for name, change := range cases {
+		invalid := req
+		change(&invalid)
+		_, err := suite.service.CreateTemplate(context.Background(), suite.doctorID, invalid)
+		suite.ErrorIs(err, ErrInvalidTemplate, name)
+	}
+
+	// Длина слота берётся из услуги, слоты помнят услугу
+	dto := suite.createTemplate(req)
+	suite.Equal(45, dto.SlotMinutes)
+	suite.Equal(consultation.ID, *dto.ServiceID)
+	suite.Require().Len(suite.slots.slots, 8)
+	for _, slot := range suite.slots.slots {
+		suite.Equal(45*time.Minute, slot.EndsAt.Sub(slot.StartsAt))
+		suite.Equal(consultation.ID, *slot.ServiceID)
+	}
+}
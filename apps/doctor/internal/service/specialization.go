@@ -1,20 +1,39 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/locale"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
 	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/repository"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
 )
 
 var ErrSpecNotFound = errors.New("specialization not found")
 
+// ErrInvalidSpecialization возвращается при несуществующем родителе, цикле в дереве или неверных переводах
+var ErrInvalidSpecialization = errors.New("invalid specialization")
+
+// ErrSpecInUse возвращается при удалении специализации, у которой есть дочерние специализации, врачи или услуги
+var ErrSpecInUse = errors.New("specialization is in use")
+
+// SpecializationService управляет деревом специализаций. Названия и описания отдаются
+// на языке клиента из контекста (см. locale.Middleware), без перевода — на языке по умолчанию.
 type SpecializationService interface {
-	CreateSpecialization(req model.Specialization) (*model.Specialization, error)
-	GetSpecializationByID(id uuid.UUID) (*model.Specialization, error)
-	UpdateSpecialization(req model.Specialization) (*model.Specialization, error)
-	DeleteSpecialization(id uuid.UUID) error
+	CreateSpecialization(ctx context.Context, req SpecializationRequest) (*SpecializationDTO, error)
+	// GetSpecializationByID возвращает специализацию вместе со всеми переводами
+	GetSpecializationByID(ctx context.Context, id uuid.UUID) (*SpecializationDTO, error)
+	// ListSpecializations возвращает дерево специализаций, узлы одного уровня — по названию
+	ListSpecializations(ctx context.Context) ([]SpecializationDTO, error)
+	UpdateSpecialization(ctx context.Context, id uuid.UUID, req SpecializationRequest) (*SpecializationDTO, error)
+	DeleteSpecialization(ctx context.Context, id uuid.UUID) error
 }
 
 type specializationService struct {
@@ -25,33 +44,195 @@ func NewSpecializationService(r repository.SpecializationRepository) Specializat
 	return &specializationService{repo: r}
 }
 
-func (s *specializationService) CreateSpecialization(req model.Specialization) (*model.Specialization, error) {
-	req.ID = uuid.New()
-	if err := s.repo.Create(&req); err != nil {
+func (s *specializationService) CreateSpecialization(ctx context.Context, req SpecializationRequest) (*SpecializationDTO, error) {
+	spec, err := specializationFromRequest(uuid.New(), req)
+	if err != nil {
+		return nil, err
+	}
+	if spec.ParentID != nil {
+		if _, err := s.repo.GetByID(*spec.ParentID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: parent %s not found", ErrInvalidSpecialization, *spec.ParentID)
+			}
+			return nil, err
+		}
+	}
+	if err := s.repo.Create(spec); err != nil {
 		return nil, err
 	}
-	return &req, nil
+	dto := toSpecializationDTO(spec, locale.Languages(ctx))
+	dto.Translations = translationDTOs(spec.Translations)
+	return &dto, nil
 }
 
-func (s *specializationService) GetSpecializationByID(id uuid.UUID) (*model.Specialization, error) {
+func (s *specializationService) GetSpecializationByID(ctx context.Context, id uuid.UUID) (*SpecializationDTO, error) {
 	spec, err := s.repo.GetByID(id)
 	if err != nil {
-		return nil, ErrSpecNotFound
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSpecNotFound
+		}
+		return nil, err
 	}
-	return spec, nil
+	dto := toSpecializationDTO(spec, locale.Languages(ctx))
+	dto.Translations = translationDTOs(spec.Translations)
+	return &dto, nil
+}
+
+func (s *specializationService) ListSpecializations(ctx context.Context) ([]SpecializationDTO, error) {
+	specs, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	prefs := locale.Languages(ctx)
+
+	known := make(map[uuid.UUID]bool, len(specs))
+	for _, spec := range specs {
+		known[spec.ID] = true
+	}
+	children := make(map[uuid.UUID][]SpecializationDTO)
+	var roots []SpecializationDTO
+	for i := range specs {
+		dto := toSpecializationDTO(&specs[i], prefs)
+		if dto.ParentID == nil || !known[*dto.ParentID] {
+			roots = append(roots, dto)
+		} else {
+			children[*dto.ParentID] = append(children[*dto.ParentID], dto)
+		}
+	}
+	return specializationTree(roots, children), nil
 }
 
-func (s *specializationService) UpdateSpecialization(req model.Specialization) (*model.Specialization, error) {
-	// ensure exists
-	if _, err := s.repo.GetByID(req.ID); err != nil {
+func (s *specializationService) UpdateSpecialization(ctx context.Context, id uuid.UUID, req SpecializationRequest) (*SpecializationDTO, error) {
+	spec, err := specializationFromRequest(id, req)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[uuid.UUID]*uuid.UUID, len(specs))
+	for _, other := range specs {
+		parents[other.ID] = other.ParentID
+	}
+	if _, ok := parents[id]; !ok {
 		return nil, ErrSpecNotFound
 	}
-	if err := s.repo.Update(&req); err != nil {
+	// Родитель не может быть самой специализацией или её потомком
+	parent := spec.ParentID
+	for depth := 0; parent != nil; depth++ {
+		grandparent, ok := parents[*parent]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: parent %s not found", ErrInvalidSpecialization, *parent)
+		case *parent == id || depth > len(parents):
+			return nil, fmt.Errorf("%w: parent would create a cycle", ErrInvalidSpecialization)
+		}
+		parent = grandparent
+	}
+
+	if err := s.repo.Update(spec); err != nil {
 		return nil, err
 	}
-	return &req, nil
+	dto := toSpecializationDTO(spec, locale.Languages(ctx))
+	dto.Translations = translationDTOs(spec.Translations)
+	return &dto, nil
 }
 
-func (s *specializationService) DeleteSpecialization(id uuid.UUID) error {
+// DeleteSpecialization удаляет только неиспользуемую специализацию: без дочерних, врачей и услуг
+func (s *specializationService) DeleteSpecialization(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSpecNotFound
+		}
+		return err
+	}
+	used, err := s.repo.InUse(id)
+	if err != nil {
+		return err
+	}
+	if used {
+		return ErrSpecInUse
+	}
 	return s.repo.Delete(id)
 }
+
+// specializationFromRequest проверяет переводы и приводит их языки к каноничному виду
+func specializationFromRequest(id uuid.UUID, req SpecializationRequest) (*model.Specialization, error) {
+	spec := &model.Specialization{
+		ID:           id,
+		ParentID:     req.ParentID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Translations: make([]model.SpecializationTranslation, 0, len(req.Translations)),
+	}
+	if spec.ParentID != nil && *spec.ParentID == id {
+		return nil, fmt.Errorf("%w: specialization cannot be its own parent", ErrInvalidSpecialization)
+	}
+	seen := make(map[string]bool, len(req.Translations))
+	for _, t := range req.Translations {
+		tag, err := language.Parse(t.Language)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSpecialization, err)
+		}
+		code := tag.String()
+		switch {
+		case tag == locale.Default:
+			return nil, fmt.Errorf("%w: %s is the default language, set name and description instead", ErrInvalidSpecialization, code)
+		case seen[code]:
+			return nil, fmt.Errorf("%w: duplicate translation to %s", ErrInvalidSpecialization, code)
+		}
+		seen[code] = true
+		spec.Translations = append(spec.Translations, model.SpecializationTranslation{
+			SpecializationID: id,
+			Language:         code,
+			Name:             t.Name,
+			Description:      t.Description,
+		})
+	}
+	return spec, nil
+}
+
+// toSpecializationDTO переводит специализацию на язык, лучше всего подходящий клиенту
+func toSpecializationDTO(spec *model.Specialization, prefs []language.Tag) SpecializationDTO {
+	dto := SpecializationDTO{
+		ID:          spec.ID,
+		ParentID:    spec.ParentID,
+		Name:        spec.Name,
+		Description: spec.Description,
+		Language:    locale.Default.String(),
+	}
+	available := make([]string, 0, len(spec.Translations))
+	for _, t := range spec.Translations {
+		available = append(available, t.Language)
+	}
+	if i := locale.Match(prefs, available); i >= 0 {
+		t := spec.Translations[i]
+		dto.Name, dto.Description, dto.Language = t.Name, t.Description, t.Language
+	}
+	return dto
+}
+
+// Support function for GetSpecializationByID, CreateSpecialization and UpdateSpecialization.
+func translationDTOs(translations []model.SpecializationTranslation) []SpecializationTranslationDTO {
+	dtos := make([]SpecializationTranslationDTO, 0, len(translations))
+	for _, t := range translations {
+		dtos = append(dtos, SpecializationTranslationDTO{Language: t.Language, Name: t.Name, Description: t.Description})
+	}
+	return dtos
+}
+
+// Support function for ListSpecializations.
+func specializationTree(nodes []SpecializationDTO, children map[uuid.UUID][]SpecializationDTO) []SpecializationDTO {
+	slices.SortStableFunc(nodes, func(a, b SpecializationDTO) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range nodes {
+		if kids, ok := children[nodes[i].ID]; ok {
+			// Узел попадает в дерево один раз, поэтому цикл в данных не зациклит обход
+			delete(children, nodes[i].ID)
+			nodes[i].Children = specializationTree(kids, children)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/locale"
+	"github.com/Ruletk/OnlineClinic/apps/doctor/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+// fakeSpecRepo хранит специализации в памяти, used — ID используемых специализаций
+type fakeSpecRepo struct {
+	specs []model.Specialization
+	used  map[uuid.UUID]bool
+}
+
+func (r *fakeSpecRepo) Create(s *model.Specialization) error {
+	r.specs = append(r.specs, *s)
+	return nil
+}
+
+func (r *fakeSpecRepo) GetByID(id uuid.UUID) (*model.Specialization, error) {
+	for i := range r.specs {
+		if r.specs[i].ID == id {
+			spec := r.specs[i]
+			return &spec, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSpecRepo) List() ([]model.Specialization, error) {
+	return append([]model.Specialization(nil), r.specs...), nil
+}
+
+func (r *fakeSpecRepo) Update(s *model.Specialization) error {
+	for i := range r.specs {
+		if r.specs[i].ID == s.ID {
+			r.specs[i] = *s
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *fakeSpecRepo) Delete(id uuid.UUID) error {
+	for i := range r.specs {
+		if r.specs[i].ID == id {
+			r.specs = append(r.specs[:i], r.specs[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeSpecRepo) InUse(id uuid.UUID) (bool, error) {
+	for _, spec := range r.specs {
+		if spec.ParentID != nil && *spec.ParentID == id {
+			return true, nil
+		}
+	}
+	return r.used[id], nil
+}
+
+type SpecializationServiceTestSuite struct {
+	suite.Suite
+	repo    *fakeSpecRepo
+	service SpecializationService
+}
+
+func TestSpecializationService(t *testing.T) {
+	suite.Run(t, new(SpecializationServiceTestSuite))
+}
+
+func (suite *SpecializationServiceTestSuite) SetupTest() {
+	suite.repo = &fakeSpecRepo{used: map[uuid.UUID]bool{}}
+	suite.service = NewSpecializationService(suite.repo)
+}
+
+// Support function for creating a specialization with an English translation.
+func (suite *SpecializationServiceTestSuite) create(name, english string, parent *uuid.UUID) uuid.UUID {
+	dto, err := suite.service.CreateSpecialization(context.Background(), SpecializationRequest{
+		Name:         name,
+		ParentID:     parent,
+		Translations: []SpecializationTranslationDTO{{Language: "en", Name: english}},
+	})
+	suite.Require().NoError(err)
+	return dto.ID
+}
+
+func (suite *SpecializationServiceTestSuite) TestListSpecializations_Tree() {
+	surgeon := suite.create("Хирург", "Surgeon", nil)
+	suite.create("Кардиохирург", "Cardiac surgeon", &surgeon)
+	suite.create("Нейрохирург", "Neurosurgeon", &surgeon)
+	suite.create("Кардиолог", "Cardiologist", nil)
+
+	tree, err := suite.service.ListSpecializations(context.Background())
+	suite.Require().NoError(err)
+	suite.Require().Len(tree, 2)
+	suite.Equal("Кардиолог", tree[0].Name)
+	suite.Equal("ru", tree[0].Language)
+	suite.Equal("Хирург", tree[1].Name)
+	suite.Require().Len(tree[1].Children, 2)
+	suite.Equal("Кардиохирург", tree[1].Children[0].Name)
+	suite.Equal(surgeon, *tree[1].Children[0].ParentID)
+
+	// На английском узлы переводятся и упорядочиваются по переведённому названию
+	ctx := locale.WithLanguages(context.Background(), []language.Tag{language.MustParse("en-GB")})
+	tree, err = suite.service.ListSpecializations(ctx)
+	suite.Require().NoError(err)
+	suite.Equal("Cardiologist", tree[0].Name)
+	suite.Equal("en", tree[0].Language)
+	suite.Equal([]string{"Cardiac surgeon", "Neurosurgeon"}, []string{tree[1].Children[0].Name, tree[1].Children[1].Name})
+}
+
+func (suite *SpecializationServiceTestSuite) TestGetSpecializationByID_Localized() {
+	id := suite.create("Кардиолог", "Cardiologist", nil)
+
+	kk := locale.WithLanguages(context.Background(), []language.Tag{language.Kazakh})
+	dto, err := suite.service.GetSpecializationByID(kk, id)
+	suite.Require().NoError(err)
+	suite.Equal("Кардиолог", dto.Name, "no Kazakh translation, the default language is used")
+	suite.Equal([]SpecializationTranslationDTO{{Language: "en", Name: "Cardiologist"}}, dto.Translations)
+
+	_, err = suite.service.GetSpecializationByID(kk, uuid.New())
+	suite.ErrorIs(err, ErrSpecNotFound)
+}
+
+func (suite *SpecializationServiceTestSuite) TestUpdateSpecialization_Invalid() {
+	ctx := context.Background()
+	root := suite.create("Хирург", "Surgeon", nil)
+	child := suite.create("Кардиохирург", "Cardiac surgeon", &root)
+	missing := uuid.New()
+
+	cases := map[string]struct {
+		id  uuid.UUID
+		req SpecializationRequest
+	}{
+		"own parent":         {root, SpecializationRequest{Name: "Хирург", ParentID: &root}},
+		"descendant parent":  {root, SpecializationRequest{Name: "Хирург", ParentID: &child}},
+		"missing parent":     {child, SpecializationRequest{Name: "Кардиохирург", ParentID: &missing}},
+		"default language":   {root, SpecializationRequest{Name: "Хирург", Translations: []SpecializationTranslationDTO{{Language: "ru", Name: "Хирург"}}}},
+		"duplicate language": {root, SpecializationRequest{Name: "Хирург", Translations: []SpecializationTranslationDTO{{Language: "en", Name: "Surgeon"}, {Language: "EN", Name: "Surgeon"}}}},
+	}
+	for name, tc := range cases {
+		_, err := suite.service.UpdateSpecialization(ctx, tc.id, tc.req)
+		suite.ErrorIs(err, ErrInvalidSpecialization, name)
+	}
+
+	_, err := suite.service.UpdateSpecialization(ctx, missing, SpecializationRequest{Name: "Нет"})
+	suite.ErrorIs(err, ErrSpecNotFound)
+
+	// Перенос в другой корень допустим, переводы заменяются целиком
+	other := suite.create("Терапевт", "Physician", nil)
+	dto, err := suite.service.UpdateSpecialization(ctx, child, SpecializationRequest{Name: "Кардиохирург", ParentID: &other})
+	suite.Require().NoError(err)
+	suite.Equal(other, *dto.ParentID)
+	suite.Empty(dto.Translations)
+}
+
+func (suite *SpecializationServiceTestSuite) TestDeleteSpecialization_InUse() {
+	ctx := context.Background()
+	root := suite.create("Хирург", "Surgeon", nil)
+	child := suite.create("Кардиохирург", "Cardiac surgeon", &root)
+
+	suite.ErrorIs(suite.service.DeleteSpecialization(ctx, root), ErrSpecInUse)
+	suite.repo.used[child] = true
+	suite.ErrorIs(suite.service.DeleteSpecialization(ctx, child), ErrSpecInUse)
+
+	suite.repo.used[child] = false
+	suite.Require().NoError(suite.service.DeleteSpecialization(ctx, child))
+	suite.Require().NoError(suite.service.DeleteSpecialization(ctx, root))
+	suite.ErrorIs(suite.service.DeleteSpecialization(ctx, root), ErrSpecNotFound)
+}
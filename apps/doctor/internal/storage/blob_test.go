@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalStoreTestSuite struct {
+	suite.Suite
+	store BlobStore
+}
+
+func TestLocalStore(t *testing.T) {
+	suite.Run(t, new(LocalStoreTestSuite))
+}
+
+func (suite *LocalStoreTestSuite) SetupTest() {
+	store, err := NewLocalStore(suite.T().TempDir())
+	suite.Require().NoError(err)
+	suite.store = store
+}
+
+func (suite *LocalStoreTestSuite) read(key string) string {
+	r, err := suite.store.Open(context.Background(), key)
+	suite.Require().NoError(err)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	suite.Require().NoError(err)
+	return string(data)
+}
+
+func (suite *LocalStoreTestSuite) TestPutOpenDelete() {
+	ctx := context.Background()
+	suite.Require().NoError(suite.store.Put(ctx, "doctors/1/photo.jpg", strings.NewReader("first")))
+	suite.Equal("first", suite.read("doctors/1/photo.jpg"))
+
+	suite.Require().NoError(suite.store.Put(ctx, "doctors/1/photo.jpg", strings.NewReader("second")))
+	suite.Equal("second", suite.read("doctors/1/photo.jpg"))
+
+	suite.Require().NoError(suite.store.Delete(ctx, "doctors/1/photo.jpg"))
+	_, err := suite.store.Open(ctx, "doctors/1/photo.jpg")
+	suite.ErrorIs(err, ErrBlobNotFound)
+	suite.NoError(suite.store.Delete(ctx, "doctors/1/photo.jpg"))
+}
+
+func (suite *LocalStoreTestSuite) TestKeyOutsideStore() {
+	ctx := context.Background()
+	suite.Error(suite.store.Put(ctx, "../escape.jpg", strings.NewReader("x")))
+	_, err := suite.store.Open(ctx, "/etc/passwd")
+	suite.Error(err)
+}
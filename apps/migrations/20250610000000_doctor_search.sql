@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Фильтры поиска врачей
+CREATE INDEX idx_doctors_specialization_id_status ON doctors(specialization_id, status);
+CREATE INDEX idx_doctors_name_trgm ON doctors
+    USING GIN (LOWER(first_name || ' ' || last_name || ' ' || COALESCE(patronymic, '')) gin_trgm_ops);
+
+-- Keyset-пагинация по каждой сортировке
+CREATE INDEX idx_doctors_name_id ON doctors(last_name, first_name, id);
+CREATE INDEX idx_doctors_created_at_id ON doctors(created_at, id);
+
+-- Наличие свободных слотов в диапазоне дат
+CREATE INDEX idx_schedule_slots_available_date_doctor_id ON schedule_slots(date, doctor_id) WHERE is_available;
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_slots_available_date_doctor_id;
+DROP INDEX IF EXISTS idx_doctors_created_at_id;
+DROP INDEX IF EXISTS idx_doctors_name_id;
+DROP INDEX IF EXISTS idx_doctors_name_trgm;
+DROP INDEX IF EXISTS idx_doctors_specialization_id_status;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE schedule_templates (
+                                    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    doctor_id UUID NOT NULL REFERENCES doctors(id) ON DELETE CASCADE,
+                                    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+                                    start_time TIME NOT NULL,
+                                    end_time TIME NOT NULL,
+                                    slot_minutes INTEGER NOT NULL CHECK (slot_minutes > 0),
+                                    valid_from DATE NOT NULL,
+                                    valid_to DATE,
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    CHECK (start_time < end_time),
+                                    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE TABLE schedule_breaks (
+                                 id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                 template_id UUID NOT NULL REFERENCES schedule_templates(id) ON DELETE CASCADE,
+                                 start_time TIME NOT NULL,
+                                 end_time TIME NOT NULL,
+                                 CHECK (start_time < end_time)
+);
+
+CREATE INDEX idx_schedule_templates_doctor_id ON schedule_templates(doctor_id);
+CREATE INDEX idx_schedule_breaks_template_id ON schedule_breaks(template_id);
+
+-- Сгенерированные слоты помнят шаблон, занятые слоты переживают его удаление
+ALTER TABLE schedule_slots ADD COLUMN template_id UUID REFERENCES schedule_templates(id) ON DELETE SET NULL;
+CREATE INDEX idx_schedule_slots_template_id ON schedule_slots(template_id);
+
+-- Генерация идемпотентна: у врача не может быть двух слотов с одним началом
+CREATE UNIQUE INDEX idx_schedule_slots_doctor_id_date_start_time ON schedule_slots(doctor_id, date, start_time);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_slots_doctor_id_date_start_time;
+DROP INDEX IF EXISTS idx_schedule_slots_template_id;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS template_id;
+DROP TABLE IF EXISTS schedule_breaks;
+DROP TABLE IF EXISTS schedule_templates;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE schedule_exceptions (
+                                     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                     doctor_id UUID REFERENCES doctors(id) ON DELETE CASCADE, -- NULL — праздник всей клиники
+                                     kind VARCHAR(20) NOT NULL CHECK (kind IN ('VACATION', 'SICK_LEAVE', 'HOLIDAY', 'EXTRA_HOURS')),
+                                     starts_at TIMESTAMPTZ NOT NULL,
+                                     ends_at TIMESTAMPTZ NOT NULL,
+                                     reason TEXT,
+                                     slot_minutes INTEGER CHECK (slot_minutes > 0),
+                                     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                     CHECK (starts_at < ends_at),
+                                     CHECK ((kind = 'HOLIDAY') = (doctor_id IS NULL)),
+                                     CHECK (kind <> 'EXTRA_HOURS' OR slot_minutes IS NOT NULL)
+);
+
+CREATE INDEX idx_schedule_exceptions_doctor_id_starts_at ON schedule_exceptions(doctor_id, starts_at);
+
+-- Слоты дополнительных часов и закрытые отпуском слоты помнят своё исключение
+ALTER TABLE schedule_slots ADD COLUMN exception_id UUID REFERENCES schedule_exceptions(id) ON DELETE SET NULL;
+ALTER TABLE schedule_slots ADD COLUMN blocked_by UUID REFERENCES schedule_exceptions(id) ON DELETE SET NULL;
+CREATE INDEX idx_schedule_slots_exception_id ON schedule_slots(exception_id);
+CREATE INDEX idx_schedule_slots_blocked_by ON schedule_slots(blocked_by);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_slots_blocked_by;
+DROP INDEX IF EXISTS idx_schedule_slots_exception_id;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS blocked_by;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS exception_id;
+DROP TABLE IF EXISTS schedule_exceptions;
+-- +goose StatementEnd
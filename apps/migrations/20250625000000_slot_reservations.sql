@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Бронь слота привязана к записи: освободить слот можно только с её токеном
+ALTER TABLE schedule_slots ADD COLUMN reservation_token UUID;
+ALTER TABLE appointments ADD COLUMN reservation_token UUID;
+
+-- У врача не может быть двух занятых слотов, пересекающихся по времени
+ALTER TABLE schedule_slots ADD CONSTRAINT schedule_slots_no_double_booking
+    EXCLUDE USING gist (doctor_id WITH =, tsrange(date + start_time, date + end_time) WITH &&)
+    WHERE (appointment_id IS NOT NULL);
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE schedule_slots DROP CONSTRAINT IF EXISTS schedule_slots_no_double_booking;
+ALTER TABLE appointments DROP COLUMN IF EXISTS reservation_token;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS reservation_token;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Удержание слота на время оформления записи, NULL у свободных и подтверждённых слотов
+ALTER TABLE schedule_slots ADD COLUMN held_until TIMESTAMPTZ;
+CREATE INDEX idx_schedule_slots_held_until ON schedule_slots(held_until) WHERE held_until IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_slots_held_until;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS held_until;
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- Часовой пояс врача (IANA), NULL — врач работает по часам клиники
+ALTER TABLE doctors ADD COLUMN time_zone VARCHAR(64);
+
+-- Слоты хранятся моментами. Прежние date и time сервис писал в UTC
+ALTER TABLE schedule_slots ADD COLUMN starts_at TIMESTAMPTZ;
+ALTER TABLE schedule_slots ADD COLUMN ends_at TIMESTAMPTZ;
+UPDATE schedule_slots
+SET starts_at = (date + start_time) AT TIME ZONE 'UTC',
+    ends_at = (date + end_time) AT TIME ZONE 'UTC';
+ALTER TABLE schedule_slots ALTER COLUMN starts_at SET NOT NULL;
+ALTER TABLE schedule_slots ALTER COLUMN ends_at SET NOT NULL;
+ALTER TABLE schedule_slots ADD CONSTRAINT schedule_slots_starts_before_ends CHECK (starts_at < ends_at);
+
+ALTER TABLE schedule_slots DROP CONSTRAINT schedule_slots_no_double_booking;
+DROP INDEX idx_schedule_slots_doctor_id_date_start_time;
+DROP INDEX idx_schedule_slots_doctor_id_date;
+ALTER TABLE schedule_slots DROP COLUMN date;
+ALTER TABLE schedule_slots DROP COLUMN start_time;
+ALTER TABLE schedule_slots DROP COLUMN end_time;
+
+-- Генерация идемпотентна: у врача не может быть двух слотов с одним началом
+CREATE UNIQUE INDEX idx_schedule_slots_doctor_id_starts_at ON schedule_slots(doctor_id, starts_at);
+
+-- У врача не может быть двух занятых слотов, пересекающихся по времени
+ALTER TABLE schedule_slots ADD CONSTRAINT schedule_slots_no_double_booking
+    EXCLUDE USING gist (doctor_id WITH =, tstzrange(starts_at, ends_at) WITH &&)
+    WHERE (appointment_id IS NOT NULL);
+
+-- Время записи тоже момент, смещение из запроса больше не теряется
+ALTER TABLE appointments ALTER COLUMN date TYPE TIMESTAMPTZ USING date AT TIME ZONE 'UTC';
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE appointments ALTER COLUMN date TYPE TIMESTAMP USING date AT TIME ZONE 'UTC';
+
+ALTER TABLE schedule_slots DROP CONSTRAINT IF EXISTS schedule_slots_no_double_booking;
+DROP INDEX IF EXISTS idx_schedule_slots_doctor_id_starts_at;
+
+ALTER TABLE schedule_slots ADD COLUMN date DATE;
+ALTER TABLE schedule_slots ADD COLUMN start_time TIME;
+ALTER TABLE schedule_slots ADD COLUMN end_time TIME;
+UPDATE schedule_slots
+SET date = (starts_at AT TIME ZONE 'UTC')::date,
+    start_time = (starts_at AT TIME ZONE 'UTC')::time,
+    end_time = (ends_at AT TIME ZONE 'UTC')::time;
+ALTER TABLE schedule_slots ALTER COLUMN date SET NOT NULL;
+ALTER TABLE schedule_slots ALTER COLUMN start_time SET NOT NULL;
+ALTER TABLE schedule_slots ALTER COLUMN end_time SET NOT NULL;
+
+CREATE INDEX idx_schedule_slots_doctor_id_date ON schedule_slots(doctor_id, date);
+CREATE UNIQUE INDEX idx_schedule_slots_doctor_id_date_start_time ON schedule_slots(doctor_id, date, start_time);
+ALTER TABLE schedule_slots ADD CONSTRAINT schedule_slots_no_double_booking
+    EXCLUDE USING gist (doctor_id WITH =, tsrange(date + start_time, date + end_time) WITH &&)
+    WHERE (appointment_id IS NOT NULL);
+
+ALTER TABLE schedule_slots DROP CONSTRAINT IF EXISTS schedule_slots_starts_before_ends;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS ends_at;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS starts_at;
+ALTER TABLE doctors DROP COLUMN IF EXISTS time_zone;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+CREATE TABLE clinics (
+                         id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                         name VARCHAR(255) NOT NULL,
+                         address VARCHAR(500),
+                         time_zone VARCHAR(64) NOT NULL,
+                         created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                         updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE rooms (
+                       id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                       clinic_id UUID NOT NULL REFERENCES clinics(id) ON DELETE CASCADE,
+                       name VARCHAR(100) NOT NULL,
+                       capacity INTEGER NOT NULL DEFAULT 1 CHECK (capacity > 0)
+);
+
+-- Врачи, принимающие в филиале
+CREATE TABLE doctor_clinics (
+                                doctor_id UUID NOT NULL REFERENCES doctors(id) ON DELETE CASCADE,
+                                clinic_id UUID NOT NULL REFERENCES clinics(id) ON DELETE CASCADE,
+                                PRIMARY KEY (doctor_id, clinic_id)
+);
+
+CREATE INDEX idx_rooms_clinic_id ON rooms(clinic_id);
+CREATE INDEX idx_doctor_clinics_clinic_id ON doctor_clinics(clinic_id);
+
+-- Шаблон и слоты без филиала остаются прежними, приём по часам врача
+ALTER TABLE schedule_templates ADD COLUMN clinic_id UUID REFERENCES clinics(id) ON DELETE SET NULL;
+ALTER TABLE schedule_templates ADD COLUMN room_id UUID REFERENCES rooms(id) ON DELETE SET NULL;
+ALTER TABLE schedule_slots ADD COLUMN clinic_id UUID REFERENCES clinics(id) ON DELETE SET NULL;
+ALTER TABLE schedule_slots ADD COLUMN room_id UUID REFERENCES rooms(id) ON DELETE SET NULL;
+
+-- Проверка вместимости ищет занятые слоты кабинета
+CREATE INDEX idx_schedule_slots_room_id_starts_at ON schedule_slots(room_id, starts_at)
+    WHERE appointment_id IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_slots_room_id_starts_at;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS room_id;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS clinic_id;
+ALTER TABLE schedule_templates DROP COLUMN IF EXISTS room_id;
+ALTER TABLE schedule_templates DROP COLUMN IF EXISTS clinic_id;
+DROP TABLE IF EXISTS doctor_clinics;
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS clinics;
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- Контакты и описание врача для карточки
+ALTER TABLE doctors ADD COLUMN email VARCHAR(255);
+ALTER TABLE doctors ADD COLUMN phone VARCHAR(32);
+ALTER TABLE doctors ADD COLUMN bio TEXT;
+ALTER TABLE doctors ADD COLUMN experience_years INTEGER NOT NULL DEFAULT 0 CHECK (experience_years >= 0);
+-- Ключ фото в хранилище файлов, сами файлы в базе не лежат
+ALTER TABLE doctors ADD COLUMN photo_key VARCHAR(255);
+
+-- У врача может быть несколько специализаций, прежняя единственная переносится в связи
+CREATE TABLE doctor_specializations (
+                                        doctor_id UUID NOT NULL REFERENCES doctors(id) ON DELETE CASCADE,
+                                        specialization_id UUID NOT NULL REFERENCES specializations(id),
+                                        PRIMARY KEY (doctor_id, specialization_id)
+);
+INSERT INTO doctor_specializations (doctor_id, specialization_id)
+SELECT id, specialization_id FROM doctors;
+CREATE INDEX idx_doctor_specializations_specialization_id ON doctor_specializations(specialization_id);
+
+DROP INDEX idx_doctors_specialization_id_status;
+ALTER TABLE doctors DROP COLUMN specialization_id;
+CREATE INDEX idx_doctors_status ON doctors(status);
+
+-- Языки приёма, теги BCP 47
+CREATE TABLE doctor_languages (
+                                  doctor_id UUID NOT NULL REFERENCES doctors(id) ON DELETE CASCADE,
+                                  code VARCHAR(35) NOT NULL,
+                                  PRIMARY KEY (doctor_id, code)
+);
+
+-- Лицензии, сертификаты и дипломы, expires_at NULL — бессрочный документ
+CREATE TABLE doctor_credentials (
+                                    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    doctor_id UUID NOT NULL REFERENCES doctors(id) ON DELETE CASCADE,
+                                    kind VARCHAR(20) NOT NULL CHECK (kind IN ('LICENSE', 'CERTIFICATE', 'DIPLOMA')),
+                                    number VARCHAR(100) NOT NULL,
+                                    issuer VARCHAR(255) NOT NULL,
+                                    issued_at DATE NOT NULL,
+                                    expires_at DATE,
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    CHECK (expires_at IS NULL OR expires_at > issued_at)
+);
+CREATE INDEX idx_doctor_credentials_doctor_id ON doctor_credentials(doctor_id);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS doctor_credentials;
+DROP TABLE IF EXISTS doctor_languages;
+
+DROP INDEX IF EXISTS idx_doctors_status;
+ALTER TABLE doctors ADD COLUMN specialization_id UUID REFERENCES specializations(id);
+-- Из нескольких специализаций остаётся одна, у врачей без специализаций колонка остаётся NULL
+UPDATE doctors
+SET specialization_id = (
+    SELECT specialization_id FROM doctor_specializations
+    WHERE doctor_specializations.doctor_id = doctors.id
+    ORDER BY specialization_id
+    LIMIT 1
+);
+CREATE INDEX idx_doctors_specialization_id_status ON doctors(specialization_id, status);
+DROP TABLE IF EXISTS doctor_specializations;
+
+ALTER TABLE doctors DROP COLUMN IF EXISTS photo_key;
+ALTER TABLE doctors DROP COLUMN IF EXISTS experience_years;
+ALTER TABLE doctors DROP COLUMN IF EXISTS bio;
+ALTER TABLE doctors DROP COLUMN IF EXISTS phone;
+ALTER TABLE doctors DROP COLUMN IF EXISTS email;
+-- +goose StatementEnd
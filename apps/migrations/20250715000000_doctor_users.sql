@@ -0,0 +1,10 @@
+-- +goose Up
+-- Пользователь auth, которому принадлежит профиль врача. Один пользователь — один врач.
+ALTER TABLE doctors ADD COLUMN user_id BIGINT;
+CREATE UNIQUE INDEX idx_doctors_user_id ON doctors(user_id);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_doctors_user_id;
+ALTER TABLE doctors DROP COLUMN IF EXISTS user_id;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Отзывы пациентов о приёмах, один отзыв на приём. В рейтинг идут только одобренные.
+CREATE TABLE reviews (
+                         id UUID PRIMARY KEY,
+                         doctor_id UUID NOT NULL REFERENCES doctors(id) ON DELETE CASCADE,
+                         appointment_id UUID NOT NULL,
+                         user_id BIGINT NOT NULL,
+                         rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+                         comment TEXT,
+                         status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+                         moderated_at TIMESTAMP,
+                         created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                         updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX idx_reviews_appointment_id ON reviews(appointment_id);
+-- Одобренные отзывы врача и очередь модерации
+CREATE INDEX idx_reviews_doctor_id_status_created_at ON reviews(doctor_id, status, created_at);
+CREATE INDEX idx_reviews_status_created_at ON reviews(status, created_at);
+
+-- Рейтинг хранится у врача, чтобы по нему сортировать поиск
+ALTER TABLE doctors ADD COLUMN rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE doctors ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX idx_doctors_rating_id ON doctors(rating_avg, rating_count, id);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_doctors_rating_id;
+ALTER TABLE doctors DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE doctors DROP COLUMN IF EXISTS rating_avg;
+DROP TABLE IF EXISTS reviews;
+-- +goose StatementEnd
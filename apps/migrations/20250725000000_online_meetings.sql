@@ -0,0 +1,15 @@
+-- +goose Up
+-- Онлайн-приёмы: при бронировании слота создаётся комната видеосвязи, при отмене она отзывается
+ALTER TABLE schedule_templates ADD COLUMN is_online BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE schedule_slots ADD COLUMN is_online BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE schedule_slots ADD COLUMN meeting_room VARCHAR(64);
+-- По комнате сервис видеосвязи проверяет ссылку при входе
+CREATE UNIQUE INDEX idx_schedule_slots_meeting_room ON schedule_slots(meeting_room) WHERE meeting_room IS NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_slots_meeting_room;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS meeting_room;
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS is_online;
+ALTER TABLE schedule_templates DROP COLUMN IF EXISTS is_online;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- Дерево специализаций: "Хирург" → "Кардиохирург". Поиск по специализации находит и дочерние.
+ALTER TABLE specializations ADD COLUMN parent_id UUID REFERENCES specializations(id);
+CREATE INDEX idx_specializations_parent_id ON specializations(parent_id);
+
+-- Переводы названий и описаний, основной язык хранится в самой специализации
+CREATE TABLE specialization_translations (
+                                             specialization_id UUID NOT NULL REFERENCES specializations(id) ON DELETE CASCADE,
+                                             language VARCHAR(35) NOT NULL,
+                                             name VARCHAR(255) NOT NULL,
+                                             description TEXT,
+                                             PRIMARY KEY (specialization_id, language)
+);
+
+-- Каталог услуг по специализациям. Цена в минимальных единицах валюты (тиынах).
+CREATE TABLE medical_services (
+                                  id UUID PRIMARY KEY,
+                                  specialization_id UUID NOT NULL REFERENCES specializations(id),
+                                  name VARCHAR(255) NOT NULL,
+                                  description TEXT,
+                                  duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+                                  price BIGINT NOT NULL DEFAULT 0 CHECK (price >= 0),
+                                  currency VARCHAR(3) NOT NULL DEFAULT 'KZT',
+                                  is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                                  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_medical_services_specialization_id_name ON medical_services(specialization_id, name);
+
+-- Длительность услуги задаёт длину слотов шаблона, слоты помнят услугу
+ALTER TABLE schedule_templates ADD COLUMN service_id UUID REFERENCES medical_services(id) ON DELETE SET NULL;
+ALTER TABLE schedule_slots ADD COLUMN service_id UUID REFERENCES medical_services(id) ON DELETE SET NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE schedule_slots DROP COLUMN IF EXISTS service_id;
+ALTER TABLE schedule_templates DROP COLUMN IF EXISTS service_id;
+DROP TABLE IF EXISTS medical_services;
+DROP TABLE IF EXISTS specialization_translations;
+DROP INDEX IF EXISTS idx_specializations_parent_id;
+ALTER TABLE specializations DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
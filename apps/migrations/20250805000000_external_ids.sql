@@ -0,0 +1,21 @@
+-- +goose Up
+-- Ключи записей во внешней системе, по ним импорт обновляет уже загруженные записи.
+-- Записи, созданные через API, ключа не имеют, NULL в уникальном индексе не конфликтует.
+ALTER TABLE specializations ADD COLUMN external_id VARCHAR(100);
+CREATE UNIQUE INDEX idx_specializations_external_id ON specializations(external_id);
+
+ALTER TABLE doctors ADD COLUMN external_id VARCHAR(100);
+CREATE UNIQUE INDEX idx_doctors_external_id ON doctors(external_id);
+
+ALTER TABLE schedule_templates ADD COLUMN external_id VARCHAR(100);
+CREATE UNIQUE INDEX idx_schedule_templates_external_id ON schedule_templates(external_id);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_templates_external_id;
+ALTER TABLE schedule_templates DROP COLUMN IF EXISTS external_id;
+DROP INDEX IF EXISTS idx_doctors_external_id;
+ALTER TABLE doctors DROP COLUMN IF EXISTS external_id;
+DROP INDEX IF EXISTS idx_specializations_external_id;
+ALTER TABLE specializations DROP COLUMN IF EXISTS external_id;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- Пользователи auth и карты пациентов имеют числовые ID, прежние UUID-ссылки переводятся в BIGINT.
+-- UUID вида 00000000-0000-0000-XXXX-XXXXXXXXXXXX — числовой ID, записанный как UUID, он переносится как есть.
+-- Прочие значения ни на что не указывали: они сохраняются в legacy_-колонках для ручной привязки,
+-- новая ссылка у таких записей остаётся NULL.
+ALTER TABLE patients RENAME COLUMN user_id TO legacy_user_id;
+ALTER TABLE patients ALTER COLUMN legacy_user_id DROP NOT NULL;
+ALTER TABLE patients ADD COLUMN user_id BIGINT;
+UPDATE patients
+SET user_id = ('x' || right(replace(legacy_user_id::text, '-', ''), 16))::bit(64)::bigint
+WHERE left(replace(legacy_user_id::text, '-', ''), 16) = '0000000000000000';
+-- Один пользователь — одна карта пациента; карты без пользователя остаются с NULL
+CREATE UNIQUE INDEX idx_patients_user_id ON patients(user_id);
+
+ALTER TABLE prescriptions RENAME COLUMN patient_id TO legacy_patient_id;
+ALTER TABLE prescriptions ALTER COLUMN legacy_patient_id DROP NOT NULL;
+ALTER TABLE prescriptions ADD COLUMN patient_id BIGINT REFERENCES patients(id);
+UPDATE prescriptions pr
+SET patient_id = p.id
+FROM patients p
+WHERE left(replace(pr.legacy_patient_id::text, '-', ''), 16) = '0000000000000000'
+  AND p.id = ('x' || right(replace(pr.legacy_patient_id::text, '-', ''), 16))::bit(64)::bigint;
+DROP INDEX IF EXISTS idx_prescriptions_patient_id;
+CREATE INDEX idx_prescriptions_patient_id ON prescriptions(patient_id);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_prescriptions_patient_id;
+ALTER TABLE prescriptions DROP COLUMN IF EXISTS patient_id;
+UPDATE prescriptions SET legacy_patient_id = '00000000-0000-0000-0000-000000000000' WHERE legacy_patient_id IS NULL;
+ALTER TABLE prescriptions ALTER COLUMN legacy_patient_id SET NOT NULL;
+ALTER TABLE prescriptions RENAME COLUMN legacy_patient_id TO patient_id;
+CREATE INDEX idx_prescriptions_patient_id ON prescriptions(patient_id);
+
+DROP INDEX IF EXISTS idx_patients_user_id;
+UPDATE patients
+SET legacy_user_id = ('00000000-0000-0000-' || substr(lpad(to_hex(user_id), 16, '0'), 1, 4) || '-' || substr(lpad(to_hex(user_id), 16, '0'), 5))::uuid
+WHERE legacy_user_id IS NULL AND user_id IS NOT NULL;
+ALTER TABLE patients DROP COLUMN IF EXISTS user_id;
+ALTER TABLE patients RENAME COLUMN legacy_user_id TO user_id;
+-- +goose StatementEnd
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	config2 "github.com/Ruletk/OnlineClinic/pkg/config"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 	"github.com/nats-io/nats.go"
+	"net/http"
 	"notification/internal/repositories/email"
 	"notification/internal/subscribers"
 )
@@ -18,6 +22,17 @@ func main() {
 	logging.InitLogger(*config)
 	logging.Logger.Infof("Starting Notification Service")
 
+	shutdownTracer, err := tracing.InitTracer(*config)
+	if err != nil {
+		logging.Logger.WithError(err).Error("Failed to initialize tracing")
+		panic(err)
+	}
+	defer func() {
+		if err := shutdownTracer(context.Background()); err != nil {
+			logging.Logger.WithError(err).Error("Failed to shutdown tracer")
+		}
+	}()
+
 	emailSender := email.NewMockEmailSender()
 	logging.Logger.Debugf("Creating NATS connection: %s", config.Nats.Url)
 	conn, err := nats.Connect(config.Nats.Url, nats.Name("Notification Service"))
@@ -32,6 +47,16 @@ func main() {
 
 	logging.Logger.Info("NATS subscriber initialized and running")
 
+	// The service has no API, the HTTP server only exposes the metrics
+	go func() {
+		addr := fmt.Sprintf("%s:%d", config.Backend.ListenAddress, config.Backend.ListenPort)
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logging.Logger.WithError(err).Error("Metrics server stopped")
+		}
+	}()
+
 	// Просто ждем сигнала завершения
 	wait := make(chan struct{})
 	<-wait
@@ -1,9 +1,14 @@
 package subscribers
 
 import (
+	"context"
 	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
 	natspb "github.com/Ruletk/OnlineClinic/pkg/proto/nats/gen"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 	"notification/internal/models"
 	"notification/internal/repositories/email"
@@ -24,7 +29,7 @@ func NewNatsService(client *nats.Conn, emailSender email.Sender) *NatsService {
 }
 
 func (n *NatsService) InitNatsSubscriber() {
-	_, err := n.client.Subscribe("email.message", n.emailSubscriber)
+	_, err := n.client.Subscribe("email.message", metrics.NatsHandler(n.emailSubscriber))
 	if err != nil {
 		logging.Logger.Errorf("Failed to subscribe to NATS topic: %v", err)
 		return
@@ -34,21 +39,31 @@ func (n *NatsService) InitNatsSubscriber() {
 }
 
 func (n *NatsService) emailSubscriber(msg *nats.Msg) {
-	logging.Logger.Debug("Received email message request")
+	ctx := tracing.ExtractNats(context.Background(), msg)
+	ctx = logging.ExtractRequestIDNats(ctx, msg)
+	log := logging.FromContext(ctx)
+	log.Debug("Received email message request")
+	_, span := tracing.Tracer("notification/nats").Start(ctx, "email.message process",
+		trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+
 	var natsEmailMessage natspb.EmailMessage
 	if err := proto.Unmarshal(msg.Data, &natsEmailMessage); err != nil {
-		logging.Logger.Errorf("Failed to unmarshal email message: %v", err)
+		log.Errorf("Failed to unmarshal email message: %v", err)
+		span.SetStatus(codes.Error, "unmarshal failed")
 		return
 	}
-	logging.Logger.Debugf("Unmarshalled email message, to: %s", natsEmailMessage.To[0:10])
+	log.Debugf("Unmarshalled email message, to: %s", natsEmailMessage.To[0:10])
 	emailMsg := models.EmailMessage{
 		To:      natsEmailMessage.To,
 		Subject: natsEmailMessage.Subject,
 		Message: natsEmailMessage.Message,
 	}
 	if err := n.emailSender.Send(emailMsg); err != nil {
-		logging.Logger.Errorf("Failed to send email: %v", err)
+		log.Errorf("Failed to send email: %v", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "send failed")
 		return
 	}
-	logging.Logger.Infof("Email sent successfully to: %s", natsEmailMessage.To[0:10])
+	log.Infof("Email sent successfully to: %s", natsEmailMessage.To[0:10])
 }
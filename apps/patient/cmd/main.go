@@ -1,6 +1,12 @@
 package main
 
 import (
+	"context"
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/metrics"
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 	"gorm.io/driver/postgres" // Или другой драйвер
@@ -21,12 +27,34 @@ import (
 )
 
 func main() {
+	cfg, err := config.GetDefaultConfiguration()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+	logging.InitLogger(*cfg)
+
+	shutdownTracer, err := tracing.InitTracer(*cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+	defer func() {
+		if err := shutdownTracer(context.Background()); err != nil {
+			log.Println("Failed to shutdown tracer:", err)
+		}
+	}()
+
 	// Инициализация БД (пример для PostgreSQL)
 	dsn := "host=localhost user=postgres password=postgres dbname=clinic port=5432 sslmode=disable"
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	if err := tracing.InstrumentGorm(db); err != nil {
+		log.Println("Failed to instrument database:", err)
+	}
+	if err := metrics.InstrumentGorm(db); err != nil {
+		log.Println("Failed to register database metrics:", err)
+	}
 
 	// Автомиграция для всех моделей
 	db.AutoMigrate(
@@ -55,14 +83,27 @@ func main() {
 
 	// Настройка REST сервера
 	router := gin.Default()
+	router.Use(logging.RequestIDMiddleware())
+	router.Use(tracing.GinMiddleware(cfg.Tracing.ServiceName))
+	router.Use(metrics.GinMiddleware())
+	router.Use(logging.GinLogger(logging.Logger))
 	restHandler := rest.NewPatientHandler(patientService)
 	restHandler.RegisterRoutes(router)
 	restHandler.RegisterAllergyRoutes(router)
 	restHandler.RegisterInsuranceRoutes(router)
 	restHandler.RegisterPrescriptionRoutes(router)
 
+	doc := openapi.NewDocument("Patient service", "1.0.0")
+	if err := restHandler.RegisterDocs(doc); err != nil {
+		log.Fatal("Failed to build OpenAPI document:", err)
+	}
+	router.GET("/openapi.json", doc.Handler())
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	// Настройка gRPC сервера
-	grpcServer := grpc.NewServer()
+	grpcOpts := append(tracing.GRPCServerOptions(), metrics.GRPCServerOptions()...)
+	grpcOpts = append(grpcOpts, grpc.ChainUnaryInterceptor(logging.UnaryServerRequestIDInterceptor()))
+	grpcServer := grpc.NewServer(grpcOpts...)
 	grpcController := grpcpackage.NewPatientController(patientService)
 	proto.RegisterPatientServiceServer(grpcServer, grpcController)
 
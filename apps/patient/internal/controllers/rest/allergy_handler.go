@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"net/http"
 	"patient/internal/dto"
+	"strconv"
 )
 
 func (h *PatientHandler) RegisterAllergyRoutes(router *gin.Engine) {
@@ -18,7 +19,7 @@ func (h *PatientHandler) RegisterAllergyRoutes(router *gin.Engine) {
 
 // AddAllergy - Добавить аллергию
 func (h *PatientHandler) AddAllergy(c *gin.Context) {
-	patientID, err := uuid.Parse(c.Param("id"))
+	patientID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -42,7 +43,7 @@ func (h *PatientHandler) AddAllergy(c *gin.Context) {
 
 // GetAllergies - Получить аллергии пациента
 func (h *PatientHandler) GetAllergies(c *gin.Context) {
-	patientID, err := uuid.Parse(c.Param("id"))
+	patientID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -65,7 +66,7 @@ func (h *PatientHandler) DeleteAllergy(c *gin.Context) {
 		return
 	}
 
-	if _, err := h.service.DeletePatientAllergy(&dto.DeleteAllergyRequest{AllergyID: allergyID}); err != nil {
+	if err := h.service.DeletePatientAllergy(&dto.DeleteAllergyRequest{AllergyID: allergyID}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
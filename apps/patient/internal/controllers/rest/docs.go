@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"net/http"
+	"patient/internal/dto"
+
+	"github.com/Ruletk/OnlineClinic/pkg/openapi"
+)
+
+// listPatientsQuery documents the paging parameters read by GetAllPatients.
+type listPatientsQuery struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+// RegisterDocs documents the routes added by RegisterRoutes, RegisterAllergyRoutes,
+// RegisterInsuranceRoutes and RegisterPrescriptionRoutes.
+func (h *PatientHandler) RegisterDocs(doc *openapi.Document) error {
+	failure := openapi.ErrorResponse{}
+	deleted := map[int]any{
+		http.StatusNoContent:           nil,
+		http.StatusBadRequest:          failure,
+		http.StatusInternalServerError: failure,
+	}
+
+	ops := []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/patients", Summary: "List patients", Tags: []string{"patients"},
+			Query: listPatientsQuery{},
+			Responses: map[int]any{
+				http.StatusOK:                  dto.PatientResponses{},
+				http.StatusInternalServerError: failure,
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/patients", Summary: "Create a patient", Tags: []string{"patients"},
+			Request: dto.CreatePatientRequest{},
+			Responses: map[int]any{
+				http.StatusCreated:             dto.PatientResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/patients/:id", Summary: "Get a patient", Tags: []string{"patients"},
+			Responses: map[int]any{
+				http.StatusOK:         dto.PatientResponse{},
+				http.StatusBadRequest: failure,
+				http.StatusNotFound:   failure,
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/patients/user/:userId", Summary: "Get the patient of an auth user", Tags: []string{"patients"},
+			Responses: map[int]any{
+				http.StatusOK:         dto.PatientResponse{},
+				http.StatusBadRequest: failure,
+				http.StatusNotFound:   failure,
+			},
+		},
+		{
+			Method: http.MethodPatch, Path: "/patients/:id", Summary: "Update a patient", Tags: []string{"patients"},
+			Request: dto.UpdatePatientRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  dto.PatientResponse{},
+				http.StatusBadRequest:          failure,
+				http.StatusInternalServerError: failure,
+			},
+		},
+		{
+			Method: http.MethodDelete, Path: "/patients/:id", Summary: "Delete a patient", Tags: []string{"patients"},
+			Responses: deleted,
+		},
+	}
+
+	// Allergies, insurances and prescriptions share the same nested routes.
+	records := []struct {
+		name     string
+		tag      string
+		request  any
+		response any
+	}{
+		{name: "allergies", tag: "allergies", request: dto.CreateAllergyRequest{}, response: dto.AllergyResponses{}},
+		{name: "insurances", tag: "insurances", request: dto.CreateInsuranceRequest{}, response: dto.InsuranceResponses{}},
+		{name: "prescriptions", tag: "prescriptions", request: dto.CreatePrescriptionRequest{}, response: dto.PrescriptionResponses{}},
+	}
+	for _, record := range records {
+		ops = append(ops,
+			openapi.Operation{
+				Method: http.MethodPost, Path: "/patients/:id/" + record.name, Summary: "Add " + record.name + " to a patient", Tags: []string{record.tag},
+				Request: record.request,
+				Responses: map[int]any{
+					http.StatusCreated:             dto.PatientResponse{},
+					http.StatusBadRequest:          failure,
+					http.StatusInternalServerError: failure,
+				},
+			},
+			openapi.Operation{
+				Method: http.MethodGet, Path: "/patients/:id/" + record.name, Summary: "List " + record.name + " of a patient", Tags: []string{record.tag},
+				Responses: map[int]any{
+					http.StatusOK:                  record.response,
+					http.StatusBadRequest:          failure,
+					http.StatusInternalServerError: failure,
+				},
+			},
+			openapi.Operation{
+				Method: http.MethodDelete, Path: "/" + record.name + "/:id", Summary: "Delete " + record.name, Tags: []string{record.tag},
+				Responses: deleted,
+			},
+		)
+	}
+
+	return doc.Add(ops...)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"net/http"
 	"patient/internal/dto"
+	"strconv"
 )
 
 func (h *PatientHandler) RegisterInsuranceRoutes(router *gin.Engine) {
@@ -18,7 +19,7 @@ func (h *PatientHandler) RegisterInsuranceRoutes(router *gin.Engine) {
 
 // AddInsurance - Добавить страховку
 func (h *PatientHandler) AddInsurance(c *gin.Context) {
-	patientID, err := uuid.Parse(c.Param("id"))
+	patientID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -42,7 +43,7 @@ func (h *PatientHandler) AddInsurance(c *gin.Context) {
 
 // GetInsurances - Получить страховки пациента
 func (h *PatientHandler) GetInsurances(c *gin.Context) {
-	patientID, err := uuid.Parse(c.Param("id"))
+	patientID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -65,7 +66,7 @@ func (h *PatientHandler) DeleteInsurance(c *gin.Context) {
 		return
 	}
 
-	if err, _ := h.service.DeletePatientInsurance(&dto.DeleteInsuranceRequest{InsuranceID: insuranceID}); err != nil {
+	if err := h.service.DeletePatientInsurance(&dto.DeleteInsuranceRequest{InsuranceID: insuranceID}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
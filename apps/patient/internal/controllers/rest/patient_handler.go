@@ -2,7 +2,6 @@ package rest
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"net/http"
 	"patient/internal/dto"
 	"patient/internal/services"
@@ -23,6 +22,7 @@ func (h *PatientHandler) RegisterRoutes(router *gin.Engine) {
 		patients.GET("", h.GetAllPatients)
 		patients.POST("", h.CreatePatient)
 		patients.GET("/:id", h.GetPatientByID)
+		patients.GET("/user/:userId", h.GetPatientByUserID)
 		patients.PATCH("/:id", h.UpdatePatient)
 		patients.DELETE("/:id", h.DeletePatient)
 	}
@@ -61,7 +61,7 @@ func (h *PatientHandler) CreatePatient(c *gin.Context) {
 
 // GetPatientByID - Получить пациента по ID
 func (h *PatientHandler) GetPatientByID(c *gin.Context) {
-	id, err := uuid.Parse(c.Param("id"))
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -76,9 +76,26 @@ func (h *PatientHandler) GetPatientByID(c *gin.Context) {
 	c.JSON(http.StatusOK, patient)
 }
 
+// GetPatientByUserID - Получить карту пациента по пользователю auth
+func (h *PatientHandler) GetPatientByUserID(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	patient, err := h.service.GetPatientByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "patient not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, patient)
+}
+
 // UpdatePatient - Обновить данные пациента
 func (h *PatientHandler) UpdatePatient(c *gin.Context) {
-	id, err := uuid.Parse(c.Param("id"))
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -102,7 +119,7 @@ func (h *PatientHandler) UpdatePatient(c *gin.Context) {
 
 // DeletePatient - Удалить пациента
 func (h *PatientHandler) DeletePatient(c *gin.Context) {
-	id, err := uuid.Parse(c.Param("id"))
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -0,0 +1,168 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"patient/internal/models"
+	"patient/internal/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakePatientRepo keeps patients in memory
+type fakePatientRepo struct {
+	patients map[int64]models.Patient
+}
+
+func (r *fakePatientRepo) Create(patient *models.Patient) error {
+	patient.ID = int64(len(r.patients) + 1)
+	r.patients[patient.ID] = *patient
+	return nil
+}
+
+func (r *fakePatientRepo) GetByID(id int64) (*models.Patient, error) {
+	patient, ok := r.patients[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &patient, nil
+}
+
+func (r *fakePatientRepo) GetByUserID(userID int64) (*models.Patient, error) {
+	for _, patient := range r.patients {
+		if patient.UserID != nil && *patient.UserID == userID {
+			return &patient, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakePatientRepo) Update(patient *models.Patient) error {
+	r.patients[patient.ID] = *patient
+	return nil
+}
+
+func (r *fakePatientRepo) Delete(id int64) error {
+	delete(r.patients, id)
+	return nil
+}
+
+func (r *fakePatientRepo) GetAll(patients *[]models.Patient, _, _ int) error {
+	for _, patient := range r.patients {
+		*patients = append(*patients, patient)
+	}
+	return nil
+}
+
+// fakePrescriptionRepo keeps prescriptions in memory
+type fakePrescriptionRepo struct {
+	prescriptions []models.Prescription
+}
+
+func (r *fakePrescriptionRepo) Create(prescription *models.Prescription) error {
+	r.prescriptions = append(r.prescriptions, *prescription)
+	return nil
+}
+
+func (r *fakePrescriptionRepo) GetByID(id uuid.UUID) (*models.Prescription, error) {
+	for _, prescription := range r.prescriptions {
+		if prescription.ID == id {
+			return &prescription, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakePrescriptionRepo) Update(*models.Prescription) error { return nil }
+
+func (r *fakePrescriptionRepo) Delete(uuid.UUID) error { return nil }
+
+func (r *fakePrescriptionRepo) GetByPatient(patientID int64) ([]models.Prescription, error) {
+	var found []models.Prescription
+	for _, prescription := range r.prescriptions {
+		if prescription.PatientID == patientID {
+			found = append(found, prescription)
+		}
+	}
+	return found, nil
+}
+
+type PatientHandlerTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func TestPatientHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PatientHandlerTestSuite))
+}
+
+func (s *PatientHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	userID := int64(7)
+	patients := &fakePatientRepo{patients: map[int64]models.Patient{
+		42: {
+			ID:         42,
+			UserID:     &userID,
+			Allergies:  []models.Allergy{{ID: uuid.New(), PatientID: 42, Name: "Penicillin"}},
+			Insurances: []models.Insurance{{ID: uuid.New(), PatientID: 42, Provider: "Clinic Care"}},
+		},
+	}}
+	prescriptions := &fakePrescriptionRepo{prescriptions: []models.Prescription{{ID: uuid.New(), PatientID: 42, Medication: "Ibuprofen"}}}
+
+	svc := services.NewPatientService(patients, nil, nil, services.NewPrescriptionService(prescriptions))
+	handler := NewPatientHandler(svc)
+	s.router = gin.New()
+	handler.RegisterRoutes(s.router)
+	handler.RegisterAllergyRoutes(s.router)
+	handler.RegisterInsuranceRoutes(s.router)
+	handler.RegisterPrescriptionRoutes(s.router)
+}
+
+func (s *PatientHandlerTestSuite) get(path string, out any) int {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if out != nil && w.Code == http.StatusOK {
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), out))
+	}
+	return w.Code
+}
+
+// The gateway dashboard looks the record up by the user, then reads its sections by the returned ID
+func (s *PatientHandlerTestSuite) TestDashboardRoutes() {
+	var patient struct {
+		ID int64 `json:"id"`
+	}
+	s.Require().Equal(http.StatusOK, s.get("/patients/user/7", &patient))
+	s.Equal(int64(42), patient.ID)
+
+	var allergies struct {
+		Count int `json:"count"`
+	}
+	s.Equal(http.StatusOK, s.get(fmt.Sprintf("/patients/%d/allergies", patient.ID), &allergies))
+	s.Equal(1, allergies.Count)
+
+	var insurances struct {
+		Count int `json:"count"`
+	}
+	s.Equal(http.StatusOK, s.get(fmt.Sprintf("/patients/%d/insurances", patient.ID), &insurances))
+	s.Equal(1, insurances.Count)
+
+	var prescriptions struct {
+		Count int `json:"count"`
+	}
+	s.Equal(http.StatusOK, s.get(fmt.Sprintf("/patients/%d/prescriptions", patient.ID), &prescriptions))
+	s.Equal(1, prescriptions.Count)
+
+	s.Equal(http.StatusOK, s.get(fmt.Sprintf("/patients/%d", patient.ID), nil))
+}
+
+func (s *PatientHandlerTestSuite) TestInvalidPatientID() {
+	s.Equal(http.StatusBadRequest, s.get("/patients/"+uuid.NewString()+"/allergies", nil))
+	s.Equal(http.StatusNotFound, s.get("/patients/user/8", nil))
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"net/http"
 	"patient/internal/dto"
+	"strconv"
 )
 
 func (h *PatientHandler) RegisterPrescriptionRoutes(router *gin.Engine) {
@@ -18,7 +19,7 @@ func (h *PatientHandler) RegisterPrescriptionRoutes(router *gin.Engine) {
 
 // AddPrescription - Добавить рецепт
 func (h *PatientHandler) AddPrescription(c *gin.Context) {
-	patientID, err := uuid.Parse(c.Param("id"))
+	patientID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -42,7 +43,7 @@ func (h *PatientHandler) AddPrescription(c *gin.Context) {
 
 // GetPrescriptions - Получить рецепты пациента
 func (h *PatientHandler) GetPrescriptions(c *gin.Context) {
-	patientID, err := uuid.Parse(c.Param("id"))
+	patientID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid patient ID"})
 		return
@@ -65,7 +66,7 @@ func (h *PatientHandler) DeletePrescription(c *gin.Context) {
 		return
 	}
 
-	if err, _ := h.service.DeletePatientPrescription(&dto.DeletePrescriptionRequest{PrescriptionID: prescriptionID}); err != nil {
+	if err := h.service.DeletePatientPrescription(&dto.DeletePrescriptionRequest{PrescriptionID: prescriptionID}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -6,32 +6,32 @@ import (
 )
 
 type CreatePatientRequest struct {
-	UserID    uuid.UUID `json:"user_id" validate:"required"`
-	BloodType string    `json:"blood_type" validate:"oneof=A+ A- B+ B- AB+ AB- O+ O-"`
-	Height    float64   `json:"height" validate:"min=0"`
-	Weight    float64   `json:"weight" validate:"min=0"`
+	UserID    int64   `json:"user_id" validate:"required"`
+	BloodType string  `json:"blood_type" validate:"oneof=A+ A- B+ B- AB+ AB- O+ O-"`
+	Height    float64 `json:"height" validate:"min=0"`
+	Weight    float64 `json:"weight" validate:"min=0"`
 }
 
 type GetPatientRequest struct {
-	PatientID uuid.UUID `json:"patient_id" validate:"required"`
+	PatientID int64 `json:"patient_id" validate:"required"`
 }
 
 type UpdatePatientRequest struct {
-	BloodType string    `json:"blood_type" validate:"omitempty,oneof=A+ A- B+ B- AB+ AB- O+ O-"`
-	Height    float64   `json:"height" validate:"omitempty,min=0"`
-	Weight    float64   `json:"weight" validate:"omitempty,min=0"`
-	PatientID uuid.UUID `json:"patient_id" validate:"required"`
+	BloodType string  `json:"blood_type" validate:"omitempty,oneof=A+ A- B+ B- AB+ AB- O+ O-"`
+	Height    float64 `json:"height" validate:"omitempty,min=0"`
+	Weight    float64 `json:"weight" validate:"omitempty,min=0"`
+	PatientID int64   `json:"patient_id" validate:"required"`
 }
 
 type DeletePatientRequest struct {
-	PatientID uuid.UUID `json:"patient_id" validate:"required"`
+	PatientID int64 `json:"patient_id" validate:"required"`
 }
 
 type CreateAllergyRequest struct {
-	PatientID  uuid.UUID `json:"patient_id" validate:"required"`
-	Name       string    `json:"name" validate:"required"`
-	ObservedAt string    `json:"observed_at" validate:"required,datetime=2006-01-02"`
-	Severity   string    `json:"severity" validate:"required,oneof=LOW MODERATE SEVERE"`
+	PatientID  int64  `json:"patient_id" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	ObservedAt string `json:"observed_at" validate:"required,datetime=2006-01-02"`
+	Severity   string `json:"severity" validate:"required,oneof=LOW MODERATE SEVERE"`
 }
 
 type DeleteAllergyRequest struct {
@@ -46,10 +46,10 @@ type UpdateAllergyRequest struct {
 }
 
 type CreateInsuranceRequest struct {
-	PatientID      uuid.UUID `json:"patient_id" validate:"required"`
-	Provider       string    `json:"provider" validate:"required"`
-	PolicyNumber   string    `json:"policy_number" validate:"required"`
-	ExpirationDate string    `json:"expiration_date" validate:"required,datetime=2006-01-02"`
+	PatientID      int64  `json:"patient_id" validate:"required"`
+	Provider       string `json:"provider" validate:"required"`
+	PolicyNumber   string `json:"policy_number" validate:"required"`
+	ExpirationDate string `json:"expiration_date" validate:"required,datetime=2006-01-02"`
 }
 
 type UpdateInsuranceRequest struct {
@@ -61,7 +61,7 @@ type DeleteInsuranceRequest struct {
 }
 
 type CreatePrescriptionRequest struct {
-	PatientID  uuid.UUID `json:"patient_id" validate:"required"`
+	PatientID  int64     `json:"patient_id" validate:"required"`
 	DoctorID   uuid.UUID `json:"doctor_id" validate:"required"`
 	Medication string    `json:"medication" validate:"required"`
 	Dosage     string    `json:"dosage" validate:"required"`
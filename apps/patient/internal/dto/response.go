@@ -8,7 +8,7 @@ import (
 
 type PatientResponse struct {
 	ID         int64              `json:"id"`
-	UserID     uuid.UUID          `json:"user_id"`
+	UserID     *int64             `json:"user_id"`
 	BloodType  string             `json:"blood_type"`
 	Height     float64            `json:"height"`
 	Weight     float64            `json:"weight"`
@@ -31,7 +31,7 @@ type PatientResponses struct {
 
 type AllergyResponse struct {
 	ID         uuid.UUID `json:"id"`
-	PatientID  uuid.UUID `json:"patient_id"`
+	PatientID  int64     `json:"patient_id"`
 	Name       string    `json:"name"`
 	Severity   string    `json:"severity"`
 	ObservedAt time.Time `json:"observed_at"`
@@ -44,7 +44,7 @@ type AllergyResponses struct {
 
 type InsuranceResponse struct {
 	ID             uuid.UUID `json:"id"`
-	PatientID      uuid.UUID `json:"patient_id"`
+	PatientID      int64     `json:"patient_id"`
 	Provider       string    `json:"provider"`
 	PolicyNumber   string    `json:"policy_number"`
 	ExpirationDate time.Time `json:"expiration_date"`
@@ -57,7 +57,7 @@ type InsuranceResponses struct {
 
 type PrescriptionResponse struct {
 	ID         uuid.UUID `json:"id"`
-	PatientID  uuid.UUID `json:"patient_id"`
+	PatientID  int64     `json:"patient_id"`
 	DoctorID   uuid.UUID `json:"doctor_id"`
 	Medication string    `json:"medication"`
 	Dosage     string    `json:"dosage"`
@@ -7,7 +7,7 @@ import (
 
 type Insurance struct {
 	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
-	PatientID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	PatientID      int64     `gorm:"not null;index"`
 	Provider       string    `gorm:"not null"`        // Название страховой
 	PolicyNumber   string    `gorm:"unique;not null"` // Номер полиса
 	ExpirationDate time.Time `gorm:"type:date"`       // Срок действия
@@ -1,13 +1,10 @@
 package models
 
-import (
-	"github.com/google/uuid"
-	"time"
-)
+import "time"
 
 type Patient struct {
-	ID         int64       `gorm:"type:uuid;primaryKey"`
-	UserID     uuid.UUID   `gorm:"type:uuid;not null;index"` // Ссылка на User сервис
+	ID         int64       `gorm:"primaryKey"`
+	UserID     *int64      `gorm:"uniqueIndex;default:null"` // Пользователь auth, которому принадлежит карта; nil — карта не привязана
 	BloodType  string      `gorm:"type:varchar(5)"`          // Например: "A+", "O-"
 	Height     float64     `gorm:"type:decimal(5,2)"`        // Рост в см
 	Weight     float64     `gorm:"type:decimal(5,2)"`        // Вес в кг
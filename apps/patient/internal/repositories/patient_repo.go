@@ -1,16 +1,16 @@
 package repositories
 
 import (
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"patient/internal/models"
 )
 
 type PatientRepository interface {
 	Create(patient *models.Patient) error
-	GetByID(uuid.UUID) (*models.Patient, error)
+	GetByID(int64) (*models.Patient, error)
+	GetByUserID(userID int64) (*models.Patient, error)
 	Update(patient *models.Patient) error
-	Delete(uuid.UUID) error
+	Delete(int64) error
 	GetAll(i *[]models.Patient, limit int, offset int) error
 }
 
@@ -26,9 +26,17 @@ func (r *PatientRepo) Create(patient *models.Patient) error {
 	return r.db.Create(patient).Error
 }
 
-func (r *PatientRepo) GetByID(id uuid.UUID) (*models.Patient, error) {
+func (r *PatientRepo) GetByID(id int64) (*models.Patient, error) {
 	var patient models.Patient
-	if err := r.db.First(&patient, id).Error; err != nil {
+	if err := r.db.Preload("Allergies").Preload("Insurances").First(&patient, id).Error; err != nil {
+		return nil, err
+	}
+	return &patient, nil
+}
+
+func (r *PatientRepo) GetByUserID(userID int64) (*models.Patient, error) {
+	var patient models.Patient
+	if err := r.db.Where("user_id = ?", userID).First(&patient).Error; err != nil {
 		return nil, err
 	}
 	return &patient, nil
@@ -38,7 +46,7 @@ func (r *PatientRepo) Update(patient *models.Patient) error {
 	return r.db.Save(patient).Error
 }
 
-func (r *PatientRepo) Delete(id uuid.UUID) error {
+func (r *PatientRepo) Delete(id int64) error {
 	return r.db.Delete(&models.Patient{}, id).Error
 }
 
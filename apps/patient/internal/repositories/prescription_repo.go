@@ -11,21 +11,18 @@ type PrescriptionRepository interface {
 	GetByID(uuid.UUID) (*models.Prescription, error)
 	Update(prescription *models.Prescription) error
 	Delete(uuid.UUID) error
-	GetByPatient(uuid.UUID) ([]models.Prescription, error)
+	GetByPatient(patientID int64) ([]models.Prescription, error)
 }
 
 type PrescriptionRepo struct {
 	db *gorm.DB
 }
 
-func (r *PrescriptionRepo) GetByPatient(u uuid.UUID) ([]models.Prescription, error) {
+func (r *PrescriptionRepo) GetByPatient(patientID int64) ([]models.Prescription, error) {
 	var prescriptions []models.Prescription
-	if err := r.db.Where("patient_id = ?", u).Find(&prescriptions).Error; err != nil {
+	if err := r.db.Where("patient_id = ?", patientID).Find(&prescriptions).Error; err != nil {
 		return nil, err
 	}
-	if len(prescriptions) == 0 {
-		return nil, gorm.ErrRecordNotFound
-	}
 	return prescriptions, nil
 }
 
@@ -1,7 +1,6 @@
 package services
 
 import (
-	"github.com/google/uuid"
 	"patient/internal/dto"
 	"patient/internal/models"
 	"patient/internal/repositories"
@@ -9,18 +8,19 @@ import (
 
 type PatientService interface {
 	GetPatientByID(req *dto.GetPatientRequest) (*dto.PatientResponse, error)
+	GetPatientByUserID(userID int64) (*dto.PatientResponse, error)
 	GetPatientAllergies(req *dto.GetPatientRequest) (*dto.AllergyResponses, error)
 	GetPatientInsurances(req *dto.GetPatientRequest) (*dto.InsuranceResponses, error)
 	GetPatientPrescriptions(req *dto.GetPatientRequest) (*dto.PrescriptionResponses, error)
 	CreatePatient(req *dto.CreatePatientRequest) (*dto.PatientResponse, error)
 	UpdatePatient(req *dto.UpdatePatientRequest) (*dto.PatientResponse, error)
-	DeletePatient(req uuid.UUID) *dto.PatientResponse
+	DeletePatient(id int64) error
 	AddPatientAllergy(req *dto.CreateAllergyRequest) (*dto.PatientResponse, error)
 	AddPatientInsurance(req *dto.CreateInsuranceRequest) (*dto.PatientResponse, error)
 	AddPatientPrescription(req *dto.CreatePrescriptionRequest) (*dto.PatientResponse, error)
-	DeletePatientAllergy(req *dto.DeleteAllergyRequest) (*dto.PatientResponse, error)
-	DeletePatientInsurance(req *dto.DeleteInsuranceRequest) (*dto.PatientResponse, error)
-	DeletePatientPrescription(req *dto.DeletePrescriptionRequest) (*dto.PatientResponse, error)
+	DeletePatientAllergy(req *dto.DeleteAllergyRequest) error
+	DeletePatientInsurance(req *dto.DeleteInsuranceRequest) error
+	DeletePatientPrescription(req *dto.DeletePrescriptionRequest) error
 	GetAllPatients(limit, offset int) (*dto.PatientResponses, error)
 }
 
@@ -32,9 +32,8 @@ type patientService struct {
 	prescriptionService PrescriptionService
 }
 
-func (p patientService) DeletePatient(req uuid.UUID) *dto.PatientResponse {
-	//TODO implement me
-	panic("implement me")
+func (p patientService) DeletePatient(id int64) error {
+	return p.repo.Delete(id)
 }
 
 func (p patientService) GetPatientByID(req *dto.GetPatientRequest) (*dto.PatientResponse, error) {
@@ -58,6 +57,27 @@ func (p patientService) GetPatientByID(req *dto.GetPatientRequest) (*dto.Patient
 	}, nil
 }
 
+func (p patientService) GetPatientByUserID(userID int64) (*dto.PatientResponse, error) {
+	// Retrieve the patient of the auth user
+	patient, err := p.repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the patient to DTO
+	return &dto.PatientResponse{
+		ID:         patient.ID,
+		UserID:     patient.UserID,
+		BloodType:  patient.BloodType,
+		Height:     patient.Height,
+		Weight:     patient.Weight,
+		Allergies:  *dto.NewAllergyResponses(patient.Allergies),
+		Insurances: *dto.NewInsuranceResponses(patient.Insurances),
+		CreatedAt:  patient.CreatedAt,
+		UpdatedAt:  patient.UpdatedAt,
+	}, nil
+}
+
 func (p patientService) GetPatientAllergies(req *dto.GetPatientRequest) (*dto.AllergyResponses, error) {
 	// Retrieve the patient by ID
 	patient, err := p.repo.GetByID(req.PatientID)
@@ -93,7 +113,7 @@ func (p patientService) GetPatientPrescriptions(req *dto.GetPatientRequest) (*dt
 func (p patientService) CreatePatient(req *dto.CreatePatientRequest) (*dto.PatientResponse, error) {
 	// Map the request DTO to the model
 	patient := &models.Patient{
-		UserID:    req.UserID,
+		UserID:    &req.UserID,
 		BloodType: req.BloodType,
 		Height:    req.Height,
 		Weight:    req.Weight,
@@ -232,85 +252,19 @@ func (p patientService) AddPatientPrescription(req *dto.CreatePrescriptionReques
 	}, nil
 }
 
-func (p patientService) DeletePatientAllergy(req *dto.DeleteAllergyRequest) (*dto.PatientResponse, error) {
+func (p patientService) DeletePatientAllergy(req *dto.DeleteAllergyRequest) error {
 	// Delete the allergy through AllergyService
-	err := p.allergyService.Delete(req)
-	if err != nil {
-		return nil, err
-	}
-
-	// Retrieve the updated patient
-	patient, err := p.repo.GetByID(req.AllergyID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert the patient to DTO
-	return &dto.PatientResponse{
-		ID:         patient.ID,
-		UserID:     patient.UserID,
-		BloodType:  patient.BloodType,
-		Height:     patient.Height,
-		Weight:     patient.Weight,
-		Allergies:  *dto.NewAllergyResponses(patient.Allergies),
-		Insurances: *dto.NewInsuranceResponses(patient.Insurances),
-		CreatedAt:  patient.CreatedAt,
-		UpdatedAt:  patient.UpdatedAt,
-	}, nil
+	return p.allergyService.Delete(req)
 }
 
-func (p patientService) DeletePatientInsurance(req *dto.DeleteInsuranceRequest) (*dto.PatientResponse, error) {
+func (p patientService) DeletePatientInsurance(req *dto.DeleteInsuranceRequest) error {
 	// Delete the insurance through InsuranceService
-	err := p.insuranceService.Delete(req)
-	if err != nil {
-		return nil, err
-	}
-
-	// Retrieve the updated patient
-	patient, err := p.repo.GetByID(req.InsuranceID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert the patient to DTO
-	return &dto.PatientResponse{
-		ID:         patient.ID,
-		UserID:     patient.UserID,
-		BloodType:  patient.BloodType,
-		Height:     patient.Height,
-		Weight:     patient.Weight,
-		Allergies:  *dto.NewAllergyResponses(patient.Allergies),
-		Insurances: *dto.NewInsuranceResponses(patient.Insurances),
-		CreatedAt:  patient.CreatedAt,
-		UpdatedAt:  patient.UpdatedAt,
-	}, nil
+	return p.insuranceService.Delete(req)
 }
 
-func (p patientService) DeletePatientPrescription(req *dto.DeletePrescriptionRequest) (*dto.PatientResponse, error) {
+func (p patientService) DeletePatientPrescription(req *dto.DeletePrescriptionRequest) error {
 	// Delete the prescription through PrescriptionService
-	err := p.prescriptionService.Delete(req)
-	if err != nil {
-		return nil, err
-	}
-
-	// Retrieve the updated patient
-	patient, err := p.repo.GetByID(req.PrescriptionID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert the patient to DTO
-	return &dto.PatientResponse{
-		ID:         patient.ID,
-		UserID:     patient.UserID,
-		BloodType:  patient.BloodType,
-		Height:     patient.Height,
-		Weight:     patient.Weight,
-		Allergies:  *dto.NewAllergyResponses(patient.Allergies),
-		Insurances: *dto.NewInsuranceResponses(patient.Insurances),
-		CreatedAt:  patient.CreatedAt,
-		UpdatedAt:  patient.UpdatedAt,
-	}, nil
+	return p.prescriptionService.Delete(req)
 }
 
 func (p patientService) GetAllPatients(limit, offset int) (*dto.PatientResponses, error) {
@@ -10,7 +10,7 @@ import (
 type PrescriptionService interface {
 	Create(req *dto.CreatePrescriptionRequest) (*dto.PrescriptionResponse, error)
 	Delete(req *dto.DeletePrescriptionRequest) error
-	GetByPatient(id uuid.UUID) (*dto.PrescriptionResponses, error)
+	GetByPatient(patientID int64) (*dto.PrescriptionResponses, error)
 }
 
 type prescriptionService struct {
@@ -46,8 +46,8 @@ func (p prescriptionService) Delete(req *dto.DeletePrescriptionRequest) error {
 
 }
 
-func (p prescriptionService) GetByPatient(id uuid.UUID) (*dto.PrescriptionResponses, error) {
-	prescriptions, err := p.repo.GetByPatient(id)
+func (p prescriptionService) GetByPatient(patientID int64) (*dto.PrescriptionResponses, error) {
+	prescriptions, err := p.repo.GetByPatient(patientID)
 
 	if err != nil {
 		return nil, err
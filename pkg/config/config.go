@@ -11,6 +11,7 @@ type Config struct {
 	Logger   LoggerConfig
 	Nats     NatsConfig
 	Redis    RedisConfig
+	Tracing  TracingConfig
 }
 
 type DatabaseConfig struct {
@@ -47,3 +48,11 @@ type RedisConfig struct {
 	Password string // Redis password to authenticate
 	DB       int    // Redis database number
 }
+
+type TracingConfig struct {
+	Exporter    string  // Span exporter: "none", "otlp", "stdout" or "file"
+	Endpoint    string  // OTLP collector endpoint (gRPC), host:port
+	FilePath    string  // Output file for the "file" exporter
+	SampleRatio float64 // Fraction of new traces to sample, from 0 to 1
+	ServiceName string  // Service name attached to every span
+}
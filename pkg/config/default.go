@@ -37,6 +37,12 @@ func GetDefaultConfiguration() (*Config, error) {
 	redisPassword := GetEnvWithDefault("REDIS_PASSWORD", "")
 	redisDB := GetEnvWithDefault("REDIS_DB", "0")
 
+	tracingExporter := GetEnvWithDefault("TRACING_EXPORTER", "none")
+	tracingEndpoint := GetEnvWithDefault("TRACING_OTLP_ENDPOINT", "localhost:4317")
+	tracingFile := GetEnvWithDefault("TRACING_FILE", "traces.json")
+	tracingSampleRatio := GetEnvWithDefault("TRACING_SAMPLE_RATIO", "1")
+	tracingServiceName := GetEnvWithDefault("TRACING_SERVICE_NAME", loggerName)
+
 	appPortInt, err := strconv.Atoi(appPort)
 	if err != nil {
 		return nil, fmt.Errorf("invalid APP_PORT value: %w", err)
@@ -62,6 +68,11 @@ func GetDefaultConfiguration() (*Config, error) {
 		return nil, fmt.Errorf("invalid REDIS_DB value: %w", err)
 	}
 
+	tracingSampleRatioFloat, err := strconv.ParseFloat(tracingSampleRatio, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO value: %w", err)
+	}
+
 	dbConfig := DatabaseConfig{
 		Host:     dbHost,
 		Port:     dbPortInt,
@@ -97,6 +108,14 @@ func GetDefaultConfiguration() (*Config, error) {
 		DB:       redisDBInt,
 	}
 
+	tracingConfig := TracingConfig{
+		Exporter:    tracingExporter,
+		Endpoint:    tracingEndpoint,
+		FilePath:    tracingFile,
+		SampleRatio: tracingSampleRatioFloat,
+		ServiceName: tracingServiceName,
+	}
+
 	if err := dbConfig.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid database configuration: %w", err)
 	}
@@ -117,12 +136,17 @@ func GetDefaultConfiguration() (*Config, error) {
 		return nil, fmt.Errorf("invalid Redis configuration: %w", err)
 	}
 
+	if err := tracingConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tracing configuration: %w", err)
+	}
+
 	return &Config{
 		Database: dbConfig,
 		Backend:  backendConfig,
 		Logger:   loggerConfig,
 		Nats:     natsConfig,
 		Redis:    redisConfig,
+		Tracing:  tracingConfig,
 	}, nil
 }
 
@@ -104,3 +104,23 @@ func (suite *DefaultConfigTestSuite) TestDefaultConfig_DatabaseConfig_Invalid()
 	_, err := GetDefaultConfiguration()
 	suite.Error(err, "Expected error when DB_PORT is invalid")
 }
+
+func (suite *DefaultConfigTestSuite) TestDefaultConfig_Tracing_NoEnv() {
+	config, err := GetDefaultConfiguration()
+	suite.NoError(err, "Expected no error when getting default configuration")
+	suite.Equal("none", config.Tracing.Exporter, "Expected default TRACING_EXPORTER to be none")
+	suite.Equal(1.0, config.Tracing.SampleRatio, "Expected default TRACING_SAMPLE_RATIO to be 1")
+	suite.Equal("default", config.Tracing.ServiceName, "Expected default TRACING_SERVICE_NAME to follow LOGGER_NAME")
+}
+
+func (suite *DefaultConfigTestSuite) TestDefaultConfig_TracingSampleRatio_Invalid() {
+	_ = os.Setenv("TRACING_SAMPLE_RATIO", "invalid")
+	_, err := GetDefaultConfiguration()
+	suite.Error(err, "Expected error when TRACING_SAMPLE_RATIO is invalid")
+}
+
+func (suite *DefaultConfigTestSuite) TestDefaultConfig_TracingExporter_Invalid() {
+	_ = os.Setenv("TRACING_EXPORTER", "invalid")
+	_, err := GetDefaultConfiguration()
+	suite.Error(err, "Expected error when TRACING_EXPORTER is invalid")
+}
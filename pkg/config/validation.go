@@ -136,3 +136,32 @@ func (c RedisConfig) Validate() error {
 	}
 	return nil
 }
+
+func (c TracingConfig) Validate() error {
+	var errs []error
+
+	switch c.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Endpoint == "" {
+			errs = append(errs, fmt.Errorf("tracing otlp endpoint cannot be empty"))
+		}
+	case "file":
+		if c.FilePath == "" {
+			errs = append(errs, fmt.Errorf("tracing file path cannot be empty"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid tracing exporter: %s", c.Exporter))
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing sample ratio must be between 0 and 1"))
+	}
+	if c.ServiceName == "" {
+		errs = append(errs, fmt.Errorf("tracing service name cannot be empty"))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
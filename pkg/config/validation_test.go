@@ -293,3 +293,71 @@ func (suite *NatsConfigValidationTestSuite) TestEmptyUrl() {
 	cfg.Url = ""
 	suite.Error(cfg.Validate())
 }
+
+type TracingConfigValidationTestSuite struct {
+	suite.Suite
+	ValidConfig *config.TracingConfig
+}
+
+func TestTracingConfigValidation(t *testing.T) {
+	suite.Run(t, new(TracingConfigValidationTestSuite))
+}
+
+func (suite *TracingConfigValidationTestSuite) SetupTest() {
+	suite.ValidConfig = &config.TracingConfig{
+		Exporter:    "otlp",
+		Endpoint:    "localhost:4317",
+		FilePath:    "traces.json",
+		SampleRatio: 1,
+		ServiceName: "test",
+	}
+}
+
+func (suite *TracingConfigValidationTestSuite) TestValidExporters() {
+	for _, exporter := range []string{"none", "otlp", "stdout", "file"} {
+		cfg := *suite.ValidConfig
+		cfg.Exporter = exporter
+		suite.NoError(cfg.Validate(), "Expected no error for exporter: %s", exporter)
+	}
+}
+
+func (suite *TracingConfigValidationTestSuite) TestInvalidExporter() {
+	cfg := *suite.ValidConfig
+	cfg.Exporter = "zipkin"
+	err := cfg.Validate()
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid tracing exporter")
+}
+
+func (suite *TracingConfigValidationTestSuite) TestOtlpEmptyEndpoint() {
+	cfg := *suite.ValidConfig
+	cfg.Endpoint = ""
+	err := cfg.Validate()
+	suite.Error(err)
+	suite.Contains(err.Error(), "tracing otlp endpoint cannot be empty")
+}
+
+func (suite *TracingConfigValidationTestSuite) TestFileEmptyPath() {
+	cfg := *suite.ValidConfig
+	cfg.Exporter = "file"
+	cfg.FilePath = ""
+	err := cfg.Validate()
+	suite.Error(err)
+	suite.Contains(err.Error(), "tracing file path cannot be empty")
+}
+
+func (suite *TracingConfigValidationTestSuite) TestSampleRatioOutOfRange() {
+	cfg := *suite.ValidConfig
+	cfg.SampleRatio = 1.5
+	err := cfg.Validate()
+	suite.Error(err)
+	suite.Contains(err.Error(), "tracing sample ratio must be between 0 and 1")
+}
+
+func (suite *TracingConfigValidationTestSuite) TestEmptyServiceName() {
+	cfg := *suite.ValidConfig
+	cfg.ServiceName = ""
+	err := cfg.Validate()
+	suite.Error(err)
+	suite.Contains(err.Error(), "tracing service name cannot be empty")
+}
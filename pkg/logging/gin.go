@@ -40,6 +40,9 @@ func GinLogger(logger logrus.FieldLogger) gin.HandlerFunc {
 			"dataLength": dataLength,
 			"userAgent":  clientUserAgent,
 		})
+		if requestID := RequestIDFromContext(c.Request.Context()); requestID != "" {
+			entry = entry.WithField(requestIDField, requestID)
+		}
 
 		if len(c.Errors) > 0 {
 			entry.Error(c.Errors.ByType(gin.ErrorTypePrivate).String())
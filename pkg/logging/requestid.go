@@ -0,0 +1,159 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is the HTTP and NATS header carrying the correlation ID.
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDMetadataKey is the gRPC metadata key carrying the correlation ID.
+// gRPC metadata keys are always lowercase.
+const requestIDMetadataKey = "x-request-id"
+
+// requestIDField is the log field holding the correlation ID.
+const requestIDField = "requestId"
+
+// ginLoggerKey is the gin.Context key of the request-scoped log entry.
+const ginLoggerKey = "logger"
+
+// maxRequestIDLength limits correlation IDs taken from clients.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// NewRequestID generates a new correlation ID.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// ValidRequestID reports whether an incoming correlation ID can be used as is:
+// at most 128 characters of letters, digits, '.', '_' and '-'.
+// Anything else would let a client inject arbitrary text into logs and response headers.
+func ValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		ch := requestID[i]
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9':
+		case ch == '.', ch == '_', ch == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID returns a copy of ctx carrying the correlation ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the correlation ID stored in ctx, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// FromContext returns a log entry tagged with the correlation ID from ctx.
+// Without an ID, the entry is still usable and logs like Logger.
+func FromContext(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(Logger)
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		entry = entry.WithField(requestIDField, requestID)
+	}
+	return entry
+}
+
+// RequestIDMiddleware takes the correlation ID from the X-Request-Id header, or creates one
+// when the header is missing or fails ValidRequestID. The ID is echoed in the response, stored in the request context
+// and a request-scoped log entry is available through GinEntry.
+// Must be registered before GinLogger, so the access log contains the ID.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !ValidRequestID(requestID) {
+			requestID = NewRequestID()
+			c.Request.Header.Set(RequestIDHeader, requestID)
+		}
+
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), requestID))
+		c.Writer.Header().Set(RequestIDHeader, requestID)
+		c.Set(ginLoggerKey, FromContext(c.Request.Context()))
+
+		c.Next()
+	}
+}
+
+// GinEntry returns the request-scoped log entry created by RequestIDMiddleware.
+// Falls back to an entry built from the request context when the middleware is not registered.
+func GinEntry(c *gin.Context) *logrus.Entry {
+	if entry, ok := c.Get(ginLoggerKey); ok {
+		return entry.(*logrus.Entry)
+	}
+	return FromContext(c.Request.Context())
+}
+
+// UnaryServerRequestIDInterceptor reads the correlation ID from incoming gRPC metadata,
+// or creates one when it is missing or invalid, and stores it in the handler context.
+func UnaryServerRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+		if !ValidRequestID(requestID) {
+			requestID = NewRequestID()
+		}
+		return handler(WithRequestID(ctx, requestID), req)
+	}
+}
+
+// UnaryClientRequestIDInterceptor copies the correlation ID from ctx into outgoing gRPC metadata.
+func UnaryClientRequestIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if requestID := RequestIDFromContext(ctx); requestID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, requestID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// InjectRequestIDNats copies the correlation ID from ctx into the message headers.
+func InjectRequestIDNats(ctx context.Context, msg *nats.Msg) {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		return
+	}
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	msg.Header.Set(RequestIDHeader, requestID)
+}
+
+// ExtractRequestIDNats returns a copy of ctx carrying the correlation ID from the message headers.
+// Messages without a valid header get a fresh ID, so consumer logs can still be grouped.
+func ExtractRequestIDNats(ctx context.Context, msg *nats.Msg) context.Context {
+	requestID := ""
+	if msg.Header != nil {
+		requestID = msg.Header.Get(RequestIDHeader)
+	}
+	if !ValidRequestID(requestID) {
+		requestID = NewRequestID()
+	}
+	return WithRequestID(ctx, requestID)
+}
@@ -0,0 +1,149 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	logconf "github.com/Ruletk/OnlineClinic/pkg/config/logging"
+	"github.com/Ruletk/OnlineClinic/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type RequestIDTestSuite struct {
+	suite.Suite
+	buf *bytes.Buffer
+}
+
+func TestRequestIDSuite(t *testing.T) {
+	suite.Run(t, new(RequestIDTestSuite))
+}
+
+func (s *RequestIDTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.buf = new(bytes.Buffer)
+	logging.InitLogger(config.Config{
+		Logger: config.LoggerConfig{
+			Format: logconf.JSON,
+			Output: s.buf,
+		},
+	})
+}
+
+func (s *RequestIDTestSuite) newRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(logging.RequestIDMiddleware())
+	r.GET("/", func(c *gin.Context) {
+		logging.GinEntry(c).Info("handler")
+		c.String(http.StatusOK, logging.RequestIDFromContext(c.Request.Context()))
+	})
+	return r
+}
+
+func (s *RequestIDTestSuite) TestMiddleware_KeepsIncomingID() {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(logging.RequestIDHeader, "incoming-id")
+	w := httptest.NewRecorder()
+
+	s.newRouter().ServeHTTP(w, req)
+
+	s.Equal("incoming-id", w.Body.String(), "Handler context should carry the incoming ID")
+	s.Equal("incoming-id", w.Header().Get(logging.RequestIDHeader), "Response should echo the ID")
+
+	var entry map[string]interface{}
+	s.Require().NoError(json.Unmarshal(s.buf.Bytes(), &entry))
+	s.Equal("incoming-id", entry["requestId"], "Request-scoped entry should contain the ID")
+}
+
+func (s *RequestIDTestSuite) TestMiddleware_GeneratesID() {
+	w := httptest.NewRecorder()
+	s.newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.NotEmpty(w.Body.String(), "A new ID should be generated")
+	s.Equal(w.Body.String(), w.Header().Get(logging.RequestIDHeader))
+}
+
+func (s *RequestIDTestSuite) TestMiddleware_ReplacesInvalidID() {
+	for _, requestID := range []string{
+		"id with spaces",
+		"id\r\nX-Injected: 1",
+		"{\"json\":true}",
+		strings.Repeat("a", 129),
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header[logging.RequestIDHeader] = []string{requestID}
+		w := httptest.NewRecorder()
+
+		s.newRouter().ServeHTTP(w, req)
+
+		s.NotEqual(requestID, w.Body.String(), "Invalid ID %q should be replaced", requestID)
+		s.True(logging.ValidRequestID(w.Body.String()), "Generated ID should be valid")
+		s.Equal(w.Body.String(), w.Header().Get(logging.RequestIDHeader))
+	}
+}
+
+func (s *RequestIDTestSuite) TestValidRequestID() {
+	s.True(logging.ValidRequestID("incoming-id"))
+	s.True(logging.ValidRequestID("Trace.42_a-B"))
+	s.True(logging.ValidRequestID(strings.Repeat("a", 128)))
+	s.True(logging.ValidRequestID(logging.NewRequestID()))
+	s.False(logging.ValidRequestID(""))
+	s.False(logging.ValidRequestID(strings.Repeat("a", 129)))
+	s.False(logging.ValidRequestID("a/b"))
+	s.False(logging.ValidRequestID("идентификатор"))
+}
+
+func (s *RequestIDTestSuite) TestFromContext_WithoutID() {
+	logging.FromContext(context.Background()).Info("no id")
+
+	var entry map[string]interface{}
+	s.Require().NoError(json.Unmarshal(s.buf.Bytes(), &entry))
+	s.NotContains(entry, "requestId")
+}
+
+func (s *RequestIDTestSuite) TestNatsRoundTrip() {
+	msg := nats.NewMsg("email.message")
+	logging.InjectRequestIDNats(logging.WithRequestID(context.Background(), "nats-id"), msg)
+	s.Equal("nats-id", msg.Header.Get(logging.RequestIDHeader))
+
+	ctx := logging.ExtractRequestIDNats(context.Background(), msg)
+	s.Equal("nats-id", logging.RequestIDFromContext(ctx))
+}
+
+func (s *RequestIDTestSuite) TestNatsExtract_GeneratesID() {
+	ctx := logging.ExtractRequestIDNats(context.Background(), &nats.Msg{Subject: "email.message"})
+	s.NotEmpty(logging.RequestIDFromContext(ctx))
+}
+
+func (s *RequestIDTestSuite) TestGRPCClientInterceptor() {
+	ctx := logging.WithRequestID(context.Background(), "grpc-id")
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		s.Require().True(ok, "Outgoing metadata should be set")
+		s.Equal([]string{"grpc-id"}, md.Get("x-request-id"))
+		return nil
+	}
+
+	err := logging.UnaryClientRequestIDInterceptor()(ctx, "/test", nil, nil, nil, invoker)
+	s.NoError(err)
+}
+
+func (s *RequestIDTestSuite) TestGRPCServerInterceptor() {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "grpc-id"))
+	handler := func(ctx context.Context, req any) (any, error) {
+		return logging.RequestIDFromContext(ctx), nil
+	}
+
+	resp, err := logging.UnaryServerRequestIDInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	s.NoError(err)
+	s.Equal("grpc-id", resp)
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var cacheLookups = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cache_lookups_total",
+		Help: "Number of cache lookups by result",
+	},
+	[]string{"cache", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(cacheLookups)
+}
+
+// CacheHit counts a lookup of cache that was answered from the cache.
+func CacheHit(cache string) {
+	cacheLookups.WithLabelValues(cache, "hit").Inc()
+}
+
+// CacheMiss counts a lookup of cache that had to go to the source.
+func CacheMiss(cache string) {
+	cacheLookups.WithLabelValues(cache, "miss").Inc()
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcServerHandled = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Duration of RPCs handled by the server",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"grpc_service", "grpc_method", "grpc_code"},
+	)
+	grpcClientHandled = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_client_handling_seconds",
+			Help:    "Duration of RPCs sent by the client, until the response is received",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"grpc_service", "grpc_method", "grpc_code"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(grpcServerHandled, grpcClientHandled)
+}
+
+// GRPCServerOptions returns server options that record the duration and status code of each incoming RPC.
+// Streams are recorded once they end.
+func GRPCServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			start := time.Now()
+			resp, err := handler(ctx, req)
+			observeRPC(grpcServerHandled, info.FullMethod, start, err)
+			return resp, err
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+			err := handler(srv, ss)
+			observeRPC(grpcServerHandled, info.FullMethod, start, err)
+			return err
+		}),
+	}
+}
+
+// GRPCDialOptions returns dial options that record the duration and status code of each outgoing unary RPC.
+func GRPCDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			start := time.Now()
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			observeRPC(grpcClientHandled, method, start, err)
+			return err
+		}),
+	}
+}
+
+// observeRPC records one RPC, fullMethod is /<package>.<Service>/<Method>.
+func observeRPC(histogram *prometheus.HistogramVec, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	histogram.
+		WithLabelValues(service, method, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+}
+
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
@@ -0,0 +1,15 @@
+// Package metrics holds the Prometheus metrics shared by all services.
+// Metrics are registered in the default registry and served by Handler,
+// the job label added by Prometheus tells the services apart.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler serves every registered metric in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
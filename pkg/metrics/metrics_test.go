@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	requestDuration.Reset()
+	grpcServerHandled.Reset()
+	grpcClientHandled.Reset()
+	queryDuration.Reset()
+	natsPublished.Reset()
+	natsConsumed.Reset()
+	cacheLookups.Reset()
+}
+
+// scrape returns the exposition served by Handler.
+func (s *MetricsTestSuite) scrape() string {
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	s.Require().Equal(http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	s.Require().NoError(err)
+	return string(body)
+}
+
+func (s *MetricsTestSuite) TestGinMiddleware_RecordsRouteTemplate() {
+	r := gin.New()
+	r.Use(GinMiddleware())
+	r.GET("/patients/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+
+	for _, path := range []string{"/patients/1", "/patients/2", "/random/scan"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := s.scrape()
+	s.Contains(body, `http_request_duration_seconds_count{method="GET",path="/patients/:id",status="404"} 2`)
+	s.Contains(body, `http_request_duration_seconds_count{method="GET",path="unmatched",status="404"} 1`)
+	s.Contains(body, "http_requests_in_flight 0")
+}
+
+func (s *MetricsTestSuite) TestGRPC_RecordsServerAndClientCalls() {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(GRPCServerOptions()...)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("doctor.DoctorService", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	dialOptions := append(GRPCDialOptions(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOptions...)
+	s.Require().NoError(err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "doctor.DoctorService"})
+	s.Require().NoError(err)
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	s.Equal(codes.NotFound, status.Code(err))
+
+	body := s.scrape()
+	for _, side := range []string{"server", "client"} {
+		s.Contains(body, `grpc_`+side+`_handling_seconds_count{grpc_code="OK",grpc_method="Check",grpc_service="grpc.health.v1.Health"} 1`)
+		s.Contains(body, `grpc_`+side+`_handling_seconds_count{grpc_code="NotFound",grpc_method="Check",grpc_service="grpc.health.v1.Health"} 1`)
+	}
+}
+
+func (s *MetricsTestSuite) TestInstrumentGorm_RecordsOperationAndTable() {
+	type Doctor struct {
+		ID   uint
+		Name string
+	}
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	s.Require().NoError(err)
+	s.Require().NoError(InstrumentGorm(db))
+
+	var doctor Doctor
+	db.First(&doctor)
+	db.Create(&Doctor{Name: "House"})
+
+	body := s.scrape()
+	s.Contains(body, `db_query_duration_seconds_count{operation="query",status="ok",table="doctors"} 1`)
+	s.Contains(body, `db_query_duration_seconds_count{operation="create",status="ok",table="doctors"} 1`)
+}
+
+func (s *MetricsTestSuite) TestNats() {
+	NatsPublished("email.message", nil)
+	NatsPublished("email.message", errors.New("connection closed"))
+
+	handled := false
+	NatsHandler(func(msg *nats.Msg) { handled = true })(&nats.Msg{Subject: "email.message"})
+	s.True(handled)
+
+	s.Equal(1.0, testutil.ToFloat64(natsPublished.WithLabelValues("email.message", "ok")))
+	s.Equal(1.0, testutil.ToFloat64(natsPublished.WithLabelValues("email.message", "error")))
+	s.Contains(s.scrape(), `nats_message_handling_seconds_count{subject="email.message"} 1`)
+}
+
+func (s *MetricsTestSuite) TestCache() {
+	CacheHit("appointment")
+	CacheHit("appointment")
+	CacheMiss("appointment")
+
+	s.Equal(2.0, testutil.ToFloat64(cacheLookups.WithLabelValues("appointment", "hit")))
+	s.Equal(1.0, testutil.ToFloat64(cacheLookups.WithLabelValues("appointment", "miss")))
+}
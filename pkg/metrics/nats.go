@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	natsPublished = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_messages_published_total",
+			Help: "Number of messages published to NATS",
+		},
+		[]string{"subject", "status"},
+	)
+	natsConsumed = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "nats_message_handling_seconds",
+			Help:    "Duration of handling messages consumed from NATS",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"subject"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(natsPublished, natsConsumed)
+}
+
+// NatsPublished counts a message published to subject, err is the result of the publish.
+// Subjects carrying IDs must be passed without them, every subject is its own series.
+func NatsPublished(subject string, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	natsPublished.WithLabelValues(subject, status).Inc()
+}
+
+// NatsHandler wraps handler to record each consumed message and the time spent on it.
+func NatsHandler(handler nats.MsgHandler) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		start := time.Now()
+		handler(msg)
+		natsConsumed.WithLabelValues(msg.Subject).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// GatewayTokenCookie is the cookie the gateway exchanges for an access token.
+const GatewayTokenCookie = "token"
+
+// Merge combines service documents into the public document of the gateway.
+// docs is keyed by the service name, which is also its public path prefix: the auth
+// service /login becomes /auth/login. Untagged operations are tagged with the service name.
+// Secured operations authenticate with the gateway cookie instead of the internal header.
+func Merge(title, version string, docs map[string]*openapi3.T) *openapi3.T {
+	merged := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: title, Version: version},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				SecuritySchemeName: &openapi3.SecuritySchemeRef{
+					Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("cookie").WithName(GatewayTokenCookie),
+				},
+			},
+		},
+	}
+
+	names := make([]string, 0, len(docs))
+	for name := range docs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		doc := docs[name]
+		merged.Tags = append(merged.Tags, &openapi3.Tag{Name: name, Description: doc.Info.Title})
+		if doc.Paths == nil {
+			continue
+		}
+		for path, item := range doc.Paths.Map() {
+			for _, operation := range item.Operations() {
+				if len(operation.Tags) == 0 {
+					operation.Tags = []string{name}
+				}
+				if operation.OperationID != "" {
+					operation.OperationID = name + strings.ToUpper(operation.OperationID[:1]) + operation.OperationID[1:]
+				}
+			}
+			merged.Paths.Set("/"+name+path, item)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,288 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// SecuritySchemeName is the name of the access token scheme used by secured operations.
+	SecuritySchemeName = "accessToken"
+	// AccessTokenHeader carries the access token from the gateway to the services.
+	AccessTokenHeader = "X-Access-Token"
+)
+
+// Operation describes one route of a service.
+type Operation struct {
+	Method    string
+	Path      string // Gin route path, e.g. /patients/:id
+	Summary   string
+	Tags      []string
+	Request   any         // JSON body, nil when the route has none
+	Query     any         // Struct with form tags describing the query parameters
+	Responses map[int]any // Response bodies by status, a nil body only documents the status
+	Secured   bool        // Requires the access token
+}
+
+// ErrorResponse is the {"error": "..."} body the Gin handlers answer with on failure.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Document is the OpenAPI 3 document of one service, built from its routes and DTOs.
+type Document struct {
+	spec *openapi3.T
+}
+
+// NewDocument creates an empty document with the access token security scheme.
+func NewDocument(title, version string) *Document {
+	return &Document{spec: &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: title, Version: version},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				SecuritySchemeName: &openapi3.SecuritySchemeRef{
+					Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("header").WithName(AccessTokenHeader),
+				},
+			},
+		},
+	}}
+}
+
+// Spec returns the underlying OpenAPI document.
+func (d *Document) Spec() *openapi3.T {
+	return d.spec
+}
+
+// Add documents ops. Schemas are generated from the Go types, fields with a
+// binding:"required" tag are marked required.
+func (d *Document) Add(ops ...Operation) error {
+	for _, op := range ops {
+		operation, err := newOperation(op)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", op.Method, op.Path, err)
+		}
+
+		path := ginPathToOpenAPI(op.Path)
+		item := d.spec.Paths.Value(path)
+		if item == nil {
+			item = &openapi3.PathItem{}
+			d.spec.Paths.Set(path, item)
+		}
+		item.SetOperation(strings.ToUpper(op.Method), operation)
+	}
+	return nil
+}
+
+// Handler serves the document as JSON.
+func (d *Document) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, d.spec)
+	}
+}
+
+// newOperation converts op to its OpenAPI form.
+// Support function for Add.
+func newOperation(op Operation) (*openapi3.Operation, error) {
+	operation := openapi3.NewOperation()
+	operation.Summary = op.Summary
+	operation.Tags = op.Tags
+	operation.OperationID = operationID(op.Method, op.Path)
+
+	for _, name := range pathParams(op.Path) {
+		operation.AddParameter(openapi3.NewPathParameter(name).WithSchema(openapi3.NewStringSchema()))
+	}
+
+	if op.Query != nil {
+		params, err := queryParams(op.Query)
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range params {
+			operation.AddParameter(param)
+		}
+	}
+
+	if op.Request != nil {
+		schema, err := schemaFor(op.Request)
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(schema),
+		}
+	}
+
+	// A responses object can't be empty, undocumented operations get the match-all default.
+	operation.Responses = openapi3.NewResponses()
+	if len(op.Responses) > 0 {
+		operation.Responses = openapi3.NewResponsesWithCapacity(len(op.Responses))
+	}
+	for _, status := range sortedStatuses(op.Responses) {
+		response := openapi3.NewResponse().WithDescription(http.StatusText(status))
+		if body := op.Responses[status]; body != nil {
+			schema, err := schemaFor(body)
+			if err != nil {
+				return nil, err
+			}
+			response.WithJSONSchemaRef(schema)
+		}
+		operation.AddResponse(status, response)
+	}
+
+	if op.Secured {
+		operation.Security = openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(SecuritySchemeName))
+	}
+	return operation, nil
+}
+
+// schemaFor generates an inline schema for the type of value.
+func schemaFor(value any) (*openapi3.SchemaRef, error) {
+	return openapi3gen.NewSchemaRefForValue(value, nil, openapi3gen.SchemaCustomizer(customizeSchema))
+}
+
+// customizeSchema fixes types the generator can't infer and adds validation rules from binding tags.
+func customizeSchema(_ string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	// uuid.UUID is a byte array serialized as a string.
+	if t.Kind() == reflect.Array && t.Name() == "UUID" {
+		*schema = *openapi3.NewUUIDSchema()
+	}
+
+	applyTagRules(tag, schema)
+
+	if t.Kind() == reflect.Struct {
+		schema.Required = requiredFields(t)
+	}
+	return nil
+}
+
+// applyTagRules adds the binding rules and the time_format of a field to its schema.
+func applyTagRules(tag reflect.StructTag, schema *openapi3.Schema) {
+	for _, rule := range strings.Split(tag.Get("binding"), ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "email":
+			schema.Format = "email"
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			for _, value := range strings.Fields(arg) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		}
+	}
+
+	if tag.Get("time_format") == time.DateOnly {
+		schema.Format = "date"
+	}
+}
+
+// requiredFields lists the JSON names of the fields of t tagged binding:"required".
+func requiredFields(t reflect.Type) []string {
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || !hasRule(field.Tag.Get("binding"), "required") {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		required = append(required, name)
+	}
+	return required
+}
+
+// queryParams builds query parameters from the form tags of a struct.
+func queryParams(value any) ([]*openapi3.Parameter, error) {
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query must be a struct, got %s", t.Kind())
+	}
+
+	var params []*openapi3.Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		schema, err := openapi3gen.NewSchemaRefForValue(reflect.Zero(field.Type).Interface(), nil, openapi3gen.SchemaCustomizer(customizeSchema))
+		if err != nil {
+			return nil, err
+		}
+		// The generator only sees the field type, the rules live in the field tag.
+		applyTagRules(field.Tag, schema.Value)
+		param := openapi3.NewQueryParameter(name).WithSchema(schema.Value)
+		param.Required = hasRule(field.Tag.Get("binding"), "required")
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+func hasRule(binding, rule string) bool {
+	for _, r := range strings.Split(binding, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// ginPathToOpenAPI turns /patients/:id into /patients/{id}.
+func ginPathToOpenAPI(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(path string) []string {
+	var params []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+		}
+	}
+	return params
+}
+
+// operationID builds a readable unique ID, e.g. GET /patients/:id/allergies becomes getPatientsIdAllergies.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == ':' || r == '*' || r == '-' || r == '_' || r == '{' || r == '}'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func sortedStatuses(responses map[int]any) []int {
+	statuses := make([]int, 0, len(responses))
+	for status := range responses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	return statuses
+}
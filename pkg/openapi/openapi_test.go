@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type createItemRequest struct {
+	Name    string    `json:"name" binding:"required"`
+	Email   string    `json:"email" binding:"required,email"`
+	OwnerID uuid.UUID `json:"owner_id"`
+	Status  string    `json:"status" binding:"oneof=active archived"`
+	Notes   string    `json:"notes,omitempty"`
+}
+
+type itemResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type listQuery struct {
+	Limit  int        `form:"limit" binding:"required"`
+	Offset int        `form:"offset"`
+	Search string     `form:"q"`
+	Order  string     `form:"order" binding:"omitempty,oneof=asc desc"`
+	Since  *time.Time `form:"since" time_format:"2006-01-02"`
+	Owner  string     `form:"owner" binding:"omitempty,uuid"`
+}
+
+type OpenAPITestSuite struct {
+	suite.Suite
+	doc *Document
+}
+
+func (s *OpenAPITestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.doc = NewDocument("Item service", "1.0.0")
+	s.Require().NoError(s.doc.Add(
+		Operation{
+			Method:    http.MethodPost,
+			Path:      "/items",
+			Summary:   "Create item",
+			Request:   createItemRequest{},
+			Responses: map[int]any{http.StatusCreated: itemResponse{}, http.StatusBadRequest: nil},
+			Secured:   true,
+		},
+		Operation{
+			Method:    http.MethodGet,
+			Path:      "/items",
+			Query:     listQuery{},
+			Responses: map[int]any{http.StatusOK: []itemResponse{}},
+		},
+		Operation{
+			Method:    http.MethodGet,
+			Path:      "/items/:id",
+			Responses: map[int]any{http.StatusOK: itemResponse{}, http.StatusNotFound: nil},
+		},
+	))
+}
+
+func TestOpenAPITestSuite(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+func (s *OpenAPITestSuite) TestDocumentIsValid() {
+	s.NoError(s.doc.Spec().Validate(context.Background()))
+}
+
+func (s *OpenAPITestSuite) TestPathParamsConverted() {
+	item := s.doc.Spec().Paths.Value("/items/{id}")
+	s.Require().NotNil(item)
+	s.Require().NotNil(item.Get)
+	s.Equal("getItemsId", item.Get.OperationID)
+
+	param := item.Get.Parameters.GetByInAndName("path", "id")
+	s.Require().NotNil(param)
+	s.True(param.Required)
+}
+
+func (s *OpenAPITestSuite) TestRequestSchemaFromBindingTags() {
+	post := s.doc.Spec().Paths.Value("/items").Post
+	s.Require().NotNil(post)
+	schema := post.RequestBody.Value.Content.Get("application/json").Schema.Value
+
+	s.ElementsMatch([]string{"name", "email"}, schema.Required)
+	s.Equal("email", schema.Properties["email"].Value.Format)
+	s.Equal("uuid", schema.Properties["owner_id"].Value.Format)
+	s.True(schema.Properties["owner_id"].Value.Type.Is("string"))
+	s.Equal([]any{"active", "archived"}, schema.Properties["status"].Value.Enum)
+}
+
+func (s *OpenAPITestSuite) TestResponses() {
+	post := s.doc.Spec().Paths.Value("/items").Post
+	s.Require().NotNil(post.Responses.Status(http.StatusCreated))
+	created := post.Responses.Status(http.StatusCreated).Value.Content.Get("application/json").Schema.Value
+	s.Equal("date-time", created.Properties["created_at"].Value.Format)
+
+	badRequest := post.Responses.Status(http.StatusBadRequest)
+	s.Require().NotNil(badRequest)
+	s.Nil(badRequest.Value.Content)
+	s.Nil(post.Responses.Default())
+}
+
+func (s *OpenAPITestSuite) TestQueryParams() {
+	get := s.doc.Spec().Paths.Value("/items").Get
+	limit := get.Parameters.GetByInAndName("query", "limit")
+	s.Require().NotNil(limit)
+	s.True(limit.Required)
+	s.True(limit.Schema.Value.Type.Is("integer"))
+	s.NotNil(get.Parameters.GetByInAndName("query", "q"))
+
+	order := get.Parameters.GetByInAndName("query", "order")
+	s.Require().NotNil(order)
+	s.Equal([]any{"asc", "desc"}, order.Schema.Value.Enum)
+
+	since := get.Parameters.GetByInAndName("query", "since")
+	s.Require().NotNil(since)
+	s.Equal("date", since.Schema.Value.Format)
+
+	owner := get.Parameters.GetByInAndName("query", "owner")
+	s.Require().NotNil(owner)
+	s.Equal("uuid", owner.Schema.Value.Format)
+}
+
+func (s *OpenAPITestSuite) TestSecurity() {
+	s.NotNil(s.doc.Spec().Paths.Value("/items").Post.Security)
+	s.Nil(s.doc.Spec().Paths.Value("/items").Get.Security)
+
+	scheme := s.doc.Spec().Components.SecuritySchemes[SecuritySchemeName].Value
+	s.Equal("header", scheme.In)
+	s.Equal(AccessTokenHeader, scheme.Name)
+}
+
+func (s *OpenAPITestSuite) TestInvalidQuery() {
+	err := s.doc.Add(Operation{Method: http.MethodGet, Path: "/bad", Query: 5})
+	s.Error(err)
+}
+
+func (s *OpenAPITestSuite) TestHandler() {
+	router := gin.New()
+	router.GET("/openapi.json", s.doc.Handler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	s.Equal(http.StatusOK, w.Code)
+
+	loaded, err := openapi3.NewLoader().LoadFromData(w.Body.Bytes())
+	s.Require().NoError(err)
+	s.NotNil(loaded.Paths.Value("/items/{id}"))
+}
+
+func (s *OpenAPITestSuite) TestMerge() {
+	other := NewDocument("Other service", "1.0.0")
+	s.Require().NoError(other.Add(Operation{Method: http.MethodGet, Path: "/ping", Tags: []string{"health"}}))
+
+	// Round trip through JSON, like the gateway does with the served documents.
+	data, err := json.Marshal(s.doc.Spec())
+	s.Require().NoError(err)
+	items, err := openapi3.NewLoader().LoadFromData(data)
+	s.Require().NoError(err)
+
+	merged := Merge("Gateway", "1.0.0", map[string]*openapi3.T{"items": items, "other": other.Spec()})
+	s.NoError(merged.Validate(context.Background()))
+
+	item := merged.Paths.Value("/items/items/{id}")
+	s.Require().NotNil(item)
+	s.Equal([]string{"items"}, item.Get.Tags)
+	s.Equal("itemsGetItemsId", item.Get.OperationID)
+	s.Equal([]string{"health"}, merged.Paths.Value("/other/ping").Get.Tags)
+	s.Len(merged.Tags, 2)
+
+	scheme := merged.Components.SecuritySchemes[SecuritySchemeName].Value
+	s.Equal("cookie", scheme.In)
+	s.Equal(GatewayTokenCookie, scheme.Name)
+}
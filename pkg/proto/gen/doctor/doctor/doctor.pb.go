@@ -0,0 +1,1077 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: doctor/doctor.proto
+
+package doctorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ===== Запрос доступности конкретного времени =====
+type CheckTimeAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	SlotTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=slot_time,json=slotTime,proto3" json:"slot_time,omitempty"` // Конкретное время для проверки
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckTimeAvailabilityRequest) Reset() {
+	*x = CheckTimeAvailabilityRequest{}
+	mi := &file_doctor_doctor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTimeAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTimeAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckTimeAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTimeAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckTimeAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckTimeAvailabilityRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *CheckTimeAvailabilityRequest) GetSlotTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlotTime
+	}
+	return nil
+}
+
+type CheckTimeAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsAvailable   bool                   `protobuf:"varint,1,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Причина недоступности (например, "already booked")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckTimeAvailabilityResponse) Reset() {
+	*x = CheckTimeAvailabilityResponse{}
+	mi := &file_doctor_doctor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTimeAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTimeAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckTimeAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTimeAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckTimeAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckTimeAvailabilityResponse) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *CheckTimeAvailabilityResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ===== Запрос всех свободных слотов =====
+type GetAvailableSlotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // Начало периода включительно (опционально)
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // Конец периода, не включая его (опционально)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableSlotsRequest) Reset() {
+	*x = GetAvailableSlotsRequest{}
+	mi := &file_doctor_doctor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableSlotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableSlotsRequest) ProtoMessage() {}
+
+func (x *GetAvailableSlotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableSlotsRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailableSlotsRequest) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAvailableSlotsRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *GetAvailableSlotsRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetAvailableSlotsRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+// Время слотов — моменты в UTC, местное время считается по часовому поясу врача
+type TimeSlot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                     // "free", "booked", "held"
+	ClinicId      string                 `protobuf:"bytes,4,opt,name=clinic_id,json=clinicId,proto3" json:"clinic_id,omitempty"` // Филиал, пусто для онлайн-приёма
+	RoomId        string                 `protobuf:"bytes,5,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`       // Кабинет филиала
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeSlot) Reset() {
+	*x = TimeSlot{}
+	mi := &file_doctor_doctor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSlot) ProtoMessage() {}
+
+func (x *TimeSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSlot.ProtoReflect.Descriptor instead.
+func (*TimeSlot) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TimeSlot) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TimeSlot) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TimeSlot) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TimeSlot) GetClinicId() string {
+	if x != nil {
+		return x.ClinicId
+	}
+	return ""
+}
+
+func (x *TimeSlot) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type GetAvailableSlotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slots         []*TimeSlot            `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableSlotsResponse) Reset() {
+	*x = GetAvailableSlotsResponse{}
+	mi := &file_doctor_doctor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableSlotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableSlotsResponse) ProtoMessage() {}
+
+func (x *GetAvailableSlotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableSlotsResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailableSlotsResponse) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAvailableSlotsResponse) GetSlots() []*TimeSlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+// ===== Запрос на изменение слота времени =====
+type ChangeTimeSlotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	SlotTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=slot_time,json=slotTime,proto3" json:"slot_time,omitempty"`
+	IsAvailable   bool                   `protobuf:"varint,3,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"` // Новый статус слота (доступен/недоступен)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeTimeSlotRequest) Reset() {
+	*x = ChangeTimeSlotRequest{}
+	mi := &file_doctor_doctor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeTimeSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeTimeSlotRequest) ProtoMessage() {}
+
+func (x *ChangeTimeSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeTimeSlotRequest.ProtoReflect.Descriptor instead.
+func (*ChangeTimeSlotRequest) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ChangeTimeSlotRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *ChangeTimeSlotRequest) GetSlotTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlotTime
+	}
+	return nil
+}
+
+func (x *ChangeTimeSlotRequest) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+type ChangeTimeSlotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // Дополнительная информация (например, "Slot updated successfully")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeTimeSlotResponse) Reset() {
+	*x = ChangeTimeSlotResponse{}
+	mi := &file_doctor_doctor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeTimeSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeTimeSlotResponse) ProtoMessage() {}
+
+func (x *ChangeTimeSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeTimeSlotResponse.ProtoReflect.Descriptor instead.
+func (*ChangeTimeSlotResponse) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChangeTimeSlotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ChangeTimeSlotResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ===== Бронирование слота под запись =====
+type ReserveSlotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	SlotTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=slot_time,json=slotTime,proto3" json:"slot_time,omitempty"`
+	AppointmentId string                 `protobuf:"bytes,3,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"` // Повторный запрос с той же записью возвращает тот же токен
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveSlotRequest) Reset() {
+	*x = ReserveSlotRequest{}
+	mi := &file_doctor_doctor_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveSlotRequest) ProtoMessage() {}
+
+func (x *ReserveSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveSlotRequest.ProtoReflect.Descriptor instead.
+func (*ReserveSlotRequest) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReserveSlotRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *ReserveSlotRequest) GetSlotTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlotTime
+	}
+	return nil
+}
+
+func (x *ReserveSlotRequest) GetAppointmentId() string {
+	if x != nil {
+		return x.AppointmentId
+	}
+	return ""
+}
+
+type ReserveSlotResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ReservationToken string                 `protobuf:"bytes,2,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"` // Нужен, чтобы освободить слот
+	Reason           string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                             // Причина отказа ("already booked", "room is occupied")
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReserveSlotResponse) Reset() {
+	*x = ReserveSlotResponse{}
+	mi := &file_doctor_doctor_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveSlotResponse) ProtoMessage() {}
+
+func (x *ReserveSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveSlotResponse.ProtoReflect.Descriptor instead.
+func (*ReserveSlotResponse) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReserveSlotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReserveSlotResponse) GetReservationToken() string {
+	if x != nil {
+		return x.ReservationToken
+	}
+	return ""
+}
+
+func (x *ReserveSlotResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReleaseSlotRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId         string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	SlotTime         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=slot_time,json=slotTime,proto3" json:"slot_time,omitempty"`
+	AppointmentId    string                 `protobuf:"bytes,3,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"`
+	ReservationToken string                 `protobuf:"bytes,4,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReleaseSlotRequest) Reset() {
+	*x = ReleaseSlotRequest{}
+	mi := &file_doctor_doctor_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseSlotRequest) ProtoMessage() {}
+
+func (x *ReleaseSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseSlotRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseSlotRequest) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReleaseSlotRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *ReleaseSlotRequest) GetSlotTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlotTime
+	}
+	return nil
+}
+
+func (x *ReleaseSlotRequest) GetAppointmentId() string {
+	if x != nil {
+		return x.AppointmentId
+	}
+	return ""
+}
+
+func (x *ReleaseSlotRequest) GetReservationToken() string {
+	if x != nil {
+		return x.ReservationToken
+	}
+	return ""
+}
+
+type ReleaseSlotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseSlotResponse) Reset() {
+	*x = ReleaseSlotResponse{}
+	mi := &file_doctor_doctor_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseSlotResponse) ProtoMessage() {}
+
+func (x *ReleaseSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseSlotResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseSlotResponse) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReleaseSlotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReleaseSlotResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ===== Временное удержание слота =====
+type HoldSlotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	SlotTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=slot_time,json=slotTime,proto3" json:"slot_time,omitempty"`
+	AppointmentId string                 `protobuf:"bytes,3,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldSlotRequest) Reset() {
+	*x = HoldSlotRequest{}
+	mi := &file_doctor_doctor_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldSlotRequest) ProtoMessage() {}
+
+func (x *HoldSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldSlotRequest.ProtoReflect.Descriptor instead.
+func (*HoldSlotRequest) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HoldSlotRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *HoldSlotRequest) GetSlotTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlotTime
+	}
+	return nil
+}
+
+func (x *HoldSlotRequest) GetAppointmentId() string {
+	if x != nil {
+		return x.AppointmentId
+	}
+	return ""
+}
+
+type HoldSlotResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ReservationToken string                 `protobuf:"bytes,2,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"` // Нужен для подтверждения и освобождения
+	HeldUntil        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=held_until,json=heldUntil,proto3" json:"held_until,omitempty"`                      // Без подтверждения слот освободится в это время
+	Reason           string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *HoldSlotResponse) Reset() {
+	*x = HoldSlotResponse{}
+	mi := &file_doctor_doctor_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldSlotResponse) ProtoMessage() {}
+
+func (x *HoldSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldSlotResponse.ProtoReflect.Descriptor instead.
+func (*HoldSlotResponse) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HoldSlotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *HoldSlotResponse) GetReservationToken() string {
+	if x != nil {
+		return x.ReservationToken
+	}
+	return ""
+}
+
+func (x *HoldSlotResponse) GetHeldUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HeldUntil
+	}
+	return nil
+}
+
+func (x *HoldSlotResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ConfirmSlotRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId         string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	SlotTime         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=slot_time,json=slotTime,proto3" json:"slot_time,omitempty"`
+	AppointmentId    string                 `protobuf:"bytes,3,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"`
+	ReservationToken string                 `protobuf:"bytes,4,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConfirmSlotRequest) Reset() {
+	*x = ConfirmSlotRequest{}
+	mi := &file_doctor_doctor_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmSlotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmSlotRequest) ProtoMessage() {}
+
+func (x *ConfirmSlotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmSlotRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmSlotRequest) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConfirmSlotRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *ConfirmSlotRequest) GetSlotTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlotTime
+	}
+	return nil
+}
+
+func (x *ConfirmSlotRequest) GetAppointmentId() string {
+	if x != nil {
+		return x.AppointmentId
+	}
+	return ""
+}
+
+func (x *ConfirmSlotRequest) GetReservationToken() string {
+	if x != nil {
+		return x.ReservationToken
+	}
+	return ""
+}
+
+type ConfirmSlotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmSlotResponse) Reset() {
+	*x = ConfirmSlotResponse{}
+	mi := &file_doctor_doctor_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmSlotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmSlotResponse) ProtoMessage() {}
+
+func (x *ConfirmSlotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_doctor_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmSlotResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmSlotResponse) Descriptor() ([]byte, []int) {
+	return file_doctor_doctor_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConfirmSlotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmSlotResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_doctor_doctor_proto protoreflect.FileDescriptor
+
+const file_doctor_doctor_proto_rawDesc = "" +
+	"\n" +
+	"\x13doctor/doctor.proto\x12\tdoctor.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"t\n" +
+	"\x1cCheckTimeAvailabilityRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x127\n" +
+	"\tslot_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bslotTime\"Z\n" +
+	"\x1dCheckTimeAvailabilityResponse\x12!\n" +
+	"\fis_available\x18\x01 \x01(\bR\visAvailable\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xa9\x01\n" +
+	"\x18GetAvailableSlotsRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xca\x01\n" +
+	"\bTimeSlot\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1b\n" +
+	"\tclinic_id\x18\x04 \x01(\tR\bclinicId\x12\x17\n" +
+	"\aroom_id\x18\x05 \x01(\tR\x06roomId\"F\n" +
+	"\x19GetAvailableSlotsResponse\x12)\n" +
+	"\x05slots\x18\x01 \x03(\v2\x13.doctor.v1.TimeSlotR\x05slots\"\x90\x01\n" +
+	"\x15ChangeTimeSlotRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x127\n" +
+	"\tslot_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bslotTime\x12!\n" +
+	"\fis_available\x18\x03 \x01(\bR\visAvailable\"L\n" +
+	"\x16ChangeTimeSlotResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x91\x01\n" +
+	"\x12ReserveSlotRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x127\n" +
+	"\tslot_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bslotTime\x12%\n" +
+	"\x0eappointment_id\x18\x03 \x01(\tR\rappointmentId\"t\n" +
+	"\x13ReserveSlotResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12+\n" +
+	"\x11reservation_token\x18\x02 \x01(\tR\x10reservationToken\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xbe\x01\n" +
+	"\x12ReleaseSlotRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x127\n" +
+	"\tslot_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bslotTime\x12%\n" +
+	"\x0eappointment_id\x18\x03 \x01(\tR\rappointmentId\x12+\n" +
+	"\x11reservation_token\x18\x04 \x01(\tR\x10reservationToken\"I\n" +
+	"\x13ReleaseSlotResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8e\x01\n" +
+	"\x0fHoldSlotRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x127\n" +
+	"\tslot_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bslotTime\x12%\n" +
+	"\x0eappointment_id\x18\x03 \x01(\tR\rappointmentId\"\xac\x01\n" +
+	"\x10HoldSlotResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12+\n" +
+	"\x11reservation_token\x18\x02 \x01(\tR\x10reservationToken\x129\n" +
+	"\n" +
+	"held_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\theldUntil\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xbe\x01\n" +
+	"\x12ConfirmSlotRequest\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x127\n" +
+	"\tslot_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bslotTime\x12%\n" +
+	"\x0eappointment_id\x18\x03 \x01(\tR\rappointmentId\x12+\n" +
+	"\x11reservation_token\x18\x04 \x01(\tR\x10reservationToken\"I\n" +
+	"\x13ConfirmSlotResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xe1\x04\n" +
+	"\rDoctorService\x12j\n" +
+	"\x15CheckTimeAvailability\x12'.doctor.v1.CheckTimeAvailabilityRequest\x1a(.doctor.v1.CheckTimeAvailabilityResponse\x12^\n" +
+	"\x11GetAvailableSlots\x12#.doctor.v1.GetAvailableSlotsRequest\x1a$.doctor.v1.GetAvailableSlotsResponse\x12U\n" +
+	"\x0eChangeTimeSlot\x12 .doctor.v1.ChangeTimeSlotRequest\x1a!.doctor.v1.ChangeTimeSlotResponse\x12L\n" +
+	"\vReserveSlot\x12\x1d.doctor.v1.ReserveSlotRequest\x1a\x1e.doctor.v1.ReserveSlotResponse\x12L\n" +
+	"\vReleaseSlot\x12\x1d.doctor.v1.ReleaseSlotRequest\x1a\x1e.doctor.v1.ReleaseSlotResponse\x12C\n" +
+	"\bHoldSlot\x12\x1a.doctor.v1.HoldSlotRequest\x1a\x1b.doctor.v1.HoldSlotResponse\x12L\n" +
+	"\vConfirmSlot\x12\x1d.doctor.v1.ConfirmSlotRequest\x1a\x1e.doctor.v1.ConfirmSlotResponseBEZCgithub.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor;doctorpbb\x06proto3"
+
+var (
+	file_doctor_doctor_proto_rawDescOnce sync.Once
+	file_doctor_doctor_proto_rawDescData []byte
+)
+
+func file_doctor_doctor_proto_rawDescGZIP() []byte {
+	file_doctor_doctor_proto_rawDescOnce.Do(func() {
+		file_doctor_doctor_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_doctor_doctor_proto_rawDesc), len(file_doctor_doctor_proto_rawDesc)))
+	})
+	return file_doctor_doctor_proto_rawDescData
+}
+
+var file_doctor_doctor_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_doctor_doctor_proto_goTypes = []any{
+	(*CheckTimeAvailabilityRequest)(nil),  // 0: doctor.v1.CheckTimeAvailabilityRequest
+	(*CheckTimeAvailabilityResponse)(nil), // 1: doctor.v1.CheckTimeAvailabilityResponse
+	(*GetAvailableSlotsRequest)(nil),      // 2: doctor.v1.GetAvailableSlotsRequest
+	(*TimeSlot)(nil),                      // 3: doctor.v1.TimeSlot
+	(*GetAvailableSlotsResponse)(nil),     // 4: doctor.v1.GetAvailableSlotsResponse
+	(*ChangeTimeSlotRequest)(nil),         // 5: doctor.v1.ChangeTimeSlotRequest
+	(*ChangeTimeSlotResponse)(nil),        // 6: doctor.v1.ChangeTimeSlotResponse
+	(*ReserveSlotRequest)(nil),            // 7: doctor.v1.ReserveSlotRequest
+	(*ReserveSlotResponse)(nil),           // 8: doctor.v1.ReserveSlotResponse
+	(*ReleaseSlotRequest)(nil),            // 9: doctor.v1.ReleaseSlotRequest
+	(*ReleaseSlotResponse)(nil),           // 10: doctor.v1.ReleaseSlotResponse
+	(*HoldSlotRequest)(nil),               // 11: doctor.v1.HoldSlotRequest
+	(*HoldSlotResponse)(nil),              // 12: doctor.v1.HoldSlotResponse
+	(*ConfirmSlotRequest)(nil),            // 13: doctor.v1.ConfirmSlotRequest
+	(*ConfirmSlotResponse)(nil),           // 14: doctor.v1.ConfirmSlotResponse
+	(*timestamppb.Timestamp)(nil),         // 15: google.protobuf.Timestamp
+}
+var file_doctor_doctor_proto_depIdxs = []int32{
+	15, // 0: doctor.v1.CheckTimeAvailabilityRequest.slot_time:type_name -> google.protobuf.Timestamp
+	15, // 1: doctor.v1.GetAvailableSlotsRequest.start_date:type_name -> google.protobuf.Timestamp
+	15, // 2: doctor.v1.GetAvailableSlotsRequest.end_date:type_name -> google.protobuf.Timestamp
+	15, // 3: doctor.v1.TimeSlot.start_time:type_name -> google.protobuf.Timestamp
+	15, // 4: doctor.v1.TimeSlot.end_time:type_name -> google.protobuf.Timestamp
+	3,  // 5: doctor.v1.GetAvailableSlotsResponse.slots:type_name -> doctor.v1.TimeSlot
+	15, // 6: doctor.v1.ChangeTimeSlotRequest.slot_time:type_name -> google.protobuf.Timestamp
+	15, // 7: doctor.v1.ReserveSlotRequest.slot_time:type_name -> google.protobuf.Timestamp
+	15, // 8: doctor.v1.ReleaseSlotRequest.slot_time:type_name -> google.protobuf.Timestamp
+	15, // 9: doctor.v1.HoldSlotRequest.slot_time:type_name -> google.protobuf.Timestamp
+	15, // 10: doctor.v1.HoldSlotResponse.held_until:type_name -> google.protobuf.Timestamp
+	15, // 11: doctor.v1.ConfirmSlotRequest.slot_time:type_name -> google.protobuf.Timestamp
+	0,  // 12: doctor.v1.DoctorService.CheckTimeAvailability:input_type -> doctor.v1.CheckTimeAvailabilityRequest
+	2,  // 13: doctor.v1.DoctorService.GetAvailableSlots:input_type -> doctor.v1.GetAvailableSlotsRequest
+	5,  // 14: doctor.v1.DoctorService.ChangeTimeSlot:input_type -> doctor.v1.ChangeTimeSlotRequest
+	7,  // 15: doctor.v1.DoctorService.ReserveSlot:input_type -> doctor.v1.ReserveSlotRequest
+	9,  // 16: doctor.v1.DoctorService.ReleaseSlot:input_type -> doctor.v1.ReleaseSlotRequest
+	11, // 17: doctor.v1.DoctorService.HoldSlot:input_type -> doctor.v1.HoldSlotRequest
+	13, // 18: doctor.v1.DoctorService.ConfirmSlot:input_type -> doctor.v1.ConfirmSlotRequest
+	1,  // 19: doctor.v1.DoctorService.CheckTimeAvailability:output_type -> doctor.v1.CheckTimeAvailabilityResponse
+	4,  // 20: doctor.v1.DoctorService.GetAvailableSlots:output_type -> doctor.v1.GetAvailableSlotsResponse
+	6,  // 21: doctor.v1.DoctorService.ChangeTimeSlot:output_type -> doctor.v1.ChangeTimeSlotResponse
+	8,  // 22: doctor.v1.DoctorService.ReserveSlot:output_type -> doctor.v1.ReserveSlotResponse
+	10, // 23: doctor.v1.DoctorService.ReleaseSlot:output_type -> doctor.v1.ReleaseSlotResponse
+	12, // 24: doctor.v1.DoctorService.HoldSlot:output_type -> doctor.v1.HoldSlotResponse
+	14, // 25: doctor.v1.DoctorService.ConfirmSlot:output_type -> doctor.v1.ConfirmSlotResponse
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_doctor_doctor_proto_init() }
+func file_doctor_doctor_proto_init() {
+	if File_doctor_doctor_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_doctor_doctor_proto_rawDesc), len(file_doctor_doctor_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_doctor_doctor_proto_goTypes,
+		DependencyIndexes: file_doctor_doctor_proto_depIdxs,
+		MessageInfos:      file_doctor_doctor_proto_msgTypes,
+	}.Build()
+	File_doctor_doctor_proto = out.File
+	file_doctor_doctor_proto_goTypes = nil
+	file_doctor_doctor_proto_depIdxs = nil
+}
@@ -1,10 +1,10 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v6.30.2
-// source: doctor.proto
+// - protoc             (unknown)
+// source: doctor/doctor.proto
 
-package gen
+package doctorpb
 
 import (
 	context "context"
@@ -22,6 +22,10 @@ const (
 	DoctorService_CheckTimeAvailability_FullMethodName = "/doctor.v1.DoctorService/CheckTimeAvailability"
 	DoctorService_GetAvailableSlots_FullMethodName     = "/doctor.v1.DoctorService/GetAvailableSlots"
 	DoctorService_ChangeTimeSlot_FullMethodName        = "/doctor.v1.DoctorService/ChangeTimeSlot"
+	DoctorService_ReserveSlot_FullMethodName           = "/doctor.v1.DoctorService/ReserveSlot"
+	DoctorService_ReleaseSlot_FullMethodName           = "/doctor.v1.DoctorService/ReleaseSlot"
+	DoctorService_HoldSlot_FullMethodName              = "/doctor.v1.DoctorService/HoldSlot"
+	DoctorService_ConfirmSlot_FullMethodName           = "/doctor.v1.DoctorService/ConfirmSlot"
 )
 
 // DoctorServiceClient is the client API for DoctorService service.
@@ -32,7 +36,16 @@ type DoctorServiceClient interface {
 	CheckTimeAvailability(ctx context.Context, in *CheckTimeAvailabilityRequest, opts ...grpc.CallOption) (*CheckTimeAvailabilityResponse, error)
 	// Получение списка всех свободных слотов (для отображения в UI)
 	GetAvailableSlots(ctx context.Context, in *GetAvailableSlotsRequest, opts ...grpc.CallOption) (*GetAvailableSlotsResponse, error)
+	// Устарело: занимает слот без записи, используйте ReserveSlot и ReleaseSlot
 	ChangeTimeSlot(ctx context.Context, in *ChangeTimeSlotRequest, opts ...grpc.CallOption) (*ChangeTimeSlotResponse, error)
+	// Атомарно занимает свободный слот под запись, из параллельных запросов выигрывает один
+	ReserveSlot(ctx context.Context, in *ReserveSlotRequest, opts ...grpc.CallOption) (*ReserveSlotResponse, error)
+	// Освобождает слот, занятый или удержанный записью, по её токену
+	ReleaseSlot(ctx context.Context, in *ReleaseSlotRequest, opts ...grpc.CallOption) (*ReleaseSlotResponse, error)
+	// Временно удерживает слот на время оформления записи, без подтверждения удержание истекает
+	HoldSlot(ctx context.Context, in *HoldSlotRequest, opts ...grpc.CallOption) (*HoldSlotResponse, error)
+	// Подтверждает удержание, после чего слот занят записью бессрочно
+	ConfirmSlot(ctx context.Context, in *ConfirmSlotRequest, opts ...grpc.CallOption) (*ConfirmSlotResponse, error)
 }
 
 type doctorServiceClient struct {
@@ -73,6 +86,46 @@ func (c *doctorServiceClient) ChangeTimeSlot(ctx context.Context, in *ChangeTime
 	return out, nil
 }
 
+func (c *doctorServiceClient) ReserveSlot(ctx context.Context, in *ReserveSlotRequest, opts ...grpc.CallOption) (*ReserveSlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveSlotResponse)
+	err := c.cc.Invoke(ctx, DoctorService_ReserveSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doctorServiceClient) ReleaseSlot(ctx context.Context, in *ReleaseSlotRequest, opts ...grpc.CallOption) (*ReleaseSlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseSlotResponse)
+	err := c.cc.Invoke(ctx, DoctorService_ReleaseSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doctorServiceClient) HoldSlot(ctx context.Context, in *HoldSlotRequest, opts ...grpc.CallOption) (*HoldSlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldSlotResponse)
+	err := c.cc.Invoke(ctx, DoctorService_HoldSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doctorServiceClient) ConfirmSlot(ctx context.Context, in *ConfirmSlotRequest, opts ...grpc.CallOption) (*ConfirmSlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmSlotResponse)
+	err := c.cc.Invoke(ctx, DoctorService_ConfirmSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DoctorServiceServer is the server API for DoctorService service.
 // All implementations must embed UnimplementedDoctorServiceServer
 // for forward compatibility.
@@ -81,7 +134,16 @@ type DoctorServiceServer interface {
 	CheckTimeAvailability(context.Context, *CheckTimeAvailabilityRequest) (*CheckTimeAvailabilityResponse, error)
 	// Получение списка всех свободных слотов (для отображения в UI)
 	GetAvailableSlots(context.Context, *GetAvailableSlotsRequest) (*GetAvailableSlotsResponse, error)
+	// Устарело: занимает слот без записи, используйте ReserveSlot и ReleaseSlot
 	ChangeTimeSlot(context.Context, *ChangeTimeSlotRequest) (*ChangeTimeSlotResponse, error)
+	// Атомарно занимает свободный слот под запись, из параллельных запросов выигрывает один
+	ReserveSlot(context.Context, *ReserveSlotRequest) (*ReserveSlotResponse, error)
+	// Освобождает слот, занятый или удержанный записью, по её токену
+	ReleaseSlot(context.Context, *ReleaseSlotRequest) (*ReleaseSlotResponse, error)
+	// Временно удерживает слот на время оформления записи, без подтверждения удержание истекает
+	HoldSlot(context.Context, *HoldSlotRequest) (*HoldSlotResponse, error)
+	// Подтверждает удержание, после чего слот занят записью бессрочно
+	ConfirmSlot(context.Context, *ConfirmSlotRequest) (*ConfirmSlotResponse, error)
 	mustEmbedUnimplementedDoctorServiceServer()
 }
 
@@ -101,6 +163,18 @@ func (UnimplementedDoctorServiceServer) GetAvailableSlots(context.Context, *GetA
 func (UnimplementedDoctorServiceServer) ChangeTimeSlot(context.Context, *ChangeTimeSlotRequest) (*ChangeTimeSlotResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeTimeSlot not implemented")
 }
+func (UnimplementedDoctorServiceServer) ReserveSlot(context.Context, *ReserveSlotRequest) (*ReserveSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveSlot not implemented")
+}
+func (UnimplementedDoctorServiceServer) ReleaseSlot(context.Context, *ReleaseSlotRequest) (*ReleaseSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSlot not implemented")
+}
+func (UnimplementedDoctorServiceServer) HoldSlot(context.Context, *HoldSlotRequest) (*HoldSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldSlot not implemented")
+}
+func (UnimplementedDoctorServiceServer) ConfirmSlot(context.Context, *ConfirmSlotRequest) (*ConfirmSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmSlot not implemented")
+}
 func (UnimplementedDoctorServiceServer) mustEmbedUnimplementedDoctorServiceServer() {}
 func (UnimplementedDoctorServiceServer) testEmbeddedByValue()                       {}
 
@@ -176,6 +250,78 @@ func _DoctorService_ChangeTimeSlot_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DoctorService_ReserveSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoctorServiceServer).ReserveSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DoctorService_ReserveSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoctorServiceServer).ReserveSlot(ctx, req.(*ReserveSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DoctorService_ReleaseSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoctorServiceServer).ReleaseSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DoctorService_ReleaseSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoctorServiceServer).ReleaseSlot(ctx, req.(*ReleaseSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DoctorService_HoldSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoctorServiceServer).HoldSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DoctorService_HoldSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoctorServiceServer).HoldSlot(ctx, req.(*HoldSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DoctorService_ConfirmSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoctorServiceServer).ConfirmSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DoctorService_ConfirmSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoctorServiceServer).ConfirmSlot(ctx, req.(*ConfirmSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DoctorService_ServiceDesc is the grpc.ServiceDesc for DoctorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -195,7 +341,23 @@ var DoctorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangeTimeSlot",
 			Handler:    _DoctorService_ChangeTimeSlot_Handler,
 		},
+		{
+			MethodName: "ReserveSlot",
+			Handler:    _DoctorService_ReserveSlot_Handler,
+		},
+		{
+			MethodName: "ReleaseSlot",
+			Handler:    _DoctorService_ReleaseSlot_Handler,
+		},
+		{
+			MethodName: "HoldSlot",
+			Handler:    _DoctorService_HoldSlot_Handler,
+		},
+		{
+			MethodName: "ConfirmSlot",
+			Handler:    _DoctorService_ConfirmSlot_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "doctor.proto",
+	Metadata: "doctor/doctor.proto",
 }
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: doctor/events.proto
+
+package doctorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Публикуется в doctor.timeoff.created, когда у врача или у всей клиники закрывается время приёма
+type TimeOffCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExceptionId   string                 `protobuf:"bytes,1,opt,name=exception_id,json=exceptionId,proto3" json:"exception_id,omitempty"`
+	DoctorId      string                 `protobuf:"bytes,2,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"` // Пусто для праздника всей клиники
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                         // "VACATION", "SICK_LEAVE", "HOLIDAY"
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Conflicts     []*ConflictingSlot     `protobuf:"bytes,7,rep,name=conflicts,proto3" json:"conflicts,omitempty"` // Занятые слоты, пациентов которых нужно перенести
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeOffCreated) Reset() {
+	*x = TimeOffCreated{}
+	mi := &file_doctor_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeOffCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeOffCreated) ProtoMessage() {}
+
+func (x *TimeOffCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeOffCreated.ProtoReflect.Descriptor instead.
+func (*TimeOffCreated) Descriptor() ([]byte, []int) {
+	return file_doctor_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TimeOffCreated) GetExceptionId() string {
+	if x != nil {
+		return x.ExceptionId
+	}
+	return ""
+}
+
+func (x *TimeOffCreated) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *TimeOffCreated) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TimeOffCreated) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *TimeOffCreated) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *TimeOffCreated) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TimeOffCreated) GetConflicts() []*ConflictingSlot {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+type ConflictingSlot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	SlotId        string                 `protobuf:"bytes,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	SlotTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=slot_time,json=slotTime,proto3" json:"slot_time,omitempty"`
+	AppointmentId string                 `protobuf:"bytes,4,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"` // Пусто, если запись не привязана к слоту
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConflictingSlot) Reset() {
+	*x = ConflictingSlot{}
+	mi := &file_doctor_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConflictingSlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictingSlot) ProtoMessage() {}
+
+func (x *ConflictingSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_doctor_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictingSlot.ProtoReflect.Descriptor instead.
+func (*ConflictingSlot) Descriptor() ([]byte, []int) {
+	return file_doctor_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConflictingSlot) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *ConflictingSlot) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *ConflictingSlot) GetSlotTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlotTime
+	}
+	return nil
+}
+
+func (x *ConflictingSlot) GetAppointmentId() string {
+	if x != nil {
+		return x.AppointmentId
+	}
+	return ""
+}
+
+var File_doctor_events_proto protoreflect.FileDescriptor
+
+const file_doctor_events_proto_rawDesc = "" +
+	"\n" +
+	"\x13doctor/events.proto\x12\tdoctor.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa4\x02\n" +
+	"\x0eTimeOffCreated\x12!\n" +
+	"\fexception_id\x18\x01 \x01(\tR\vexceptionId\x12\x1b\n" +
+	"\tdoctor_id\x18\x02 \x01(\tR\bdoctorId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x127\n" +
+	"\tstarts_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x128\n" +
+	"\tconflicts\x18\a \x03(\v2\x1a.doctor.v1.ConflictingSlotR\tconflicts\"\xa7\x01\n" +
+	"\x0fConflictingSlot\x12\x1b\n" +
+	"\tdoctor_id\x18\x01 \x01(\tR\bdoctorId\x12\x17\n" +
+	"\aslot_id\x18\x02 \x01(\tR\x06slotId\x127\n" +
+	"\tslot_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bslotTime\x12%\n" +
+	"\x0eappointment_id\x18\x04 \x01(\tR\rappointmentIdBEZCgithub.com/Ruletk/OnlineClinic/pkg/proto/gen/doctor/doctor;doctorpbb\x06proto3"
+
+var (
+	file_doctor_events_proto_rawDescOnce sync.Once
+	file_doctor_events_proto_rawDescData []byte
+)
+
+func file_doctor_events_proto_rawDescGZIP() []byte {
+	file_doctor_events_proto_rawDescOnce.Do(func() {
+		file_doctor_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_doctor_events_proto_rawDesc), len(file_doctor_events_proto_rawDesc)))
+	})
+	return file_doctor_events_proto_rawDescData
+}
+
+var file_doctor_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_doctor_events_proto_goTypes = []any{
+	(*TimeOffCreated)(nil),        // 0: doctor.v1.TimeOffCreated
+	(*ConflictingSlot)(nil),       // 1: doctor.v1.ConflictingSlot
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_doctor_events_proto_depIdxs = []int32{
+	2, // 0: doctor.v1.TimeOffCreated.starts_at:type_name -> google.protobuf.Timestamp
+	2, // 1: doctor.v1.TimeOffCreated.ends_at:type_name -> google.protobuf.Timestamp
+	1, // 2: doctor.v1.TimeOffCreated.conflicts:type_name -> doctor.v1.ConflictingSlot
+	2, // 3: doctor.v1.ConflictingSlot.slot_time:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_doctor_events_proto_init() }
+func file_doctor_events_proto_init() {
+	if File_doctor_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_doctor_events_proto_rawDesc), len(file_doctor_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_doctor_events_proto_goTypes,
+		DependencyIndexes: file_doctor_events_proto_depIdxs,
+		MessageInfos:      file_doctor_events_proto_msgTypes,
+	}.Build()
+	File_doctor_events_proto = out.File
+	file_doctor_events_proto_goTypes = nil
+	file_doctor_events_proto_depIdxs = nil
+}
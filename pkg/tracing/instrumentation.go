@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+)
+
+// GinMiddleware starts a server span for every request, continuing the trace
+// from incoming W3C traceparent headers when present.
+func GinMiddleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName)
+}
+
+// GRPCServerOptions returns server options that start a span for each incoming RPC.
+func GRPCServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	}
+}
+
+// GRPCDialOptions returns dial options that start a client span for each outgoing RPC
+// and propagate the trace context in gRPC metadata.
+func GRPCDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+}
+
+// InstrumentGorm registers the OpenTelemetry plugin, so every query becomes a span.
+// Queries are only traced when they carry a context, e.g. db.WithContext(ctx).
+func InstrumentGorm(db *gorm.DB) error {
+	return db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics()))
+}
+
+// InstrumentRedis adds a span for every Redis command sent through the client.
+func InstrumentRedis(client redis.UniversalClient) error {
+	return redisotel.InstrumentTracing(client)
+}
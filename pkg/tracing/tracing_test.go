@@ -0,0 +1,84 @@
+package tracing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ruletk/OnlineClinic/pkg/config"
+	"github.com/Ruletk/OnlineClinic/pkg/tracing"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	cfg config.Config
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.cfg = config.Config{
+		Tracing: config.TracingConfig{
+			Exporter:    "none",
+			SampleRatio: 1,
+			ServiceName: "test",
+		},
+	}
+}
+
+func (s *TracingTestSuite) TestInitTracer_None() {
+	shutdown, err := tracing.InitTracer(s.cfg)
+	s.Require().NoError(err)
+	s.NoError(shutdown(context.Background()))
+}
+
+func (s *TracingTestSuite) TestInitTracer_UnknownExporter() {
+	s.cfg.Tracing.Exporter = "zipkin"
+	_, err := tracing.InitTracer(s.cfg)
+	s.Error(err)
+	s.ErrorContains(err, "unknown tracing exporter")
+}
+
+func (s *TracingTestSuite) TestInitTracer_FileExporterWritesSpans() {
+	path := filepath.Join(s.T().TempDir(), "traces.json")
+	s.cfg.Tracing.Exporter = "file"
+	s.cfg.Tracing.FilePath = path
+
+	shutdown, err := tracing.InitTracer(s.cfg)
+	s.Require().NoError(err)
+
+	_, span := tracing.Tracer("test").Start(context.Background(), "file-span")
+	span.End()
+	s.Require().NoError(shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Contains(string(data), "file-span")
+}
+
+func (s *TracingTestSuite) TestNatsPropagation() {
+	shutdown, err := tracing.InitTracer(s.cfg)
+	s.Require().NoError(err)
+	defer func() { _ = shutdown(context.Background()) }()
+
+	ctx, span := tracing.Tracer("test").Start(context.Background(), "publish")
+	defer span.End()
+
+	msg := nats.NewMsg("email.message")
+	tracing.InjectNats(ctx, msg)
+	s.NotEmpty(msg.Header.Get("traceparent"), "traceparent header should be set")
+
+	extracted := trace.SpanContextFromContext(tracing.ExtractNats(context.Background(), msg))
+	s.Equal(span.SpanContext().TraceID(), extracted.TraceID(), "Trace ID should survive the round trip")
+}
+
+func (s *TracingTestSuite) TestExtractNats_NoHeaders() {
+	ctx := context.Background()
+	s.Equal(ctx, tracing.ExtractNats(ctx, &nats.Msg{Subject: "email.message"}))
+}